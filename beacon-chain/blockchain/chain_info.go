@@ -53,6 +53,7 @@ type HeadFetcher interface {
 	HeadValidatorIndexToPublicKey(ctx context.Context, index types.ValidatorIndex) ([48]byte, error)
 	ProtoArrayStore() *protoarray.Store
 	ChainHeads() ([][32]byte, []types.Slot)
+	ForkChoiceVotesCount() uint64
 	HeadSyncCommitteeFetcher
 	HeadDomainFetcher
 }
@@ -225,6 +226,11 @@ func (s *Service) ProtoArrayStore() *protoarray.Store {
 	return s.cfg.ForkChoiceStore.Store()
 }
 
+// ForkChoiceVotesCount returns the number of validator votes tracked by fork choice.
+func (s *Service) ForkChoiceVotesCount() uint64 {
+	return s.cfg.ForkChoiceStore.VotesCount()
+}
+
 // GenesisTime returns the genesis time of beacon chain.
 func (s *Service) GenesisTime() time.Time {
 	return s.genesisTime
@@ -283,6 +283,14 @@ func TestService_ProtoArrayStore(t *testing.T) {
 	require.Equal(t, 0, int(p.FinalizedEpoch()))
 }
 
+func TestService_ForkChoiceVotesCount(t *testing.T) {
+	ctx := context.Background()
+	c := &Service{cfg: &Config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{})}}
+	require.Equal(t, uint64(0), c.ForkChoiceVotesCount())
+	c.cfg.ForkChoiceStore.ProcessAttestation(ctx, []uint64{0, 4}, [32]byte{'a'}, 1)
+	require.Equal(t, uint64(5), c.ForkChoiceVotesCount())
+}
+
 func TestService_ChainHeads(t *testing.T) {
 	ctx := context.Background()
 	c := &Service{cfg: &Config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{})}}
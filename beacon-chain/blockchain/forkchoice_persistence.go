@@ -0,0 +1,60 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"go.opencensus.io/trace"
+)
+
+// This saves the fork choice store to the DB, so that it can be restored on restart instead of being
+// rebuilt from the finalized checkpoint. An empty store, before the chain is initialized, is not saved.
+func (s *Service) saveForkChoice(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.saveForkChoice")
+	defer span.End()
+
+	store, ok := s.cfg.ForkChoiceStore.(*protoarray.ForkChoice)
+	if !ok || len(store.Nodes()) == 0 {
+		return nil
+	}
+	return s.cfg.BeaconDB.SaveForkChoice(ctx, store)
+}
+
+// This restores the fork choice store saved in the DB. The store is only used if it contains the
+// finalized block, is not ahead of the finalized checkpoint and all of its nodes are blocks known to
+// the DB. It returns nil if no store was saved.
+func (s *Service) restoreForkChoice(ctx context.Context, finalizedCheckpoint *ethpb.Checkpoint) (*protoarray.ForkChoice, error) {
+	ctx, span := trace.StartSpan(ctx, "blockChain.restoreForkChoice")
+	defer span.End()
+
+	store, err := s.cfg.BeaconDB.ForkChoice(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get fork choice store from db")
+	}
+	if store == nil {
+		return nil, nil
+	}
+	if store.Store().FinalizedEpoch() > finalizedCheckpoint.Epoch {
+		return nil, fmt.Errorf("fork choice store finalized epoch %d is ahead of finalized checkpoint epoch %d",
+			store.Store().FinalizedEpoch(), finalizedCheckpoint.Epoch)
+	}
+	finalizedRoot := s.ensureRootNotZeros(bytesutil.ToBytes32(finalizedCheckpoint.Root))
+	if !store.HasNode(finalizedRoot) {
+		return nil, fmt.Errorf("finalized block %#x is not in fork choice store", finalizedRoot)
+	}
+	for _, n := range store.Nodes() {
+		root := n.Root()
+		if !s.cfg.BeaconDB.HasBlock(ctx, root) {
+			return nil, fmt.Errorf("fork choice store block %#x is not in db", root)
+		}
+	}
+	// The store may have been saved before the latest finalization.
+	if err := store.Prune(ctx, finalizedRoot); err != nil {
+		return nil, errors.Wrap(err, "could not prune fork choice store")
+	}
+	return store, nil
+}
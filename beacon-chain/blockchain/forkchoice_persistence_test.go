@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSaveAndResumeForkChoice(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	// Save a genesis block and two competing children.
+	roots := make([][32]byte, 3)
+	for i := range roots {
+		blk := testutil.NewBeaconBlock()
+		if i > 0 {
+			blk.Block.Slot = 1
+			blk.Block.ParentRoot = roots[0][:]
+			blk.Block.Body.Graffiti = bytesutil.PadTo([]byte{byte(i)}, 32)
+		}
+		r, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+		roots[i] = r
+	}
+	fc := protoarray.New(0, 0, params.BeaconConfig().ZeroHash)
+	require.NoError(t, fc.ProcessBlock(ctx, 0, roots[0], params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, roots[1], roots[0], [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, roots[2], roots[0], [32]byte{}, 0, 0))
+	fc.ProcessAttestation(ctx, []uint64{0}, roots[1], 1)
+	head, err := fc.Head(ctx, 0, roots[0], []uint64{10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, roots[1], head)
+
+	s := &Service{
+		cfg:         &Config{ForkChoiceStore: fc, BeaconDB: beaconDB},
+		genesisRoot: roots[0],
+	}
+	require.NoError(t, s.saveForkChoice(ctx))
+
+	checkpoint := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	s.cfg.ForkChoiceStore = protoarray.New(0, 0, [32]byte{})
+	s.resumeForkChoice(ctx, checkpoint, checkpoint)
+	for _, r := range roots {
+		assert.Equal(t, true, s.cfg.ForkChoiceStore.HasNode(r))
+	}
+	assert.Equal(t, uint64(10), s.cfg.ForkChoiceStore.Node(roots[1]).Weight())
+	head, err = s.cfg.ForkChoiceStore.Head(ctx, 0, roots[0], []uint64{10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, roots[1], head)
+}
+
+func TestRestoreForkChoice_NotSaved(t *testing.T) {
+	s := &Service{cfg: &Config{BeaconDB: testDB.SetupDB(t)}}
+	store, err := s.restoreForkChoice(context.Background(), &ethpb.Checkpoint{Root: make([]byte, 32)})
+	require.NoError(t, err)
+	assert.Equal(t, (*protoarray.ForkChoice)(nil), store)
+}
+
+func TestRestoreForkChoice_Inconsistent(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	genesis := testutil.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	unknownRoot := bytesutil.ToBytes32([]byte("unknown"))
+
+	fc := protoarray.New(0, 0, params.BeaconConfig().ZeroHash)
+	require.NoError(t, fc.ProcessBlock(ctx, 0, genesisRoot, params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, unknownRoot, genesisRoot, [32]byte{}, 0, 0))
+	s := &Service{
+		cfg:         &Config{ForkChoiceStore: fc, BeaconDB: beaconDB},
+		genesisRoot: genesisRoot,
+	}
+	require.NoError(t, s.saveForkChoice(ctx))
+
+	checkpoint := &ethpb.Checkpoint{Root: params.BeaconConfig().ZeroHash[:]}
+	_, err = s.restoreForkChoice(ctx, checkpoint)
+	require.ErrorContains(t, "is not in db", err)
+
+	_, err = s.restoreForkChoice(ctx, &ethpb.Checkpoint{Root: bytesutil.PadTo([]byte("finalized"), 32)})
+	require.ErrorContains(t, "is not in fork choice store", err)
+
+	// The fork choice store is rebuilt from the finalized checkpoint.
+	s.resumeForkChoice(ctx, checkpoint, checkpoint)
+	assert.Equal(t, false, s.cfg.ForkChoiceStore.HasNode(genesisRoot))
+	assert.Equal(t, false, s.cfg.ForkChoiceStore.HasNode(unknownRoot))
+}
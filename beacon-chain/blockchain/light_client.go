@@ -0,0 +1,274 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"go.opencensus.io/trace"
+)
+
+// minSyncCommitteeParticipants is the minimum number of sync committee participants for a light client update
+// to be produced.
+const minSyncCommitteeParticipants = 1
+
+// LightClientUpdateFetcher retrieves the latest light client updates produced from imported blocks.
+type LightClientUpdateFetcher interface {
+	LatestLightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate
+	LatestLightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate
+}
+
+// LatestLightClientFinalityUpdate returns the finality update with the highest attested slot produced since
+// start up, or nil if there is none. The returned update must not be modified.
+func (s *Service) LatestLightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientFinalityUpdate
+}
+
+// LatestLightClientOptimisticUpdate returns the optimistic update with the highest attested slot produced since
+// start up, or nil if there is none. The returned update must not be modified.
+func (s *Service) LatestLightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientOptimisticUpdate
+}
+
+// This produces the light client update proven by the sync aggregate of the block. The attested block of the
+// update is the parent block, and attestedState is the post-state of the parent block. The update is saved as
+// the best update of the sync committee period of its attested header, and the latest finality and optimistic
+// updates are replaced when the update attests a later block.
+func (s *Service) processLightClientUpdate(ctx context.Context, signed block.SignedBeaconBlock, attestedState state.BeaconState) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdate")
+	defer span.End()
+
+	if signed.Version() == version.Phase0 || attestedState.Version() == version.Phase0 {
+		return nil
+	}
+	update, err := s.lightClientUpdate(ctx, signed.Block(), attestedState)
+	if err != nil {
+		return err
+	}
+	if update == nil {
+		return nil
+	}
+
+	period := core.SyncCommitteePeriod(core.SlotToEpoch(update.AttestedHeader.Slot))
+	best, err := s.cfg.BeaconDB.LightClientUpdate(ctx, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get light client update from db")
+	}
+	if best == nil || isBetterLightClientUpdate(update, best) {
+		if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+			return errors.Wrap(err, "could not save light client update")
+		}
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+	if update.FinalizedHeader != nil && (s.lightClientFinalityUpdate == nil ||
+		update.AttestedHeader.Slot > s.lightClientFinalityUpdate.AttestedHeader.Slot) {
+		s.lightClientFinalityUpdate = &ethpbv2.LightClientFinalityUpdate{
+			AttestedHeader:  update.AttestedHeader,
+			FinalizedHeader: update.FinalizedHeader,
+			FinalityBranch:  update.FinalityBranch,
+			SyncAggregate:   update.SyncAggregate,
+			SignatureSlot:   update.SignatureSlot,
+		}
+	}
+	if s.lightClientOptimisticUpdate == nil ||
+		update.AttestedHeader.Slot > s.lightClientOptimisticUpdate.AttestedHeader.Slot {
+		s.lightClientOptimisticUpdate = &ethpbv2.LightClientOptimisticUpdate{
+			AttestedHeader: update.AttestedHeader,
+			SyncAggregate:  update.SyncAggregate,
+			SignatureSlot:  update.SignatureSlot,
+		}
+	}
+	return nil
+}
+
+// This builds the light client update of the block from the post-state of its parent block. It returns nil
+// when the sync aggregate of the block has too few participants.
+//
+// Spec code:
+// def create_light_client_update(state, block, attested_state, finalized_block) -> LightClientUpdate:
+//    assert sum(block.message.body.sync_aggregate.sync_committee_bits) >= MIN_SYNC_COMMITTEE_PARTICIPANTS
+//    assert attested_state.slot == attested_state.latest_block_header.slot
+//    attested_header = attested_state.latest_block_header.copy()
+//    attested_header.state_root = hash_tree_root(attested_state)
+//    assert hash_tree_root(attested_header) == block.message.parent_root
+//    update_attested_period = compute_sync_committee_period_at_slot(attested_header.slot)
+//
+//    # `next_sync_committee` is only useful if the message is signed by the current sync committee
+//    if update_attested_period == compute_sync_committee_period_at_slot(block.message.slot):
+//        next_sync_committee = attested_state.next_sync_committee
+//        next_sync_committee_branch = compute_merkle_proof_for_state(attested_state, NEXT_SYNC_COMMITTEE_INDEX)
+//
+//    # Indicate finality whenever possible
+//    if finalized_block is not None:
+//        finalized_header = block_to_header(finalized_block.message)
+//        assert hash_tree_root(finalized_header) == attested_state.finalized_checkpoint.root
+//        finality_branch = compute_merkle_proof_for_state(attested_state, FINALIZED_ROOT_INDEX)
+//    ...
+func (s *Service) lightClientUpdate(ctx context.Context, b block.BeaconBlock, attestedState state.BeaconState) (*ethpbv2.LightClientUpdate, error) {
+	syncAggregate, err := b.Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < minSyncCommitteeParticipants {
+		return nil, nil
+	}
+
+	header := attestedState.LatestBlockHeader()
+	if header.Slot != attestedState.Slot() {
+		return nil, errors.New("attested state is not the post-state of a block")
+	}
+	stateRoot, err := attestedState.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attested state root")
+	}
+	header.StateRoot = stateRoot[:]
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attested header root")
+	}
+	if !bytes.Equal(headerRoot[:], b.ParentRoot()) {
+		return nil, errors.New("attested state is not the post-state of the parent block")
+	}
+
+	update := &ethpbv2.LightClientUpdate{
+		AttestedHeader: &ethpbv1.BeaconBlockHeader{
+			Slot:          header.Slot,
+			ProposerIndex: header.ProposerIndex,
+			ParentRoot:    header.ParentRoot,
+			StateRoot:     header.StateRoot,
+			BodyRoot:      header.BodyRoot,
+		},
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      syncAggregate.SyncCommitteeBits,
+			SyncCommitteeSignature: syncAggregate.SyncCommitteeSignature,
+		},
+		SignatureSlot: b.Slot(),
+	}
+
+	attestedPeriod := core.SyncCommitteePeriod(core.SlotToEpoch(header.Slot))
+	if attestedPeriod == core.SyncCommitteePeriod(core.SlotToEpoch(b.Slot())) {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		branch, err := attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute next sync committee proof")
+		}
+		update.NextSyncCommittee = &ethpbv2.SyncCommittee{
+			Pubkeys:         committee.Pubkeys,
+			AggregatePubkey: committee.AggregatePubkey,
+		}
+		update.NextSyncCommitteeBranch = branch
+	}
+
+	// The finalized block is not known before the first finalization, or when it precedes the origin of
+	// a node started from a checkpoint.
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		return update, nil
+	}
+	finalizedBlock, err := s.cfg.BeaconDB.Block(ctx, finalizedRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	if finalizedBlock == nil || finalizedBlock.IsNil() {
+		return update, nil
+	}
+	finalizedHeader, err := migration.BlockIfaceToV1BlockHeader(finalizedBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block header")
+	}
+	branch, err := attestedState.FinalizedRootProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finalized root proof")
+	}
+	update.FinalizedHeader = finalizedHeader.Message
+	update.FinalityBranch = branch
+	return update, nil
+}
+
+// isBetterLightClientUpdate returns true if the new update should replace the old one as the best update
+// of a sync committee period.
+//
+// Spec code:
+// def is_better_update(new_update: LightClientUpdate, old_update: LightClientUpdate) -> bool:
+//    # Compare supermajority (> 2/3) sync committee participation
+//    max_active_participants = len(new_update.sync_aggregate.sync_committee_bits)
+//    new_num_active_participants = sum(new_update.sync_aggregate.sync_committee_bits)
+//    old_num_active_participants = sum(old_update.sync_aggregate.sync_committee_bits)
+//    new_has_supermajority = new_num_active_participants * 3 >= max_active_participants * 2
+//    old_has_supermajority = old_num_active_participants * 3 >= max_active_participants * 2
+//    if new_has_supermajority != old_has_supermajority:
+//        return new_has_supermajority > old_has_supermajority
+//    if not new_has_supermajority and new_num_active_participants != old_num_active_participants:
+//        return new_num_active_participants > old_num_active_participants
+//
+//    # Compare presence of relevant sync committee
+//    new_has_relevant_sync_committee = is_sync_committee_update(new_update)
+//    old_has_relevant_sync_committee = is_sync_committee_update(old_update)
+//    if new_has_relevant_sync_committee != old_has_relevant_sync_committee:
+//        return new_has_relevant_sync_committee
+//
+//    # Compare indication of any finality
+//    new_has_finality = is_finality_update(new_update)
+//    old_has_finality = is_finality_update(old_update)
+//    if new_has_finality != old_has_finality:
+//        return new_has_finality
+//
+//    # Tiebreaker 1: Sync committee participation beyond supermajority
+//    if new_num_active_participants != old_num_active_participants:
+//        return new_num_active_participants > old_num_active_participants
+//
+//    # Tiebreaker 2: Prefer older data (fewer changes to best)
+//    if new_update.attested_header.slot != old_update.attested_header.slot:
+//        return new_update.attested_header.slot < old_update.attested_header.slot
+//    return new_update.signature_slot < old_update.signature_slot
+func isBetterLightClientUpdate(newUpdate, oldUpdate *ethpbv2.LightClientUpdate) bool {
+	maxActive := newUpdate.SyncAggregate.SyncCommitteeBits.Len()
+	newActive := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldActive := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newSupermajority := newActive*3 >= maxActive*2
+	oldSupermajority := oldActive*3 >= maxActive*2
+	if newSupermajority != oldSupermajority {
+		return newSupermajority
+	}
+	if !newSupermajority && newActive != oldActive {
+		return newActive > oldActive
+	}
+
+	newHasSyncCommittee := newUpdate.NextSyncCommittee != nil
+	oldHasSyncCommittee := oldUpdate.NextSyncCommittee != nil
+	if newHasSyncCommittee != oldHasSyncCommittee {
+		return newHasSyncCommittee
+	}
+
+	newHasFinality := newUpdate.FinalizedHeader != nil
+	oldHasFinality := oldUpdate.FinalizedHeader != nil
+	if newHasFinality != oldHasFinality {
+		return newHasFinality
+	}
+
+	if newActive != oldActive {
+		return newActive > oldActive
+	}
+	if newUpdate.AttestedHeader.Slot != oldUpdate.AttestedHeader.Slot {
+		return newUpdate.AttestedHeader.Slot < oldUpdate.AttestedHeader.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
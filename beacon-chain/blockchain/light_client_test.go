@@ -0,0 +1,176 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func syncCommitteeBits(participants uint64) bitfield.Bitvector512 {
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	return bits
+}
+
+// lightClientBlock returns a block at slot whose parent is the block of the attested state.
+func lightClientBlock(t *testing.T, attestedState state.BeaconState, slot types.Slot, participants uint64) block.SignedBeaconBlock {
+	header := attestedState.LatestBlockHeader()
+	stateRoot, err := attestedState.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	header.StateRoot = stateRoot[:]
+	parentRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	blk := testutil.NewBeaconBlockAltair()
+	blk.Block.Slot = slot
+	blk.Block.ParentRoot = parentRoot[:]
+	blk.Block.Body.SyncAggregate.SyncCommitteeBits = syncCommitteeBits(participants)
+	signed, err := wrapper.WrappedAltairSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestProcessLightClientUpdate(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s := &Service{cfg: &Config{BeaconDB: beaconDB}}
+	attestedState, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+
+	require.NoError(t, s.processLightClientUpdate(ctx, lightClientBlock(t, attestedState, 1, 0), attestedState))
+	update, err := beaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update, "Update without participants was saved")
+
+	require.NoError(t, s.processLightClientUpdate(ctx, lightClientBlock(t, attestedState, 1, 512), attestedState))
+	update, err = beaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.Equal(t, types.Slot(0), update.AttestedHeader.Slot)
+	assert.Equal(t, types.Slot(1), update.SignatureSlot)
+	require.NotNil(t, update.NextSyncCommittee)
+	assert.Equal(t, 5, len(update.NextSyncCommitteeBranch))
+	assert.Equal(t, (*ethpbv1.BeaconBlockHeader)(nil), update.FinalizedHeader, "No block is finalized")
+	assert.Equal(t, (*ethpbv2.LightClientFinalityUpdate)(nil), s.LatestLightClientFinalityUpdate())
+	require.NotNil(t, s.LatestLightClientOptimisticUpdate())
+	assert.Equal(t, types.Slot(1), s.LatestLightClientOptimisticUpdate().SignatureSlot)
+
+	// An update proving finality replaces the saved update of the period.
+	finalizedBlock := testutil.NewBeaconBlockAltair()
+	finalizedRoot, err := finalizedBlock.Block.HashTreeRoot()
+	require.NoError(t, err)
+	signedFinalized, err := wrapper.WrappedAltairSignedBeaconBlock(finalizedBlock)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, signedFinalized))
+	require.NoError(t, attestedState.SetFinalizedCheckpoint(&ethpb.Checkpoint{Root: finalizedRoot[:]}))
+
+	require.NoError(t, s.processLightClientUpdate(ctx, lightClientBlock(t, attestedState, 2, 400), attestedState))
+	update, err = beaconDB.LightClientUpdate(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2), update.SignatureSlot)
+	require.NotNil(t, update.FinalizedHeader)
+	headerRoot, err := update.FinalizedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, finalizedRoot, headerRoot)
+	assert.Equal(t, 6, len(update.FinalityBranch))
+	finalityUpdate := s.LatestLightClientFinalityUpdate()
+	require.NotNil(t, finalityUpdate)
+	assert.Equal(t, types.Slot(2), finalityUpdate.SignatureSlot)
+	// The optimistic update is only replaced by an update attesting a later block.
+	assert.Equal(t, types.Slot(1), s.LatestLightClientOptimisticUpdate().SignatureSlot)
+}
+
+func TestProcessLightClientUpdate_WrongAttestedState(t *testing.T) {
+	s := &Service{cfg: &Config{BeaconDB: testDB.SetupDB(t)}}
+	attestedState, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+	signed := lightClientBlock(t, attestedState, 1, 512)
+	require.NoError(t, attestedState.SetGenesisTime(1))
+
+	err := s.processLightClientUpdate(context.Background(), signed, attestedState)
+	assert.ErrorContains(t, "attested state is not the post-state of the parent block", err)
+}
+
+func TestIsBetterLightClientUpdate(t *testing.T) {
+	update := func(participants uint64, syncCommittee, finality bool, attestedSlot, signatureSlot types.Slot) *ethpbv2.LightClientUpdate {
+		u := &ethpbv2.LightClientUpdate{
+			AttestedHeader: &ethpbv1.BeaconBlockHeader{Slot: attestedSlot},
+			SyncAggregate:  &ethpbv1.SyncAggregate{SyncCommitteeBits: syncCommitteeBits(participants)},
+			SignatureSlot:  signatureSlot,
+		}
+		if syncCommittee {
+			u.NextSyncCommittee = &ethpbv2.SyncCommittee{}
+		}
+		if finality {
+			u.FinalizedHeader = &ethpbv1.BeaconBlockHeader{}
+		}
+		return u
+	}
+
+	tests := []struct {
+		name      string
+		newUpdate *ethpbv2.LightClientUpdate
+		oldUpdate *ethpbv2.LightClientUpdate
+		want      bool
+	}{
+		{
+			name:      "supermajority wins",
+			newUpdate: update(342, false, false, 1, 2),
+			oldUpdate: update(341, true, true, 1, 2),
+			want:      true,
+		},
+		{
+			name:      "more participants without supermajority",
+			newUpdate: update(100, false, false, 1, 2),
+			oldUpdate: update(99, true, true, 1, 2),
+			want:      true,
+		},
+		{
+			name:      "sync committee wins",
+			newUpdate: update(400, true, false, 1, 2),
+			oldUpdate: update(500, false, true, 1, 2),
+			want:      true,
+		},
+		{
+			name:      "finality wins",
+			newUpdate: update(400, true, true, 1, 2),
+			oldUpdate: update(500, true, false, 1, 2),
+			want:      true,
+		},
+		{
+			name:      "more participants with supermajority",
+			newUpdate: update(400, true, true, 1, 2),
+			oldUpdate: update(500, true, true, 1, 2),
+			want:      false,
+		},
+		{
+			name:      "older attested header wins",
+			newUpdate: update(400, true, true, 1, 3),
+			oldUpdate: update(400, true, true, 2, 3),
+			want:      true,
+		},
+		{
+			name:      "older signature slot wins",
+			newUpdate: update(400, true, true, 1, 3),
+			oldUpdate: update(400, true, true, 1, 2),
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isBetterLightClientUpdate(tt.newUpdate, tt.oldUpdate))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -98,6 +99,7 @@ func (s *Service) onAttestation(ctx context.Context, a *ethpb.Attestation) error
 
 	// Update forkchoice store with the new attestation for updating weight.
 	s.cfg.ForkChoiceStore.ProcessAttestation(ctx, indexedAtt.AttestingIndices, bytesutil.ToBytes32(a.Data.BeaconBlockRoot), a.Data.Target.Epoch)
+	cache.Liveness.MarkLive(a.Data.Target.Epoch, indexedAtt.AttestingIndices)
 
 	return nil
 }
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -20,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/version"
 	"go.opencensus.io/trace"
 )
 
@@ -97,6 +101,13 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 		return err
 	}
 
+	// The state transition modifies the pre-state, which is the post-state of the parent block
+	// attested by the sync aggregate of the block.
+	var attestedState state.BeaconState
+	if featureconfig.Get().EnableLightClientServer && preState.Version() != version.Phase0 {
+		attestedState = preState.Copy()
+	}
+
 	postState, err := transition.ExecuteStateTransition(ctx, preState, signed)
 	if err != nil {
 		return err
@@ -106,6 +117,19 @@ func (s *Service) onBlock(ctx context.Context, signed block.SignedBeaconBlock, b
 		return err
 	}
 
+	if attestedState != nil {
+		if err := s.processLightClientUpdate(ctx, signed, attestedState); err != nil {
+			log.WithError(err).Warn("Could not process light client update")
+		}
+	}
+
+	// A block received before the attestation deadline of its slot is boosted in fork choice.
+	if err := s.cfg.ForkChoiceStore.BoostProposerRoot(ctx, b.Slot(), blockRoot, s.genesisTime); err != nil {
+		return err
+	}
+	// The slashings of the block were verified by the state transition.
+	s.insertSlashingsToForkChoiceStore(ctx, b.Body().AttesterSlashings())
+
 	// Updating next slot state cache can happen in the background. It shouldn't block rest of the process.
 	if featureconfig.Get().EnableNextSlotStateCache {
 		go func() {
@@ -370,6 +394,7 @@ func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Contex
 	if err := s.insertBlockToForkChoiceStore(ctx, blk, root, fCheckpoint, jCheckpoint); err != nil {
 		return err
 	}
+	cache.Liveness.MarkLive(core.SlotToEpoch(blk.Slot()), []uint64{uint64(blk.ProposerIndex())})
 	// Feed in block's attestations to fork choice store.
 	for _, a := range blk.Body().Attestations() {
 		committee, err := helpers.BeaconCommitteeFromState(st, a.Data.Slot, a.Data.CommitteeIndex)
@@ -381,10 +406,21 @@ func (s *Service) insertBlockAndAttestationsToForkChoiceStore(ctx context.Contex
 			return err
 		}
 		s.cfg.ForkChoiceStore.ProcessAttestation(ctx, indices, bytesutil.ToBytes32(a.Data.BeaconBlockRoot), a.Data.Target.Epoch)
+		cache.Liveness.MarkLive(a.Data.Target.Epoch, indices)
 	}
 	return nil
 }
 
+// Inserts the indices of the validators slashed by the attester slashings to the fork choice store, which
+// discards their votes. The slashings must have been verified by the caller.
+func (s *Service) insertSlashingsToForkChoiceStore(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
+	for _, slashing := range slashings {
+		for _, index := range blocks.SlashableAttesterIndices(slashing) {
+			s.cfg.ForkChoiceStore.InsertSlashedIndex(ctx, types.ValidatorIndex(index))
+		}
+	}
+}
+
 func (s *Service) insertBlockToForkChoiceStore(ctx context.Context, blk block.BeaconBlock,
 	root [32]byte, fCheckpoint, jCheckpoint *ethpb.Checkpoint) error {
 	if err := s.fillInForkChoiceMissingBlocks(ctx, blk, fCheckpoint, jCheckpoint); err != nil {
@@ -437,6 +437,16 @@ func (s *Service) insertFinalizedDeposits(ctx context.Context, fRoot [32]byte) e
 	if err = s.cfg.DepositCache.PruneProofs(ctx, eth1DepositIndex); err != nil {
 		return errors.Wrap(err, "could not prune deposit proofs")
 	}
+	// Deposits processed in the finalized state are never needed again to build deposit proofs,
+	// and are pruned down to a deposit snapshot. This is only done once all the deposits of the
+	// finalized eth1 data are processed, so that the snapshot matches the eth1 data of the finalized
+	// state and proofs can still be built for the pending deposits.
+	if finalizedState.Eth1DepositIndex() != finalizedState.Eth1Data().DepositCount {
+		return nil
+	}
+	if err := s.cfg.DepositCache.PruneFinalizedDeposits(ctx, int64(finalizedState.Eth1Data().DepositCount)); err != nil {
+		return errors.Wrap(err, "could not prune finalized deposits")
+	}
 	return nil
 }
 
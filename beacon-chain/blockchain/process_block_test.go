@@ -982,6 +982,7 @@ func TestInsertFinalizedDeposits(t *testing.T) {
 	service.finalizedCheckpt = &ethpb.Checkpoint{Root: gRoot[:]}
 	gs = gs.Copy()
 	assert.NoError(t, gs.SetEth1Data(&ethpb.Eth1Data{DepositCount: 10}))
+	assert.NoError(t, gs.SetEth1DepositIndex(5))
 	assert.NoError(t, service.cfg.StateGen.SaveState(ctx, [32]byte{'m', 'o', 'c', 'k'}, gs))
 	zeroSig := [96]byte{}
 	for i := uint64(0); i < uint64(4*params.BeaconConfig().SlotsPerEpoch); i++ {
@@ -996,10 +997,25 @@ func TestInsertFinalizedDeposits(t *testing.T) {
 	assert.NoError(t, service.insertFinalizedDeposits(ctx, [32]byte{'m', 'o', 'c', 'k'}))
 	fDeposits := depositCache.FinalizedDeposits(ctx)
 	assert.Equal(t, 9, int(fDeposits.MerkleTrieIndex), "Finalized deposits not inserted correctly")
+	// Deposits are not pruned while the finalized state has pending deposits.
+	assert.Equal(t, true, depositCache.DepositSnapshot(ctx) == nil, "Finalized deposits pruned with pending deposits")
 	deps := depositCache.AllDeposits(ctx, big.NewInt(109))
+	assert.Equal(t, 10, len(deps))
 	for _, d := range deps {
 		assert.DeepEqual(t, [][]byte(nil), d.Proof, "Proofs are not empty")
 	}
+
+	// Once the pending deposits are processed, deposits are pruned down to a snapshot
+	// matching the eth1 data of the finalized state.
+	fRoot := [32]byte{'f', 'i', 'n', 'a', 'l'}
+	gs = gs.Copy()
+	assert.NoError(t, gs.SetEth1DepositIndex(10))
+	assert.NoError(t, service.cfg.StateGen.SaveState(ctx, fRoot, gs))
+	assert.NoError(t, service.insertFinalizedDeposits(ctx, fRoot))
+	snapshot := depositCache.DepositSnapshot(ctx)
+	require.NotNil(t, snapshot, "Finalized deposits not pruned")
+	assert.Equal(t, gs.Eth1Data().DepositCount, snapshot.DepositCount, "Finalized deposits not pruned correctly")
+	assert.Equal(t, 0, len(depositCache.AllDeposits(ctx, big.NewInt(109))))
 }
 
 func TestRemoveBlockAttestationsInPool_Canonical(t *testing.T) {
@@ -119,7 +119,17 @@ func (s *Service) processAttestationsRoutine(subscribedToStateEvents chan<- stru
 		select {
 		case <-s.ctx.Done():
 			return
-		case <-st.C():
+		case slot := <-st.C():
+			// The proposer boost only lasts for the slot of the boosted block.
+			if err := s.cfg.ForkChoiceStore.ResetBoostedProposerRoot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not reset boosted proposer root in fork choice")
+			}
+			// Periodically save fork choice store, so it can be restored after an unclean shutdown.
+			if core.IsEpochStart(slot) {
+				if err := s.saveForkChoice(s.ctx); err != nil {
+					log.WithError(err).Error("Could not save fork choice store")
+				}
+			}
 			// Continue when there's no fork choice attestation, there's nothing to process and update head.
 			// This covers the condition when the node is still initial syncing to the head of the chain.
 			if s.cfg.AttPool.ForkchoiceAttestationCount() == 0 {
@@ -8,6 +8,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/timeutils"
@@ -25,6 +26,11 @@ type BlockReceiver interface {
 	HasInitSyncBlock(root [32]byte) bool
 }
 
+// SlashingReceiver interface defines the methods of chain service for receiving verified slashings.
+type SlashingReceiver interface {
+	ReceiveAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing)
+}
+
 // ReceiveBlock is a function that defines the the operations (minus pubsub)
 // that are performed on blocks that is received from regular sync service. The operations consists of:
 //   1. Validate block, apply state transition and update check points
@@ -162,6 +168,13 @@ func (s *Service) handlePostBlockOperations(b block.BeaconBlock) error {
 	return nil
 }
 
+// ReceiveAttesterSlashing receives an attester slashing from gossip or from the API, and inserts
+// the slashed indices into the fork choice store for their votes to be discarded before the
+// slashing is included in a block. The slashing must have been verified by the caller.
+func (s *Service) ReceiveAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing) {
+	s.insertSlashingsToForkChoiceStore(ctx, []*ethpb.AttesterSlashing{slashing})
+}
+
 // This checks whether it's time to start saving hot state to DB.
 // It's time when there's `epochsSinceFinalitySaveHotStateDB` epochs of non-finality.
 func (s *Service) checkSaveHotStateDB(ctx context.Context) error {
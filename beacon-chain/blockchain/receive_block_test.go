@@ -354,6 +354,25 @@ func TestService_HasInitSyncBlock(t *testing.T) {
 	}
 }
 
+func TestService_ReceiveAttesterSlashing(t *testing.T) {
+	ctx := context.Background()
+	fc := protoarray.New(0, 0, [32]byte{})
+	s, err := NewService(ctx, &Config{StateNotifier: &blockchainTesting.MockStateNotifier{}, ForkChoiceStore: fc})
+	require.NoError(t, err)
+
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{0, 1}},
+		Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1}},
+	}
+	s.ReceiveAttesterSlashing(ctx, slashing)
+
+	// The votes of the slashed validator are ignored by fork choice.
+	fc.ProcessAttestation(ctx, []uint64{1}, [32]byte{'a'}, 1)
+	assert.Equal(t, uint64(0), fc.VotesCount())
+	fc.ProcessAttestation(ctx, []uint64{0}, [32]byte{'a'}, 1)
+	assert.Equal(t, uint64(1), fc.VotesCount())
+}
+
 func TestCheckSaveHotStateDB_Enabling(t *testing.T) {
 	beaconDB := testDB.SetupDB(t)
 	hook := logTest.NewGlobal()
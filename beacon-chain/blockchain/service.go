@@ -29,6 +29,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -65,6 +66,10 @@ type Service struct {
 	justifiedBalances     []uint64
 	justifiedBalancesLock sync.RWMutex
 	wsVerified            bool
+	// Latest light client updates, produced from imported blocks.
+	lightClientLock             sync.RWMutex
+	lightClientFinalityUpdate   *ethpbv2.LightClientFinalityUpdate
+	lightClientOptimisticUpdate *ethpbv2.LightClientOptimisticUpdate
 }
 
 // Config options for the service.
@@ -172,7 +177,10 @@ func (s *Service) Start() {
 		s.bestJustifiedCheckpt = ethpb.CopyCheckpoint(justifiedCheckpoint)
 		s.finalizedCheckpt = ethpb.CopyCheckpoint(finalizedCheckpoint)
 		s.prevFinalizedCheckpt = ethpb.CopyCheckpoint(finalizedCheckpoint)
-		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		s.resumeForkChoice(s.ctx, justifiedCheckpoint, finalizedCheckpoint)
+		if err := s.insertOriginToForkChoice(s.ctx, finalizedCheckpoint); err != nil {
+			log.Fatalf("Could not insert origin checkpoint to fork choice store: %v", err)
+		}
 
 		ss, err := core.StartSlot(s.finalizedCheckpt.Epoch)
 		if err != nil {
@@ -316,7 +324,12 @@ func (s *Service) Stop() error {
 	}
 
 	// Save initial sync cached blocks to the DB before stop.
-	return s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks())
+	if err := s.cfg.BeaconDB.SaveBlocks(s.ctx, s.getInitSyncBlocks()); err != nil {
+		return err
+	}
+
+	// Save fork choice store to the DB, so it is restored on restart.
+	return s.saveForkChoice(s.ctx)
 }
 
 // Status always returns nil unless there is an error condition that causes
@@ -455,11 +468,50 @@ func (s *Service) initializeChainInfo(ctx context.Context) error {
 	return nil
 }
 
-// This is called when a client starts from non-genesis slot. This passes last justified and finalized
+// This is called when a client starts from non-genesis slot. The fork choice store saved in DB is
+// restored if it is consistent with the DB, otherwise this passes last justified and finalized
 // information to fork choice service to initializes fork choice store.
-func (s *Service) resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint *ethpb.Checkpoint) {
-	store := protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, bytesutil.ToBytes32(finalizedCheckpoint.Root))
-	s.cfg.ForkChoiceStore = store
+func (s *Service) resumeForkChoice(ctx context.Context, justifiedCheckpoint, finalizedCheckpoint *ethpb.Checkpoint) {
+	store, err := s.restoreForkChoice(ctx, finalizedCheckpoint)
+	if err != nil {
+		log.WithError(err).Warn("Could not restore fork choice store, rebuilding it from the finalized checkpoint")
+	}
+	if store != nil {
+		log.WithField("nodes", len(store.Nodes())).Info("Restored fork choice store from db")
+		s.cfg.ForkChoiceStore = store
+		return
+	}
+	s.cfg.ForkChoiceStore = protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, bytesutil.ToBytes32(finalizedCheckpoint.Root))
+}
+
+// This is called when a client was started from a checkpoint rather than genesis. The ancestors of the
+// origin block are not in the DB, so the origin block itself is seeded as the root of the fork choice store
+// for as long as it is the finalized block.
+func (s *Service) insertOriginToForkChoice(ctx context.Context, finalizedCheckpoint *ethpb.Checkpoint) error {
+	originRoot, err := s.cfg.BeaconDB.OriginBlockRoot(ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block root")
+	}
+	if originRoot != bytesutil.ToBytes32(finalizedCheckpoint.Root) || s.cfg.ForkChoiceStore.HasNode(originRoot) {
+		return nil
+	}
+	originBlock, err := s.cfg.BeaconDB.Block(ctx, originRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not get origin block")
+	}
+	if originBlock == nil || originBlock.IsNil() {
+		return errors.New("origin block not found in db")
+	}
+	return s.cfg.ForkChoiceStore.ProcessBlock(ctx,
+		originBlock.Block().Slot(),
+		originRoot,
+		bytesutil.ToBytes32(originBlock.Block().ParentRoot()),
+		bytesutil.ToBytes32(originBlock.Block().Body().Graffiti()),
+		finalizedCheckpoint.Epoch,
+		finalizedCheckpoint.Epoch)
 }
 
 // This returns true if block has been processed before. Two ways to verify the block has been processed:
@@ -521,3 +521,44 @@ func BenchmarkHasBlockForkChoiceStore(b *testing.B) {
 		require.Equal(b, true, s.cfg.ForkChoiceStore.HasNode(r), "Block is not in fork choice store")
 	}
 }
+
+func TestInsertOriginToForkChoice(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	s := &Service{
+		cfg: &Config{ForkChoiceStore: protoarray.New(0, 0, [32]byte{}), BeaconDB: beaconDB},
+	}
+
+	// Nothing is inserted for a node which was synced from genesis.
+	require.NoError(t, s.insertOriginToForkChoice(ctx, &ethpb.Checkpoint{Root: make([]byte, 32)}))
+	assert.Equal(t, false, s.cfg.ForkChoiceStore.HasNode([32]byte{}))
+
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(64))
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 64
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       64,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	serState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	serBlock, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveOrigin(ctx, serState, serBlock))
+
+	finalized, err := beaconDB.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	require.NoError(t, s.insertOriginToForkChoice(ctx, finalized))
+	assert.Equal(t, true, s.cfg.ForkChoiceStore.HasNode(root))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -49,6 +50,7 @@ type ChainService struct {
 	opNotifier                  opfeed.Notifier
 	ValidAttestation            bool
 	ForkChoiceStore             *protoarray.Store
+	VotesCount                  uint64
 	VerifyBlkDescendantErr      error
 	Slot                        *types.Slot // Pointer because 0 is a useful value, so checking against it can be incorrect.
 	CurrentSyncCommitteeIndices []types.CommitteeIndex
@@ -58,6 +60,8 @@ type ChainService struct {
 	SyncContributionProofDomain []byte
 	PublicKey                   [48]byte
 	SyncCommitteePubkeys        [][]byte
+	LightClientFinalityUpdate   *ethpbv2.LightClientFinalityUpdate
+	LightClientOptimisticUpdate *ethpbv2.LightClientOptimisticUpdate
 }
 
 // StateNotifier mocks the same method in the chain service.
@@ -294,6 +298,9 @@ func (s *ChainService) ReceiveAttestationNoPubsub(context.Context, *ethpb.Attest
 	return nil
 }
 
+// ReceiveAttesterSlashing mocks ReceiveAttesterSlashing method in chain service.
+func (s *ChainService) ReceiveAttesterSlashing(context.Context, *ethpb.AttesterSlashing) {}
+
 // AttestationPreState mocks AttestationPreState method in chain service.
 func (s *ChainService) AttestationPreState(_ context.Context, _ *ethpb.Attestation) (state.BeaconState, error) {
 	return s.State, nil
@@ -322,6 +329,11 @@ func (s *ChainService) ProtoArrayStore() *protoarray.Store {
 	return s.ForkChoiceStore
 }
 
+// ForkChoiceVotesCount mocks the same method in the chain service.
+func (s *ChainService) ForkChoiceVotesCount() uint64 {
+	return s.VotesCount
+}
+
 // GenesisTime mocks the same method in the chain service.
 func (s *ChainService) GenesisTime() time.Time {
 	return s.Genesis
@@ -439,3 +451,13 @@ func (s *ChainService) HeadSyncSelectionProofDomain(ctx context.Context, slot ty
 func (s *ChainService) HeadSyncContributionProofDomain(ctx context.Context, slot types.Slot) ([]byte, error) {
 	return s.SyncContributionProofDomain, nil
 }
+
+// LatestLightClientFinalityUpdate mocks LatestLightClientFinalityUpdate and always returns the stored update.
+func (s *ChainService) LatestLightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	return s.LightClientFinalityUpdate
+}
+
+// LatestLightClientOptimisticUpdate mocks LatestLightClientOptimisticUpdate and always returns the stored update.
+func (s *ChainService) LatestLightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	return s.LightClientOptimisticUpdate
+}
@@ -38,6 +38,7 @@ type DepositFetcher interface {
 	DepositsNumberAndRootAtHeight(ctx context.Context, blockHeight *big.Int) (uint64, [32]byte)
 	FinalizedDeposits(ctx context.Context) *FinalizedDeposits
 	NonFinalizedDeposits(ctx context.Context, untilBlk *big.Int) []*ethpb.Deposit
+	DepositSnapshot(ctx context.Context) *ethpb.DepositSnapshot
 }
 
 // FinalizedDeposits stores the trie of deposits that have been included
@@ -54,7 +55,9 @@ type DepositCache struct {
 	pendingDeposits   []*dbpb.DepositContainer
 	deposits          []*dbpb.DepositContainer
 	finalizedDeposits *FinalizedDeposits
-	depositsLock      sync.RWMutex
+	// Snapshot of the deposit tree the finalized deposits have been pruned to.
+	snapshot     *ethpb.DepositSnapshot
+	depositsLock sync.RWMutex
 }
 
 // New instantiates a new deposit cache
@@ -90,8 +93,8 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if int(index) != len(dc.deposits) {
-		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", len(dc.deposits), index)
+	if wanted := dc.prunedDepositCount() + int64(len(dc.deposits)); index != wanted {
+		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", wanted, index)
 	}
 	// Keep the slice sorted on insertion in order to avoid costly sorting on retrieval.
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
@@ -146,7 +149,8 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 	}
 }
 
-// AllDepositContainers returns all historical deposit containers.
+// AllDepositContainers returns all historical deposit containers, except for
+// the ones pruned down to the deposit snapshot.
 func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*dbpb.DepositContainer {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDepositContainers")
 	defer span.End()
@@ -158,6 +162,7 @@ func (dc *DepositCache) AllDepositContainers(ctx context.Context) []*dbpb.Deposi
 
 // AllDeposits returns a list of historical deposits until the given block number
 // (inclusive). If no block is specified then this method returns all historical deposits.
+// Deposits pruned down to the deposit snapshot are not part of the returned deposits.
 func (dc *DepositCache) AllDeposits(ctx context.Context, untilBlk *big.Int) []*ethpb.Deposit {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.AllDeposits")
 	defer span.End()
@@ -181,12 +186,18 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight > blockHeight.Uint64() })
-	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
-	// deposit.
 	if heightIdx == 0 {
+		// Send the deposit root of the snapshot the deposits have been pruned to, if the block height
+		// is past the snapshot.
+		if dc.snapshot != nil && blockHeight.Uint64() >= dc.snapshot.ExecutionBlockHeight {
+			return dc.snapshot.DepositCount, bytesutil.ToBytes32(dc.snapshot.DepositRoot)
+		}
+		// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
+		// deposit.
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	last := dc.deposits[heightIdx-1]
+	return uint64(last.Index + 1), bytesutil.ToBytes32(last.DepositRoot)
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
@@ -252,11 +263,12 @@ func (dc *DepositCache) PruneProofs(ctx context.Context, untilDepositIndex int64
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if untilDepositIndex >= int64(len(dc.deposits)) {
-		untilDepositIndex = int64(len(dc.deposits) - 1)
+	prunedCount := dc.prunedDepositCount()
+	if untilDepositIndex >= prunedCount+int64(len(dc.deposits)) {
+		untilDepositIndex = prunedCount + int64(len(dc.deposits)) - 1
 	}
 
-	for i := untilDepositIndex; i >= 0; i-- {
+	for i := untilDepositIndex - prunedCount; i >= 0; i-- {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
@@ -269,3 +281,95 @@ func (dc *DepositCache) PruneProofs(ctx context.Context, untilDepositIndex int64
 
 	return nil
 }
+
+// PruneFinalizedDeposits removes the deposits with an index lower than depositCount, which have been
+// processed in the finalized beacon state and are no longer needed to build deposit proofs. The trie of
+// finalized deposits is reduced to the deposit snapshot at that count, along with the following deposits.
+func (dc *DepositCache) PruneFinalizedDeposits(ctx context.Context, depositCount int64) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.PruneFinalizedDeposits")
+	defer span.End()
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	// Only deposits inserted in the trie of finalized deposits can be pruned.
+	if numItems := int64(dc.finalizedDeposits.Deposits.NumOfItems()); depositCount > numItems {
+		depositCount = numItems
+	}
+	prunedCount := dc.prunedDepositCount()
+	if depositCount <= prunedCount {
+		return nil
+	}
+	lastIdx := depositCount - 1 - prunedCount
+	if lastIdx >= int64(len(dc.deposits)) || dc.deposits[lastIdx].Index != depositCount-1 {
+		return errors.Errorf("deposit with index %d is missing from the cache", depositCount-1)
+	}
+	last := dc.deposits[lastIdx]
+	finalized, err := dc.finalizedDeposits.Deposits.FinalizedRoots(int(depositCount))
+	if err != nil {
+		return errors.Wrap(err, "could not compute finalized roots of the deposit trie")
+	}
+	if err := dc.finalizedDeposits.Deposits.Finalize(int(depositCount)); err != nil {
+		return errors.Wrap(err, "could not finalize deposit trie")
+	}
+	dc.snapshot = &ethpb.DepositSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          bytesutil.SafeCopyBytes(last.DepositRoot),
+		DepositCount:         uint64(depositCount),
+		ExecutionBlockHeight: last.Eth1BlockHeight,
+	}
+	// Copy the remaining deposits so that the pruned ones can be garbage collected.
+	dc.deposits = append([]*dbpb.DepositContainer{}, dc.deposits[lastIdx+1:]...)
+	return nil
+}
+
+// InsertDepositSnapshot initializes the finalized deposits from a deposit snapshot, for a node which
+// does not have the deposits preceding it. This must be done before inserting any deposit.
+func (dc *DepositCache) InsertDepositSnapshot(ctx context.Context, snapshot *ethpb.DepositSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertDepositSnapshot")
+	defer span.End()
+	if snapshot == nil {
+		return errors.New("nil deposit snapshot")
+	}
+	depositTrie, err := trieutil.TrieFromFinalizedRoots(
+		snapshot.Finalized, snapshot.DepositCount, params.BeaconConfig().DepositContractTreeDepth,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit trie from snapshot")
+	}
+	if root := depositTrie.HashTreeRoot(); !bytes.Equal(root[:], snapshot.DepositRoot) {
+		return errors.Errorf("deposit snapshot root %#x does not match the root of its finalized roots %#x",
+			snapshot.DepositRoot, root)
+	}
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	if len(dc.deposits) > 0 || dc.finalizedDeposits.MerkleTrieIndex >= 0 {
+		return errors.New("deposit cache already contains deposits")
+	}
+	dc.finalizedDeposits = &FinalizedDeposits{
+		Deposits:        depositTrie,
+		MerkleTrieIndex: int64(snapshot.DepositCount) - 1,
+	}
+	dc.snapshot = ethpb.CopyDepositSnapshot(snapshot)
+	return nil
+}
+
+// DepositSnapshot returns the deposit snapshot the finalized deposits have been pruned to,
+// or nil if no deposits have been pruned yet. The execution block hash of the snapshot is
+// not known to the cache, only the height of the block of its last deposit.
+func (dc *DepositCache) DepositSnapshot(ctx context.Context) *ethpb.DepositSnapshot {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.DepositSnapshot")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	return ethpb.CopyDepositSnapshot(dc.snapshot)
+}
+
+// Number of deposits pruned from the cache, which precede the first deposit container.
+func (dc *DepositCache) prunedDepositCount() int64 {
+	if dc.snapshot == nil {
+		return 0
+	}
+	return int64(dc.snapshot.DepositCount)
+}
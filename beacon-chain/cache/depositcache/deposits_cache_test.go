@@ -785,6 +785,92 @@ func TestPruneProofs_CorrectlyHandleLastIndex(t *testing.T) {
 	assert.DeepEqual(t, [][]byte(nil), dc.deposits[3].Deposit.Proof)
 }
 
+func TestPruneFinalizedDeposits(t *testing.T) {
+	ctx := context.Background()
+	dc, err := New()
+	require.NoError(t, err)
+	trie, roots := insertTestDeposits(t, dc, 10)
+	dc.InsertFinalizedDeposits(ctx, 7)
+	require.NoError(t, dc.PruneProofs(ctx, 7))
+	assert.Equal(t, true, dc.DepositSnapshot(ctx) == nil)
+
+	require.NoError(t, dc.PruneFinalizedDeposits(ctx, 5))
+	snapshot := dc.DepositSnapshot(ctx)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, uint64(5), snapshot.DepositCount)
+	assert.Equal(t, uint64(14), snapshot.ExecutionBlockHeight)
+	assert.DeepEqual(t, roots[4][:], snapshot.DepositRoot)
+	// 5 deposits are covered by subtrees of 4 and 1 deposits.
+	assert.Equal(t, 2, len(snapshot.Finalized))
+	ctrs := dc.AllDepositContainers(ctx)
+	require.Equal(t, 5, len(ctrs))
+	assert.Equal(t, int64(5), ctrs[0].Index)
+
+	// The pruned trie of finalized deposits still proves the following deposits.
+	fd := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(7), fd.MerkleTrieIndex)
+	insertIndex := int(fd.MerkleTrieIndex + 1)
+	for _, d := range dc.NonFinalizedDeposits(ctx, nil) {
+		hash, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		fd.Deposits.Insert(hash[:], insertIndex)
+		insertIndex++
+	}
+	assert.Equal(t, trie.HashTreeRoot(), fd.Deposits.HashTreeRoot())
+	for i := 5; i < 10; i++ {
+		wanted, err := trie.MerkleProof(i)
+		require.NoError(t, err)
+		proof, err := fd.Deposits.MerkleProof(i)
+		require.NoError(t, err)
+		assert.DeepEqual(t, wanted, proof)
+	}
+
+	count, root := dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(13))
+	assert.Equal(t, uint64(0), count)
+	count, root = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(14))
+	assert.Equal(t, uint64(5), count)
+	assert.Equal(t, roots[4], root)
+	count, root = dc.DepositsNumberAndRootAtHeight(ctx, big.NewInt(16))
+	assert.Equal(t, uint64(7), count)
+	assert.Equal(t, roots[6], root)
+
+	assert.ErrorContains(t, "wanted deposit with index 10", dc.InsertDeposit(ctx, &ethpb.Deposit{}, 20, 5, [32]byte{}))
+	require.NoError(t, dc.InsertDeposit(ctx, &ethpb.Deposit{}, 20, 10, [32]byte{}))
+
+	// Deposits can only be pruned up to the finalized deposits.
+	require.NoError(t, dc.PruneFinalizedDeposits(ctx, 10))
+	assert.Equal(t, uint64(8), dc.DepositSnapshot(ctx).DepositCount)
+	assert.Equal(t, 3, len(dc.AllDepositContainers(ctx)))
+}
+
+func TestInsertDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	source, err := New()
+	require.NoError(t, err)
+	trie, roots := insertTestDeposits(t, source, 10)
+	source.InsertFinalizedDeposits(ctx, 9)
+	require.NoError(t, source.PruneFinalizedDeposits(ctx, 6))
+	snapshot := source.DepositSnapshot(ctx)
+
+	dc, err := New()
+	require.NoError(t, err)
+	invalid := ethpb.CopyDepositSnapshot(snapshot)
+	invalid.DepositRoot = roots[3][:]
+	assert.ErrorContains(t, "does not match", dc.InsertDepositSnapshot(ctx, invalid))
+	require.NoError(t, dc.InsertDepositSnapshot(ctx, snapshot))
+	assert.DeepEqual(t, snapshot, dc.DepositSnapshot(ctx))
+	fd := dc.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(5), fd.MerkleTrieIndex)
+	assert.Equal(t, roots[5], fd.Deposits.HashTreeRoot())
+
+	for _, ctr := range source.AllDepositContainers(ctx) {
+		require.NoError(t, dc.InsertDeposit(ctx, ctr.Deposit, ctr.Eth1BlockHeight, ctr.Index, bytesutil.ToBytes32(ctr.DepositRoot)))
+	}
+	dc.InsertFinalizedDeposits(ctx, 9)
+	assert.Equal(t, trie.HashTreeRoot(), dc.FinalizedDeposits(ctx).Deposits.HashTreeRoot())
+	assert.ErrorContains(t, "already contains deposits", dc.InsertDepositSnapshot(ctx, snapshot))
+}
+
 func makeDepositProof() [][]byte {
 	proof := make([][]byte, int(params.BeaconConfig().DepositContractTreeDepth)+1)
 	for i := range proof {
@@ -792,3 +878,26 @@ func makeDepositProof() [][]byte {
 	}
 	return proof
 }
+
+// insertTestDeposits inserts n deposits, one per eth1 block starting at block 10, and returns the
+// trie of these deposits along with the deposit root after each deposit.
+func insertTestDeposits(t *testing.T, dc *DepositCache, n int) (*trieutil.SparseMerkleTrie, [][32]byte) {
+	trie, err := trieutil.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	roots := make([][32]byte, n)
+	for i := 0; i < n; i++ {
+		deposit := &ethpb.Deposit{
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			},
+		}
+		hash, err := deposit.Data.HashTreeRoot()
+		require.NoError(t, err)
+		trie.Insert(hash[:], i)
+		roots[i] = trie.HashTreeRoot()
+		require.NoError(t, dc.InsertDeposit(context.Background(), deposit, uint64(10+i), int64(i), roots[i]))
+	}
+	return trie, roots
+}
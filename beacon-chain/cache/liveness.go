@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"sync"
+
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// livenessEpochs is the number of most recent epochs for which the liveness of
+// validators is retained.
+const livenessEpochs = 3
+
+type liveness struct {
+	epochs       map[types.Epoch]map[types.ValidatorIndex]bool
+	highestEpoch types.Epoch
+	lock         sync.RWMutex
+}
+
+// Liveness of validators, that is whether the beacon node processed attestations or
+// blocks of theirs, in the most recent epochs.
+var Liveness = newLiveness()
+
+func newLiveness() *liveness {
+	return &liveness{epochs: make(map[types.Epoch]map[types.ValidatorIndex]bool)}
+}
+
+// MarkLive records the validators with the given indices as live in the epoch. Epochs
+// older than the retained ones are ignored.
+func (l *liveness) MarkLive(epoch types.Epoch, indices []uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if epoch+livenessEpochs <= l.highestEpoch {
+		return
+	}
+	if epoch > l.highestEpoch {
+		l.highestEpoch = epoch
+		for e := range l.epochs {
+			if e+livenessEpochs <= epoch {
+				delete(l.epochs, e)
+			}
+		}
+	}
+	live, ok := l.epochs[epoch]
+	if !ok {
+		live = make(map[types.ValidatorIndex]bool, len(indices))
+		l.epochs[epoch] = live
+	}
+	for _, index := range indices {
+		live[types.ValidatorIndex(index)] = true
+	}
+}
+
+// IsLive returns true if the validator with the given index was live in the epoch.
+func (l *liveness) IsLive(epoch types.Epoch, index types.ValidatorIndex) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return l.epochs[epoch][index]
+}
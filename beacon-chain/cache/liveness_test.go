@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+)
+
+func TestLiveness_MarkLive(t *testing.T) {
+	l := newLiveness()
+	assert.Equal(t, false, l.IsLive(1, 10))
+
+	l.MarkLive(1, []uint64{10, 11})
+	l.MarkLive(2, []uint64{12})
+	assert.Equal(t, true, l.IsLive(1, 10))
+	assert.Equal(t, true, l.IsLive(1, 11))
+	assert.Equal(t, false, l.IsLive(1, 12))
+	assert.Equal(t, true, l.IsLive(2, 12))
+	assert.Equal(t, false, l.IsLive(2, 10))
+}
+
+func TestLiveness_PrunesOldEpochs(t *testing.T) {
+	l := newLiveness()
+	l.MarkLive(1, []uint64{10})
+	l.MarkLive(3, []uint64{10})
+	assert.Equal(t, true, l.IsLive(1, 10))
+
+	l.MarkLive(4, []uint64{10})
+	assert.Equal(t, false, l.IsLive(1, 10), "Epoch was not pruned")
+	assert.Equal(t, true, l.IsLive(3, 10))
+
+	// Epochs older than the retained ones are ignored.
+	l.MarkLive(1, []uint64{10})
+	assert.Equal(t, false, l.IsLive(1, 10))
+	l.MarkLive(2, []uint64{11})
+	assert.Equal(t, true, l.IsLive(2, 11))
+}
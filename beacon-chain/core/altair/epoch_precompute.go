@@ -216,38 +216,144 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	return state, nil
 }
 
+// AttestationDeltaDetail is the breakdown of the rewards and penalties of a validator for its previous epoch
+// attestation, as applied by the epoch transition.
+type AttestationDeltaDetail struct {
+	SourceReward      uint64
+	SourcePenalty     uint64
+	TargetReward      uint64
+	TargetPenalty     uint64
+	HeadReward        uint64
+	InactivityPenalty uint64
+}
+
+// Reward returns the total reward of the attestation.
+func (d *AttestationDeltaDetail) Reward() uint64 {
+	return d.SourceReward + d.TargetReward + d.HeadReward
+}
+
+// Penalty returns the total penalty of the attestation.
+func (d *AttestationDeltaDetail) Penalty() uint64 {
+	return d.SourcePenalty + d.TargetPenalty + d.InactivityPenalty
+}
+
 // AttestationsDelta computes and returns the rewards and penalties differences for individual validators based on the
 // voting records.
 func AttestationsDelta(state state.BeaconStateAltair, bal *precompute.Balance, vals []*precompute.Validator) (rewards, penalties []uint64, err error) {
-	numOfVals := state.NumValidators()
-	rewards = make([]uint64, numOfVals)
-	penalties = make([]uint64, numOfVals)
+	details, err := AttestationsDeltaDetails(state, bal, vals)
+	if err != nil {
+		return nil, nil, err
+	}
+	rewards = make([]uint64, len(details))
+	penalties = make([]uint64, len(details))
+	for i, d := range details {
+		rewards[i], penalties[i] = d.Reward(), d.Penalty()
+	}
+	return rewards, penalties, nil
+}
+
+// AttestationsDeltaDetails computes the breakdown of the rewards and penalties of individual validators based on
+// the voting records.
+func AttestationsDeltaDetails(state state.BeaconStateAltair, bal *precompute.Balance, vals []*precompute.Validator) ([]*AttestationDeltaDetail, error) {
+	deltaFn := attestationDeltaFunc(state, bal)
+	details := make([]*AttestationDeltaDetail, len(vals))
+	for i, v := range vals {
+		details[i] = deltaFn(v)
+	}
+	return details, nil
+}
+
+// IdealAttestationDeltaDetail returns the rewards a validator with the given effective balance would receive
+// for a timely attestation voting for the correct source, target and head.
+func IdealAttestationDeltaDetail(state state.BeaconStateAltair, bal *precompute.Balance, effectiveBalance uint64) *AttestationDeltaDetail {
+	return attestationDeltaFunc(state, bal)(&precompute.Validator{
+		IsActivePrevEpoch:            true,
+		IsPrevEpochAttester:          true,
+		IsPrevEpochTargetAttester:    true,
+		IsPrevEpochHeadAttester:      true,
+		CurrentEpochEffectiveBalance: effectiveBalance,
+	})
+}
+
+// EpochAttestationsDeltaDetails returns the breakdown of the attestation rewards and penalties applied by the epoch
+// transition of the input state, which are the rewards for the attestations of the previous epoch. The ideal rewards
+// are computed for each of the given effective balances. The input state is not modified.
+func EpochAttestationsDeltaDetails(
+	ctx context.Context,
+	st state.BeaconStateAltair,
+	idealEffectiveBalances []uint64,
+) (details []*AttestationDeltaDetail, ideal []*AttestationDeltaDetail, err error) {
+	ctx, span := trace.StartSpan(ctx, "altair.EpochAttestationsDeltaDetails")
+	defer span.End()
+
+	if st == nil || st.IsNil() {
+		return nil, nil, errors.New("nil state")
+	}
+	// Rewards and penalties are not processed in genesis epoch.
+	if core.CurrentEpoch(st) == 0 {
+		return nil, nil, errors.New("no attestation rewards are processed in genesis epoch")
+	}
+	// Justification and inactivity updates are processed before rewards and penalties, and modify the state.
+	st = st.Copy()
+	vp, bp, err := InitializeEpochValidators(ctx, st)
+	if err != nil {
+		return nil, nil, err
+	}
+	vp, bp, err = ProcessEpochParticipation(ctx, st, bp, vp)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bp)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not process justification")
+	}
+	st, vp, err = ProcessInactivityScores(ctx, st, vp)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not process inactivity updates")
+	}
+
+	details, err = AttestationsDeltaDetails(st, bp, vp)
+	if err != nil {
+		return nil, nil, err
+	}
+	ideal = make([]*AttestationDeltaDetail, len(idealEffectiveBalances))
+	for i, b := range idealEffectiveBalances {
+		ideal[i] = IdealAttestationDeltaDetail(st, bp, b)
+	}
+	return details, ideal, nil
+}
+
+// This returns a function computing the attestation delta of a validator, with the reward parameters of the state.
+func attestationDeltaFunc(state state.BeaconStateAltair, bal *precompute.Balance) func(v *precompute.Validator) *AttestationDeltaDetail {
 	prevEpoch := core.PrevEpoch(state)
 	finalizedEpoch := state.FinalizedCheckpointEpoch()
 
 	cfg := params.BeaconConfig()
 	increment := cfg.EffectiveBalanceIncrement
 	factor := cfg.BaseRewardFactor
-	baseRewardMultiplier := increment * factor / mathutil.IntegerSquareRoot(bal.ActiveCurrentEpoch)
+	baseRewardMultiplier := uint64(0)
+	// Per spec `ActiveCurrentEpoch` can't be 0 to process attestation delta.
+	if bal.ActiveCurrentEpoch > 0 {
+		baseRewardMultiplier = increment * factor / mathutil.IntegerSquareRoot(bal.ActiveCurrentEpoch)
+	}
 	leak := helpers.IsInInactivityLeak(prevEpoch, finalizedEpoch)
 	inactivityDenominator := cfg.InactivityScoreBias * cfg.InactivityPenaltyQuotientAltair
 
-	for i, v := range vals {
-		rewards[i], penalties[i] = attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
+	return func(v *precompute.Validator) *AttestationDeltaDetail {
+		return attestationDelta(bal, v, baseRewardMultiplier, inactivityDenominator, leak)
 	}
-
-	return rewards, penalties, nil
 }
 
 func attestationDelta(
 	bal *precompute.Balance,
 	v *precompute.Validator,
 	baseRewardMultiplier, inactivityDenominator uint64,
-	inactivityLeak bool) (reward, penalty uint64) {
+	inactivityLeak bool) *AttestationDeltaDetail {
+	d := &AttestationDeltaDetail{}
 	eligible := v.IsActivePrevEpoch || (v.IsSlashed && !v.IsWithdrawableCurrentEpoch)
 	// Per spec `ActiveCurrentEpoch` can't be 0 to process attestation delta.
 	if !eligible || bal.ActiveCurrentEpoch == 0 {
-		return 0, 0
+		return d
 	}
 
 	cfg := params.BeaconConfig()
@@ -260,32 +366,31 @@ func attestationDelta(
 	srcWeight := cfg.TimelySourceWeight
 	tgtWeight := cfg.TimelyTargetWeight
 	headWeight := cfg.TimelyHeadWeight
-	reward, penalty = uint64(0), uint64(0)
 	// Process source reward / penalty
 	if v.IsPrevEpochAttester && !v.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * srcWeight * (bal.PrevEpochAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.SourceReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * srcWeight / weightDenominator
+		d.SourcePenalty = baseReward * srcWeight / weightDenominator
 	}
 
 	// Process target reward / penalty
 	if v.IsPrevEpochTargetAttester && !v.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * tgtWeight * (bal.PrevEpochTargetAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.TargetReward = n / (activeIncrement * weightDenominator)
 		}
 	} else {
-		penalty += baseReward * tgtWeight / weightDenominator
+		d.TargetPenalty = baseReward * tgtWeight / weightDenominator
 	}
 
 	// Process head reward / penalty
 	if v.IsPrevEpochHeadAttester && !v.IsSlashed {
 		if !inactivityLeak {
 			n := baseReward * headWeight * (bal.PrevEpochHeadAttested / increment)
-			reward += n / (activeIncrement * weightDenominator)
+			d.HeadReward = n / (activeIncrement * weightDenominator)
 		}
 	}
 
@@ -293,8 +398,8 @@ func attestationDelta(
 	// Apply an additional penalty to validators that did not vote on the correct target or slashed
 	if !v.IsPrevEpochTargetAttester || v.IsSlashed {
 		n := effectiveBalance * v.InactivityScore
-		penalty += n / inactivityDenominator
+		d.InactivityPenalty = n / inactivityDenominator
 	}
 
-	return reward, penalty
+	return d
 }
@@ -205,6 +205,38 @@ func TestAttestationsDelta(t *testing.T) {
 	require.Equal(t, uint64(0), penalties[len(penalties)-1])
 }
 
+func TestAttestationsDeltaDetails(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializeEpochValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	details, err := AttestationsDeltaDetails(s, balance, validators)
+	require.NoError(t, err)
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+	require.Equal(t, len(rewards), len(details))
+	for i, d := range details {
+		assert.Equal(t, rewards[i], d.Reward())
+		assert.Equal(t, penalties[i], d.Penalty())
+	}
+
+	// Validator 0 did not attest.
+	assert.Equal(t, uint64(0), details[0].Reward())
+	assert.Equal(t, true, details[0].SourcePenalty > 0)
+	assert.Equal(t, true, details[0].TargetPenalty > 0)
+	// Validator 1 only voted for the correct source.
+	assert.Equal(t, true, details[1].SourceReward > 0)
+	assert.Equal(t, uint64(0), details[1].SourcePenalty)
+	assert.Equal(t, true, details[1].TargetPenalty > 0)
+	// Validator 3 attested perfectly, so it received the ideal reward.
+	ideal := IdealAttestationDeltaDetail(s, balance, params.BeaconConfig().MaxEffectiveBalance)
+	assert.DeepEqual(t, ideal, details[3])
+	assert.Equal(t, true, ideal.HeadReward > 0)
+	assert.Equal(t, uint64(0), ideal.Penalty())
+}
+
 func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	s, err := testState()
 	require.NoError(t, err)
@@ -7,6 +7,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
@@ -41,3 +42,44 @@ func TestProcessEpoch_CanProcess(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, params.BeaconConfig().SyncCommitteeSize, uint64(len(sc.Pubkeys)))
 }
+
+func TestEpochAttestationsDeltaDetails_MatchesProcessEpoch(t *testing.T) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	require.NoError(t, st.SetSlot(10*params.BeaconConfig().SlotsPerEpoch))
+	participation := make([]byte, st.NumValidators())
+	for i := range participation {
+		// Half of the validators attested perfectly, the other half only voted for the correct source.
+		participation[i] = altair.AddValidatorFlag(0, params.BeaconConfig().TimelySourceFlagIndex)
+		if i%2 == 0 {
+			participation[i] = altair.AddValidatorFlag(participation[i], params.BeaconConfig().TimelyTargetFlagIndex)
+			participation[i] = altair.AddValidatorFlag(participation[i], params.BeaconConfig().TimelyHeadFlagIndex)
+		}
+	}
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+	before := st.Balances()
+
+	details, ideal, err := altair.EpochAttestationsDeltaDetails(ctx, st, []uint64{params.BeaconConfig().MaxEffectiveBalance})
+	require.NoError(t, err)
+	require.Equal(t, st.NumValidators(), len(details))
+	require.Equal(t, 1, len(ideal))
+	scores, err := st.InactivityScores()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), scores[1], "Input state was modified")
+
+	newState, err := altair.ProcessEpoch(ctx, st.Copy())
+	require.NoError(t, err)
+	after := newState.Balances()
+	for i, d := range details {
+		assert.Equal(t, before[i]+d.Reward()-d.Penalty(), after[i])
+	}
+	assert.DeepEqual(t, ideal[0], details[0])
+	assert.Equal(t, true, details[1].TargetPenalty > 0)
+	assert.Equal(t, true, details[1].InactivityPenalty > 0)
+}
+
+func TestEpochAttestationsDeltaDetails_GenesisEpoch(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	_, _, err := altair.EpochAttestationsDeltaDetails(context.Background(), st, nil)
+	require.ErrorContains(t, "no attestation rewards are processed in genesis epoch", err)
+}
@@ -1,12 +1,15 @@
 package helpers
 
 import (
+	"bytes"
+	"context"
 	"math"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
@@ -71,3 +74,18 @@ func BlockRoot(state state.ReadOnlyBeaconState, epoch types.Epoch) ([]byte, erro
 	}
 	return BlockRootAtSlot(state, s)
 }
+
+// LatestBlockRoot computes the root of the block the state was derived from, using the state's
+// latest block header.
+func LatestBlockRoot(ctx context.Context, st state.BeaconState) ([32]byte, error) {
+	header := ethpb.CopyBeaconBlockHeader(st.LatestBlockHeader())
+	// The state root of the header is only filled in when the next slot is processed.
+	if bytes.Equal(header.StateRoot, params.BeaconConfig().ZeroHash[:]) {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not compute state root")
+		}
+		header.StateRoot = stateRoot[:]
+	}
+	return header.HashTreeRoot()
+}
@@ -29,3 +29,11 @@ type SlasherDatabase = iface.SlasherDatabase
 // ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 // when one already exists in a database.
 var ErrExistingGenesisState = iface.ErrExistingGenesisState
+
+// ErrNotFoundOriginBlockRoot is an error when the database was not started from a checkpoint
+// and therefore has no origin block root.
+var ErrNotFoundOriginBlockRoot = iface.ErrNotFoundOriginBlockRoot
+
+// ErrNotFoundBackfillBlockRoot is an error when no block preceding the origin block has been
+// backfilled yet.
+var ErrNotFoundBackfillBlockRoot = iface.ErrNotFoundBackfillBlockRoot
@@ -0,0 +1,260 @@
+// Package archive reads and writes portable archives of the finalized history of the beacon chain.
+//
+// An archive file covers a range of epochs and holds the finalized blocks of that range, along with
+// periodic state snapshots. Every file is a snappy framed stream which starts with a header, followed
+// by records made of a kind, the fork version of the object, and its SSZ encoding. Archives do not
+// depend on the layout of the database, so they remain readable across schema migrations.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/version"
+)
+
+// FileExtension of archive files.
+const FileExtension = ".ssz_snappy"
+
+// formatVersion is the version of the archive format, written in the header of every file.
+const formatVersion byte = 1
+
+// maxRecordSize bounds the size of a single record, to reject corrupted length prefixes.
+const maxRecordSize = 1 << 30
+
+var magic = [8]byte{'p', 'r', 'y', 's', 'm', 'a', 'r', 'c'}
+
+// RecordKind identifies the type of object stored in a record.
+type RecordKind byte
+
+const (
+	// BlockRecord holds a signed beacon block.
+	BlockRecord RecordKind = iota + 1
+	// StateRecord holds a beacon state, along with the root of the block it is the post state of.
+	StateRecord
+	// CheckpointRecord holds the finalized checkpoint the history was exported up to.
+	CheckpointRecord
+)
+
+// Header describes the content of an archive file.
+type Header struct {
+	GenesisValidatorsRoot [32]byte
+	// StartEpoch is the first epoch of the archive file.
+	StartEpoch types.Epoch
+	// EndEpoch is the epoch following the last epoch of the archive file.
+	EndEpoch types.Epoch
+}
+
+// Record is a single object read from an archive file. Only the fields matching its kind are set.
+type Record struct {
+	Kind       RecordKind
+	Block      block.SignedBeaconBlock
+	State      state.BeaconState
+	BlockRoot  [32]byte
+	Checkpoint *ethpb.Checkpoint
+}
+
+// FileName returns the name of the archive file covering the given epochs.
+func FileName(startEpoch, endEpoch types.Epoch) string {
+	return fmt.Sprintf("beacon-%08d-%08d%s", startEpoch, endEpoch, FileExtension)
+}
+
+// Writer writes records to an archive file.
+type Writer struct {
+	w *snappy.Writer
+}
+
+// NewWriter writes the archive header to w and returns a writer for the records of the archive.
+// The writer must be closed to flush the archive.
+func NewWriter(w io.Writer, h *Header) (*Writer, error) {
+	sw := snappy.NewBufferedWriter(w)
+	buf := new(bytes.Buffer)
+	buf.Write(magic[:])
+	buf.WriteByte(formatVersion)
+	buf.Write(h.GenesisValidatorsRoot[:])
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], uint64(h.StartEpoch))
+	buf.Write(tmp[:])
+	binary.LittleEndian.PutUint64(tmp[:], uint64(h.EndEpoch))
+	buf.Write(tmp[:])
+	if _, err := sw.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return &Writer{w: sw}, nil
+}
+
+// WriteBlock appends a signed beacon block to the archive.
+func (w *Writer) WriteBlock(blk block.SignedBeaconBlock) error {
+	if blk == nil || blk.IsNil() {
+		return errors.New("nil block")
+	}
+	enc, err := blk.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal block")
+	}
+	return w.writeRecord(BlockRecord, byte(blk.Version()), enc)
+}
+
+// WriteState appends the post state of the block with the given root to the archive.
+func (w *Writer) WriteState(blockRoot [32]byte, st state.BeaconState) error {
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+	return w.writeRecord(StateRecord, byte(st.Version()), append(blockRoot[:], enc...))
+}
+
+// WriteCheckpoint appends the finalized checkpoint of the exported history to the archive.
+func (w *Writer) WriteCheckpoint(cp *ethpb.Checkpoint) error {
+	enc, err := cp.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not marshal checkpoint")
+	}
+	return w.writeRecord(CheckpointRecord, 0, enc)
+}
+
+// Close flushes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	return w.w.Close()
+}
+
+func (w *Writer) writeRecord(kind RecordKind, v byte, payload []byte) error {
+	var prefix [2 + binary.MaxVarintLen64]byte
+	prefix[0] = byte(kind)
+	prefix[1] = v
+	n := binary.PutUvarint(prefix[2:], uint64(len(payload)))
+	if _, err := w.w.Write(prefix[:2+n]); err != nil {
+		return err
+	}
+	_, err := w.w.Write(payload)
+	return err
+}
+
+// Reader reads the records of an archive file.
+type Reader struct {
+	r      *bufio.Reader
+	header *Header
+}
+
+// NewReader reads the archive header from r and returns a reader for the records of the archive.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(snappy.NewReader(r))
+	enc := make([]byte, len(magic)+1+32+8+8)
+	if _, err := io.ReadFull(br, enc); err != nil {
+		return nil, errors.Wrap(err, "could not read archive header")
+	}
+	if !bytes.Equal(enc[:len(magic)], magic[:]) {
+		return nil, errors.New("not an archive file")
+	}
+	enc = enc[len(magic):]
+	if enc[0] != formatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %d", enc[0])
+	}
+	h := &Header{}
+	copy(h.GenesisValidatorsRoot[:], enc[1:33])
+	h.StartEpoch = types.Epoch(binary.LittleEndian.Uint64(enc[33:41]))
+	h.EndEpoch = types.Epoch(binary.LittleEndian.Uint64(enc[41:49]))
+	return &Reader{r: br, header: h}, nil
+}
+
+// Header of the archive file.
+func (r *Reader) Header() *Header {
+	return r.header
+}
+
+// Next returns the next record of the archive, or io.EOF once all the records were read.
+func (r *Reader) Next() (*Record, error) {
+	kind, err := r.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	v, err := r.r.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read record version")
+	}
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read record size")
+	}
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("record size %d exceeds the maximum of %d", size, maxRecordSize)
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, errors.Wrap(err, "could not read record")
+	}
+
+	rec := &Record{Kind: RecordKind(kind)}
+	switch rec.Kind {
+	case BlockRecord:
+		rec.Block, err = unmarshalBlock(int(v), payload)
+	case StateRecord:
+		if len(payload) < 32 {
+			return nil, errors.New("state record is too short")
+		}
+		copy(rec.BlockRoot[:], payload[:32])
+		rec.State, err = unmarshalState(int(v), payload[32:])
+	case CheckpointRecord:
+		rec.Checkpoint = &ethpb.Checkpoint{}
+		err = rec.Checkpoint.UnmarshalSSZ(payload)
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func unmarshalBlock(v int, enc []byte) (block.SignedBeaconBlock, error) {
+	switch v {
+	case version.Phase0:
+		blk := &ethpb.SignedBeaconBlock{}
+		if err := blk.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase 0 block")
+		}
+		return wrapper.WrappedPhase0SignedBeaconBlock(blk), nil
+	case version.Altair:
+		blk := &ethpb.SignedBeaconBlockAltair{}
+		if err := blk.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair block")
+		}
+		return wrapper.WrappedAltairSignedBeaconBlock(blk)
+	default:
+		return nil, fmt.Errorf("unsupported block version %d", v)
+	}
+}
+
+func unmarshalState(v int, enc []byte) (state.BeaconState, error) {
+	switch v {
+	case version.Phase0:
+		pb := &ethpb.BeaconState{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase 0 state")
+		}
+		return v1.InitializeFromProtoUnsafe(pb)
+	case version.Altair:
+		pb := &ethpb.BeaconStateAltair{}
+		if err := pb.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair state")
+		}
+		return v2.InitializeFromProtoUnsafe(pb)
+	default:
+		return nil, fmt.Errorf("unsupported state version %d", v)
+	}
+}
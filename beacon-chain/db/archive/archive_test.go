@@ -0,0 +1,107 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/golang/snappy"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "beacon-00000256-00000512.ssz_snappy", FileName(256, 512))
+}
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 5
+	blkRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	altairBlk := testutil.NewBeaconBlockAltair()
+	altairBlk.Block.Slot = 6
+	wrappedAltair, err := wrapper.WrappedAltairSignedBeaconBlock(altairBlk)
+	require.NoError(t, err)
+	cp := &ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte("root"), 32)}
+	h := &Header{
+		GenesisValidatorsRoot: bytesutil.ToBytes32([]byte("gvr")),
+		StartEpoch:            4,
+		EndEpoch:              8,
+	}
+
+	buf := new(bytes.Buffer)
+	w, err := NewWriter(buf, h)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteBlock(wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	require.NoError(t, w.WriteState(blkRoot, st))
+	require.NoError(t, w.WriteBlock(wrappedAltair))
+	require.NoError(t, w.WriteCheckpoint(cp))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	assert.DeepEqual(t, h, r.Header())
+
+	rec, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, BlockRecord, rec.Kind)
+	gotRoot, err := rec.Block.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, blkRoot, gotRoot)
+
+	rec, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, StateRecord, rec.Kind)
+	assert.Equal(t, blkRoot, rec.BlockRoot)
+	wantStateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotStateRoot, err := rec.State.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, wantStateRoot, gotStateRoot)
+
+	rec, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, BlockRecord, rec.Kind)
+	assert.Equal(t, types.Slot(6), rec.Block.Block().Slot())
+	assert.Equal(t, wrappedAltair.Version(), rec.Block.Version())
+
+	rec, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, CheckpointRecord, rec.Kind)
+	assert.DeepEqual(t, cp, rec.Checkpoint)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNewReader_NotAnArchive(t *testing.T) {
+	buf := new(bytes.Buffer)
+	sw := snappy.NewBufferedWriter(buf)
+	_, err := sw.Write(make([]byte, 64))
+	require.NoError(t, err)
+	require.NoError(t, sw.Close())
+
+	_, err = NewReader(buf)
+	require.ErrorContains(t, "not an archive file", err)
+}
+
+func TestReader_UnknownRecordKind(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w, err := NewWriter(buf, &Header{})
+	require.NoError(t, err)
+	require.NoError(t, w.writeRecord(RecordKind(42), 0, []byte{1, 2, 3}))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(buf)
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.ErrorContains(t, "unknown record kind 42", err)
+}
@@ -0,0 +1,226 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// ExportConfig defines the history exported from a database.
+type ExportConfig struct {
+	DB db.NoHeadAccessDatabase
+	// OutputDir is the directory the archive files are written to.
+	OutputDir string
+	// EpochsPerFile is the number of epochs covered by each archive file. Every file starts with a
+	// state snapshot, so it is also the interval between state snapshots.
+	EpochsPerFile types.Epoch
+}
+
+// Export writes the finalized blocks of the database to archive files, starting from the genesis
+// block, or from the origin block of a checkpoint synced node, up to the finalized checkpoint.
+func Export(ctx context.Context, cfg *ExportConfig) error {
+	if cfg.EpochsPerFile == 0 {
+		return errors.New("epochs per archive file must be greater than zero")
+	}
+	cp, err := cfg.DB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve finalized checkpoint")
+	}
+	if cp == nil || bytesutil.ToBytes32(cp.Root) == params.BeaconConfig().ZeroHash {
+		return errors.New("database has no finalized checkpoint to export")
+	}
+	roots, err := exportedRoots(ctx, cfg.DB, bytesutil.ToBytes32(cp.Root))
+	if err != nil {
+		return err
+	}
+	if err := fileutil.MkdirAll(cfg.OutputDir); err != nil {
+		return err
+	}
+
+	sg := stategen.New(cfg.DB)
+	var w *Writer
+	var f *os.File
+	closeFile := func() error {
+		if w == nil {
+			return nil
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		w = nil
+		err := f.Close()
+		f = nil
+		return err
+	}
+	// Always release the current file, close errors on success are reported below.
+	defer func() {
+		if f != nil {
+			_ = f.Close()
+		}
+	}()
+
+	var fileEnd types.Epoch
+	var gvr [32]byte
+	var lastState [32]byte
+	for i, r := range roots {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		blk, err := cfg.DB.Block(ctx, r)
+		if err != nil {
+			return err
+		}
+		if blk == nil || blk.IsNil() {
+			return fmt.Errorf("missing block in database: block root=%#x", r)
+		}
+		epoch := core.SlotToEpoch(blk.Block().Slot())
+		newFile := w == nil || epoch >= fileEnd
+		if newFile {
+			if err := closeFile(); err != nil {
+				return err
+			}
+			start := epoch - epoch%cfg.EpochsPerFile
+			fileEnd = start + cfg.EpochsPerFile
+			st, err := snapshotState(ctx, sg, r, i == 0)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				gvr = bytesutil.ToBytes32(st.GenesisValidatorRoot())
+			}
+			if st != nil {
+				lastState = r
+			}
+			f, err = os.Create(filepath.Join(cfg.OutputDir, FileName(start, fileEnd)))
+			if err != nil {
+				return err
+			}
+			w, err = NewWriter(f, &Header{
+				GenesisValidatorsRoot: gvr,
+				StartEpoch:            start,
+				EndEpoch:              fileEnd,
+			})
+			if err != nil {
+				return err
+			}
+			if err := writeBlockAndState(w, blk, r, st); err != nil {
+				return err
+			}
+			log.WithFields(logrus.Fields{
+				"startEpoch": start,
+				"endEpoch":   fileEnd,
+			}).Info("Exporting blocks to new archive file")
+			continue
+		}
+		if err := w.WriteBlock(blk); err != nil {
+			return err
+		}
+	}
+
+	// The finalized state is required to resume a node from the archive.
+	if last := roots[len(roots)-1]; last != lastState {
+		st, err := snapshotState(ctx, sg, last, true)
+		if err != nil {
+			return err
+		}
+		if err := w.WriteState(last, st); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteCheckpoint(cp); err != nil {
+		return err
+	}
+	if err := closeFile(); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":         len(roots),
+		"finalizedEpoch": cp.Epoch,
+	}).Info("Exported finalized history")
+	return nil
+}
+
+// exportedRoots returns the roots of the finalized blocks up to the given block root, sorted by
+// ascending slot. The history starts at the genesis block, or at the origin block of a checkpoint
+// synced node if the database has no state to start from before it.
+func exportedRoots(ctx context.Context, d db.ReadOnlyDatabase, root [32]byte) ([][32]byte, error) {
+	originRoot, err := d.OriginBlockRoot(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return nil, err
+	}
+	var roots [][32]byte
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		blk, err := d.Block(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+		if blk == nil || blk.IsNil() {
+			break
+		}
+		roots = append(roots, root)
+		if blk.Block().Slot() == 0 {
+			break
+		}
+		root = bytesutil.ToBytes32(blk.Block().ParentRoot())
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("finalized block is missing from the database")
+	}
+	// Reverse to ascending slot order.
+	for i, j := 0, len(roots)-1; i < j; i, j = i+1, j-1 {
+		roots[i], roots[j] = roots[j], roots[i]
+	}
+	if d.HasState(ctx, roots[0]) {
+		return roots, nil
+	}
+	for i, r := range roots {
+		if r == originRoot {
+			log.WithField("blocks", i).Warn("Skipping backfilled blocks, which have no state to import them from")
+			return roots[i:], nil
+		}
+	}
+	return nil, errors.New("no state to start the export from")
+}
+
+// snapshotState returns the post state of the block with the given root. The first state of the
+// export is required, later snapshots are skipped if the state can't be regenerated.
+func snapshotState(ctx context.Context, sg *stategen.State, root [32]byte, required bool) (state.BeaconState, error) {
+	st, err := sg.StateByRoot(ctx, root)
+	if err == nil && (st == nil || st.IsNil()) {
+		err = errors.New("nil state")
+	}
+	if err != nil {
+		if required {
+			return nil, errors.Wrapf(err, "could not retrieve state of block %#x", root)
+		}
+		log.WithError(err).WithField("root", fmt.Sprintf("%#x", root)).Warn("Could not regenerate state snapshot")
+		return nil, nil
+	}
+	return st, nil
+}
+
+func writeBlockAndState(w *Writer, blk block.SignedBeaconBlock, root [32]byte, st state.BeaconState) error {
+	if err := w.WriteBlock(blk); err != nil {
+		return err
+	}
+	if st == nil {
+		return nil
+	}
+	return w.WriteState(root, st)
+}
@@ -0,0 +1,118 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// setupChain saves a chain of blocks up to the given slot to the database, along with the genesis
+// state, and finalizes its last block. It returns the block roots sorted by slot.
+func setupChain(t *testing.T, beaconDB db.Database, lastSlot types.Slot) [][32]byte {
+	ctx := context.Background()
+	st, keys := testutil.DeterministicGenesisState(t, 64)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	require.NoError(t, beaconDB.SaveState(ctx, st, genesisRoot))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+	require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 0, Root: genesisRoot[:]}))
+
+	roots := [][32]byte{genesisRoot}
+	st = st.Copy()
+	for i := types.Slot(1); i <= lastSlot; i++ {
+		b, err := testutil.GenerateFullBlock(st, keys, testutil.DefaultBlockGenConfig(), i)
+		require.NoError(t, err)
+		wb := wrapper.WrappedPhase0SignedBeaconBlock(b)
+		st, err = transition.ExecuteStateTransition(ctx, st, wb)
+		require.NoError(t, err)
+		r, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, wb))
+		require.NoError(t, beaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: i, Root: r[:]}))
+		roots = append(roots, r)
+	}
+	last := roots[len(roots)-1]
+	require.NoError(t, beaconDB.SaveState(ctx, st, last))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: last[:]}))
+	return roots
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	ctx := context.Background()
+	srcDB := dbtest.SetupDB(t)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	roots := setupChain(t, srcDB, slotsPerEpoch.Mul(3)-1)
+
+	dir := t.TempDir()
+	require.NoError(t, Export(ctx, &ExportConfig{DB: srcDB, OutputDir: dir, EpochsPerFile: 1}))
+	for i := types.Epoch(0); i < 3; i++ {
+		_, err := os.Stat(filepath.Join(dir, FileName(i, i+1)))
+		require.NoError(t, err)
+	}
+
+	dstDB := dbtest.SetupDB(t)
+	require.NoError(t, Import(ctx, &ImportConfig{DB: dstDB, InputDir: dir}))
+	for _, r := range roots {
+		assert.Equal(t, true, dstDB.HasBlock(ctx, r))
+	}
+	last := roots[len(roots)-1]
+	assert.Equal(t, true, dstDB.HasState(ctx, roots[0]))
+	assert.Equal(t, true, dstDB.HasState(ctx, last))
+	// Every archive file starts with a state snapshot.
+	assert.Equal(t, true, dstDB.HasState(ctx, roots[slotsPerEpoch]))
+
+	cp, err := dstDB.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(2), cp.Epoch)
+	assert.DeepEqual(t, last[:], cp.Root)
+	head, err := dstDB.HeadBlock(ctx)
+	require.NoError(t, err)
+	headRoot, err := head.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, last, headRoot)
+	genesisBlk, err := dstDB.GenesisBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(0), genesisBlk.Block().Slot())
+
+	err = Import(ctx, &ImportConfig{DB: dstDB, InputDir: dir})
+	require.ErrorContains(t, "archives can only be imported into an empty database", err)
+}
+
+func TestImport_MissingLastArchive(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	params.OverrideBeaconConfig(params.MinimalSpecConfig())
+	ctx := context.Background()
+	srcDB := dbtest.SetupDB(t)
+	setupChain(t, srcDB, params.BeaconConfig().SlotsPerEpoch.Mul(2)-1)
+
+	dir := t.TempDir()
+	require.NoError(t, Export(ctx, &ExportConfig{DB: srcDB, OutputDir: dir, EpochsPerFile: 1}))
+	require.NoError(t, os.Remove(filepath.Join(dir, FileName(1, 2))))
+
+	err := Import(ctx, &ImportConfig{DB: dbtest.SetupDB(t), InputDir: dir})
+	require.ErrorContains(t, "archives do not end with a finalized checkpoint", err)
+}
+
+func TestExport_NoFinalizedCheckpoint(t *testing.T) {
+	err := Export(context.Background(), &ExportConfig{DB: dbtest.SetupDB(t), OutputDir: t.TempDir(), EpochsPerFile: 1})
+	require.ErrorContains(t, "no finalized checkpoint", err)
+}
@@ -0,0 +1,284 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+// importBatchSize is the number of blocks saved to the database at once.
+const importBatchSize = 256
+
+// ImportConfig defines the archives imported into a database.
+type ImportConfig struct {
+	DB db.HeadAccessDatabase
+	// InputDir is the directory the archive files are read from.
+	InputDir string
+}
+
+// importer tracks the progress of an import.
+type importer struct {
+	db         db.HeadAccessDatabase
+	started    bool
+	lastBlock  block.SignedBeaconBlock
+	lastRoot   [32]byte
+	blocks     []block.SignedBeaconBlock
+	summaries  []*ethpb.StateSummary
+	imported   int
+	checkpoint *ethpb.Checkpoint
+}
+
+// Import loads the archive files of a directory into an empty database. The blocks of the archives
+// must form a single chain, and the last archive must end with the finalized checkpoint it was
+// exported up to, which becomes the finalized checkpoint and head of the database.
+func Import(ctx context.Context, cfg *ImportConfig) error {
+	head, err := cfg.DB.HeadBlock(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := cfg.DB.GenesisBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if (head != nil && !head.IsNil()) || (genesis != nil && !genesis.IsNil()) {
+		return errors.New("archives can only be imported into an empty database")
+	}
+
+	files, err := archiveFiles(cfg.InputDir)
+	if err != nil {
+		return err
+	}
+	imp := &importer{db: cfg.DB}
+	var prev *Header
+	for _, file := range files {
+		h, err := imp.importFile(ctx, file, prev)
+		if err != nil {
+			return errors.Wrapf(err, "could not import %s", file)
+		}
+		prev = h
+	}
+	if err := imp.flush(ctx); err != nil {
+		return err
+	}
+	return imp.finalize(ctx)
+}
+
+// archiveFiles returns the archive files of a directory.
+func archiveFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), FileExtension) {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no archive files found in %s", dir)
+	}
+	// File names are zero padded, so they sort by epoch.
+	sort.Strings(files)
+	return files, nil
+}
+
+func (imp *importer) importFile(ctx context.Context, file string, prev *Header) (*Header, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close archive file")
+		}
+	}()
+	r, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	h := r.Header()
+	if prev != nil {
+		if h.GenesisValidatorsRoot != prev.GenesisValidatorsRoot {
+			return nil, errors.New("archive belongs to a different chain")
+		}
+		if h.StartEpoch < prev.EndEpoch {
+			return nil, fmt.Errorf("archive starting at epoch %d overlaps the previous archive", h.StartEpoch)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch": h.StartEpoch,
+		"endEpoch":   h.EndEpoch,
+	}).Info("Importing archive file")
+
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		rec, err := r.Next()
+		if err == io.EOF {
+			return h, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch rec.Kind {
+		case BlockRecord:
+			err = imp.importBlock(ctx, rec.Block)
+		case StateRecord:
+			err = imp.importState(ctx, rec.BlockRoot, rec.State)
+		case CheckpointRecord:
+			imp.checkpoint = rec.Checkpoint
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (imp *importer) importBlock(ctx context.Context, blk block.SignedBeaconBlock) error {
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if imp.lastBlock != nil && !imp.started {
+		return errors.New("first block of the archives has no state")
+	}
+	if imp.started && !bytes.Equal(blk.Block().ParentRoot(), imp.lastRoot[:]) {
+		return fmt.Errorf("block at slot %d is not a child of the previous block", blk.Block().Slot())
+	}
+	imp.lastBlock = blk
+	imp.lastRoot = root
+	// The first block is saved along with its state.
+	if !imp.started {
+		return nil
+	}
+	imp.blocks = append(imp.blocks, blk)
+	imp.summaries = append(imp.summaries, &ethpb.StateSummary{Slot: blk.Block().Slot(), Root: root[:]})
+	if len(imp.blocks) >= importBatchSize {
+		return imp.flush(ctx)
+	}
+	return nil
+}
+
+func (imp *importer) importState(ctx context.Context, blockRoot [32]byte, st state.BeaconState) error {
+	if imp.lastBlock == nil || blockRoot != imp.lastRoot {
+		return fmt.Errorf("state of block %#x does not follow its block", blockRoot)
+	}
+	if err := verifyStateBlock(ctx, st, blockRoot); err != nil {
+		return err
+	}
+
+	if !imp.started {
+		imp.started = true
+		imp.imported++
+		return imp.importFirst(ctx, blockRoot, st)
+	}
+	if err := imp.flush(ctx); err != nil {
+		return err
+	}
+	return imp.db.SaveState(ctx, st, blockRoot)
+}
+
+// verifyStateBlock ensures that the state is the post state of the block with the given root,
+// possibly advanced through empty slots.
+func verifyStateBlock(ctx context.Context, st state.BeaconState, blockRoot [32]byte) error {
+	headerRoot, err := helpers.LatestBlockRoot(ctx, st)
+	if err != nil {
+		return err
+	}
+	if headerRoot != blockRoot {
+		return fmt.Errorf("state does not derive from block %#x", blockRoot)
+	}
+	return nil
+}
+
+// importFirst saves the first block of the archives and its state. The history starts either from
+// the genesis block, or from the origin block of a checkpoint synced node.
+func (imp *importer) importFirst(ctx context.Context, root [32]byte, st state.BeaconState) error {
+	if imp.lastBlock.Block().Slot() == 0 {
+		if err := imp.db.SaveBlock(ctx, imp.lastBlock); err != nil {
+			return err
+		}
+		if err := imp.db.SaveState(ctx, st, root); err != nil {
+			return err
+		}
+		if err := imp.db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 0, Root: root[:]}); err != nil {
+			return err
+		}
+		return imp.db.SaveGenesisBlockRoot(ctx, root)
+	}
+	serState, err := st.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	serBlock, err := imp.lastBlock.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	return imp.db.SaveOrigin(ctx, serState, serBlock)
+}
+
+// flush saves the pending blocks to the database.
+func (imp *importer) flush(ctx context.Context) error {
+	if len(imp.blocks) == 0 {
+		return nil
+	}
+	if err := imp.db.SaveBlocks(ctx, imp.blocks); err != nil {
+		return err
+	}
+	if err := imp.db.SaveStateSummaries(ctx, imp.summaries); err != nil {
+		return err
+	}
+	imp.imported += len(imp.blocks)
+	imp.blocks = nil
+	imp.summaries = nil
+	return nil
+}
+
+// finalize marks the last imported block as the finalized checkpoint and head of the database.
+func (imp *importer) finalize(ctx context.Context) error {
+	if !imp.started {
+		return errors.New("archives contain no block")
+	}
+	cp := imp.checkpoint
+	if cp == nil {
+		return errors.New("archives do not end with a finalized checkpoint, some files may be missing")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	if root != imp.lastRoot {
+		return fmt.Errorf("finalized checkpoint root %#x is not the last imported block", root)
+	}
+	if !imp.db.HasState(ctx, root) {
+		return errors.New("archives do not contain the finalized state")
+	}
+	if err := imp.db.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return err
+	}
+	if err := imp.db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return err
+	}
+	if err := imp.db.SaveHeadBlockRoot(ctx, root); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":         imp.imported,
+		"finalizedEpoch": cp.Epoch,
+	}).Info("Imported finalized history")
+	return nil
+}
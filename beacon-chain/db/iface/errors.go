@@ -8,4 +8,12 @@ var (
 	// ErrExistingGenesisState is an error when the user attempts to save a different genesis state
 	// when one already exists in a database.
 	ErrExistingGenesisState = errors.New("genesis state exists already in the DB")
+
+	// ErrNotFoundOriginBlockRoot is an error when the database was not started from a
+	// checkpoint and therefore has no origin block root.
+	ErrNotFoundOriginBlockRoot = errors.New("origin block root not found in the DB")
+
+	// ErrNotFoundBackfillBlockRoot is an error when no block preceding the origin block has been
+	// backfilled yet.
+	ErrNotFoundBackfillBlockRoot = errors.New("backfill block root not found in the DB")
 )
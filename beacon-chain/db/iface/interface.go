@@ -10,8 +10,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	eth "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	v2 "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
@@ -32,6 +34,7 @@ type ReadOnlyDatabase interface {
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	FinalizedChildBlock(ctx context.Context, blockRoot [32]byte) (block.SignedBeaconBlock, error)
 	HighestSlotBlocksBelow(ctx context.Context, slot types.Slot) ([]block.SignedBeaconBlock, error)
+	EarliestAvailableSlot(ctx context.Context) (types.Slot, error)
 	// State related methods.
 	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
 	GenesisState(ctx context.Context) (state.BeaconState, error)
@@ -54,10 +57,16 @@ type ReadOnlyDatabase interface {
 	HasArchivedPoint(ctx context.Context, slot types.Slot) bool
 	LastArchivedRoot(ctx context.Context) [32]byte
 	LastArchivedSlot(ctx context.Context) (types.Slot, error)
+	// Checkpoint sync related methods.
+	OriginBlockRoot(ctx context.Context) ([32]byte, error)
+	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*v2.ETH1ChainData, error)
+	// Light client operations.
+	LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error)
+	LightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]*ethpbv2.LightClientUpdate, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -68,9 +77,11 @@ type NoHeadAccessDatabase interface {
 	SaveBlock(ctx context.Context, block block.SignedBeaconBlock) error
 	SaveBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error
 	SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	SaveBackfillBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error
 	// State related methods.
 	SaveState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
+	SaveStateDiff(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
@@ -87,10 +98,13 @@ type NoHeadAccessDatabase interface {
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *v2.ETH1ChainData) error
+	// Light client operations.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint types.Slot) error
+	PruneHistory(ctx context.Context, slot types.Slot) error
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -105,6 +119,13 @@ type HeadAccessDatabase interface {
 	LoadGenesis(ctx context.Context, r io.Reader) error
 	SaveGenesisData(ctx context.Context, state state.BeaconState) error
 	EnsureEmbeddedGenesis(ctx context.Context) error
+
+	// Checkpoint sync operations.
+	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
+
+	// Fork choice operations.
+	ForkChoice(ctx context.Context) (*protoarray.ForkChoice, error)
+	SaveForkChoice(ctx context.Context, forkChoice *protoarray.ForkChoice) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -0,0 +1,108 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	dbpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// BackfillBlockRoot returns the root of the lowest block imported while backfilling the history
+// preceding the origin block of a checkpoint synced node. ErrNotFoundBackfillBlockRoot is returned
+// if no block has been backfilled yet.
+func (s *Store) BackfillBlockRoot(ctx context.Context) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.BackfillBlockRoot")
+	defer span.End()
+
+	var root [32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(blocksBucket).Get(backfillBlockRootKey)
+		if len(enc) == 0 {
+			return dbIface.ErrNotFoundBackfillBlockRoot
+		}
+		root = bytesutil.ToBytes32(enc)
+		return nil
+	})
+	return root, err
+}
+
+// SaveBackfillBlocks saves a batch of finalized blocks preceding the lowest block in the database
+// of a checkpoint synced node. Blocks must be sorted by ascending slot, each block must be the
+// parent of the next one, and the last block must be the parent of the lowest backfilled block, or
+// of the origin block if nothing was backfilled yet. The blocks are added to the finalized block
+// roots index and the backfill progress is moved to the first block of the batch.
+func (s *Store) SaveBackfillBlocks(ctx context.Context, blocks []block.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBackfillBlocks")
+	defer span.End()
+
+	if len(blocks) == 0 {
+		return nil
+	}
+	childRoot, err := s.BackfillBlockRoot(ctx)
+	if errors.Is(err, dbIface.ErrNotFoundBackfillBlockRoot) {
+		childRoot, err = s.OriginBlockRoot(ctx)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not determine lowest block to backfill from")
+	}
+	child, err := s.Block(ctx, childRoot)
+	if err != nil {
+		return err
+	}
+	if child == nil || child.IsNil() {
+		return fmt.Errorf("missing block in database: block root=%#x", childRoot)
+	}
+
+	roots := make([][32]byte, len(blocks))
+	for i, blk := range blocks {
+		if blk == nil || blk.IsNil() {
+			return errors.New("nil block in backfill batch")
+		}
+		roots[i], err = blk.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if i > 0 && !bytes.Equal(blk.Block().ParentRoot(), roots[i-1][:]) {
+			return fmt.Errorf("block at slot %d is not the child of block %#x", blk.Block().Slot(), roots[i-1])
+		}
+	}
+	last := len(blocks) - 1
+	if !bytes.Equal(child.Block().ParentRoot(), roots[last][:]) {
+		return fmt.Errorf("block %#x is not the parent of the lowest block %#x", roots[last], childRoot)
+	}
+
+	if err := s.SaveBlocks(ctx, blocks); err != nil {
+		return errors.Wrap(err, "could not save backfilled blocks")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		for i, blk := range blocks {
+			next := childRoot
+			if i < last {
+				next = roots[i+1]
+			}
+			container := &dbpb.FinalizedBlockRootContainer{
+				ParentRoot: blk.Block().ParentRoot(),
+				ChildRoot:  next[:],
+			}
+			enc, err := encode(ctx, container)
+			if err != nil {
+				traceutil.AnnotateError(span, err)
+				return err
+			}
+			if err := bkt.Put(roots[i][:], enc); err != nil {
+				traceutil.AnnotateError(span, err)
+				return err
+			}
+		}
+		return tx.Bucket(blocksBucket).Put(backfillBlockRootKey, roots[0][:])
+	})
+}
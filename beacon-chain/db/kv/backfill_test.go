@@ -0,0 +1,110 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// backfillChain returns a chain of blocks at the given slots, linked through their parent roots.
+func backfillChain(t *testing.T, slots ...types.Slot) ([]block.SignedBeaconBlock, [][32]byte) {
+	blks := make([]block.SignedBeaconBlock, len(slots))
+	roots := make([][32]byte, len(slots))
+	parent := bytesutil.PadTo([]byte("genesis"), 32)
+	for i, slot := range slots {
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks[i] = wrapper.WrappedPhase0SignedBeaconBlock(b)
+		roots[i] = root
+		parent = root[:]
+	}
+	return blks, roots
+}
+
+// saveTestOrigin marks the given block as the origin block of the database.
+func saveTestOrigin(t *testing.T, db *Store, blk block.SignedBeaconBlock, root [32]byte) {
+	require.NoError(t, db.SaveBlock(context.Background(), blk))
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blocksBucket).Put(originBlockRootKey, root[:])
+	}))
+}
+
+func TestStore_BackfillBlockRoot_NotFound(t *testing.T) {
+	db := setupDB(t)
+	_, err := db.BackfillBlockRoot(context.Background())
+	require.ErrorContains(t, dbIface.ErrNotFoundBackfillBlockRoot.Error(), err)
+}
+
+func TestStore_SaveBackfillBlocks(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	blks, roots := backfillChain(t, 1, 2, 4, 5, 7)
+	saveTestOrigin(t, db, blks[4], roots[4])
+
+	require.NoError(t, db.SaveBackfillBlocks(ctx, blks[2:4]))
+	root, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[2], root)
+
+	require.NoError(t, db.SaveBackfillBlocks(ctx, blks[:2]))
+	root, err = db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[0], root)
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, true, db.HasBlock(ctx, roots[i]))
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[i]))
+		child, err := db.FinalizedChildBlock(ctx, roots[i])
+		require.NoError(t, err)
+		childRoot, err := child.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, roots[i+1], childRoot)
+	}
+	ok, bySlot, err := db.BlockRootsBySlot(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.DeepEqual(t, [][32]byte{roots[2]}, bySlot)
+}
+
+func TestStore_SaveBackfillBlocks_NotParentOfLowest(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	blks, roots := backfillChain(t, 1, 2, 3)
+	saveTestOrigin(t, db, blks[2], roots[2])
+
+	err := db.SaveBackfillBlocks(ctx, blks[:1])
+	assert.ErrorContains(t, "is not the parent of the lowest block", err)
+	assert.Equal(t, false, db.HasBlock(ctx, roots[0]))
+	_, err = db.BackfillBlockRoot(ctx)
+	require.ErrorContains(t, dbIface.ErrNotFoundBackfillBlockRoot.Error(), err)
+}
+
+func TestStore_SaveBackfillBlocks_Unlinked(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	blks, roots := backfillChain(t, 1, 2, 3)
+	saveTestOrigin(t, db, blks[2], roots[2])
+
+	other, _ := backfillChain(t, 0)
+	err := db.SaveBackfillBlocks(ctx, []block.SignedBeaconBlock{other[0], blks[1]})
+	assert.ErrorContains(t, "is not the child of block", err)
+}
+
+func TestStore_SaveBackfillBlocks_NoOrigin(t *testing.T) {
+	db := setupDB(t)
+	blks, _ := backfillChain(t, 1)
+	err := db.SaveBackfillBlocks(context.Background(), blks)
+	assert.ErrorContains(t, dbIface.ErrNotFoundOriginBlockRoot.Error(), err)
+}
@@ -48,6 +48,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	root := checkpoint.Root
 	var previousRoot []byte
 	genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+	originRoot := tx.Bucket(blocksBucket).Get(originBlockRootKey)
 
 	// De-index recent finalized block roots, to be re-indexed.
 	previousFinalizedCheckpoint := &ethpb.Checkpoint{}
@@ -74,7 +75,7 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	}
 
 	// Walk up the ancestry chain until we reach a block root present in the finalized block roots
-	// index bucket or genesis block root.
+	// index bucket, the genesis block root or the origin block root of a checkpoint synced node.
 	for {
 		if bytes.Equal(root, genesisRoot) {
 			break
@@ -126,6 +127,10 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			}
 			break
 		}
+		// Ancestors of the origin block are not available in the database.
+		if originRoot != nil && bytes.Equal(root, originRoot) {
+			break
+		}
 		previousRoot = root
 		root = block.ParentRoot()
 	}
@@ -0,0 +1,59 @@
+package kv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveForkChoice saves the fork choice store, overwriting the previously saved one.
+func (s *Store) SaveForkChoice(ctx context.Context, forkChoice *protoarray.ForkChoice) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoice")
+	defer span.End()
+
+	if forkChoice == nil {
+		err := errors.New("cannot save nil fork choice store")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	enc, err := forkChoice.MarshalBinary()
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(forkChoiceBucket).Put(forkChoiceStoreKey, snappy.Encode(nil, enc))
+	})
+	traceutil.AnnotateError(span, err)
+	return err
+}
+
+// ForkChoice retrieves the saved fork choice store. It returns nil if no store was saved.
+func (s *Store) ForkChoice(ctx context.Context) (*protoarray.ForkChoice, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForkChoice")
+	defer span.End()
+
+	var forkChoice *protoarray.ForkChoice
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(forkChoiceBucket).Get(forkChoiceStoreKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		dec, err := snappy.Decode(nil, enc)
+		if err != nil {
+			return err
+		}
+		forkChoice = &protoarray.ForkChoice{}
+		return forkChoice.UnmarshalBinary(dec)
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, err
+	}
+	return forkChoice, nil
+}
@@ -0,0 +1,51 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_ForkChoice_CanSaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	fc, err := db.ForkChoice(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, (*protoarray.ForkChoice)(nil), fc)
+
+	root := bytesutil.ToBytes32([]byte("root"))
+	want := protoarray.New(1, 0, params.BeaconConfig().ZeroHash)
+	require.NoError(t, want.ProcessBlock(ctx, 32, root, params.BeaconConfig().ZeroHash, [32]byte{}, 1, 0))
+	want.ProcessAttestation(ctx, []uint64{0, 2}, root, 1)
+	require.NoError(t, db.SaveForkChoice(ctx, want))
+
+	got, err := db.ForkChoice(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want.Nodes(), got.Nodes())
+	assert.Equal(t, want.Store().JustifiedEpoch(), got.Store().JustifiedEpoch())
+	head, err := got.Head(ctx, 1, root, []uint64{10, 10, 10}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, root, head)
+	assert.Equal(t, uint64(20), got.Node(root).Weight())
+}
+
+func TestStore_SaveForkChoice_Nil(t *testing.T) {
+	db := setupDB(t)
+	require.ErrorContains(t, "cannot save nil fork choice store", db.SaveForkChoice(context.Background(), nil))
+}
+
+func TestStore_ForkChoice_Corrupted(t *testing.T) {
+	db := setupDB(t)
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(forkChoiceBucket).Put(forkChoiceStoreKey, []byte("corrupted"))
+	}))
+	_, err := db.ForkChoice(context.Background())
+	require.NotNil(t, err)
+}
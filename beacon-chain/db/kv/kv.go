@@ -161,6 +161,9 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			powchainBucket,
 			stateSummaryBucket,
 			stateValidatorsBucket,
+			stateDiffBucket,
+			forkChoiceBucket,
+			lightClientUpdatesBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -172,6 +175,7 @@ func NewKVStore(ctx context.Context, dirPath string, config *Config) (*Store, er
 			blockParentRootIndicesBucket,
 			finalizedBlockRootsIndexBucket,
 			blockRootValidatorHashesBucket,
+			stateDiffBaseIndicesBucket,
 			// State management service bucket.
 			newStateServiceCompatibleBucket,
 			// Migrations
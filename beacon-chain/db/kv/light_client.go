@@ -0,0 +1,82 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveLightClientUpdate saves the light client update of a sync committee period, overwriting any previously
+// saved update for that period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+
+	enc, err := encode(ctx, update)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lightClientUpdatesBucket).Put(bytesutil.Uint64ToBytesBigEndian(period), enc)
+	})
+	traceutil.AnnotateError(span, err)
+	return err
+}
+
+// LightClientUpdate retrieves the light client update saved for a sync committee period. It returns nil
+// if there is none.
+func (s *Store) LightClientUpdate(ctx context.Context, period uint64) (*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdate")
+	defer span.End()
+
+	var update *ethpbv2.LightClientUpdate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientUpdatesBucket).Get(bytesutil.Uint64ToBytesBigEndian(period))
+		if enc == nil {
+			return nil
+		}
+		update = &ethpbv2.LightClientUpdate{}
+		return decode(ctx, enc, update)
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, err
+	}
+	return update, nil
+}
+
+// LightClientUpdates retrieves the light client updates of at most count consecutive sync committee periods,
+// starting at startPeriod. Retrieval stops at the first period without a saved update.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, count uint64) ([]*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+
+	updates := make([]*ethpbv2.LightClientUpdate, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		period := startPeriod
+		for k, v := c.Seek(bytesutil.Uint64ToBytesBigEndian(startPeriod)); k != nil && uint64(len(updates)) < count; k, v = c.Next() {
+			if bytesutil.BytesToUint64BigEndian(k) != period {
+				break
+			}
+			update := &ethpbv2.LightClientUpdate{}
+			if err := decode(ctx, v, update); err != nil {
+				return errors.Wrapf(err, "could not decode light client update of period %d", period)
+			}
+			updates = append(updates, update)
+			period++
+		}
+		return nil
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, err
+	}
+	return updates, nil
+}
@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func lightClientUpdate(slot types.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader: &ethpbv1.BeaconBlockHeader{
+			Slot:       slot,
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			BodyRoot:   make([]byte, 32),
+		},
+		SignatureSlot: slot + 1,
+	}
+}
+
+func TestStore_LightClientUpdate_CanSaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	update, err := db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientUpdate)(nil), update)
+
+	want := lightClientUpdate(4)
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want, update)
+
+	want.SignatureSlot++
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 1, want))
+	update, err = db.LightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, want.SignatureSlot, update.SignatureSlot)
+}
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	for _, period := range []uint64{1, 2, 3, 5} {
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, lightClientUpdate(types.Slot(period*8192))))
+	}
+
+	tests := []struct {
+		name        string
+		startPeriod uint64
+		count       uint64
+		want        int
+	}{
+		{name: "all consecutive periods", startPeriod: 1, count: 10, want: 3},
+		{name: "count limit", startPeriod: 1, count: 2, want: 2},
+		{name: "after gap", startPeriod: 5, count: 10, want: 1},
+		{name: "missing start period", startPeriod: 4, count: 10, want: 0},
+		{name: "no updates", startPeriod: 6, count: 10, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updates, err := db.LightClientUpdates(ctx, tt.startPeriod, tt.count)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, len(updates))
+		})
+	}
+}
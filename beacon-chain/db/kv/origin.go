@@ -0,0 +1,124 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/detect"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// OriginBlockRoot returns the block root of the checkpoint the node was started from. If the node
+// was synced from genesis, ErrNotFoundOriginBlockRoot is returned.
+func (s *Store) OriginBlockRoot(ctx context.Context) ([32]byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.OriginBlockRoot")
+	defer span.End()
+
+	var root [32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(blocksBucket).Get(originBlockRootKey)
+		if len(enc) == 0 {
+			return dbIface.ErrNotFoundOriginBlockRoot
+		}
+		root = bytesutil.ToBytes32(enc)
+		return nil
+	})
+	return root, err
+}
+
+// SaveOrigin loads a ssz serialized finalized beacon state and the block it was derived from, and
+// prepares the database so that the beacon node can begin syncing using these values as its point
+// of origin. This is an alternative to syncing from genesis and should only be done once, on a
+// database that has not imported any block beyond genesis.
+func (s *Store) SaveOrigin(ctx context.Context, serState, serBlock []byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveOrigin")
+	defer span.End()
+
+	if _, err := s.OriginBlockRoot(ctx); err == nil {
+		return errors.New("database was already initialized from a checkpoint")
+	} else if !errors.Is(err, dbIface.ErrNotFoundOriginBlockRoot) {
+		return err
+	}
+	head, err := s.HeadBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head block")
+	}
+	if head != nil && !head.IsNil() && head.Block().Slot() > 0 {
+		return fmt.Errorf("database already contains blocks up to slot %d, "+
+			"checkpoint sync requires an empty database", head.Block().Slot())
+	}
+
+	st, err := detect.UnmarshalBeaconState(serState)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal checkpoint state")
+	}
+	blk, err := detect.UnmarshalSignedBeaconBlock(serBlock)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal checkpoint block")
+	}
+	blockRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute checkpoint block root")
+	}
+	if err := verifyOriginPair(ctx, st, blockRoot); err != nil {
+		return err
+	}
+
+	if err := s.SaveBlock(ctx, blk); err != nil {
+		return errors.Wrap(err, "could not save checkpoint block")
+	}
+	if err := s.SaveState(ctx, st, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save checkpoint state")
+	}
+	// The state may have been advanced through empty slots, the summary is indexed by the
+	// slot of the block.
+	if err := s.SaveStateSummary(ctx, &ethpb.StateSummary{
+		Slot: blk.Block().Slot(),
+		Root: blockRoot[:],
+	}); err != nil {
+		return errors.Wrap(err, "could not save checkpoint state summary")
+	}
+	// The origin root is saved before the checkpoints so that indexing the finalized
+	// chain stops at the origin block instead of looking for its missing ancestors.
+	if err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blocksBucket).Put(originBlockRootKey, blockRoot[:])
+	}); err != nil {
+		return errors.Wrap(err, "could not save origin block root")
+	}
+	checkpoint := &ethpb.Checkpoint{
+		Epoch: core.SlotToEpoch(st.Slot()),
+		Root:  blockRoot[:],
+	}
+	if err := s.SaveJustifiedCheckpoint(ctx, checkpoint); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := s.SaveFinalizedCheckpoint(ctx, checkpoint); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	if err := s.SaveHeadBlockRoot(ctx, blockRoot); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	return nil
+}
+
+// verifyOriginPair ensures that the state is the post state of the block with the given root,
+// possibly advanced through empty slots.
+func verifyOriginPair(ctx context.Context, st state.BeaconState, blockRoot [32]byte) error {
+	headerRoot, err := helpers.LatestBlockRoot(ctx, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute latest block header root")
+	}
+	if headerRoot != blockRoot {
+		return fmt.Errorf("checkpoint block root %#x does not match the latest block header "+
+			"of the checkpoint state %#x", blockRoot, headerRoot)
+	}
+	return nil
+}
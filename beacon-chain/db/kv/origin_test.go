@@ -0,0 +1,146 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbIface "github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// originPair returns a serialized state and the serialized block that produced it at the given slot.
+func originPair(t *testing.T, slot types.Slot) ([]byte, []byte, [32]byte) {
+	return skippedSlotsOriginPair(t, slot, slot)
+}
+
+// skippedSlotsOriginPair returns a serialized state advanced through empty slots up to the state
+// slot, and the serialized block at the block slot that produced it.
+func skippedSlotsOriginPair(t *testing.T, blockSlot, stateSlot types.Slot) ([]byte, []byte, [32]byte) {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(blockSlot))
+
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = blockSlot
+	blk.Block.ParentRoot = bytesutil.PadTo([]byte("parent"), 32)
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       blk.Block.Slot,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	blockRoot, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	if stateSlot > blockSlot {
+		// The first empty slot fills in the state root of the latest block header.
+		header := st.LatestBlockHeader()
+		header.StateRoot = stateRoot[:]
+		require.NoError(t, st.SetLatestBlockHeader(header))
+		require.NoError(t, st.SetSlot(stateSlot))
+	}
+
+	serState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	serBlock, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	return serState, serBlock, blockRoot
+}
+
+func TestStore_OriginBlockRoot_NotFound(t *testing.T) {
+	db := setupDB(t)
+	_, err := db.OriginBlockRoot(context.Background())
+	require.ErrorContains(t, dbIface.ErrNotFoundOriginBlockRoot.Error(), err)
+}
+
+func TestStore_SaveOrigin(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	gs, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisData(ctx, gs))
+
+	serState, serBlock, blockRoot := originPair(t, 64)
+	require.NoError(t, db.SaveOrigin(ctx, serState, serBlock))
+
+	originRoot, err := db.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, originRoot)
+
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	headRoot, err := head.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, headRoot)
+
+	cp, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(2), cp.Epoch)
+	assert.DeepEqual(t, blockRoot[:], cp.Root)
+	cp, err = db.JustifiedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, blockRoot[:], cp.Root)
+
+	assert.Equal(t, true, db.HasState(ctx, blockRoot))
+	assert.Equal(t, true, db.HasStateSummary(ctx, blockRoot))
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, blockRoot))
+
+	// A second checkpoint can not be applied on top of the first one.
+	assert.ErrorContains(t, "already initialized", db.SaveOrigin(ctx, serState, serBlock))
+}
+
+func TestStore_SaveOrigin_SkippedSlots(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	gs, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveGenesisData(ctx, gs))
+
+	// The checkpoint block is at slot 62, and the checkpoint state at the start of epoch 2.
+	serState, serBlock, blockRoot := skippedSlotsOriginPair(t, 62, 64)
+	require.NoError(t, db.SaveOrigin(ctx, serState, serBlock))
+
+	summary, err := db.StateSummary(ctx, blockRoot)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(62), summary.Slot)
+	st, err := db.State(ctx, blockRoot)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(64), st.Slot())
+}
+
+func TestStore_SaveOrigin_MismatchedBlock(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	serState, _, _ := originPair(t, 64)
+	_, serBlock, _ := originPair(t, 65)
+	assert.ErrorContains(t, "does not match the latest block header", db.SaveOrigin(ctx, serState, serBlock))
+	_, err := db.OriginBlockRoot(ctx)
+	require.ErrorContains(t, dbIface.ErrNotFoundOriginBlockRoot.Error(), err)
+}
+
+func TestStore_SaveOrigin_NonEmptyDatabase(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = 10
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 10, Root: root[:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, root))
+
+	serState, serBlock, _ := originPair(t, 64)
+	assert.ErrorContains(t, "requires an empty database", db.SaveOrigin(ctx, serState, serBlock))
+}
@@ -0,0 +1,215 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// pruneBatchSize is the number of blocks deleted from the database in a single transaction,
+// so that pruning a large history does not hold the DB lock for too long.
+const pruneBatchSize = 256
+
+// EarliestAvailableSlot returns the slot of the earliest block the database can still serve.
+// Blocks below this slot were deleted when pruning the history of the chain.
+func (s *Store) EarliestAvailableSlot(ctx context.Context) (types.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.EarliestAvailableSlot")
+	defer span.End()
+
+	var slot types.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(blocksBucket).Get(earliestAvailableSlotKey)
+		if len(enc) != 0 {
+			slot = bytesutil.BytesToSlotBigEndian(enc)
+		}
+		return nil
+	})
+	return slot, err
+}
+
+// PruneHistory deletes the blocks with a slot lower than the given slot, along with their states,
+// state summaries and the index entries referencing them. The genesis block and state are never
+// deleted, neither are the state snapshots which states saved as diffs after the slot derive from.
+func (s *Store) PruneHistory(ctx context.Context, slot types.Slot) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneHistory")
+	defer span.End()
+
+	earliest, err := s.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return err
+	}
+	if slot <= earliest {
+		return nil
+	}
+
+	var roots [][32]byte
+	protected := make(map[[32]byte]bool)
+	err = s.db.View(func(tx *bolt.Tx) error {
+		genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+		c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+		for k, v := c.First(); k != nil && bytesutil.BytesToSlotBigEndian(k) < slot; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			for i := 0; i+32 <= len(v); i += 32 {
+				if root := bytesutil.ToBytes32(v[i : i+32]); root != bytesutil.ToBytes32(genesisRoot) {
+					roots = append(roots, root)
+				}
+			}
+		}
+		pruned := make(map[[32]byte]bool, len(roots))
+		for _, r := range roots {
+			pruned[r] = true
+		}
+		return tx.Bucket(stateDiffBucket).ForEach(func(k, v []byte) error {
+			if len(v) >= 32 && !pruned[bytesutil.ToBytes32(k)] {
+				protected[bytesutil.ToBytes32(v[:32])] = true
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// States saved as diffs have a higher slot than the snapshot they are based on, so states are
+	// deleted from the highest slot down for no snapshot to be deleted before its diffs.
+	for i := len(roots) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if protected[roots[i]] || !s.HasState(ctx, roots[i]) {
+			continue
+		}
+		if err := s.DeleteState(ctx, roots[i]); err != nil {
+			return errors.Wrapf(err, "could not delete state %#x", roots[i])
+		}
+	}
+
+	for len(roots) > 0 {
+		batch := roots
+		if len(batch) > pruneBatchSize {
+			batch = roots[:pruneBatchSize]
+		}
+		roots = roots[len(batch):]
+		if err := s.pruneBlocks(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blocksBucket).Put(earliestAvailableSlotKey, bytesutil.SlotToBytesBigEndian(slot))
+	})
+}
+
+// pruneBlocks deletes the given blocks and the data derived from them, other than their states.
+// States must be deleted first, as their slot index is looked up from the block or state summary.
+func (s *Store) pruneBlocks(ctx context.Context, blockRoots [][32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.pruneBlocks")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		for _, r := range blockRoots {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if enc := bkt.Get(r[:]); enc != nil {
+				blk, err := unmarshalBlock(ctx, enc)
+				if err != nil {
+					return err
+				}
+				indicesByBucket := createBlockIndicesFromBlock(ctx, blk.Block())
+				if err := deleteValueForIndices(ctx, indicesByBucket, r[:], tx); err != nil {
+					return errors.Wrap(err, "could not delete root for DB indices")
+				}
+				if err := bkt.Delete(r[:]); err != nil {
+					return err
+				}
+				s.blockCache.Del(string(r[:]))
+			}
+			for _, b := range [][]byte{
+				stateSummaryBucket,
+				finalizedBlockRootsIndexBucket,
+			} {
+				if err := tx.Bucket(b).Delete(r[:]); err != nil {
+					return err
+				}
+			}
+		}
+		return pruneAttestations(tx, blockRoots)
+	})
+}
+
+// pruneAttestations deletes the attestations voting for a pruned block as their head, source or
+// target, along with the index entries of the block roots and every other index entry of the
+// deleted attestations.
+func pruneAttestations(tx *bolt.Tx, blockRoots [][32]byte) error {
+	attBkt := tx.Bucket(attestationsBucket)
+	deleted := make(map[[32]byte]bool)
+	for _, r := range blockRoots {
+		for _, b := range [][]byte{
+			attestationHeadBlockRootBucket,
+			attestationSourceRootIndicesBucket,
+			attestationTargetRootIndicesBucket,
+		} {
+			bkt := tx.Bucket(b)
+			keys := bytesutil.SafeCopyBytes(bkt.Get(r[:]))
+			for i := 0; i+32 <= len(keys); i += 32 {
+				if err := attBkt.Delete(keys[i : i+32]); err != nil {
+					return err
+				}
+				deleted[bytesutil.ToBytes32(keys[i:i+32])] = true
+			}
+			if err := bkt.Delete(r[:]); err != nil {
+				return err
+			}
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	// The deleted attestations are also indexed by the roots of blocks which are kept and by
+	// their epochs.
+	for _, b := range [][]byte{
+		attestationHeadBlockRootBucket,
+		attestationSourceRootIndicesBucket,
+		attestationSourceEpochIndicesBucket,
+		attestationTargetRootIndicesBucket,
+		attestationTargetEpochIndicesBucket,
+	} {
+		bkt := tx.Bucket(b)
+		updated := make(map[string][]byte)
+		if err := bkt.ForEach(func(k, v []byte) error {
+			var kept []byte
+			for i := 0; i+32 <= len(v); i += 32 {
+				if !deleted[bytesutil.ToBytes32(v[i:i+32])] {
+					kept = append(kept, v[i:i+32]...)
+				}
+			}
+			if len(kept) != len(v) {
+				updated[string(k)] = kept
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for k, v := range updated {
+			if len(v) == 0 {
+				if err := bkt.Delete([]byte(k)); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := bkt.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	genesis := testutil.NewBeaconBlock()
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+	genesisState, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, genesisState, genesisRoot))
+
+	blks, roots := backfillChain(t, 1, 2, 3, 4, 5)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	for i, r := range roots {
+		slot := blks[i].Block().Slot()
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: slot, Root: r[:]}))
+		if slot == 5 {
+			continue
+		}
+		st := genesisState.Copy()
+		require.NoError(t, st.SetSlot(slot))
+		require.NoError(t, db.SaveState(ctx, st, r))
+	}
+	// The state at slot 5 derives from the state at slot 2, which must be kept.
+	diffState := genesisState.Copy()
+	require.NoError(t, diffState.SetSlot(5))
+	require.NoError(t, db.SaveStateDiff(ctx, diffState, roots[4], roots[1]))
+
+	require.NoError(t, db.PruneHistory(ctx, 4))
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(4), earliest)
+
+	for i, r := range roots {
+		pruned := i < 3
+		assert.Equal(t, !pruned, db.HasBlock(ctx, r))
+		assert.Equal(t, !pruned, db.HasStateSummary(ctx, r))
+		ok, _, err := db.BlockRootsBySlot(ctx, blks[i].Block().Slot())
+		require.NoError(t, err)
+		assert.Equal(t, !pruned, ok)
+	}
+	assert.Equal(t, false, db.HasState(ctx, roots[0]))
+	assert.Equal(t, true, db.HasState(ctx, roots[1]))
+	assert.Equal(t, false, db.HasState(ctx, roots[2]))
+	st, err := db.State(ctx, roots[4])
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(5), st.Slot())
+	assert.Equal(t, true, db.HasBlock(ctx, genesisRoot))
+	assert.Equal(t, true, db.HasState(ctx, genesisRoot))
+
+	// Pruning below the earliest available slot is a no-op.
+	require.NoError(t, db.PruneHistory(ctx, 2))
+	earliest, err = db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(4), earliest)
+}
+
+func TestStore_EarliestAvailableSlot_NotPruned(t *testing.T) {
+	db := setupDB(t)
+	earliest, err := db.EarliestAvailableSlot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(0), earliest)
+}
+
+func TestStore_PruneHistory_Attestations(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	blks, roots := backfillChain(t, 1, 2, 3)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	prunedAtt, keptAtt := [32]byte{'a'}, [32]byte{'b'}
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		for _, k := range [][32]byte{prunedAtt, keptAtt} {
+			if err := tx.Bucket(attestationsBucket).Put(k[:], []byte("attestation")); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket(attestationHeadBlockRootBucket).Put(roots[0][:], prunedAtt[:]); err != nil {
+			return err
+		}
+		// The pruned attestation is also indexed by a kept block and by its epoch.
+		if err := tx.Bucket(attestationTargetEpochIndicesBucket).Put([]byte{0}, prunedAtt[:]); err != nil {
+			return err
+		}
+		return tx.Bucket(attestationTargetRootIndicesBucket).Put(roots[2][:], append(prunedAtt[:], keptAtt[:]...))
+	}))
+
+	require.NoError(t, db.PruneHistory(ctx, 2))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		assert.Equal(t, true, tx.Bucket(attestationsBucket).Get(prunedAtt[:]) == nil, "Attestation was not pruned")
+		assert.Equal(t, true, tx.Bucket(attestationHeadBlockRootBucket).Get(roots[0][:]) == nil)
+		assert.DeepEqual(t, []byte("attestation"), tx.Bucket(attestationsBucket).Get(keptAtt[:]))
+		assert.DeepEqual(t, keptAtt[:], tx.Bucket(attestationTargetRootIndicesBucket).Get(roots[2][:]))
+		assert.Equal(t, true, tx.Bucket(attestationTargetEpochIndicesBucket).Get([]byte{0}) == nil, "Epoch index was not pruned")
+		return nil
+	}))
+}
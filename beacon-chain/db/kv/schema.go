@@ -18,6 +18,11 @@ var (
 	checkpointBucket        = []byte("check-point")
 	powchainBucket          = []byte("powchain")
 	stateValidatorsBucket   = []byte("state-validators")
+	stateDiffBucket         = []byte("state-diff")
+	forkChoiceBucket        = []byte("fork-choice")
+
+	// Light client updates, keyed by sync committee period.
+	lightClientUpdatesBucket = []byte("light-client-updates")
 
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
@@ -35,14 +40,19 @@ var (
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
 	blockRootValidatorHashesBucket      = []byte("block-root-validator-hashes")
+	stateDiffBaseIndicesBucket          = []byte("state-diff-base-indices")
 
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
 	genesisBlockRootKey       = []byte("genesis-root")
+	originBlockRootKey        = []byte("origin-block-root")
+	backfillBlockRootKey      = []byte("backfill-block-root")
+	earliestAvailableSlotKey  = []byte("earliest-available-slot")
 	depositContractAddressKey = []byte("deposit-contract")
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
+	forkChoiceStoreKey        = []byte("fork-choice-store")
 	// Altair key used to identify object is altair compatible.
 	// Objects that are only compatible with altair should be prefixed with such key.
 	altairKey = []byte("altair")
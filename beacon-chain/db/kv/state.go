@@ -34,7 +34,8 @@ func (s *Store) State(ctx context.Context, blockRoot [32]byte) (state.BeaconStat
 	}
 
 	if len(enc) == 0 {
-		return nil, nil
+		// The state may be stored as a diff against an archived state snapshot.
+		return s.stateFromDiff(ctx, blockRoot)
 	}
 	// get the validator entries of the state
 	valEntries, valErr := s.validatorEntries(ctx, blockRoot)
@@ -284,6 +285,9 @@ func (s *Store) HasState(ctx context.Context, blockRoot [32]byte) bool {
 		if len(stBytes) > 0 {
 			hasState = true
 		}
+		if diff := tx.Bucket(stateDiffBucket).Get(blockRoot[:]); len(diff) > 0 {
+			hasState = true
+		}
 		return nil
 	})
 	if err != nil {
@@ -318,6 +322,16 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 			return errors.New("cannot delete genesis, finalized, or head state")
 		}
 
+		// A state snapshot cannot be deleted while states saved as diffs are based on it.
+		diffBkt := tx.Bucket(stateDiffBucket)
+		diff := diffBkt.Get(blockRoot[:])
+		isDiff := diff != nil
+		if !isDiff {
+			if diffRoots := tx.Bucket(stateDiffBaseIndicesBucket).Get(blockRoot[:]); len(diffRoots) >= 32 {
+				return errors.Errorf("cannot delete state %#x, the state diff of block root %#x is based on it", blockRoot, diffRoots[:32])
+			}
+		}
+
 		slot, err := s.slotByBlockRoot(ctx, tx, blockRoot[:])
 		if err != nil {
 			return err
@@ -327,6 +341,16 @@ func (s *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 			return errors.Wrap(err, "could not delete root for DB indices")
 		}
 
+		// States stored as a diff have no validator entries of their own.
+		if isDiff {
+			if len(diff) >= 32 {
+				if err := deleteValueForIndices(ctx, stateDiffBaseIndices(diff[:32]), blockRoot[:], tx); err != nil {
+					return errors.Wrap(err, "could not delete base index")
+				}
+			}
+			return diffBkt.Delete(blockRoot[:])
+		}
+
 		ok, err := s.isStateValidatorMigrationOver()
 		if err != nil {
 			return err
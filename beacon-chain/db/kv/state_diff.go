@@ -0,0 +1,103 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/statediff"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveStateDiff stores a state using block's signing root as a field level diff against the full
+// state saved for the base block root. If the base state is itself stored as a diff, the new diff
+// is taken against the full state it derives from, so any state is rebuilt from a single diff.
+func (s *Store) SaveStateDiff(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if enc := tx.Bucket(stateDiffBucket).Get(baseRoot[:]); len(enc) >= 32 {
+			baseRoot = bytesutil.ToBytes32(enc[:32])
+		}
+		if tx.Bucket(stateBucket).Get(baseRoot[:]) == nil {
+			return fmt.Errorf("no full state saved for base block root %#x", baseRoot)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	base, err := s.State(ctx, baseRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve base state")
+	}
+	diff, err := statediff.Diff(base, st)
+	if err != nil {
+		return errors.Wrap(err, "could not compute state diff")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		indicesByBucket := createStateIndicesFromStateSlot(ctx, st.Slot())
+		if err := updateValueForIndices(ctx, indicesByBucket, blockRoot[:], tx); err != nil {
+			return errors.Wrap(err, "could not update DB indices")
+		}
+		bkt := tx.Bucket(stateDiffBucket)
+		if enc := bkt.Get(blockRoot[:]); len(enc) >= 32 {
+			if err := deleteValueForIndices(ctx, stateDiffBaseIndices(enc[:32]), blockRoot[:], tx); err != nil {
+				return errors.Wrap(err, "could not delete previous base index")
+			}
+		}
+		if err := updateValueForIndices(ctx, stateDiffBaseIndices(baseRoot[:]), blockRoot[:], tx); err != nil {
+			return errors.Wrap(err, "could not update base index")
+		}
+		return bkt.Put(blockRoot[:], append(baseRoot[:], diff...))
+	})
+}
+
+// stateDiffBaseIndices returns the index of the state diffs based on the state of the base root.
+func stateDiffBaseIndices(baseRoot []byte) map[string][]byte {
+	return map[string][]byte{
+		string(stateDiffBaseIndicesBucket): bytesutil.SafeCopyBytes(baseRoot),
+	}
+}
+
+// stateFromDiff rebuilds a state saved as a diff by applying it to its base state. A nil state is
+// returned if no diff is saved for the block root.
+func (s *Store) stateFromDiff(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateFromDiff")
+	defer span.End()
+
+	var enc []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(stateDiffBucket).Get(blockRoot[:]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	if len(enc) < 32 {
+		return nil, fmt.Errorf("invalid state diff length %d", len(enc))
+	}
+	baseRoot := bytesutil.ToBytes32(enc[:32])
+	if baseRoot == blockRoot {
+		return nil, fmt.Errorf("state diff %#x references itself", blockRoot)
+	}
+	base, err := s.State(ctx, baseRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve base state")
+	}
+	if base == nil || base.IsNil() {
+		return nil, fmt.Errorf("missing base state %#x of state diff %#x", baseRoot, blockRoot)
+	}
+	return statediff.Apply(base, enc[32:])
+}
@@ -0,0 +1,137 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	baseRoot := [32]byte{'A'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(64))
+	require.NoError(t, st.UpdateBalancesAtIndex(5, 1))
+	root := [32]byte{'B'}
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+	assert.Equal(t, true, db.HasState(ctx, root))
+
+	saved, err := db.State(ctx, root)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.InnerStateUnsafe(), saved.InnerStateUnsafe())
+
+	// A diff against a diff is taken against the underlying full state.
+	next := st.Copy()
+	require.NoError(t, next.SetSlot(128))
+	nextRoot := [32]byte{'C'}
+	require.NoError(t, db.SaveStateDiff(ctx, next, nextRoot, root))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(stateDiffBucket).Get(nextRoot[:])
+		assert.DeepEqual(t, baseRoot[:], enc[:32])
+		return nil
+	}))
+	saved, err = db.State(ctx, nextRoot)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, next.InnerStateUnsafe(), saved.InnerStateUnsafe())
+
+	highest, err := db.HighestSlotStatesBelow(ctx, 129)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(highest))
+	assert.Equal(t, next.Slot(), highest[0].Slot())
+}
+
+func TestStore_SaveStateDiff_MissingBase(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	err = db.SaveStateDiff(ctx, st, [32]byte{'B'}, [32]byte{'A'})
+	assert.ErrorContains(t, "no full state saved for base block root", err)
+	assert.Equal(t, false, db.HasState(ctx, [32]byte{'B'}))
+}
+
+func TestStore_DeleteStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	baseRoot := [32]byte{'A'}
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(64))
+	root := [32]byte{'B'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 64, Root: root[:]}))
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+
+	require.NoError(t, db.DeleteState(ctx, root))
+	assert.Equal(t, false, db.HasState(ctx, root))
+	assert.Equal(t, true, db.HasState(ctx, baseRoot))
+	saved, err := db.State(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, true, saved == nil)
+}
+
+func TestStore_DeleteState_SnapshotWithDiffs(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	baseRoot := [32]byte{'A'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 0, Root: baseRoot[:]}))
+	require.NoError(t, db.SaveState(ctx, base, baseRoot))
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(64))
+	require.NoError(t, st.UpdateBalancesAtIndex(5, 1))
+	root := [32]byte{'B'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 64, Root: root[:]}))
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+
+	assert.ErrorContains(t, "the state diff of block root", db.DeleteState(ctx, baseRoot))
+	assert.Equal(t, true, db.HasState(ctx, baseRoot))
+	saved, err := db.State(ctx, root)
+	require.NoError(t, err)
+	require.DeepSSZEqual(t, st.InnerStateUnsafe(), saved.InnerStateUnsafe())
+
+	// The snapshot can be deleted once no diff is based on it anymore.
+	require.NoError(t, db.DeleteState(ctx, root))
+	require.NoError(t, db.DeleteState(ctx, baseRoot))
+	assert.Equal(t, false, db.HasState(ctx, baseRoot))
+}
+
+func TestStore_SaveStateDiff_NewBase(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	oldBaseRoot, newBaseRoot := [32]byte{'A'}, [32]byte{'B'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 0, Root: oldBaseRoot[:]}))
+	require.NoError(t, db.SaveState(ctx, base, oldBaseRoot))
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 0, Root: newBaseRoot[:]}))
+	require.NoError(t, db.SaveState(ctx, base, newBaseRoot))
+	st := base.Copy()
+	require.NoError(t, st.SetSlot(64))
+	root := [32]byte{'C'}
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: 64, Root: root[:]}))
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, oldBaseRoot))
+	require.NoError(t, db.SaveStateDiff(ctx, st, root, newBaseRoot))
+
+	// Only the current base of the diff is protected.
+	assert.ErrorContains(t, "the state diff of block root", db.DeleteState(ctx, newBaseRoot))
+	require.NoError(t, db.DeleteState(ctx, oldBaseRoot))
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		assert.DeepEqual(t, root[:], tx.Bucket(stateDiffBaseIndicesBucket).Get(newBaseRoot[:]))
+		assert.Equal(t, 0, len(tx.Bucket(stateDiffBaseIndicesBucket).Get(oldBaseRoot[:])))
+		return nil
+	}))
+}
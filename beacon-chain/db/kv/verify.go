@@ -0,0 +1,472 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Names of the integrity checks run by VerifyIntegrity.
+const (
+	MissingParentCheck          = "missing-parent"
+	BlockIndicesCheck           = "block-indices"
+	FinalizedIndexCheck         = "finalized-index"
+	OrphanedStateSummaryCheck   = "orphaned-state-summary"
+	ArchivedPointCheck          = "archived-point"
+	CheckpointCheck             = "checkpoint"
+	StateRootCheck              = "state-root"
+	OrphanedValidatorEntryCheck = "orphaned-validator-entry"
+)
+
+// IntegrityIssue is a broken invariant of the database.
+type IntegrityIssue struct {
+	// Check is the name of the check which found the issue.
+	Check string
+	// Key is the key of the inconsistent entry, usually a block root.
+	Key []byte
+	// Message describes the issue.
+	Message string
+	// Repaired is true if the issue was fixed.
+	Repaired bool
+}
+
+// IntegrityReport is the outcome of the verification of a database.
+type IntegrityReport struct {
+	Blocks         int
+	States         int
+	StateSummaries int
+	Issues         []*IntegrityIssue
+}
+
+// Unrepaired returns the number of issues which were not fixed.
+func (r *IntegrityReport) Unrepaired() int {
+	n := 0
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *IntegrityReport) add(check string, key []byte, repaired bool, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, &IntegrityIssue{
+		Check:    check,
+		Key:      bytesutil.SafeCopyBytes(key),
+		Message:  fmt.Sprintf(format, args...),
+		Repaired: repaired,
+	})
+}
+
+// VerifyIntegrity walks the database and reports its broken invariants. If repair is set, the
+// issues which can be fixed without losing data are repaired: block and finalized indices are
+// rebuilt from the blocks, and entries referencing data missing from the database are deleted.
+// Missing blocks and states which do not match their block can't be repaired, and are only reported.
+func (s *Store) VerifyIntegrity(ctx context.Context, repair bool) (*IntegrityReport, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyIntegrity")
+	defer span.End()
+
+	// State summaries are cached in memory until they are flushed to the database.
+	if err := s.saveCachedStateSummariesDB(ctx); err != nil {
+		return nil, err
+	}
+	report := &IntegrityReport{}
+	// Block indices are verified first, as the repair of the finalized index relies on them.
+	checks := []func(context.Context, *IntegrityReport, bool) error{
+		s.verifyBlocks,
+		s.verifyFinalizedIndex,
+		s.verifyStateSummaries,
+		s.verifyArchivedPoints,
+		s.verifyCheckpoints,
+		s.verifyStates,
+		s.verifyValidatorEntries,
+	}
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err := check(ctx, report, repair); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// verifyBlocks ensures every block has a parent in the database and is present in the block
+// indices. The parent of the genesis block, of the origin block of a checkpoint synced node and of
+// the lowest backfilled block are not expected in the database, neither are the parents of the
+// lowest blocks remaining after the history was pruned.
+func (s *Store) verifyBlocks(ctx context.Context, report *IntegrityReport, repair bool) error {
+	earliest, err := s.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return err
+	}
+	var missingIndices [][]byte
+	err = s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		originRoot := bkt.Get(originBlockRootKey)
+		backfillRoot := bkt.Get(backfillBlockRootKey)
+		lowestSlot := types.Slot(0)
+		if earliest > 0 {
+			if k, _ := tx.Bucket(blockSlotIndicesBucket).Cursor().Seek(bytesutil.SlotToBytesBigEndian(earliest)); k != nil {
+				lowestSlot = bytesutil.BytesToSlotBigEndian(k)
+			}
+		}
+		return bkt.ForEach(func(k, v []byte) error {
+			// The blocks bucket also holds the roots of noteworthy blocks under named keys.
+			if len(k) != 32 {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.Blocks++
+			blk, err := unmarshalBlock(ctx, v)
+			if err != nil {
+				report.add(MissingParentCheck, k, false, "could not decode block: %v", err)
+				return nil
+			}
+			b := blk.Block()
+			parentExempt := b.Slot() == 0 || bytes.Equal(k, originRoot) || bytes.Equal(k, backfillRoot) ||
+				(earliest > 0 && b.Slot() == lowestSlot)
+			if !parentExempt && bkt.Get(b.ParentRoot()) == nil {
+				report.add(MissingParentCheck, k, false, "parent %#x of block at slot %d is missing", b.ParentRoot(), b.Slot())
+			}
+			for bucket, idx := range createBlockIndicesFromBlock(ctx, b) {
+				if !containsRoot(tx.Bucket([]byte(bucket)).Get(idx), k) {
+					report.add(BlockIndicesCheck, k, repair, "block at slot %d is missing from the %s index", b.Slot(), bucket)
+					missingIndices = append(missingIndices, bytesutil.SafeCopyBytes(k))
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil || !repair || len(missingIndices) == 0 {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(blocksBucket)
+		for _, root := range missingIndices {
+			blk, err := unmarshalBlock(ctx, bkt.Get(root))
+			if err != nil {
+				return err
+			}
+			if err := updateValueForIndices(ctx, createBlockIndicesFromBlock(ctx, blk.Block()), root, tx); err != nil {
+				return errors.Wrap(err, "could not update DB indices")
+			}
+		}
+		return nil
+	})
+}
+
+// verifyFinalizedIndex ensures that the canonical chain of blocks up to the finalized checkpoint is
+// present in the finalized block roots index, and that every entry points to its child.
+func (s *Store) verifyFinalizedIndex(ctx context.Context, report *IntegrityReport, repair bool) error {
+	cp, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	if bytesutil.ToBytes32(cp.Root) == params.BeaconConfig().ZeroHash {
+		return nil
+	}
+
+	walk := func(tx *bolt.Tx, fix bool) error {
+		blocks := tx.Bucket(blocksBucket)
+		genesisRoot := blocks.Get(genesisBlockRootKey)
+		originRoot := blocks.Get(originBlockRootKey)
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		var childRoot []byte
+		// The walk stops at the genesis block, which is not indexed, at the origin block of a
+		// checkpoint synced node, or at the first block deleted by pruning.
+		for root := cp.Root; !bytes.Equal(root, genesisRoot); {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			enc := blocks.Get(root)
+			if enc == nil {
+				return nil
+			}
+			blk, err := unmarshalBlock(ctx, enc)
+			if err != nil {
+				return err
+			}
+			parentRoot := blk.Block().ParentRoot()
+			var broken bool
+			entry := bkt.Get(root)
+			switch {
+			case entry == nil:
+				broken = true
+				if !fix {
+					report.add(FinalizedIndexCheck, root, repair, "finalized block at slot %d is missing from the index", blk.Block().Slot())
+				}
+			case bytes.Equal(entry, containerFinalizedButNotCanonical):
+				// Blocks of the finalized epoch are reindexed on the next finalized checkpoint.
+			default:
+				container := &ethpb.FinalizedBlockRootContainer{}
+				if err := decode(ctx, entry, container); err != nil {
+					return err
+				}
+				if !bytes.Equal(container.ParentRoot, parentRoot) || (childRoot != nil && !bytes.Equal(container.ChildRoot, childRoot)) {
+					broken = true
+					if !fix {
+						report.add(FinalizedIndexCheck, root, repair, "index entry of finalized block at slot %d does not match the chain", blk.Block().Slot())
+					}
+				}
+			}
+			if broken && fix {
+				enc, err := encode(ctx, &ethpb.FinalizedBlockRootContainer{ParentRoot: parentRoot, ChildRoot: childRoot})
+				if err != nil {
+					return err
+				}
+				if err := bkt.Put(root, enc); err != nil {
+					return err
+				}
+			}
+			if originRoot != nil && bytes.Equal(root, originRoot) {
+				return nil
+			}
+			childRoot = root
+			root = parentRoot
+		}
+		return nil
+	}
+
+	issues := len(report.Issues)
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		return walk(tx, false)
+	}); err != nil {
+		return err
+	}
+	if !repair || len(report.Issues) == issues {
+		return nil
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return walk(tx, true)
+	})
+}
+
+// verifyStateSummaries ensures that every state summary has a block.
+func (s *Store) verifyStateSummaries(ctx context.Context, report *IntegrityReport, repair bool) error {
+	var orphans [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		blocks := tx.Bucket(blocksBucket)
+		return tx.Bucket(stateSummaryBucket).ForEach(func(k, _ []byte) error {
+			report.StateSummaries++
+			if blocks.Get(k) == nil {
+				report.add(OrphanedStateSummaryCheck, k, repair, "state summary has no block")
+				orphans = append(orphans, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+	})
+	if err != nil || !repair || len(orphans) == 0 {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateSummaryBucket)
+		for _, k := range orphans {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// verifyArchivedPoints ensures that every root of the state slot index resolves to a saved state.
+func (s *Store) verifyArchivedPoints(ctx context.Context, report *IntegrityReport, repair bool) error {
+	type archivedPoint struct {
+		slot types.Slot
+		root []byte
+	}
+	var unresolved []archivedPoint
+	err := s.db.View(func(tx *bolt.Tx) error {
+		states := tx.Bucket(stateBucket)
+		diffs := tx.Bucket(stateDiffBucket)
+		return tx.Bucket(stateSlotIndicesBucket).ForEach(func(k, v []byte) error {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			for i := 0; i+32 <= len(v); i += 32 {
+				root := v[i : i+32]
+				if states.Get(root) == nil && diffs.Get(root) == nil {
+					report.add(ArchivedPointCheck, root, repair, "archived point at slot %d has no state", slot)
+					unresolved = append(unresolved, archivedPoint{slot: slot, root: bytesutil.SafeCopyBytes(root)})
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil || !repair || len(unresolved) == 0 {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, p := range unresolved {
+			if err := deleteValueForIndices(ctx, createStateIndicesFromStateSlot(ctx, p.slot), p.root, tx); err != nil {
+				return errors.Wrap(err, "could not delete root for DB indices")
+			}
+		}
+		return nil
+	})
+}
+
+// verifyCheckpoints ensures that the checkpoints and the noteworthy block roots of the database
+// point to saved blocks. A head root pointing to a missing block is repaired by resetting the head
+// to the finalized block.
+func (s *Store) verifyCheckpoints(ctx context.Context, report *IntegrityReport, repair bool) error {
+	justified, err := s.JustifiedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	finalized, err := s.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+	var resetHead bool
+	err = s.db.View(func(tx *bolt.Tx) error {
+		blocks := tx.Bucket(blocksBucket)
+		roots := []struct {
+			name string
+			root []byte
+		}{
+			{"justified checkpoint", justified.Root},
+			{"finalized checkpoint", finalized.Root},
+			{"genesis block root", blocks.Get(genesisBlockRootKey)},
+			{"origin block root", blocks.Get(originBlockRootKey)},
+			{"backfill block root", blocks.Get(backfillBlockRootKey)},
+		}
+		for _, r := range roots {
+			if len(r.root) == 0 || bytesutil.ToBytes32(r.root) == params.BeaconConfig().ZeroHash {
+				continue
+			}
+			if blocks.Get(r.root) == nil {
+				report.add(CheckpointCheck, r.root, false, "%s points to a missing block", r.name)
+			}
+		}
+		head := blocks.Get(headBlockRootKey)
+		if len(head) != 0 && blocks.Get(head) == nil {
+			resetHead = repair && blocks.Get(finalized.Root) != nil
+			report.add(CheckpointCheck, head, resetHead, "head block root points to a missing block")
+		}
+		return nil
+	})
+	if err != nil || !resetHead {
+		return err
+	}
+	return s.SaveHeadBlockRoot(ctx, bytesutil.ToBytes32(finalized.Root))
+}
+
+// verifyStates ensures that every state is the post state of its block. States saved after empty
+// slots are checked against the latest block header they hold.
+func (s *Store) verifyStates(ctx context.Context, report *IntegrityReport, _ bool) error {
+	var roots [][32]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{stateBucket, stateDiffBucket} {
+			if err := tx.Bucket(b).ForEach(func(k, _ []byte) error {
+				roots = append(roots, bytesutil.ToBytes32(k))
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		report.States++
+		blk, err := s.Block(ctx, root)
+		if err != nil {
+			return err
+		}
+		if blk == nil || blk.IsNil() {
+			report.add(StateRootCheck, root[:], false, "state has no block to verify it against")
+			continue
+		}
+		st, err := s.State(ctx, root)
+		if err != nil {
+			report.add(StateRootCheck, root[:], false, "could not load state: %v", err)
+			continue
+		}
+		if st.Slot() != blk.Block().Slot() {
+			if err := verifyOriginPair(ctx, st, root); err != nil {
+				report.add(StateRootCheck, root[:], false, "state at slot %d does not derive from its block: %v", st.Slot(), err)
+			}
+			continue
+		}
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(stateRoot[:], blk.Block().StateRoot()) {
+			report.add(StateRootCheck, root[:], false, "state root %#x does not match the state root %#x of its block", stateRoot, blk.Block().StateRoot())
+		}
+	}
+	return nil
+}
+
+// verifyValidatorEntries ensures that every validator entry is referenced by a saved state.
+func (s *Store) verifyValidatorEntries(ctx context.Context, report *IntegrityReport, repair bool) error {
+	var orphans [][]byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		referenced := make(map[[32]byte]bool)
+		if err := tx.Bucket(blockRootValidatorHashesBucket).ForEach(func(k, v []byte) error {
+			hashes, err := snappy.Decode(nil, v)
+			if err != nil {
+				return errors.Wrapf(err, "failed to uncompress validator keys of state %#x", k)
+			}
+			for i := 0; i+hashLength <= len(hashes); i += hashLength {
+				referenced[bytesutil.ToBytes32(hashes[i:i+hashLength])] = true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(stateValidatorsBucket).ForEach(func(k, _ []byte) error {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !referenced[bytesutil.ToBytes32(k)] {
+				report.add(OrphanedValidatorEntryCheck, k, repair, "validator entry is not referenced by any state")
+				orphans = append(orphans, bytesutil.SafeCopyBytes(k))
+			}
+			return nil
+		})
+	})
+	if err != nil || !repair || len(orphans) == 0 {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(stateValidatorsBucket)
+		for _, k := range orphans {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+			s.validatorEntryCache.Del(k)
+		}
+		return nil
+	})
+}
+
+// containsRoot returns true if the concatenated roots of an index entry contain the given root.
+func containsRoot(values, root []byte) bool {
+	for i := 0; i+32 <= len(values); i += 32 {
+		if bytes.Equal(values[i:i+32], root) {
+			return true
+		}
+	}
+	return false
+}
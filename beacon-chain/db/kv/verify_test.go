@@ -0,0 +1,155 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupVerifyChain saves a genesis block and state followed by 5 blocks, and finalizes the block
+// at slot 3.
+func setupVerifyChain(t *testing.T, db *Store) (state.BeaconState, []block.SignedBeaconBlock, [][32]byte) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	genesis := blocks.NewGenesisBlock(stateRoot[:])
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(genesis)))
+	require.NoError(t, db.SaveState(ctx, st, genesisRoot))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	blks := makeBlocks(t, 0, 5, genesisRoot)
+	require.NoError(t, db.SaveBlocks(ctx, blks))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: roots[i][:]}))
+	}
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 0, Root: roots[2][:]}))
+	require.NoError(t, db.SaveHeadBlockRoot(ctx, roots[4]))
+	return st, blks, roots
+}
+
+func issuesByCheck(report *IntegrityReport) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range report.Issues {
+		counts[issue.Check]++
+	}
+	return counts
+}
+
+func TestStore_VerifyIntegrity_Consistent(t *testing.T) {
+	db := setupDB(t)
+	setupVerifyChain(t, db)
+
+	report, err := db.VerifyIntegrity(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(report.Issues))
+	assert.Equal(t, 6, report.Blocks)
+	assert.Equal(t, 1, report.States)
+	assert.Equal(t, 5, report.StateSummaries)
+}
+
+func TestStore_VerifyIntegrity_Repair(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	_, blks, roots := setupVerifyChain(t, db)
+
+	unknownRoot := bytesutil.PadTo([]byte("unknown"), 32)
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(finalizedBlockRootsIndexBucket).Delete(roots[1][:]); err != nil {
+			return err
+		}
+		if err := deleteValueForIndices(ctx, createBlockIndicesFromBlock(ctx, blks[3].Block()), roots[3][:], tx); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSummaryBucket).Put(unknownRoot, []byte("summary")); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateSlotIndicesBucket).Put(bytesutil.SlotToBytesBigEndian(100), unknownRoot); err != nil {
+			return err
+		}
+		if err := tx.Bucket(stateValidatorsBucket).Put(unknownRoot, []byte("validator")); err != nil {
+			return err
+		}
+		return tx.Bucket(blocksBucket).Put(headBlockRootKey, unknownRoot)
+	}))
+
+	report, err := db.VerifyIntegrity(ctx, false)
+	require.NoError(t, err)
+	counts := issuesByCheck(report)
+	assert.Equal(t, 1, counts[FinalizedIndexCheck])
+	assert.Equal(t, 2, counts[BlockIndicesCheck])
+	assert.Equal(t, 1, counts[OrphanedStateSummaryCheck])
+	assert.Equal(t, 1, counts[ArchivedPointCheck])
+	assert.Equal(t, 1, counts[OrphanedValidatorEntryCheck])
+	assert.Equal(t, 1, counts[CheckpointCheck])
+	assert.Equal(t, len(report.Issues), report.Unrepaired())
+	assert.Equal(t, false, db.IsFinalizedBlock(ctx, roots[1]))
+
+	report, err = db.VerifyIntegrity(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 7, len(report.Issues))
+	assert.Equal(t, 0, report.Unrepaired())
+
+	report, err = db.VerifyIntegrity(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(report.Issues))
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[1]))
+	ok, slotRoots, err := db.BlockRootsBySlot(ctx, blks[3].Block().Slot())
+	require.NoError(t, err)
+	assert.Equal(t, true, ok)
+	assert.DeepEqual(t, [][32]byte{roots[3]}, slotRoots)
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	headRoot, err := head.Block().HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, roots[2], headRoot)
+}
+
+func TestStore_VerifyIntegrity_Unrepairable(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	st, _, roots := setupVerifyChain(t, db)
+
+	// A block whose state does not match its state root.
+	mismatch := testutil.NewBeaconBlock()
+	mismatch.Block.Slot = 6
+	mismatch.Block.ParentRoot = roots[4][:]
+	mismatchRoot, err := mismatch.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(mismatch)))
+	mismatchState := st.Copy()
+	require.NoError(t, mismatchState.SetSlot(6))
+	require.NoError(t, db.SaveState(ctx, mismatchState, mismatchRoot))
+
+	// A block whose parent is missing.
+	orphan := testutil.NewBeaconBlock()
+	orphan.Block.Slot = 7
+	orphan.Block.ParentRoot = bytesutil.PadTo([]byte("missing"), 32)
+	require.NoError(t, db.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(orphan)))
+
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte("unknown"), 32)}))
+
+	report, err := db.VerifyIntegrity(ctx, true)
+	require.NoError(t, err)
+	counts := issuesByCheck(report)
+	assert.Equal(t, 1, counts[StateRootCheck])
+	assert.Equal(t, 1, counts[MissingParentCheck])
+	assert.Equal(t, 1, counts[CheckpointCheck])
+	assert.Equal(t, 3, len(report.Issues))
+	assert.Equal(t, 3, report.Unrepaired())
+}
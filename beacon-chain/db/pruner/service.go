@@ -0,0 +1,152 @@
+// Package pruner defines a service which deletes the history of the chain older than a configured
+// retention window from the database of a beacon node, whenever a new checkpoint is finalized.
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*Service)(nil)
+
+// Config to set up the pruner service.
+type Config struct {
+	DB              db.NoHeadAccessDatabase
+	HeadFetcher     blockchain.HeadFetcher
+	StateNotifier   statefeed.Notifier
+	RetentionEpochs types.Epoch
+}
+
+// Service deletes the blocks and states older than the retention window behind the finalized
+// checkpoint. The window is never shorter than the weak subjectivity period of the chain.
+type Service struct {
+	cfg            *Config
+	ctx            context.Context
+	cancel         context.CancelFunc
+	warnedWSPeriod bool
+}
+
+// NewService configures the pruner service.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		cfg:    cfg,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start the pruner service.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruner service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// run prunes the history of the chain every time a new checkpoint is finalized.
+func (s *Service) run() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.FinalizedCheckpoint {
+				continue
+			}
+			data, ok := event.Data.(*ethpbv1.EventFinalizedCheckpoint)
+			if !ok {
+				continue
+			}
+			if err := s.prune(s.ctx, data.Epoch); err != nil {
+				log.WithError(err).Error("Could not prune chain history")
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			return
+		}
+	}
+}
+
+// prune deletes the history older than the retention window behind the finalized epoch.
+func (s *Service) prune(ctx context.Context, finalizedEpoch types.Epoch) error {
+	retention, err := s.retentionEpochs(ctx)
+	if err != nil {
+		return err
+	}
+	if finalizedEpoch <= retention {
+		return nil
+	}
+	slot, err := core.StartSlot(finalizedEpoch - retention)
+	if err != nil {
+		return err
+	}
+	earliest, err := s.cfg.DB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return err
+	}
+	if slot <= earliest {
+		return nil
+	}
+
+	start := time.Now()
+	if err := s.cfg.DB.PruneHistory(ctx, slot); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"earliestSlot": slot,
+		"duration":     time.Since(start),
+	}).Info("Pruned chain history")
+	return nil
+}
+
+// retentionEpochs returns the configured retention window, extended to the weak subjectivity
+// period of the head state if it is shorter.
+func (s *Service) retentionEpochs(ctx context.Context) (types.Epoch, error) {
+	st, err := s.cfg.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve head state")
+	}
+	if st == nil || st.IsNil() {
+		return 0, errors.New("nil head state")
+	}
+	wsPeriod, err := helpers.ComputeWeakSubjectivityPeriod(st)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not compute weak subjectivity period")
+	}
+	if s.cfg.RetentionEpochs >= wsPeriod {
+		return s.cfg.RetentionEpochs, nil
+	}
+	if !s.warnedWSPeriod {
+		log.WithFields(logrus.Fields{
+			"retentionEpochs": s.cfg.RetentionEpochs,
+			"wsPeriod":        wsPeriod,
+		}).Warn("History retention is shorter than the weak subjectivity period, retaining the weak subjectivity period instead")
+		s.warnedWSPeriod = true
+	}
+	return wsPeriod, nil
+}
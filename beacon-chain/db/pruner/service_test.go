@@ -0,0 +1,80 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestService_Prune(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	wsPeriod, err := helpers.ComputeWeakSubjectivityPeriod(st)
+	require.NoError(t, err)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	old := testutil.NewBeaconBlock()
+	old.Block.Slot = slotsPerEpoch.Mul(5)
+	oldRoot, err := old.Block.HashTreeRoot()
+	require.NoError(t, err)
+	recent := testutil.NewBeaconBlock()
+	recent.Block.Slot = slotsPerEpoch.Mul(15)
+	recentRoot, err := recent.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(old)))
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(recent)))
+
+	s := NewService(ctx, &Config{
+		DB:              beaconDB,
+		HeadFetcher:     &mock.ChainService{State: st},
+		RetentionEpochs: wsPeriod + 100,
+	})
+
+	// Nothing to prune while the chain is shorter than the retention window.
+	require.NoError(t, s.prune(ctx, wsPeriod+50))
+	assert.Equal(t, true, beaconDB.HasBlock(ctx, oldRoot))
+
+	require.NoError(t, s.prune(ctx, wsPeriod+110))
+	assert.Equal(t, false, beaconDB.HasBlock(ctx, oldRoot))
+	assert.Equal(t, true, beaconDB.HasBlock(ctx, recentRoot))
+	earliest, err := beaconDB.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, slotsPerEpoch.Mul(10), earliest)
+	require.LogsContain(t, hook, "Pruned chain history")
+	require.LogsDoNotContain(t, hook, "History retention is shorter than the weak subjectivity period")
+}
+
+func TestService_Prune_RetainsWeakSubjectivityPeriod(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	wsPeriod, err := helpers.ComputeWeakSubjectivityPeriod(st)
+	require.NoError(t, err)
+
+	s := NewService(ctx, &Config{
+		DB:              beaconDB,
+		HeadFetcher:     &mock.ChainService{State: st},
+		RetentionEpochs: 1,
+	})
+	require.NoError(t, s.prune(ctx, wsPeriod+2))
+	earliest, err := beaconDB.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch.Mul(2), earliest)
+	require.LogsContain(t, hook, "History retention is shorter than the weak subjectivity period")
+
+	retention, err := s.retentionEpochs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, wsPeriod, retention)
+}
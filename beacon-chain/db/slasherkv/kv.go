@@ -20,6 +20,8 @@ var _ iface.SlasherDatabase = (*Store)(nil)
 const (
 	// DatabaseFileName is the name of the beacon node database.
 	DatabaseFileName = "slasher.db"
+	// SlasherDbDirName is the name of the directory containing the slasher database of the beacon node.
+	SlasherDbDirName = "slasherkv"
 	boltAllocSize    = 8 * 1024 * 1024
 )
 
@@ -2,6 +2,7 @@ package forkchoice
 
 import (
 	"context"
+	"time"
 
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
@@ -12,6 +13,7 @@ type ForkChoicer interface {
 	HeadRetriever        // to compute head.
 	BlockProcessor       // to track new block for fork choice.
 	AttestationProcessor // to track new attestation for fork choice.
+	ProposerBooster      // to boost the weight of timely blocks.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
 }
@@ -29,6 +31,13 @@ type BlockProcessor interface {
 // AttestationProcessor processes the attestation that's used for accounting fork choice.
 type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, types.Epoch)
+	InsertSlashedIndex(context.Context, types.ValidatorIndex)
+}
+
+// ProposerBooster boosts the weight of a block received in a timely manner, until the next slot.
+type ProposerBooster interface {
+	BoostProposerRoot(ctx context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time) error
+	ResetBoostedProposerRoot(ctx context.Context) error
 }
 
 // Pruner prunes the fork choice upon new finalization. This is used to keep fork choice sane.
@@ -45,4 +54,5 @@ type Getter interface {
 	HasParent(root [32]byte) bool
 	AncestorRoot(ctx context.Context, root [32]byte, slot types.Slot) ([]byte, error)
 	IsCanonical(root [32]byte) bool
+	VotesCount() uint64
 }
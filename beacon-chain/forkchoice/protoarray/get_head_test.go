@@ -0,0 +1,189 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// The tests of this file port the scenarios of the fork choice get_head tests of the consensus
+// specs (tests/core/pyspec/eth2spec/test/phase0/fork_choice/test_get_head.py). Blocks are given
+// increasing roots where the scenario relies on the tie breaking by highest root, and the
+// validators of a slot committee are the indices [10*n, 10*n+10) of 320 validators.
+
+func committee(n uint64) []uint64 {
+	indices := make([]uint64, 10)
+	for i := range indices {
+		indices[i] = 10*n + uint64(i)
+	}
+	return indices
+}
+
+func TestGetHead_Genesis(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	r, err := f.Head(ctx, 0, zeroHash, equalBalances(320, 10), 0)
+	require.NoError(t, err)
+	assert.Equal(t, zeroHash, r)
+}
+
+func TestGetHead_ChainNoAttestations(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), [32]byte{}, 0, 0))
+	r, err := f.Head(ctx, 0, zeroHash, equalBalances(320, 10), 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), r)
+}
+
+func TestGetHead_SplitTieBreakerNoAttestations(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	low, high := [32]byte{'a'}, [32]byte{'b'}
+	require.NoError(t, f.ProcessBlock(ctx, 1, high, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 1, low, zeroHash, [32]byte{}, 0, 0))
+	r, err := f.Head(ctx, 0, zeroHash, equalBalances(320, 10), 0)
+	require.NoError(t, err)
+	assert.Equal(t, high, r, "Tie is not broken in favor of the highest root")
+}
+
+func TestGetHead_ShorterChainButHeavierWeight(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	// A long chain of 10 blocks, competing with a single block.
+	parent := zeroHash
+	for i := uint64(1); i <= 10; i++ {
+		require.NoError(t, f.ProcessBlock(ctx, types.Slot(i), indexToHash(i), parent, [32]byte{}, 0, 0))
+		parent = indexToHash(i)
+	}
+	short := indexToHash(100)
+	require.NoError(t, f.ProcessBlock(ctx, 1, short, zeroHash, [32]byte{}, 0, 0))
+
+	f.ProcessAttestation(ctx, committee(1), short, 0)
+	r, err := f.Head(ctx, 0, zeroHash, equalBalances(320, 10), 0)
+	require.NoError(t, err)
+	assert.Equal(t, short, r)
+}
+
+func TestGetHead_FilteredBlockTree(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	// A chain justifying epoch 1 at block 5.
+	parent := zeroHash
+	for i := uint64(1); i <= 6; i++ {
+		var justified types.Epoch
+		if i >= 5 {
+			justified = 1
+		}
+		require.NoError(t, f.ProcessBlock(ctx, types.Slot(i), indexToHash(i), parent, [32]byte{}, justified, 0))
+		parent = indexToHash(i)
+	}
+	expectedHead := indexToHash(6)
+
+	// A rogue block on top of the head, whose state does not justify the checkpoint of the store,
+	// attested by an epoch's worth of committees.
+	rogue := indexToHash(100)
+	require.NoError(t, f.ProcessBlock(ctx, 7, rogue, expectedHead, [32]byte{0xa4}, 0, 0))
+	for i := uint64(0); i < 32; i++ {
+		f.ProcessAttestation(ctx, committee(i), rogue, 1)
+	}
+
+	r, err := f.Head(ctx, 1, indexToHash(5), equalBalances(320, 10), 0)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHead, r, "Head is not filtered by the justified checkpoint")
+}
+
+func TestGetHead_ProposerBoostCorrectHead(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	balances := equalBalances(320, 10)
+	f := setup(0, 0)
+
+	// Block 2 is the head, and block 1 is the head only on timely arrival and only in its slot.
+	block1, block2 := [32]byte{'a'}, [32]byte{'b'}
+
+	// Block 2 is processed late, at the slot of block 1.
+	require.NoError(t, f.ProcessBlock(ctx, 3, block2, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.BoostProposerRoot(ctx, 3, block2, genesisTimeForSlot(4)))
+	assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	r, err := f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r)
+
+	// Block 1 arrives timely, the head temporarily changes to it.
+	require.NoError(t, f.ProcessBlock(ctx, 4, block1, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.BoostProposerRoot(ctx, 4, block1, genesisTimeForSlot(4)))
+	assert.Equal(t, block1, f.store.proposerBoostRoot)
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block1, r, "Timely block is not the head")
+
+	// After the slot of block 1, the head reverts to block 2.
+	require.NoError(t, f.ResetBoostedProposerRoot(ctx))
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r, "Head did not revert once the boost was reset")
+	assert.Equal(t, uint64(0), nodeWeight(t, f, block1))
+}
+
+func TestGetHead_DiscardEquivocations(t *testing.T) {
+	ctx := context.Background()
+	zeroHash := params.BeaconConfig().ZeroHash
+	balances := equalBalances(320, 10)
+	f := setup(0, 0)
+
+	// Block 1 is the head before the equivocations are discarded, block 2 after.
+	block1, block2 := [32]byte{'a'}, [32]byte{'b'}
+
+	// Both blocks are processed late, at slot 7.
+	require.NoError(t, f.ProcessBlock(ctx, 3, block2, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.BoostProposerRoot(ctx, 3, block2, genesisTimeForSlot(7)))
+	assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	r, err := f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r)
+
+	require.NoError(t, f.ProcessBlock(ctx, 4, block1, zeroHash, [32]byte{}, 0, 0))
+	require.NoError(t, f.BoostProposerRoot(ctx, 4, block1, genesisTimeForSlot(7)))
+	assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r)
+
+	// The committee of slot 5 attests to block 1, the head changes to block 1.
+	f.ProcessAttestation(ctx, committee(5), block1, 0)
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block1, r)
+
+	// The committee is slashed for attesting to a conflicting block at the same slot, the head
+	// reverts to block 2.
+	for _, index := range committee(5) {
+		f.InsertSlashedIndex(ctx, types.ValidatorIndex(index))
+	}
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r, "Equivocating votes are not discarded")
+	assert.Equal(t, uint64(0), nodeWeight(t, f, block1))
+
+	// Later votes of the slashed validators are ignored.
+	f.ProcessAttestation(ctx, committee(5), block1, 1)
+	r, err = f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, block2, r)
+}
@@ -3,17 +3,20 @@ package protoarray
 import (
 	"context"
 
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
 
 // This computes validator balance delta from validator votes.
 // It returns a list of deltas that represents the difference between old balances and new balances.
+// The votes of equivocating validators are removed from the deltas, and cleared.
 func computeDeltas(
 	ctx context.Context,
 	blockIndices map[[32]byte]uint64,
 	votes []Vote,
 	oldBalances, newBalances []uint64,
+	equivocatingIndices map[types.ValidatorIndex]bool,
 ) ([]int, []Vote, error) {
 	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.computeDeltas")
 	defer span.End()
@@ -38,6 +41,20 @@ func computeDeltas(
 			newBalance = newBalances[validatorIndex]
 		}
 
+		// Remove the vote of an equivocating validator from the weight of its current root. The vote
+		// is then cleared, so it is skipped from now on.
+		if equivocatingIndices[types.ValidatorIndex(validatorIndex)] {
+			currentDeltaIndex, ok := blockIndices[vote.currentRoot]
+			if ok {
+				if int(currentDeltaIndex) >= len(deltas) {
+					return nil, nil, errInvalidNodeDelta
+				}
+				deltas[currentDeltaIndex] -= int(oldBalance)
+			}
+			votes[validatorIndex] = Vote{currentRoot: params.BeaconConfig().ZeroHash, nextRoot: params.BeaconConfig().ZeroHash}
+			continue
+		}
+
 		// Perform delta only if the validator's balance or vote has changed.
 		if vote.currentRoot != vote.nextRoot || oldBalance != newBalance {
 			// Ignore the vote if it's not known in `blockIndices`,
@@ -70,6 +87,33 @@ func computeDeltas(
 	return deltas, votes, nil
 }
 
+// This computes the score added to the weight of a timely block, as a percentage of the weight of
+// a committee. Validators with a zero balance are considered inactive.
+//
+// Spec pseudocode definition:
+//  avg_balance = get_total_active_balance(state) // num_validators
+//  committee_size = num_validators // SLOTS_PER_EPOCH
+//  committee_weight = committee_size * avg_balance
+//  proposer_score = (committee_weight * PROPOSER_SCORE_BOOST) // 100
+func computeProposerBoostScore(justifiedStateBalances []uint64) uint64 {
+	totalActiveBalance := uint64(0)
+	numActive := uint64(0)
+	for _, balance := range justifiedStateBalances {
+		if balance == 0 {
+			continue
+		}
+		totalActiveBalance += balance
+		numActive++
+	}
+	if numActive == 0 {
+		return 0
+	}
+	avgBalance := totalActiveBalance / numActive
+	committeeSize := numActive / uint64(params.BeaconConfig().SlotsPerEpoch)
+	committeeWeight := committeeSize * avgBalance
+	return (committeeWeight * params.BeaconConfig().ProposerScoreBoost) / 100
+}
+
 // This return a copy of the proto array node object.
 func copyNode(node *Node) *Node {
 	if node == nil {
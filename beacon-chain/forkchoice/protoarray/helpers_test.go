@@ -25,7 +25,7 @@ func TestComputeDelta_ZeroHash(t *testing.T) {
 		newBalances = append(newBalances, 0)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -52,7 +52,7 @@ func TestComputeDelta_AllVoteTheSame(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -84,7 +84,7 @@ func TestComputeDelta_DifferentVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -113,7 +113,7 @@ func TestComputeDelta_MovingVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, int(validatorCount), len(delta))
 
@@ -145,7 +145,7 @@ func TestComputeDelta_MoveOutOfTree(t *testing.T) {
 		Vote{indexToHash(1), params.BeaconConfig().ZeroHash, 0},
 		Vote{indexToHash(1), [32]byte{'A'}, 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(delta))
 	assert.Equal(t, 0-2*int(balance), delta[0])
@@ -173,7 +173,7 @@ func TestComputeDelta_ChangingBalances(t *testing.T) {
 		newBalances = append(newBalances, newBalance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, 16, len(delta))
 
@@ -206,7 +206,7 @@ func TestComputeDelta_ValidatorAppear(t *testing.T) {
 		Vote{indexToHash(1), indexToHash(2), 0},
 		Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(delta))
 	assert.Equal(t, 0-int(balance), delta[0])
@@ -231,7 +231,7 @@ func TestComputeDelta_ValidatorDisappears(t *testing.T) {
 		Vote{indexToHash(1), indexToHash(2), 0},
 		Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	require.NoError(t, err)
 	assert.Equal(t, 2, len(delta))
 	assert.Equal(t, 0-2*int(balance), delta[0])
@@ -0,0 +1,232 @@
+package protoarray
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+)
+
+// encodingVersion is the version of the binary encoding of the fork choice store. It is bumped
+// whenever the layout changes, so that stores encoded by a previous version are discarded.
+const encodingVersion = byte(1)
+
+const (
+	// nodeEncodingSize is the size of the slot, parent, epochs, weight and best links of a node,
+	// and of its root and graffiti.
+	nodeEncodingSize = 7*8 + 2*32
+	voteEncodingSize = 2*32 + 8
+)
+
+var errInvalidEncoding = errors.New("invalid fork choice store encoding")
+
+// MarshalBinary encodes the fork choice store, including its block nodes, the latest votes and
+// justified balances of validators, equivocating validators and the proposer boost.
+func (f *ForkChoice) MarshalBinary() ([]byte, error) {
+	f.votesLock.RLock()
+	defer f.votesLock.RUnlock()
+	f.store.nodesLock.RLock()
+	defer f.store.nodesLock.RUnlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+
+	s := f.store
+	enc := []byte{encodingVersion}
+	enc = appendUint64(enc, s.pruneThreshold)
+	enc = appendUint64(enc, uint64(s.justifiedEpoch))
+	enc = appendUint64(enc, uint64(s.finalizedEpoch))
+	enc = append(enc, s.finalizedRoot[:]...)
+	enc = append(enc, s.proposerBoostRoot[:]...)
+	enc = append(enc, s.previousProposerBoostRoot[:]...)
+	enc = appendUint64(enc, s.previousProposerBoostScore)
+
+	enc = appendUint64(enc, uint64(len(s.nodes)))
+	for _, n := range s.nodes {
+		enc = appendUint64(enc, uint64(n.slot))
+		enc = append(enc, n.root[:]...)
+		enc = appendUint64(enc, n.parent)
+		enc = appendUint64(enc, uint64(n.justifiedEpoch))
+		enc = appendUint64(enc, uint64(n.finalizedEpoch))
+		enc = appendUint64(enc, n.weight)
+		enc = appendUint64(enc, n.bestChild)
+		enc = appendUint64(enc, n.bestDescendant)
+		enc = append(enc, n.graffiti[:]...)
+	}
+
+	canonicalRoots := make([][32]byte, 0, len(s.canonicalNodes))
+	for r, ok := range s.canonicalNodes {
+		if ok {
+			canonicalRoots = append(canonicalRoots, r)
+		}
+	}
+	enc = appendUint64(enc, uint64(len(canonicalRoots)))
+	for _, r := range canonicalRoots {
+		enc = append(enc, r[:]...)
+	}
+
+	enc = appendUint64(enc, uint64(len(f.votes)))
+	for _, v := range f.votes {
+		enc = append(enc, v.currentRoot[:]...)
+		enc = append(enc, v.nextRoot[:]...)
+		enc = appendUint64(enc, uint64(v.nextEpoch))
+	}
+
+	enc = appendUint64(enc, uint64(len(f.balances)))
+	for _, b := range f.balances {
+		enc = appendUint64(enc, b)
+	}
+
+	equivocating := make([]types.ValidatorIndex, 0, len(f.equivocatingIndices))
+	for i, ok := range f.equivocatingIndices {
+		if ok {
+			equivocating = append(equivocating, i)
+		}
+	}
+	sort.Slice(equivocating, func(i, j int) bool {
+		return equivocating[i] < equivocating[j]
+	})
+	enc = appendUint64(enc, uint64(len(equivocating)))
+	for _, i := range equivocating {
+		enc = appendUint64(enc, uint64(i))
+	}
+	return enc, nil
+}
+
+// UnmarshalBinary decodes a fork choice store encoded with MarshalBinary into the receiver,
+// replacing all of its content. The links between the decoded nodes are checked to be in range.
+func (f *ForkChoice) UnmarshalBinary(enc []byte) error {
+	if len(enc) == 0 || enc[0] != encodingVersion {
+		return errors.Wrap(errInvalidEncoding, "unknown encoding version")
+	}
+	d := &decoder{buf: enc[1:]}
+
+	s := &Store{
+		pruneThreshold: d.uint64(),
+		justifiedEpoch: types.Epoch(d.uint64()),
+		finalizedEpoch: types.Epoch(d.uint64()),
+		finalizedRoot:  d.root(),
+		canonicalNodes: make(map[[32]byte]bool),
+	}
+	s.proposerBoostRoot = d.root()
+	s.previousProposerBoostRoot = d.root()
+	s.previousProposerBoostScore = d.uint64()
+
+	numNodes := d.length(nodeEncodingSize)
+	s.nodes = make([]*Node, 0, numNodes)
+	s.nodesIndices = make(map[[32]byte]uint64, numNodes)
+	for i := uint64(0); i < numNodes; i++ {
+		n := &Node{
+			slot:           types.Slot(d.uint64()),
+			root:           d.root(),
+			parent:         d.uint64(),
+			justifiedEpoch: types.Epoch(d.uint64()),
+			finalizedEpoch: types.Epoch(d.uint64()),
+			weight:         d.uint64(),
+			bestChild:      d.uint64(),
+			bestDescendant: d.uint64(),
+			graffiti:       d.root(),
+		}
+		s.nodesIndices[n.root] = i
+		s.nodes = append(s.nodes, n)
+	}
+
+	numCanonical := d.length(32)
+	for i := uint64(0); i < numCanonical; i++ {
+		s.canonicalNodes[d.root()] = true
+	}
+
+	numVotes := d.length(voteEncodingSize)
+	votes := make([]Vote, numVotes)
+	for i := range votes {
+		votes[i] = Vote{currentRoot: d.root(), nextRoot: d.root(), nextEpoch: types.Epoch(d.uint64())}
+	}
+
+	numBalances := d.length(8)
+	balances := make([]uint64, numBalances)
+	for i := range balances {
+		balances[i] = d.uint64()
+	}
+
+	numEquivocating := d.length(8)
+	equivocating := make(map[types.ValidatorIndex]bool, numEquivocating)
+	for i := uint64(0); i < numEquivocating; i++ {
+		equivocating[types.ValidatorIndex(d.uint64())] = true
+	}
+
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.buf) != 0 {
+		return errors.Wrap(errInvalidEncoding, "trailing bytes")
+	}
+	if uint64(len(s.nodesIndices)) != numNodes {
+		return errors.Wrap(errInvalidEncoding, "duplicated node roots")
+	}
+	for _, n := range s.nodes {
+		for _, i := range []uint64{n.parent, n.bestChild, n.bestDescendant} {
+			if i != NonExistentNode && i >= numNodes {
+				return errors.Wrapf(errInvalidEncoding, "node %#x links to index %d out of range", n.root, i)
+			}
+		}
+	}
+
+	f.votesLock.Lock()
+	defer f.votesLock.Unlock()
+	f.store = s
+	f.votes = votes
+	f.balances = balances
+	f.equivocatingIndices = equivocating
+	return nil
+}
+
+func appendUint64(enc []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(enc, b[:]...)
+}
+
+// decoder reads the fields of an encoded fork choice store. After the first read past the end
+// of the buffer, err is set and all subsequent reads return zero values.
+type decoder struct {
+	buf []byte
+	err error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.buf) < n {
+		d.err = errors.Wrap(errInvalidEncoding, "unexpected end of input")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (d *decoder) root() [32]byte {
+	var r [32]byte
+	copy(r[:], d.next(32))
+	return r
+}
+
+// length reads the number of items of a list, each encoded with the given size. It fails when the
+// remaining input is too short to hold them, so that a corrupted length does not cause a large allocation.
+func (d *decoder) length(itemSize uint64) uint64 {
+	l := d.uint64()
+	if d.err == nil && l > uint64(len(d.buf))/itemSize {
+		d.err = errors.Wrap(errInvalidEncoding, "list length exceeds input")
+		return 0
+	}
+	return l
+}
@@ -0,0 +1,136 @@
+package protoarray
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestForkChoice_MarshalUnmarshalBinary(t *testing.T) {
+	ctx := context.Background()
+	balances := []uint64{10, 20, 15}
+	zeroHash := params.BeaconConfig().ZeroHash
+	f := setup(0, 0)
+
+	// Define the following tree:
+	//            0
+	//           / \
+	//          1   2
+	//          |
+	//          3
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), zeroHash, [32]byte{'a'}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(2), zeroHash, [32]byte{'b'}, 0, 0))
+	require.NoError(t, f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), [32]byte{'c'}, 1, 0))
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 1)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 1)
+	f.InsertSlashedIndex(ctx, 5)
+	head, err := f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(3), head)
+
+	enc, err := f.MarshalBinary()
+	require.NoError(t, err)
+	restored := &ForkChoice{}
+	require.NoError(t, restored.UnmarshalBinary(enc))
+
+	assert.DeepEqual(t, f.Nodes(), restored.Nodes())
+	assert.DeepEqual(t, f.store.nodesIndices, restored.store.nodesIndices)
+	assert.DeepEqual(t, f.store.canonicalNodes, restored.store.canonicalNodes)
+	assert.DeepEqual(t, f.votes, restored.votes)
+	assert.DeepEqual(t, f.balances, restored.balances)
+	assert.DeepEqual(t, f.equivocatingIndices, restored.equivocatingIndices)
+	assert.Equal(t, f.store.pruneThreshold, restored.store.pruneThreshold)
+	assert.Equal(t, f.store.justifiedEpoch, restored.store.justifiedEpoch)
+	assert.Equal(t, f.store.finalizedEpoch, restored.store.finalizedEpoch)
+	assert.Equal(t, true, restored.IsCanonical(indexToHash(3)))
+
+	// Head selection resumes from the restored votes and balances.
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 2)
+	restored.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 2)
+	want, err := f.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	got, err := restored.Head(ctx, 0, zeroHash, balances, 0)
+	require.NoError(t, err)
+	assert.Equal(t, indexToHash(2), want)
+	assert.Equal(t, want, got)
+	assert.DeepEqual(t, f.Nodes(), restored.Nodes())
+}
+
+func TestForkChoice_MarshalUnmarshalBinary_FewNodes(t *testing.T) {
+	ctx := context.Background()
+	for numNodes := uint64(1); numNodes <= 3; numNodes++ {
+		t.Run(fmt.Sprintf("%d nodes", numNodes), func(t *testing.T) {
+			f := setup(0, 0)
+			parent := params.BeaconConfig().ZeroHash
+			for i := uint64(1); i < numNodes; i++ {
+				require.NoError(t, f.ProcessBlock(ctx, types.Slot(i), indexToHash(i), parent, [32]byte{}, 0, 0))
+				parent = indexToHash(i)
+			}
+			require.Equal(t, numNodes, uint64(len(f.Nodes())))
+
+			enc, err := f.MarshalBinary()
+			require.NoError(t, err)
+			restored := &ForkChoice{}
+			require.NoError(t, restored.UnmarshalBinary(enc))
+			assert.DeepEqual(t, f.Nodes(), restored.Nodes())
+			assert.DeepEqual(t, f.store.nodesIndices, restored.store.nodesIndices)
+		})
+	}
+}
+
+func TestForkChoice_UnmarshalBinary_Invalid(t *testing.T) {
+	ctx := context.Background()
+	f := setup(0, 0)
+	require.NoError(t, f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0))
+	enc, err := f.MarshalBinary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		enc     []byte
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			enc:     []byte{},
+			wantErr: "unknown encoding version",
+		},
+		{
+			name:    "unknown version",
+			enc:     append([]byte{encodingVersion + 1}, enc[1:]...),
+			wantErr: "unknown encoding version",
+		},
+		{
+			name:    "truncated",
+			enc:     enc[:len(enc)-1],
+			wantErr: "unexpected end of input",
+		},
+		{
+			name:    "trailing bytes",
+			enc:     append(append([]byte{}, enc...), 0),
+			wantErr: "trailing bytes",
+		},
+		{
+			name: "node link out of range",
+			enc: func() []byte {
+				c := append([]byte{}, enc...)
+				// The parent index of the first node follows the header, the node count, the slot and the root.
+				parentOffset := 1 + 3*8 + 3*32 + 8 + 8 + 8 + 32
+				c[parentOffset] = 2
+				return c
+			}(),
+			wantErr: "out of range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&ForkChoice{}).UnmarshalBinary(tt.enc)
+			require.ErrorContains(t, tt.wantErr, err)
+		})
+	}
+}
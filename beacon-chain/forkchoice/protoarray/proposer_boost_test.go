@@ -0,0 +1,69 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// genesisTimeForSlot returns a genesis time such that the current time is at the start of the given slot.
+func genesisTimeForSlot(slot types.Slot) time.Time {
+	return time.Now().Add(-time.Duration(uint64(slot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+}
+
+func equalBalances(n int, balance uint64) []uint64 {
+	balances := make([]uint64, n)
+	for i := range balances {
+		balances[i] = balance
+	}
+	return balances
+}
+
+func nodeWeight(t *testing.T, f *ForkChoice, root [32]byte) uint64 {
+	i, ok := f.store.nodesIndices[root]
+	require.Equal(t, true, ok)
+	return f.store.nodes[i].weight
+}
+
+func TestComputeProposerBoostScore(t *testing.T) {
+	// 320 validators with a balance of 10 form committees of 10 validators, the committee weight is 100.
+	assert.Equal(t, uint64(40), computeProposerBoostScore(equalBalances(320, 10)))
+	// Inactive validators are not accounted for.
+	assert.Equal(t, uint64(40), computeProposerBoostScore(append(equalBalances(320, 10), 0, 0, 0)))
+	assert.Equal(t, uint64(0), computeProposerBoostScore(equalBalances(16, 10)))
+	assert.Equal(t, uint64(0), computeProposerBoostScore([]uint64{}))
+}
+
+func TestForkChoice_BoostProposerRoot(t *testing.T) {
+	ctx := context.Background()
+	root := indexToHash(1)
+
+	t.Run("timely block is boosted", func(t *testing.T) {
+		f := setup(0, 0)
+		require.NoError(t, f.BoostProposerRoot(ctx, 1, root, genesisTimeForSlot(1)))
+		assert.Equal(t, root, f.store.proposerBoostRoot)
+		require.NoError(t, f.ResetBoostedProposerRoot(ctx))
+		assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	})
+	t.Run("late block is not boosted", func(t *testing.T) {
+		f := setup(0, 0)
+		genesisTime := genesisTimeForSlot(1).Add(-5 * time.Second)
+		require.NoError(t, f.BoostProposerRoot(ctx, 1, root, genesisTime))
+		assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	})
+	t.Run("block from a previous slot is not boosted", func(t *testing.T) {
+		f := setup(0, 0)
+		require.NoError(t, f.BoostProposerRoot(ctx, 1, root, genesisTimeForSlot(2)))
+		assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	})
+	t.Run("block before genesis is not boosted", func(t *testing.T) {
+		f := setup(0, 0)
+		require.NoError(t, f.BoostProposerRoot(ctx, 0, root, time.Now().Add(time.Hour)))
+		assert.Equal(t, [32]byte{}, f.store.proposerBoostRoot)
+	})
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"go.opencensus.io/trace"
 )
 
@@ -33,7 +35,7 @@ func New(justifiedEpoch, finalizedEpoch types.Epoch, finalizedRoot [32]byte) *Fo
 	b := make([]uint64, 0)
 	v := make([]Vote, 0)
 
-	return &ForkChoice{store: s, balances: b, votes: v}
+	return &ForkChoice{store: s, balances: b, votes: v, equivocatingIndices: make(map[types.ValidatorIndex]bool)}
 }
 
 // Head returns the head root from fork choice store.
@@ -57,13 +59,13 @@ func (f *ForkChoice) Head(
 	// Using the write lock here because `updateCanonicalNodes` that gets called subsequently requires a write operation.
 	f.store.nodesLock.Lock()
 	defer f.store.nodesLock.Unlock()
-	deltas, newVotes, err := computeDeltas(ctx, f.store.nodesIndices, f.votes, f.balances, newBalances)
+	deltas, newVotes, err := computeDeltas(ctx, f.store.nodesIndices, f.votes, f.balances, newBalances, f.equivocatingIndices)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not compute deltas")
 	}
 	f.votes = newVotes
 
-	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, deltas); err != nil {
+	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, newBalances, deltas); err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not apply score changes")
 	}
 	f.balances = newBalances
@@ -80,6 +82,11 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	defer f.votesLock.Unlock()
 
 	for _, index := range validatorIndices {
+		// Votes of equivocating validators are no longer accounted for.
+		if f.equivocatingIndices[types.ValidatorIndex(index)] {
+			continue
+		}
+
 		// Validator indices will grow the vote cache.
 		for index >= uint64(len(f.votes)) {
 			f.votes = append(f.votes, Vote{currentRoot: params.BeaconConfig().ZeroHash, nextRoot: params.BeaconConfig().ZeroHash})
@@ -99,6 +106,76 @@ func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []
 	processedAttestationCount.Inc()
 }
 
+// InsertSlashedIndex marks a validator as equivocating, once it was slashed by an attester slashing.
+// Its latest vote is removed from the weight of the nodes on the next head computation, and its
+// subsequent attestations are ignored.
+//
+// Spec pseudocode definition:
+//  def on_attester_slashing(store: Store, attester_slashing: AttesterSlashing) -> None:
+//      ...
+//      indices = set(attestation_1.attesting_indices).intersection(attestation_2.attesting_indices)
+//      for index in indices:
+//          store.equivocating_indices.add(index)
+func (f *ForkChoice) InsertSlashedIndex(ctx context.Context, index types.ValidatorIndex) {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.InsertSlashedIndex")
+	defer span.End()
+	f.votesLock.Lock()
+	defer f.votesLock.Unlock()
+
+	f.equivocatingIndices[index] = true
+}
+
+// BoostProposerRoot sets the block root which is boosted in the weight computation of fork choice,
+// if the block is received during the first interval of its slot. The boost is applied until it is
+// reset at the start of the next slot.
+//
+// Spec pseudocode definition:
+//  # Add proposer score boost if the block is timely
+//  time_into_slot = (store.time - store.genesis_time) % SECONDS_PER_SLOT
+//  is_before_attesting_interval = time_into_slot < SECONDS_PER_SLOT // INTERVALS_PER_SLOT
+//  if get_current_slot(store) == block.slot and is_before_attesting_interval:
+//      store.proposer_boost_root = hash_tree_root(block)
+func (f *ForkChoice) BoostProposerRoot(ctx context.Context, blockSlot types.Slot, blockRoot [32]byte, genesisTime time.Time) error {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.BoostProposerRoot")
+	defer span.End()
+
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	intervalsPerSlot := params.BeaconConfig().IntervalsPerSlot
+	if secondsPerSlot == 0 || intervalsPerSlot == 0 {
+		return errors.New("invalid fork choice interval configuration")
+	}
+	sinceGenesis := time.Since(genesisTime)
+	if sinceGenesis < 0 {
+		return nil
+	}
+	timeIntoSlot := uint64(sinceGenesis.Seconds()) % secondsPerSlot
+	isBeforeAttestingInterval := timeIntoSlot < secondsPerSlot/intervalsPerSlot
+	if slotutil.SlotsSinceGenesis(genesisTime) != blockSlot || !isBeforeAttestingInterval {
+		return nil
+	}
+
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	f.store.proposerBoostRoot = blockRoot
+	return nil
+}
+
+// ResetBoostedProposerRoot clears the boosted block root, at the start of every slot.
+//
+// Spec pseudocode definition:
+//  # Reset store.proposer_boost_root if this is a new slot
+//  if current_slot > previous_slot:
+//      store.proposer_boost_root = Root()
+func (f *ForkChoice) ResetBoostedProposerRoot(ctx context.Context) error {
+	_, span := trace.StartSpan(ctx, "protoArrayForkChoice.ResetBoostedProposerRoot")
+	defer span.End()
+
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	f.store.proposerBoostRoot = [32]byte{}
+	return nil
+}
+
 // ProcessBlock processes a new block by inserting it to the fork choice store.
 func (f *ForkChoice) ProcessBlock(
 	ctx context.Context,
@@ -180,6 +257,14 @@ func (f *ForkChoice) IsCanonical(root [32]byte) bool {
 	return f.store.canonicalNodes[root]
 }
 
+// VotesCount returns the number of validator votes tracked by the fork choice store.
+func (f *ForkChoice) VotesCount() uint64 {
+	f.votesLock.RLock()
+	defer f.votesLock.RUnlock()
+
+	return uint64(len(f.votes))
+}
+
 // AncestorRoot returns the ancestor root of input block root at a given slot.
 func (f *ForkChoice) AncestorRoot(ctx context.Context, root [32]byte, slot types.Slot) ([]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "protoArray.AncestorRoot")
@@ -233,6 +318,18 @@ func (s *Store) Nodes() []*Node {
 	return s.nodes
 }
 
+// CopyNodes returns a copy of the nodes of fork choice store, which can be read while the store
+// keeps being updated.
+func (s *Store) CopyNodes() []*Node {
+	s.nodesLock.RLock()
+	defer s.nodesLock.RUnlock()
+	nodes := make([]*Node, len(s.nodes))
+	for i, n := range s.nodes {
+		nodes[i] = copyNode(n)
+	}
+	return nodes
+}
+
 // NodesIndices of fork choice store.
 func (s *Store) NodesIndices() map[[32]byte]uint64 {
 	s.nodesLock.RLock()
@@ -240,6 +337,14 @@ func (s *Store) NodesIndices() map[[32]byte]uint64 {
 	return s.nodesIndices
 }
 
+// ViableForHead returns true if the node's justified and finalized epochs match the ones of the
+// fork choice store, which makes it a candidate for head.
+func (s *Store) ViableForHead(node *Node) bool {
+	s.nodesLock.RLock()
+	defer s.nodesLock.RUnlock()
+	return s.viableForHead(node)
+}
+
 // head starts from justified root and then follows the best descendant links
 // to find the best block for head.
 func (s *Store) head(ctx context.Context, justifiedRoot [32]byte) ([32]byte, error) {
@@ -378,7 +483,12 @@ func (s *Store) insert(ctx context.Context,
 // and its best child. For each node, it updates the weight with input delta and
 // back propagate the nodes delta to its parents delta. After scoring changes,
 // the best child is then updated along with best descendant.
-func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch, finalizedEpoch types.Epoch, delta []int) error {
+// The proposer boost score applied in the previous call is removed from the previously boosted
+// node, and the score of the current boost, derived from the new balances, is added to the
+// currently boosted node.
+func (s *Store) applyWeightChanges(
+	ctx context.Context, justifiedEpoch, finalizedEpoch types.Epoch, newBalances []uint64, delta []int,
+) error {
 	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.applyWeightChanges")
 	defer span.End()
 
@@ -393,6 +503,13 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch, finalize
 		s.finalizedEpoch = finalizedEpoch
 	}
 
+	s.proposerBoostLock.Lock()
+	defer s.proposerBoostLock.Unlock()
+	var proposerScore uint64
+	if s.proposerBoostRoot != params.BeaconConfig().ZeroHash {
+		proposerScore = computeProposerBoostScore(newBalances)
+	}
+
 	// Iterate backwards through all index to node in store.
 	for i := len(s.nodes) - 1; i >= 0; i-- {
 		n := s.nodes[i]
@@ -405,6 +522,14 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch, finalize
 
 		nodeDelta := delta[i]
 
+		// Swap the previous proposer boost for the current one.
+		if s.previousProposerBoostRoot != params.BeaconConfig().ZeroHash && s.previousProposerBoostRoot == n.root {
+			nodeDelta -= int(s.previousProposerBoostScore)
+		}
+		if s.proposerBoostRoot != params.BeaconConfig().ZeroHash && s.proposerBoostRoot == n.root {
+			nodeDelta += int(proposerScore)
+		}
+
 		if nodeDelta < 0 {
 			// A node's weight can not be negative but the delta can be negative.
 			if int(n.weight)+nodeDelta < 0 {
@@ -448,6 +573,9 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch, finalize
 		}
 	}
 
+	s.previousProposerBoostRoot = s.proposerBoostRoot
+	s.previousProposerBoostScore = proposerScore
+
 	return nil
 }
 
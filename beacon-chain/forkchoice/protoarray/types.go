@@ -8,10 +8,11 @@ import (
 
 // ForkChoice defines the overall fork choice store which includes all block nodes, validator's latest votes and balances.
 type ForkChoice struct {
-	store     *Store
-	votes     []Vote // tracks individual validator's last vote.
-	votesLock sync.RWMutex
-	balances  []uint64 // tracks individual validator's last justified balances.
+	store               *Store
+	votes               []Vote // tracks individual validator's last vote.
+	votesLock           sync.RWMutex
+	balances            []uint64                      // tracks individual validator's last justified balances.
+	equivocatingIndices map[types.ValidatorIndex]bool // tracks validators slashed for equivocating, whose votes are discarded.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
@@ -24,6 +25,11 @@ type Store struct {
 	nodesIndices   map[[32]byte]uint64 // the root of block node and the nodes index in the list.
 	canonicalNodes map[[32]byte]bool   // the canonical block nodes.
 	nodesLock      sync.RWMutex
+
+	proposerBoostRoot          [32]byte // latest block root boosted for being received in a timely manner.
+	previousProposerBoostRoot  [32]byte // block root boosted in the previous weight update.
+	previousProposerBoostScore uint64   // score given to the block root boosted in the previous weight update.
+	proposerBoostLock          sync.Mutex
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
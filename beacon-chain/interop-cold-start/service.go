@@ -160,6 +160,11 @@ func (s *Service) NonFinalizedDeposits(_ context.Context, _ *big.Int) []*ethpb.D
 	return []*ethpb.Deposit{}
 }
 
+// DepositSnapshot mocks out the deposit cache functionality for interop.
+func (s *Service) DepositSnapshot(_ context.Context) *ethpb.DepositSnapshot {
+	return nil
+}
+
 func (s *Service) saveGenesisState(ctx context.Context, genesisState state.BeaconState) error {
 	if err := s.cfg.BeaconDB.SaveGenesisData(ctx, genesisState); err != nil {
 		return err
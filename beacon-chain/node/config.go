@@ -1,6 +1,8 @@
 package node
 
 import (
+	"fmt"
+
 	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -52,6 +54,19 @@ func configureSlotsPerArchivedPoint(cliCtx *cli.Context) {
 	}
 }
 
+func configureSlotsPerStateSnapshot(cliCtx *cli.Context) error {
+	if cliCtx.IsSet(flags.SlotsPerStateSnapshot.Name) {
+		c := params.BeaconConfig()
+		c.SlotsPerStateSnapshot = types.Slot(cliCtx.Int(flags.SlotsPerStateSnapshot.Name))
+		if c.SlotsPerStateSnapshot%c.SlotsPerArchivedPoint != 0 {
+			return fmt.Errorf("--%s=%d is not a multiple of the %d slots per archived point",
+				flags.SlotsPerStateSnapshot.Name, c.SlotsPerStateSnapshot, c.SlotsPerArchivedPoint)
+		}
+		params.OverrideBeaconConfig(c)
+	}
+	return nil
+}
+
 func configureEth1Config(cliCtx *cli.Context) {
 	if cliCtx.IsSet(flags.ChainID.Name) {
 		c := params.BeaconConfig()
@@ -50,6 +50,22 @@ func TestConfigureSlotsPerArchivedPoint(t *testing.T) {
 	assert.Equal(t, types.Slot(100), params.BeaconConfig().SlotsPerArchivedPoint)
 }
 
+func TestConfigureSlotsPerStateSnapshot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.Int(flags.SlotsPerStateSnapshot.Name, 0, "")
+	require.NoError(t, set.Set(flags.SlotsPerStateSnapshot.Name, strconv.Itoa(4096)))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, configureSlotsPerStateSnapshot(cliCtx))
+	assert.Equal(t, types.Slot(4096), params.BeaconConfig().SlotsPerStateSnapshot)
+
+	require.NoError(t, set.Set(flags.SlotsPerStateSnapshot.Name, strconv.Itoa(100)))
+	assert.ErrorContains(t, "is not a multiple", configureSlotsPerStateSnapshot(cliCtx))
+}
+
 func TestConfigureProofOfWork(t *testing.T) {
 	params.SetupTestConfigCleanup(t)
 
@@ -16,11 +16,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/pruner"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	gateway2 "github.com/prysmaticlabs/prysm/beacon-chain/gateway"
@@ -34,10 +37,13 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	regularsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/checkpoint"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/backuputil"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -49,6 +55,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/prereq"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -67,6 +74,7 @@ type BeaconNode struct {
 	lock              sync.RWMutex
 	stop              chan struct{} // Channel to wait for termination notifications.
 	db                db.Database
+	slasherDB         db.SlasherDatabase
 	attestationPool   attestations.Pool
 	exitPool          voluntaryexits.PoolManager
 	slashingsPool     slashings.PoolManager
@@ -93,6 +101,9 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 	configureChainConfig(cliCtx)
 	configureHistoricalSlasher(cliCtx)
 	configureSlotsPerArchivedPoint(cliCtx)
+	if err := configureSlotsPerStateSnapshot(cliCtx); err != nil {
+		return nil, err
+	}
 	configureEth1Config(cliCtx)
 	configureNetwork(cliCtx)
 	configureInteropConfig(cliCtx)
@@ -126,6 +137,12 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if cliCtx.Bool(flags.SlasherFlag.Name) {
+		if err := beacon.startSlasherDB(cliCtx); err != nil {
+			return nil, err
+		}
+	}
+
 	beacon.startStateGen()
 
 	if err := beacon.registerP2P(cliCtx); err != nil {
@@ -150,14 +167,30 @@ func New(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if cliCtx.Uint64(flags.HistoryRetention.Name) > 0 {
+		if err := beacon.registerPrunerService(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := beacon.registerInitialSyncService(); err != nil {
 		return nil, err
 	}
 
+	if err := beacon.registerBackfillService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerSyncService(); err != nil {
 		return nil, err
 	}
 
+	if cliCtx.Bool(flags.SlasherFlag.Name) {
+		if err := beacon.registerSlasherService(); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
 	}
@@ -243,6 +276,11 @@ func (b *BeaconNode) Close() {
 	if err := b.db.Close(); err != nil {
 		log.Errorf("Failed to close database: %v", err)
 	}
+	if b.slasherDB != nil {
+		if err := b.slasherDB.Close(); err != nil {
+			log.Errorf("Failed to close slasher database: %v", err)
+		}
+	}
 	b.collector.unregister()
 	b.cancel()
 	close(b.stop)
@@ -330,6 +368,10 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 		return err
 	}
 
+	if err := b.startFromCheckpoint(cliCtx); err != nil {
+		return errors.Wrap(err, "could not start from checkpoint")
+	}
+
 	knownContract, err := b.db.DepositContractAddress(b.ctx)
 	if err != nil {
 		return err
@@ -351,6 +393,101 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	return nil
 }
 
+// startFromCheckpoint saves the finalized state and block given through the checkpoint sync flags
+// as the origin of an empty database, so the node syncs from that point instead of genesis.
+func (b *BeaconNode) startFromCheckpoint(cliCtx *cli.Context) error {
+	var initializer checkpoint.Initializer
+	switch {
+	case cliCtx.IsSet(flags.CheckpointSyncURL.Name):
+		wsCheckpt, err := helpers.ParseWeakSubjectivityInputString(cliCtx.String(flags.WeakSubjectivityCheckpt.Name))
+		if err != nil {
+			return err
+		}
+		initializer, err = checkpoint.NewAPIInitializer(cliCtx.String(flags.CheckpointSyncURL.Name), wsCheckpt)
+		if err != nil {
+			return err
+		}
+	case cliCtx.IsSet(flags.CheckpointStatePath.Name) || cliCtx.IsSet(flags.CheckpointBlockPath.Name):
+		if !cliCtx.IsSet(flags.CheckpointStatePath.Name) || !cliCtx.IsSet(flags.CheckpointBlockPath.Name) {
+			return fmt.Errorf("--%s and --%s must be used together",
+				flags.CheckpointStatePath.Name, flags.CheckpointBlockPath.Name)
+		}
+		var err error
+		initializer, err = checkpoint.NewFileInitializer(
+			cliCtx.String(flags.CheckpointStatePath.Name),
+			cliCtx.String(flags.CheckpointBlockPath.Name),
+		)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	if _, err := b.db.OriginBlockRoot(b.ctx); err == nil {
+		log.Info("Database was already initialized from a checkpoint, ignoring checkpoint sync flags")
+		return nil
+	} else if !errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		return err
+	}
+	// The genesis state is still needed to start the chain clock and to serve genesis data.
+	gs, err := b.db.GenesisState(b.ctx)
+	if err != nil {
+		return err
+	}
+	if gs == nil || gs.IsNil() {
+		return fmt.Errorf("checkpoint sync requires a genesis state, use --%s to provide one",
+			flags.GenesisStatePath.Name)
+	}
+	return initializer.Initialize(b.ctx, b.db)
+}
+
+func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
+	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
+	if cliCtx.IsSet(flags.SlasherDirFlag.Name) {
+		baseDir = cliCtx.String(flags.SlasherDirFlag.Name)
+	}
+	dbPath := filepath.Join(baseDir, slasherkv.SlasherDbDirName)
+	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
+	forceClearDB := cliCtx.Bool(cmd.ForceClearDB.Name)
+
+	log.WithField("database-path", dbPath).Info("Checking slasher DB")
+
+	d, err := slasherkv.NewKVStore(b.ctx, dbPath, &slasherkv.Config{
+		InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+	})
+	if err != nil {
+		return err
+	}
+	clearDBConfirmed := false
+	if clearDB && !forceClearDB {
+		actionText := "This will delete your slasher database stored in your data directory. " +
+			"Your database backups will not be removed - do you want to proceed? (Y/N)"
+		deniedText := "Slasher database will not be deleted. No changes have been made."
+		clearDBConfirmed, err = cmd.ConfirmAction(actionText, deniedText)
+		if err != nil {
+			return err
+		}
+	}
+	if clearDBConfirmed || forceClearDB {
+		log.Warning("Removing slasher database")
+		if err := d.Close(); err != nil {
+			return errors.Wrap(err, "could not close db prior to clearing")
+		}
+		if err := d.ClearDB(); err != nil {
+			return errors.Wrap(err, "could not clear database")
+		}
+		d, err = slasherkv.NewKVStore(b.ctx, dbPath, &slasherkv.Config{
+			InitialMMapSize: cliCtx.Int(cmd.BoltMMapInitialSizeFlag.Name),
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not create new database")
+		}
+	}
+	b.slasherDB = d
+	return nil
+}
+
 func (b *BeaconNode) startStateGen() {
 	b.stateGen = stategen.New(b.db)
 }
@@ -364,6 +501,7 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	svc, err := p2p.NewService(b.ctx, &p2p.Config{
 		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
 		StaticPeers:       sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
+		TrustedPeers:      sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.TrustedPeers.Name)),
 		BootstrapNodeAddr: bootstrapNodeAddrs,
 		RelayNodeAddr:     cliCtx.String(cmd.RelayNode.Name),
 		DataDir:           dataDir,
@@ -445,6 +583,21 @@ func (b *BeaconNode) registerBlockchainService() error {
 	return b.services.RegisterService(blockchainService)
 }
 
+func (b *BeaconNode) registerPrunerService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	ps := pruner.NewService(b.ctx, &pruner.Config{
+		DB:              b.db,
+		HeadFetcher:     chainService,
+		StateNotifier:   b,
+		RetentionEpochs: types.Epoch(b.cliCtx.Uint64(flags.HistoryRetention.Name)),
+	})
+	return b.services.RegisterService(ps)
+}
+
 func (b *BeaconNode) registerPOWChainService() error {
 	if b.cliCtx.Bool(testSkipPowFlag) {
 		return b.services.RegisterService(&powchain.Service{})
@@ -460,6 +613,11 @@ func (b *BeaconNode) registerPOWChainService() error {
 		return err
 	}
 
+	depositSnapshot, err := b.depositSnapshot()
+	if err != nil {
+		return errors.Wrap(err, "could not load deposit snapshot")
+	}
+
 	cfg := &powchain.Web3ServiceConfig{
 		HttpEndpoints:          endpoints,
 		DepositContract:        common.HexToAddress(depAddress),
@@ -468,7 +626,9 @@ func (b *BeaconNode) registerPOWChainService() error {
 		StateNotifier:          b,
 		StateGen:               b.stateGen,
 		Eth1HeaderReqLimit:     b.cliCtx.Uint64(flags.Eth1HeaderReqLimit.Name),
+		Eth1Quorum:             b.cliCtx.Uint64(flags.Eth1Quorum.Name),
 		BeaconNodeStatsUpdater: bs,
+		DepositSnapshot:        depositSnapshot,
 	}
 
 	web3Service, err := powchain.NewService(b.ctx, cfg)
@@ -479,6 +639,26 @@ func (b *BeaconNode) registerPOWChainService() error {
 	return b.services.RegisterService(web3Service)
 }
 
+// depositSnapshot loads the deposit snapshot given through the deposit snapshot flags, unless
+// deposits have already been processed, in which case the snapshot would be ignored anyway.
+func (b *BeaconNode) depositSnapshot() (*ethpb.DepositSnapshot, error) {
+	if !b.cliCtx.IsSet(flags.DepositSnapshotURL.Name) && !b.cliCtx.IsSet(flags.DepositSnapshotPath.Name) {
+		return nil, nil
+	}
+	eth1Data, err := b.db.PowchainData(b.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if eth1Data != nil && eth1Data.Trie != nil && trieutil.CreateTrieFromProto(eth1Data.Trie).NumOfItems() > 0 {
+		log.Info("Deposits have already been processed, ignoring deposit snapshot flags")
+		return nil, nil
+	}
+	if b.cliCtx.IsSet(flags.DepositSnapshotURL.Name) {
+		return checkpoint.FetchDepositSnapshot(b.ctx, b.cliCtx.String(flags.DepositSnapshotURL.Name))
+	}
+	return checkpoint.LoadDepositSnapshotFile(b.cliCtx.String(flags.DepositSnapshotPath.Name))
+}
+
 func (b *BeaconNode) registerSyncService() error {
 	var web3Service *powchain.Service
 	if err := b.services.FetchService(&web3Service); err != nil {
@@ -513,6 +693,25 @@ func (b *BeaconNode) registerSyncService() error {
 	return b.services.RegisterService(rs)
 }
 
+func (b *BeaconNode) registerSlasherService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	s := slasher.NewService(b.ctx, &slasher.ServiceConfig{
+		Database:                b.slasherDB,
+		StateNotifier:           b,
+		BlockNotifier:           b,
+		OperationNotifier:       b,
+		HeadStateFetcher:        chainService,
+		AttestationStateFetcher: chainService,
+		SlashingPoolInserter:    b.slashingsPool,
+		SlashingReceiver:        chainService,
+	})
+	return b.services.RegisterService(s)
+}
+
 func (b *BeaconNode) registerInitialSyncService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -529,6 +728,21 @@ func (b *BeaconNode) registerInitialSyncService() error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService() error {
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+
+	bs := initialsync.NewBackfillService(b.ctx, &initialsync.BackfillConfig{
+		DB:            b.db,
+		Chain:         chainService,
+		P2P:           b.fetchP2P(),
+		StateNotifier: b,
+	})
+	return b.services.RegisterService(bs)
+}
+
 func (b *BeaconNode) registerRPCService() error {
 	var chainService *blockchain.Service
 	if err := b.services.FetchService(&chainService); err != nil {
@@ -582,6 +796,7 @@ func (b *BeaconNode) registerRPCService() error {
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
 		PeerManager:             p2pService,
+		BandwidthFetcher:        p2pService,
 		MetadataProvider:        p2pService,
 		ChainInfoFetcher:        chainService,
 		HeadFetcher:             chainService,
@@ -589,6 +804,8 @@ func (b *BeaconNode) registerRPCService() error {
 		ForkFetcher:             chainService,
 		FinalizationFetcher:     chainService,
 		BlockReceiver:           chainService,
+		SlashingReceiver:        chainService,
+		LightClientFetcher:      chainService,
 		AttestationReceiver:     chainService,
 		GenesisTimeFetcher:      chainService,
 		GenesisFetcher:          chainService,
@@ -0,0 +1,112 @@
+package p2p
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// The duration after which the bandwidth meters of idle peers and protocols are dropped.
+const bandwidthIdleTimeout = time.Hour
+
+const (
+	directionReceived = "received"
+	directionSent     = "sent"
+)
+
+// Bandwidth returns the bandwidth reporter of the libp2p host, which meters the
+// traffic of the node by peer and by protocol.
+func (s *Service) Bandwidth() metrics.Reporter {
+	return s.bandwidth
+}
+
+// updateBandwidthMetrics exports the bandwidth used in total, by protocol, and by each of the
+// connected peers.
+func (s *Service) updateBandwidthMetrics() {
+	s.bandwidth.TrimIdle(time.Now().Add(-bandwidthIdleTimeout))
+
+	totals := s.bandwidth.GetBandwidthTotals()
+	p2pBandwidthBytes.WithLabelValues(directionReceived).Set(float64(totals.TotalIn))
+	p2pBandwidthBytes.WithLabelValues(directionSent).Set(float64(totals.TotalOut))
+	p2pBandwidthRate.WithLabelValues(directionReceived).Set(totals.RateIn)
+	p2pBandwidthRate.WithLabelValues(directionSent).Set(totals.RateOut)
+
+	for proto, stats := range s.bandwidth.GetBandwidthByProtocol() {
+		p2pProtocolBandwidthBytes.WithLabelValues(string(proto), directionReceived).Set(float64(stats.TotalIn))
+		p2pProtocolBandwidthBytes.WithLabelValues(string(proto), directionSent).Set(float64(stats.TotalOut))
+	}
+
+	// Peers are only exported while connected, to keep the number of series bounded by the peer limit.
+	p2pPeerBandwidthBytes.Reset()
+	for _, pid := range s.peers.Connected() {
+		stats := s.bandwidth.GetBandwidthForPeer(pid)
+		p2pPeerBandwidthBytes.WithLabelValues(pid.String(), directionReceived).Set(float64(stats.TotalIn))
+		p2pPeerBandwidthBytes.WithLabelValues(pid.String(), directionSent).Set(float64(stats.TotalOut))
+	}
+}
+
+// gossipBandwidthTracer meters the size of the gossip messages sent and received on each topic.
+// Control messages are not attributed to any topic, and are only metered as part of the gossipsub
+// protocol by the bandwidth reporter of the host.
+type gossipBandwidthTracer struct{}
+
+var _ pubsub.RawTracer = gossipBandwidthTracer{}
+
+// RecvRPC meters the messages of a received RPC.
+func (gossipBandwidthTracer) RecvRPC(rpc *pubsub.RPC) {
+	meterGossipMessages(rpc.GetPublish(), directionReceived)
+}
+
+// SendRPC meters the messages of a sent RPC.
+func (gossipBandwidthTracer) SendRPC(rpc *pubsub.RPC, _ peer.ID) {
+	meterGossipMessages(rpc.GetPublish(), directionSent)
+}
+
+func meterGossipMessages(msgs []*pubsubpb.Message, direction string) {
+	for _, msg := range msgs {
+		p2pTopicBandwidthTotal.WithLabelValues(msg.GetTopic(), direction).Add(float64(msg.Size()))
+	}
+}
+
+// AddPeer is a no-op.
+func (gossipBandwidthTracer) AddPeer(peer.ID, protocol.ID) {}
+
+// RemovePeer is a no-op.
+func (gossipBandwidthTracer) RemovePeer(peer.ID) {}
+
+// Join is a no-op.
+func (gossipBandwidthTracer) Join(string) {}
+
+// Leave is a no-op.
+func (gossipBandwidthTracer) Leave(string) {}
+
+// Graft is a no-op.
+func (gossipBandwidthTracer) Graft(peer.ID, string) {}
+
+// Prune is a no-op.
+func (gossipBandwidthTracer) Prune(peer.ID, string) {}
+
+// ValidateMessage is a no-op.
+func (gossipBandwidthTracer) ValidateMessage(*pubsub.Message) {}
+
+// DeliverMessage is a no-op.
+func (gossipBandwidthTracer) DeliverMessage(*pubsub.Message) {}
+
+// RejectMessage is a no-op.
+func (gossipBandwidthTracer) RejectMessage(*pubsub.Message, string) {}
+
+// DuplicateMessage is a no-op.
+func (gossipBandwidthTracer) DuplicateMessage(*pubsub.Message) {}
+
+// ThrottlePeer is a no-op.
+func (gossipBandwidthTracer) ThrottlePeer(peer.ID) {}
+
+// DropRPC is a no-op.
+func (gossipBandwidthTracer) DropRPC(*pubsub.RPC, peer.ID) {}
+
+// UndeliverableMessage is a no-op.
+func (gossipBandwidthTracer) UndeliverableMessage(*pubsub.Message) {}
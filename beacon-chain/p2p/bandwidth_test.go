@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/metrics"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_UpdateBandwidthMetrics(t *testing.T) {
+	s := &Service{
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		bandwidth: metrics.NewBandwidthCounter(),
+	}
+	connected, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	disconnected, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	s.peers.Add(nil, connected, nil, network.DirInbound)
+	s.peers.SetConnectionState(connected, peers.PeerConnected)
+	s.peers.Add(nil, disconnected, nil, network.DirOutbound)
+	s.peers.SetConnectionState(disconnected, peers.PeerDisconnected)
+
+	proto := protocol.ID("/eth2/beacon_chain/req/status/1/ssz_snappy")
+	s.bandwidth.LogRecvMessage(100)
+	s.bandwidth.LogRecvMessageStream(100, proto, connected)
+	s.bandwidth.LogSentMessage(50)
+	s.bandwidth.LogSentMessageStream(50, proto, disconnected)
+	// Meters are only updated by the periodic sweep of the bandwidth counter.
+	for i := 0; i < 50 && s.bandwidth.GetBandwidthForPeer(disconnected).TotalOut == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	s.updateBandwidthMetrics()
+	assert.Equal(t, float64(100), gaugeValue(t, p2pProtocolBandwidthBytes, string(proto), directionReceived))
+	assert.Equal(t, float64(50), gaugeValue(t, p2pProtocolBandwidthBytes, string(proto), directionSent))
+	assert.Equal(t, float64(100), gaugeValue(t, p2pPeerBandwidthBytes, connected.String(), directionReceived))
+	assert.Equal(t, false, hasLabel(t, p2pPeerBandwidthBytes, disconnected.String()), "Disconnected peer is exported")
+}
+
+func TestGossipBandwidthTracer(t *testing.T) {
+	topic := "/eth2/00000000/beacon_block/ssz_snappy"
+	msg := &pubsubpb.Message{Data: make([]byte, 100), Topic: &topic}
+	rpc := &pubsub.RPC{RPC: pubsubpb.RPC{Publish: []*pubsubpb.Message{msg, msg}}}
+	received := counterValue(t, p2pTopicBandwidthTotal, topic, directionReceived)
+	sent := counterValue(t, p2pTopicBandwidthTotal, topic, directionSent)
+
+	tracer := gossipBandwidthTracer{}
+	tracer.RecvRPC(rpc)
+	tracer.SendRPC(rpc, "")
+	tracer.SendRPC(rpc, "")
+	assert.Equal(t, received+float64(2*msg.Size()), counterValue(t, p2pTopicBandwidthTotal, topic, directionReceived))
+	assert.Equal(t, sent+float64(4*msg.Size()), counterValue(t, p2pTopicBandwidthTotal, topic, directionSent))
+}
+
+func gaugeValue(t *testing.T, vec *prometheus.GaugeVec, labels ...string) float64 {
+	m := &dto.Metric{}
+	require.NoError(t, vec.WithLabelValues(labels...).Write(m))
+	return m.GetGauge().GetValue()
+}
+
+func counterValue(t *testing.T, vec *prometheus.CounterVec, labels ...string) float64 {
+	m := &dto.Metric{}
+	require.NoError(t, vec.WithLabelValues(labels...).Write(m))
+	return m.GetCounter().GetValue()
+}
+
+func hasLabel(t *testing.T, vec *prometheus.GaugeVec, value string) bool {
+	ch := make(chan prometheus.Metric, 100)
+	vec.Collect(ch)
+	close(ch)
+	for metric := range ch {
+		m := &dto.Metric{}
+		require.NoError(t, metric.Write(m))
+		for _, l := range m.GetLabel() {
+			if l.GetValue() == value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -49,7 +49,9 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "exceeded dial limit"}).Trace("Not accepting inbound dial from ip address")
 		return false
 	}
-	if s.isPeerAtLimit(true /* inbound */) {
+	// Connections above the peer limit are accepted while slots are reserved for trusted peers,
+	// and are only kept once secured if they come from a trusted peer.
+	if s.isPeerAtLimit(true /* inbound */) && s.reservedSlots() == 0 {
 		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
 		return false
@@ -59,7 +61,12 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 
 // InterceptSecured tests whether a given connection, now authenticated,
 // is allowed.
-func (s *Service) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) (allow bool) {
+func (s *Service) InterceptSecured(direction network.Direction, pid peer.ID, n network.ConnMultiaddrs) (allow bool) {
+	if direction == network.DirInbound && !s.peers.IsTrusted(pid) && s.isPeerAtLimit(true /* inbound */) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "at peer limit"}).Trace("Not accepting inbound connection from untrusted peer")
+		return false
+	}
 	return true
 }
 
@@ -42,6 +42,7 @@ func (s *Service) RefreshENR() {
 	if s.dv5Listener == nil || !s.isInitialized() {
 		return
 	}
+	s.refreshEarliestSlotRecord()
 	bitV := bitfield.NewBitvector64()
 	committees := cache.SubnetIDs.GetAllSubnets()
 	for _, idx := range committees {
@@ -204,6 +205,8 @@ func (s *Service) createListener(
 		}
 		dv5Cfg.Bootnodes = append(dv5Cfg.Bootnodes, bootNode)
 	}
+	// Known-good peers from a previous run seed the routing table along with the bootnodes.
+	dv5Cfg.Bootnodes = append(dv5Cfg.Bootnodes, s.knownPeerNodes()...)
 
 	listener, err := discover.ListenV5(conn, localNode, dv5Cfg)
 	if err != nil {
@@ -308,6 +311,11 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 	}
 	// Add peer to peer handler.
 	s.peers.Add(nodeENR, peerData.ID, multiAddr, network.DirUnknown)
+	if slot, err := earliestSlot(nodeENR); err != nil {
+		log.WithError(err).Debug("Could not retrieve earliest slot")
+	} else {
+		s.peers.SetEarliestAvailableSlot(peerData.ID, slot)
+	}
 	return true
 }
 
@@ -315,20 +323,23 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 // determines whether our currently connected and
 // active peers are above our set max peer limit.
 func (s *Service) isPeerAtLimit(inbound bool) bool {
-	numOfConns := len(s.host.Network().Peers())
+	// Trusted peers connect through reserved slots, and do not count against the peer limits
+	// once connected. Trusted peers still connecting may never take their slot.
+	connectedTrusted := len(s.peers.ConnectedTrusted())
+	numOfConns := len(s.host.Network().Peers()) - connectedTrusted
 	maxPeers := int(s.cfg.MaxPeers)
 	// If we are measuring the limit for inbound peers
 	// we apply the high watermark buffer.
 	if inbound {
 		maxPeers += highWatermarkBuffer
 		maxInbound := s.peers.InboundLimit() + highWatermarkBuffer
-		currInbound := len(s.peers.InboundConnected())
+		currInbound := len(s.peers.InboundConnected()) - len(s.peers.InboundConnectedTrusted())
 		// Exit early if we are at the inbound limit.
 		if currInbound >= maxInbound {
 			return true
 		}
 	}
-	activePeers := len(s.Peers().Active())
+	activePeers := len(s.Peers().Active()) - connectedTrusted
 	return activePeers >= maxPeers || numOfConns >= maxPeers
 }
 
@@ -269,6 +269,30 @@ func TestInboundPeerLimit(t *testing.T) {
 	require.Equal(t, true, s.isPeerAtLimit(true), "not at limit for inbound peers")
 }
 
+func TestPeerLimit_ConnectingTrustedPeer(t *testing.T) {
+	fakePeer := testp2p.NewTestP2P(t)
+	s := &Service{
+		cfg:       &Config{MaxPeers: 30},
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		host: fakePeer.BHost,
+	}
+
+	for i := 0; i < 29; i++ {
+		_ = addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	trustedPeer := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTING))
+	s.peers.AddTrustedPeer(trustedPeer, nil)
+
+	// A trusted peer only stops counting against the peer limit once connected.
+	require.Equal(t, true, s.isPeerAtLimit(false), "not at limit for outbound peers")
+	s.peers.SetConnectionState(trustedPeer, peers.PeerConnected)
+	require.Equal(t, false, s.isPeerAtLimit(false), "at limit for outbound peers")
+}
+
 func TestUDPMultiAddress(t *testing.T) {
 	port := 6500
 	ipAddr, pkey := createAddrAndPrivKey(t)
@@ -0,0 +1,45 @@
+package p2p
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var earliestSlotEnrKey = params.BeaconNetworkConfig().EarliestSlotKey
+
+// refreshEarliestSlotRecord advertises in our enr the earliest slot the node can serve
+// blocks from, so that syncing peers do not request the blocks pruned from its history.
+func (s *Service) refreshEarliestSlotRecord() {
+	if s.cfg.DB == nil {
+		return
+	}
+	slot, err := s.cfg.DB.EarliestAvailableSlot(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve earliest available slot")
+		return
+	}
+	current, err := earliestSlot(s.dv5Listener.Self().Record())
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve earliest slot from enr")
+		return
+	}
+	if slot == current {
+		return
+	}
+	s.dv5Listener.LocalNode().Set(enr.WithEntry(earliestSlotEnrKey, uint64(slot)))
+}
+
+// earliestSlot parses the earliest slot entry of a record, which is 0 for the nodes
+// serving their full history.
+func earliestSlot(record *enr.Record) (types.Slot, error) {
+	var slot uint64
+	if err := record.Load(enr.WithEntry(earliestSlotEnrKey, &slot)); err != nil {
+		if enr.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "could not load earliest slot entry")
+	}
+	return types.Slot(slot), nil
+}
@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	types "github.com/prysmaticlabs/eth2-types"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestRefreshEarliestSlotRecord(t *testing.T) {
+	ctx := context.Background()
+	db := dbutil.SetupDB(t)
+	nodeDB, err := enode.OpenDB(t.TempDir())
+	require.NoError(t, err)
+	_, key := createAddrAndPrivKey(t)
+	s := &Service{
+		ctx:         ctx,
+		cfg:         &Config{DB: db},
+		dv5Listener: mockListener{localNode: enode.NewLocalNode(nodeDB, key)},
+	}
+
+	// Nodes serving their full history have no earliest slot entry.
+	s.refreshEarliestSlotRecord()
+	slot, err := earliestSlot(s.dv5Listener.Self().Record())
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(0), slot)
+
+	require.NoError(t, db.PruneHistory(ctx, 64))
+	s.refreshEarliestSlotRecord()
+	slot, err = earliestSlot(s.dv5Listener.Self().Record())
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(64), slot)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -29,6 +30,7 @@ type P2P interface {
 	ConnectionHandler
 	PeersProvider
 	MetadataProvider
+	BandwidthProvider
 }
 
 // Broadcaster broadcasts messages to peers over the p2p pubsub protocol.
@@ -91,6 +93,11 @@ type PeersProvider interface {
 	Peers() *peers.Status
 }
 
+// BandwidthProvider returns the bandwidth used by the local peer.
+type BandwidthProvider interface {
+	Bandwidth() metrics.Reporter
+}
+
 // MetadataProvider returns the metadata related information for the local peer.
 type MetadataProvider interface {
 	Metadata() metadata.Metadata
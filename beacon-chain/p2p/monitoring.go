@@ -39,6 +39,31 @@ var (
 		Name: "p2p_sync_committee_subnet_attempted_broadcasts",
 		Help: "The number of sync committee that were attempted to be broadcast.",
 	})
+	p2pBandwidthBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_bandwidth_bytes",
+		Help: "The number of bytes received or sent by the libp2p host.",
+	},
+		[]string{"direction"})
+	p2pBandwidthRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_bandwidth_rate_bytes",
+		Help: "The number of bytes per second received or sent by the libp2p host.",
+	},
+		[]string{"direction"})
+	p2pProtocolBandwidthBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_protocol_bandwidth_bytes",
+		Help: "The number of bytes received or sent over a given libp2p protocol.",
+	},
+		[]string{"protocol", "direction"})
+	p2pPeerBandwidthBytes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_peer_bandwidth_bytes",
+		Help: "The number of bytes received from or sent to a given connected peer.",
+	},
+		[]string{"peer", "direction"})
+	p2pTopicBandwidthTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_topic_bandwidth_bytes_total",
+		Help: "The number of bytes of the gossip messages received or sent on a given topic.",
+	},
+		[]string{"topic", "direction"})
 )
 
 func (s *Service) updateMetrics() {
@@ -47,4 +72,5 @@ func (s *Service) updateMetrics() {
 	p2pPeerCount.WithLabelValues("Connecting").Set(float64(len(s.peers.Connecting())))
 	p2pPeerCount.WithLabelValues("Disconnecting").Set(float64(len(s.peers.Disconnecting())))
 	p2pPeerCount.WithLabelValues("Bad").Set(float64(len(s.peers.Bad())))
+	s.updateBandwidthMetrics()
 }
@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/sirupsen/logrus"
+)
+
+// The interval at which the peer records are persisted.
+var peerRecordsSaveInterval = 5 * time.Minute
+
+// peerRecordsPath returns the path of the persisted peer records, which is empty
+// if the node has no data directory to persist them to.
+func (s *Service) peerRecordsPath() string {
+	if s.cfg.DataDir == "" {
+		return ""
+	}
+	return path.Join(s.cfg.DataDir, peerRecordsFile)
+}
+
+// loadPeerRecords restores the peer records persisted by a previous run of the node, so that
+// known-bad peers stay banned and known-good peers are used to seed discovery.
+func (s *Service) loadPeerRecords() {
+	recordsPath := s.peerRecordsPath()
+	if recordsPath == "" {
+		return
+	}
+	records, err := peerdata.LoadRecords(recordsPath)
+	if err != nil {
+		log.WithError(err).Error("Could not load peer records")
+		return
+	}
+	if records == nil {
+		return
+	}
+	knownPeers := s.peers.RestoreRecords(records.Peers, records.SavedAt)
+	if len(knownPeers) > int(s.cfg.MaxPeers) {
+		knownPeers = knownPeers[:s.cfg.MaxPeers]
+	}
+	s.knownPeers = knownPeers
+	log.WithFields(logrus.Fields{
+		"restored":   len(records.Peers),
+		"knownPeers": len(knownPeers),
+	}).Debug("Restored peer records")
+}
+
+// savePeerRecords persists the records of all the peers currently known to the node.
+func (s *Service) savePeerRecords() {
+	recordsPath := s.peerRecordsPath()
+	if recordsPath == "" {
+		return
+	}
+	records := &peerdata.PeerRecords{
+		SavedAt: time.Now(),
+		Peers:   s.peers.Records(),
+	}
+	if err := peerdata.SaveRecords(recordsPath, records); err != nil {
+		log.WithError(err).Error("Could not save peer records")
+	}
+}
+
+// knownPeerNodes returns the discovery nodes of the restored known-good peers.
+func (s *Service) knownPeerNodes() []*enode.Node {
+	nodes := make([]*enode.Node, 0, len(s.knownPeers))
+	for _, record := range s.knownPeers {
+		node, err := recordNode(record)
+		if err != nil || node == nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// connectToKnownPeers dials the restored known-good peers, through their ENR if they have one,
+// or through the address we last dialed them on otherwise.
+func (s *Service) connectToKnownPeers() {
+	multiAddrs := convertToMultiAddr(s.knownPeerNodes())
+	for _, record := range s.knownPeers {
+		if len(record.ENR) > 0 || record.Address == "" || record.Direction != network.DirOutbound {
+			continue
+		}
+		addr, err := multiaddr.NewMultiaddr(record.Address + "/p2p/" + record.ID.String())
+		if err != nil {
+			log.WithError(err).Debug("Could not parse known peer address")
+			continue
+		}
+		multiAddrs = append(multiAddrs, addr)
+	}
+	if len(multiAddrs) == 0 {
+		return
+	}
+	s.connectWithAllPeers(multiAddrs)
+}
+
+// recordNode returns the discovery node of the peer record, which is nil if the record
+// has no ENR.
+func recordNode(record *peerdata.PeerRecord) (*enode.Node, error) {
+	if len(record.ENR) == 0 {
+		return nil, nil
+	}
+	enrRecord := &enr.Record{}
+	if err := rlp.DecodeBytes(record.ENR, enrRecord); err != nil {
+		return nil, err
+	}
+	return enode.New(enode.ValidSchemes, enrRecord)
+}
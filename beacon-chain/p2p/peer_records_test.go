@@ -0,0 +1,61 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_SaveAndLoadPeerRecords(t *testing.T) {
+	newService := func(dataDir string) *Service {
+		return &Service{
+			cfg: &Config{DataDir: dataDir, MaxPeers: 30},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit:    30,
+				ScorerParams: &scorers.Config{},
+			}),
+		}
+	}
+	dataDir := t.TempDir()
+	s := newService(dataDir)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	record := &enr.Record{}
+	record.Set(enr.IPv4(net.IPv4(213, 202, 254, 180)))
+	record.Set(enr.TCP(13000))
+	require.NoError(t, enode.SignV4(record, key))
+	pid, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	s.peers.Add(record, pid, address, network.DirOutbound)
+	s.savePeerRecords()
+
+	restored := newService(dataDir)
+	restored.loadPeerRecords()
+	require.Equal(t, 1, len(restored.knownPeers))
+	assert.Equal(t, pid, restored.knownPeers[0].ID)
+	nodes := restored.knownPeerNodes()
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, enode.PubkeyToIDV4(&key.PublicKey), nodes[0].ID())
+	assert.Equal(t, 13000, nodes[0].TCP())
+
+	// Records are not persisted without a data directory.
+	s = newService("")
+	s.peers.Add(record, pid, address, network.DirOutbound)
+	s.savePeerRecords()
+	s.loadPeerRecords()
+	assert.Equal(t, 0, len(s.knownPeers))
+}
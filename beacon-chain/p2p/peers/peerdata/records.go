@@ -0,0 +1,72 @@
+package peerdata
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+)
+
+// PeerRecord is the reputation of a single peer, as persisted across restarts of the node.
+type PeerRecord struct {
+	ID               peer.ID           `json:"id"`
+	Address          string            `json:"address,omitempty"`
+	Direction        network.Direction `json:"direction"`
+	ENR              []byte            `json:"enr,omitempty"`
+	BadResponses     int               `json:"bad_responses"`
+	ProcessedBlocks  uint64            `json:"processed_blocks"`
+	GossipScore      float64           `json:"gossip_score"`
+	BehaviourPenalty float64           `json:"behaviour_penalty"`
+	Score            float64           `json:"score"`
+	BannedUntil      time.Time         `json:"banned_until"`
+	LastSeen         time.Time         `json:"last_seen"`
+}
+
+// PeerRecords is the set of peer records saved by the node at a given time.
+type PeerRecords struct {
+	SavedAt time.Time     `json:"saved_at"`
+	Peers   []*PeerRecord `json:"peers"`
+}
+
+// SaveRecords writes the peer records to the given file. The records are written to a temporary
+// file first, which is then renamed into place, so that the previous records are kept intact if
+// the node stops while saving them.
+func SaveRecords(path string, records *PeerRecords) error {
+	enc, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal peer records")
+	}
+	expanded, err := fileutil.ExpandPath(path)
+	if err != nil {
+		return err
+	}
+	tmpPath := expanded + ".tmp"
+	if err := fileutil.WriteFile(tmpPath, enc); err != nil {
+		return errors.Wrapf(err, "could not write peer records to %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, expanded); err != nil {
+		return errors.Wrapf(err, "could not move peer records to %s", expanded)
+	}
+	return nil
+}
+
+// LoadRecords reads the peer records from the given file. Nil records are returned if the file
+// does not exist, as is the case on the first start of a node.
+func LoadRecords(path string) (*PeerRecords, error) {
+	enc, err := fileutil.ReadFileAsBytes(path)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "could not read peer records from %s", path)
+	}
+	records := &PeerRecords{}
+	if err := json.Unmarshal(enc, records); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal peer records")
+	}
+	return records, nil
+}
@@ -0,0 +1,64 @@
+package peerdata_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSaveLoadRecords(t *testing.T) {
+	recordsPath := filepath.Join(t.TempDir(), "peers.json")
+	pid1, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	pid2, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	records := &peerdata.PeerRecords{
+		SavedAt: time.Unix(1000, 0).UTC(),
+		Peers: []*peerdata.PeerRecord{
+			{
+				ID:           pid1,
+				Address:      "/ip4/1.2.3.4/tcp/13000",
+				Direction:    network.DirOutbound,
+				ENR:          []byte{1, 2, 3},
+				BadResponses: 2,
+				GossipScore:  1.5,
+				Score:        0.25,
+				LastSeen:     time.Unix(900, 0).UTC(),
+			},
+			{
+				ID:           pid2,
+				Direction:    network.DirInbound,
+				BadResponses: 7,
+				BannedUntil:  time.Unix(2000, 0).UTC(),
+			},
+		},
+	}
+	require.NoError(t, peerdata.SaveRecords(recordsPath, records))
+	// The temporary file the records are written to is renamed into place.
+	_, err = os.Stat(recordsPath + ".tmp")
+	assert.Equal(t, true, os.IsNotExist(err))
+
+	loaded, err := peerdata.LoadRecords(recordsPath)
+	require.NoError(t, err)
+	assert.DeepEqual(t, records, loaded)
+
+	// Records are overwritten on the next save.
+	records.Peers = records.Peers[:1]
+	require.NoError(t, peerdata.SaveRecords(recordsPath, records))
+	loaded, err = peerdata.LoadRecords(recordsPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(loaded.Peers))
+}
+
+func TestLoadRecords_NoFile(t *testing.T) {
+	loaded, err := peerdata.LoadRecords(filepath.Join(t.TempDir(), "peers.json"))
+	require.NoError(t, err)
+	assert.Equal(t, (*peerdata.PeerRecords)(nil), loaded)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
+	types "github.com/prysmaticlabs/eth2-types"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	pb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/metadata"
@@ -39,9 +40,10 @@ type StoreConfig struct {
 // the mutex when accessing data.
 type Store struct {
 	sync.RWMutex
-	ctx    context.Context
-	config *StoreConfig
-	peers  map[peer.ID]*PeerData
+	ctx          context.Context
+	config       *StoreConfig
+	peers        map[peer.ID]*PeerData
+	trustedPeers map[peer.ID]bool
 }
 
 // PeerData aggregates protocol and application level info about a single peer.
@@ -52,11 +54,14 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	LastSeen      time.Time
+	BannedUntil   time.Time
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *pb.Status
 	ChainStateLastUpdated     time.Time
 	ChainStateValidationError error
+	EarliestAvailableSlot     types.Slot
 	// Scorers internal data.
 	BadResponses         int
 	ProcessedBlocks      uint64
@@ -70,9 +75,10 @@ type PeerData struct {
 // NewStore creates new peer data store.
 func NewStore(ctx context.Context, config *StoreConfig) *Store {
 	return &Store{
-		ctx:    ctx,
-		config: config,
-		peers:  make(map[peer.ID]*PeerData),
+		ctx:          ctx,
+		config:       config,
+		peers:        make(map[peer.ID]*PeerData),
+		trustedPeers: make(map[peer.ID]bool),
 	}
 }
 
@@ -112,6 +118,34 @@ func (s *Store) Peers() map[peer.ID]*PeerData {
 	return s.peers
 }
 
+// SetTrustedPeer marks the given peer as trusted.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) SetTrustedPeer(pid peer.ID) {
+	s.trustedPeers[pid] = true
+}
+
+// DeleteTrustedPeer removes the given peer from the trusted peers.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) DeleteTrustedPeer(pid peer.ID) {
+	delete(s.trustedPeers, pid)
+}
+
+// IsTrustedPeer checks whether the given peer is trusted.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) IsTrustedPeer(pid peer.ID) bool {
+	return s.trustedPeers[pid]
+}
+
+// TrustedPeers returns the IDs of the trusted peers.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) TrustedPeers() []peer.ID {
+	pids := make([]peer.ID, 0, len(s.trustedPeers))
+	for pid := range s.trustedPeers {
+		pids = append(pids, pid)
+	}
+	return pids
+}
+
 // Config exposes store configuration params.
 func (s *Store) Config() *StoreConfig {
 	return s.config
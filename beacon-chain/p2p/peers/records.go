@@ -0,0 +1,141 @@
+package peers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+)
+
+// Records returns the reputation records of all the known peers, sorted by descending score,
+// to be persisted across restarts of the node.
+func (p *Status) Records() []*peerdata.PeerRecord {
+	now := time.Now()
+	badResponses := p.scorers.BadResponsesScorer().Params()
+
+	p.store.RLock()
+	records := make([]*peerdata.PeerRecord, 0, len(p.store.Peers()))
+	for pid, peerData := range p.store.Peers() {
+		record := &peerdata.PeerRecord{
+			ID:               pid,
+			Direction:        peerData.Direction,
+			BadResponses:     peerData.BadResponses,
+			ProcessedBlocks:  peerData.ProcessedBlocks,
+			GossipScore:      peerData.GossipScore,
+			BehaviourPenalty: peerData.BehaviourPenalty,
+			LastSeen:         peerData.LastSeen,
+		}
+		// Bans restored from a previous run are kept until they expire.
+		if now.Before(peerData.BannedUntil) {
+			record.BannedUntil = peerData.BannedUntil
+		}
+		if peerData.Address != nil {
+			record.Address = peerData.Address.String()
+		}
+		if peerData.Enr != nil {
+			if enc, err := rlp.EncodeToBytes(peerData.Enr); err == nil {
+				record.ENR = enc
+			}
+		}
+		// A peer stays banned until its bad responses are decayed below the threshold.
+		if peerData.BadResponses >= badResponses.Threshold {
+			intervals := time.Duration(peerData.BadResponses - badResponses.Threshold + 1)
+			if bannedUntil := now.Add(intervals * badResponses.DecayInterval); bannedUntil.After(record.BannedUntil) {
+				record.BannedUntil = bannedUntil
+			}
+		}
+		records = append(records, record)
+	}
+	p.store.RUnlock()
+
+	// Scores are calculated outside of the store lock, as scorers acquire it themselves.
+	for _, record := range records {
+		record.Score = p.scorers.Score(record.ID)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Score > records[j].Score
+	})
+	return records
+}
+
+// RestoreRecords repopulates the peer store with the records saved at the given time. Bad responses
+// are decayed by the time elapsed since the records were saved, and the restored peers which are
+// not banned are returned, in the order of the records. Bad peers are restored even once the peer
+// limit is reached, so that they stay banned across restarts.
+func (p *Status) RestoreRecords(records []*peerdata.PeerRecord, savedAt time.Time) []*peerdata.PeerRecord {
+	now := time.Now()
+	badResponses := p.scorers.BadResponsesScorer().Params()
+	decay := 0
+	if elapsed := now.Sub(savedAt); elapsed > 0 {
+		decay = int(elapsed / badResponses.DecayInterval)
+	}
+
+	p.store.Lock()
+	restored := make([]*peerdata.PeerRecord, 0, len(records))
+	for _, record := range records {
+		if record.ID == "" {
+			continue
+		}
+		if _, ok := p.store.PeerData(record.ID); ok {
+			continue
+		}
+		peerData := &peerdata.PeerData{
+			Direction:        record.Direction,
+			ConnState:        PeerDisconnected,
+			BadResponses:     record.BadResponses - decay,
+			ProcessedBlocks:  record.ProcessedBlocks,
+			GossipScore:      record.GossipScore,
+			BehaviourPenalty: record.BehaviourPenalty,
+			LastSeen:         record.LastSeen,
+			BannedUntil:      record.BannedUntil,
+		}
+		if peerData.BadResponses < 0 {
+			peerData.BadResponses = 0
+		}
+		isBad := bannedAt(record, now) || peerData.BadResponses >= badResponses.Threshold || peerData.GossipScore < 0
+		if !isBad && len(p.store.Peers()) >= p.store.Config().MaxPeers {
+			continue
+		}
+		if record.Address != "" {
+			if addr, err := ma.NewMultiaddr(record.Address); err == nil {
+				peerData.Address = addr
+			}
+		}
+		if len(record.ENR) > 0 {
+			enrRecord := &enr.Record{}
+			if err := rlp.DecodeBytes(record.ENR, enrRecord); err == nil {
+				peerData.Enr = enrRecord
+			}
+		}
+		p.store.SetPeerData(record.ID, peerData)
+		restored = append(restored, record)
+	}
+	p.tallyIPTracker()
+	p.store.Unlock()
+
+	knownGood := make([]*peerdata.PeerRecord, 0, len(restored))
+	for _, record := range restored {
+		if !bannedAt(record, now) && !p.scorers.IsBadPeer(record.ID) && !p.scorers.GossipScorer().IsBadPeer(record.ID) {
+			knownGood = append(knownGood, record)
+		}
+	}
+	return knownGood
+}
+
+// isBanned returns true if the peer is still banned by the record restored for it, so that
+// the ban is enforced when the peer reconnects, regardless of its decayed bad responses.
+func (p *Status) isBanned(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	peerData, ok := p.store.PeerData(pid)
+	return ok && time.Now().Before(peerData.BannedUntil)
+}
+
+// bannedAt returns true if the peer of the record is still banned at the given time.
+func bannedAt(record *peerdata.PeerRecord, t time.Time) bool {
+	return !record.BannedUntil.IsZero() && t.Before(record.BannedUntil)
+}
@@ -0,0 +1,176 @@
+package peers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func newRecordsTestStatus() *peers.Status {
+	return peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold:     2,
+				DecayInterval: time.Hour,
+			},
+		},
+	})
+}
+
+func TestStatus_RecordsAndRestore(t *testing.T) {
+	p := newRecordsTestStatus()
+
+	goodPeer, err := peer.Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	badPeer, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	record := &enr.Record{}
+	require.NoError(t, enode.SignV4(record, key))
+
+	p.Add(record, goodPeer, address, network.DirOutbound)
+	p.SetConnectionState(goodPeer, peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks(goodPeer, 64)
+	p.Add(nil, badPeer, nil, network.DirInbound)
+	for i := 0; i < 3; i++ {
+		p.Scorers().BadResponsesScorer().Increment(badPeer)
+	}
+	require.Equal(t, true, p.IsBad(badPeer))
+
+	records := p.Records()
+	require.Equal(t, 2, len(records))
+	// Records are sorted by descending score.
+	assert.Equal(t, goodPeer, records[0].ID)
+	assert.Equal(t, address.String(), records[0].Address)
+	assert.Equal(t, uint64(64), records[0].ProcessedBlocks)
+	assert.Equal(t, true, records[0].BannedUntil.IsZero())
+	assert.Equal(t, false, records[0].LastSeen.IsZero())
+	assert.NotEqual(t, 0, len(records[0].ENR))
+	assert.Equal(t, badPeer, records[1].ID)
+	assert.Equal(t, 3, records[1].BadResponses)
+	assert.Equal(t, true, records[1].BannedUntil.After(time.Now().Add(time.Hour)))
+
+	// Known-bad peers stay banned when records are restored right away.
+	restored := newRecordsTestStatus()
+	knownGood := restored.RestoreRecords(records, time.Now())
+	require.Equal(t, 1, len(knownGood))
+	assert.Equal(t, goodPeer, knownGood[0].ID)
+	assert.Equal(t, true, restored.IsBad(badPeer))
+	resAddress, err := restored.Address(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, address.String(), resAddress.String())
+	resRecord, err := restored.ENR(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, record.Seq(), resRecord.Seq())
+	state, err := restored.ConnectionState(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+	direction, err := restored.Direction(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, network.DirOutbound, direction)
+	assert.Equal(t, uint64(64), restored.Scorers().BlockProviderScorer().ProcessedBlocks(goodPeer))
+}
+
+func TestStatus_RestoreRecords_DecaysBadResponses(t *testing.T) {
+	badPeer, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	savedAt := time.Now().Add(-2 * time.Hour)
+	records := []*peerdata.PeerRecord{
+		{
+			ID:           badPeer,
+			Direction:    network.DirInbound,
+			BadResponses: 3,
+			BannedUntil:  savedAt.Add(2 * time.Hour),
+		},
+	}
+
+	// The bad responses of the peer are decayed once per elapsed decay interval.
+	p := newRecordsTestStatus()
+	knownGood := p.RestoreRecords(records, savedAt)
+	assert.Equal(t, 1, len(knownGood))
+	assert.Equal(t, false, p.IsBad(badPeer))
+	count, err := p.Scorers().BadResponsesScorer().Count(badPeer)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Peers already known are left untouched.
+	p.Scorers().BadResponsesScorer().Increment(badPeer)
+	knownGood = p.RestoreRecords(records, time.Now())
+	assert.Equal(t, 0, len(knownGood))
+	count, err = p.Scorers().BadResponsesScorer().Count(badPeer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestStatus_RestoreRecords_BannedUntil(t *testing.T) {
+	bannedPeer, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	bannedUntil := time.Now().Add(2 * time.Hour)
+	records := []*peerdata.PeerRecord{
+		{
+			ID:          bannedPeer,
+			Direction:   network.DirInbound,
+			BannedUntil: bannedUntil,
+		},
+	}
+
+	// The ban is enforced when the peer reconnects, even though it has no bad responses left.
+	p := newRecordsTestStatus()
+	knownGood := p.RestoreRecords(records, time.Now())
+	assert.Equal(t, 0, len(knownGood))
+	assert.Equal(t, true, p.IsBad(bannedPeer))
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	p.Add(nil, bannedPeer, address, network.DirInbound)
+	p.SetConnectionState(bannedPeer, peers.PeerConnected)
+	assert.Equal(t, true, p.IsBad(bannedPeer))
+
+	// The ban is persisted until it expires.
+	saved := p.Records()
+	require.Equal(t, 1, len(saved))
+	assert.Equal(t, true, bannedUntil.Equal(saved[0].BannedUntil))
+}
+
+func TestStatus_RestoreRecords_BadPeersBeyondPeerLimit(t *testing.T) {
+	p := newRecordsTestStatus()
+	badPeer, err := peer.Decode("16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy")
+	require.NoError(t, err)
+	// The banned peer has the lowest score, so its record comes after the records of more
+	// peers than the limit.
+	records := make([]*peerdata.PeerRecord, 0, p.MaxPeerLimit()+2)
+	for i := 0; i <= p.MaxPeerLimit(); i++ {
+		records = append(records, &peerdata.PeerRecord{
+			ID:        peer.ID(fmt.Sprintf("peer%d", i)),
+			Direction: network.DirOutbound,
+		})
+	}
+	records = append(records, &peerdata.PeerRecord{
+		ID:           badPeer,
+		Direction:    network.DirInbound,
+		BadResponses: 3,
+		BannedUntil:  time.Now().Add(2 * time.Hour),
+	})
+
+	knownGood := p.RestoreRecords(records, time.Now())
+	assert.Equal(t, p.MaxPeerLimit(), len(knownGood))
+	assert.Equal(t, true, p.IsBad(badPeer), "Banned peer was not restored")
+	_, err = p.ConnectionState(records[p.MaxPeerLimit()].ID)
+	assert.ErrorContains(t, "peer unknown", err)
+}
@@ -211,9 +211,11 @@ func (p *Status) IsAboveInboundLimit() bool {
 	p.store.RLock()
 	defer p.store.RUnlock()
 	totalInbound := 0
-	for _, peerData := range p.store.Peers() {
+	for pid, peerData := range p.store.Peers() {
+		// Trusted peers connect through reserved slots.
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound &&
+			!p.store.IsTrustedPeer(pid) {
 			totalInbound += 1
 		}
 	}
@@ -229,6 +231,30 @@ func (p *Status) InboundLimit() int {
 	return int(float64(p.ConnectedPeerLimit()) * InboundRatio)
 }
 
+// SetEarliestAvailableSlot records that the given remote peer does not serve the blocks
+// before slot. The earliest slot of a peer only moves forward, as peers prune their history.
+func (p *Status) SetEarliestAvailableSlot(pid peer.ID, slot types.Slot) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	if slot > peerData.EarliestAvailableSlot {
+		peerData.EarliestAvailableSlot = slot
+	}
+}
+
+// EarliestAvailableSlot returns the earliest slot the given remote peer is known to serve
+// blocks from, which is 0 for unknown peers and peers serving their full history.
+func (p *Status) EarliestAvailableSlot(pid peer.ID) types.Slot {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	if peerData, ok := p.store.PeerData(pid); ok {
+		return peerData.EarliestAvailableSlot
+	}
+	return 0
+}
+
 // SetMetadata sets the metadata of the given remote peer.
 func (p *Status) SetMetadata(pid peer.ID, metaData metadata.Metadata) {
 	p.store.Lock()
@@ -297,6 +323,9 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
 	peerData.ConnState = state
+	if state == PeerConnected {
+		peerData.LastSeen = timeutils.Now()
+	}
 }
 
 // ConnectionState gets the connection state of the given remote peer.
@@ -326,7 +355,10 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 // IsBad states if the peer is to be considered bad (by *any* of the registered scorers).
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
 func (p *Status) IsBad(pid peer.ID) bool {
-	return p.isfromBadIP(pid) || p.scorers.IsBadPeer(pid)
+	if p.IsTrusted(pid) {
+		return false
+	}
+	return p.isfromBadIP(pid) || p.isBanned(pid) || p.scorers.IsBadPeer(pid)
 }
 
 // NextValidTime gets the earliest possible time it is to contact/dial
@@ -548,9 +580,9 @@ func (p *Status) Prune() {
 		badResp int
 	}
 	peersToPrune := make([]*peerResp, 0)
-	// Select disconnected peers with a smaller bad response count.
+	// Select disconnected peers with a smaller bad response count, trusted peers are always kept.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) && !p.store.IsTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -689,11 +721,13 @@ func (p *Status) BestNonFinalized(minPeers int, ourHeadEpoch types.Epoch) (types
 func (p *Status) PeersToPrune() []peer.ID {
 	connLimit := p.ConnectedPeerLimit()
 	inBoundLimit := p.InboundLimit()
-	activePeers := p.Active()
-	numInboundPeers := len(p.InboundConnected())
+	// Trusted peers connect through reserved slots, so they are
+	// neither counted against our limits nor pruned.
+	numActivePeers := len(p.Active()) - len(p.ActiveTrusted())
+	numInboundPeers := len(p.InboundConnected()) - len(p.InboundConnectedTrusted())
 	// Exit early if we are still below our max
 	// limit.
-	if len(activePeers) <= int(connLimit) {
+	if numActivePeers <= int(connLimit) {
 		return []peer.ID{}
 	}
 	p.store.Lock()
@@ -707,7 +741,8 @@ func (p *Status) PeersToPrune() []peer.ID {
 	// Select connected and inbound peers to prune.
 	for pid, peerData := range p.store.Peers() {
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound &&
+			!p.store.IsTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -723,7 +758,7 @@ func (p *Status) PeersToPrune() []peer.ID {
 
 	// Determine amount of peers to prune using our
 	// max connection limit.
-	amountToPrune := len(activePeers) - int(connLimit)
+	amountToPrune := numActivePeers - int(connLimit)
 
 	// Also check for inbound peers above our limit.
 	excessInbound := 0
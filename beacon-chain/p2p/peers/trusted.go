@@ -0,0 +1,101 @@
+package peers
+
+import (
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// AddTrustedPeer marks the given peer as trusted. Trusted peers are never considered bad,
+// are exempt from pruning, and connect through reserved slots which do not count against
+// the peer limits. The address, if provided, is used to redial the peer whenever it is
+// not connected.
+func (p *Status) AddTrustedPeer(pid peer.ID, address ma.Multiaddr) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	p.store.SetTrustedPeer(pid)
+	if address == nil {
+		return
+	}
+	peerData := p.store.PeerDataGetOrCreate(pid)
+	// Do not override the address of a live connection.
+	if peerData.ConnState == PeerConnected || peerData.ConnState == PeerConnecting {
+		return
+	}
+	prevAddress := peerData.Address
+	peerData.Address = address
+	if !sameIP(prevAddress, address) {
+		p.addIpToTracker(pid)
+	}
+}
+
+// RemoveTrustedPeer removes the given peer from the trusted peers. The peer is then
+// subject to the same scoring, pruning and limits as any other peer.
+func (p *Status) RemoveTrustedPeer(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	p.store.DeleteTrustedPeer(pid)
+}
+
+// IsTrusted checks whether the given peer is trusted.
+func (p *Status) IsTrusted(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	return p.store.IsTrustedPeer(pid)
+}
+
+// TrustedPeers returns the IDs of all the trusted peers, regardless of their state.
+func (p *Status) TrustedPeers() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	return p.store.TrustedPeers()
+}
+
+// ActiveTrusted returns the trusted peers that are connecting or connected.
+func (p *Status) ActiveTrusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peers := make([]peer.ID, 0)
+	for _, pid := range p.store.TrustedPeers() {
+		peerData, ok := p.store.PeerData(pid)
+		if ok && (peerData.ConnState == PeerConnecting || peerData.ConnState == PeerConnected) {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
+
+// ConnectedTrusted returns the trusted peers that are connected.
+func (p *Status) ConnectedTrusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peers := make([]peer.ID, 0)
+	for _, pid := range p.store.TrustedPeers() {
+		peerData, ok := p.store.PeerData(pid)
+		if ok && peerData.ConnState == PeerConnected {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
+
+// InboundConnectedTrusted returns the trusted peers that are connected through an inbound connection.
+func (p *Status) InboundConnectedTrusted() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	peers := make([]peer.ID, 0)
+	for _, pid := range p.store.TrustedPeers() {
+		peerData, ok := p.store.PeerData(pid)
+		if ok && peerData.ConnState == PeerConnected && peerData.Direction == network.DirInbound {
+			peers = append(peers, pid)
+		}
+	}
+	return peers
+}
@@ -0,0 +1,111 @@
+package peers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestStatus_TrustedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit: 30,
+		ScorerParams: &scorers.Config{
+			BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+				Threshold: 1,
+			},
+		},
+	})
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	trustedPeer := createPeer(t, p, nil, network.DirInbound, connected)
+	otherPeer := createPeer(t, p, nil, network.DirInbound, connected)
+	p.AddTrustedPeer(trustedPeer, nil)
+	p.Scorers().BadResponsesScorer().Increment(trustedPeer)
+	p.Scorers().BadResponsesScorer().Increment(otherPeer)
+
+	// Trusted peers are never considered bad.
+	assert.Equal(t, true, p.IsTrusted(trustedPeer))
+	assert.Equal(t, false, p.IsBad(trustedPeer))
+	assert.Equal(t, true, p.IsBad(otherPeer))
+	assert.DeepEqual(t, []peer.ID{trustedPeer}, p.TrustedPeers())
+	assert.DeepEqual(t, []peer.ID{trustedPeer}, p.ActiveTrusted())
+	assert.DeepEqual(t, []peer.ID{trustedPeer}, p.ConnectedTrusted())
+	assert.DeepEqual(t, []peer.ID{trustedPeer}, p.InboundConnectedTrusted())
+
+	// The peer is scored again once it is no longer trusted.
+	p.RemoveTrustedPeer(trustedPeer)
+	assert.Equal(t, false, p.IsTrusted(trustedPeer))
+	assert.Equal(t, true, p.IsBad(trustedPeer))
+	assert.Equal(t, 0, len(p.TrustedPeers()))
+}
+
+func TestStatus_AddTrustedPeer_Address(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	disconnectedPeer := createPeer(t, p, nil, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	p.AddTrustedPeer(disconnectedPeer, address)
+	resAddress, err := p.Address(disconnectedPeer)
+	require.NoError(t, err)
+	assert.Equal(t, address, resAddress)
+
+	// The address of a live connection is kept.
+	connectedAddress, err := ma.NewMultiaddr("/ip4/52.23.23.253/tcp/30000")
+	require.NoError(t, err)
+	connectedPeer := createPeer(t, p, connectedAddress, network.DirInbound, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	p.AddTrustedPeer(connectedPeer, address)
+	resAddress, err = p.Address(connectedPeer)
+	require.NoError(t, err)
+	assert.Equal(t, connectedAddress, resAddress)
+}
+
+func TestPrunePeers_TrustedPeers(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	for i := 0; i < 30; i++ {
+		createPeer(t, p, nil, network.DirOutbound, connected)
+	}
+	// Trusted peers connect through reserved slots.
+	var trusted []peer.ID
+	for i := 0; i < 5; i++ {
+		pid := createPeer(t, p, nil, network.DirInbound, connected)
+		p.AddTrustedPeer(pid, nil)
+		trusted = append(trusted, pid)
+	}
+	assert.Equal(t, 0, len(p.PeersToPrune()))
+	assert.Equal(t, false, p.IsAboveInboundLimit())
+
+	// Only untrusted peers are pruned above the limit.
+	untrusted := createPeer(t, p, nil, network.DirInbound, connected)
+	assert.DeepEqual(t, []peer.ID{untrusted}, p.PeersToPrune())
+
+	// Disconnected trusted peers are kept in the peer store.
+	disconnected := peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED)
+	for _, pid := range trusted {
+		p.SetConnectionState(pid, disconnected)
+	}
+	// Fill the peer store with connected peers, so that the disconnected trusted peers
+	// would be the only ones to prune.
+	for i := 0; i < p.MaxPeerLimit(); i++ {
+		createPeer(t, p, nil, network.DirOutbound, connected)
+	}
+	p.Prune()
+	for _, pid := range trusted {
+		_, err := p.ConnectionState(pid)
+		assert.NoError(t, err)
+	}
+}
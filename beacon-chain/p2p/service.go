@@ -14,6 +14,7 @@ import (
 	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
@@ -26,6 +27,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/metadata"
@@ -80,6 +82,9 @@ type Service struct {
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
 	activeValidatorCount  uint64
+	knownPeers            []*peerdata.PeerRecord
+	trustedBackoffs       map[peer.ID]*trustedPeerBackoff
+	bandwidth             *metrics.BandwidthCounter
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
@@ -90,13 +95,14 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop().
 
 	s := &Service{
-		ctx:           ctx,
-		stateNotifier: cfg.StateNotifier,
-		cancel:        cancel,
-		cfg:           cfg,
-		isPreGenesis:  true,
-		joinedTopics:  make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
-		subnetsLock:   make(map[uint64]*sync.RWMutex),
+		ctx:             ctx,
+		stateNotifier:   cfg.StateNotifier,
+		cancel:          cancel,
+		cfg:             cfg,
+		isPreGenesis:    true,
+		joinedTopics:    make(map[string]*pubsub.Topic, len(gossipTopicMappings)),
+		subnetsLock:     make(map[uint64]*sync.RWMutex),
+		trustedBackoffs: make(map[peer.ID]*trustedPeerBackoff),
 	}
 
 	dv5Nodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -120,6 +126,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		return nil, err
 	}
 	s.ipLimiter = leakybucket.NewCollector(ipLimit, ipBurst, true /* deleteEmptyBuckets */)
+	s.bandwidth = metrics.NewBandwidthCounter()
 
 	opts := s.buildOptions(ipAddr, s.privKey)
 	h, err := libp2p.New(s.ctx, opts...)
@@ -146,6 +153,14 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		pubsub.WithPeerScore(peerScoringParams()),
 		pubsub.WithPeerScoreInspect(s.peerInspector, time.Minute),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
+		pubsub.WithRawTracer(gossipBandwidthTracer{}),
+	}
+	// Trusted peers are direct peers of gossipsub, which always forwards messages to them
+	// regardless of their score and of the mesh. Gossipsub only takes its direct peers at
+	// startup, so trusted peers added or removed at runtime do not change them.
+	trustedPeers := trustedPeerInfos(s.cfg.TrustedPeers)
+	if len(trustedPeers) > 0 {
+		psOpts = append(psOpts, pubsub.WithDirectPeers(trustedPeers))
 	}
 	// Set the pubsub global parameters that we require.
 	setPubSubParameters()
@@ -169,6 +184,8 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 			},
 		},
 	})
+	s.loadPeerRecords()
+	s.addTrustedPeers(trustedPeers)
 
 	// Initialize Data maps.
 	types.InitializeDataMaps()
@@ -226,6 +243,7 @@ func (s *Service) Start() {
 		}
 		s.connectWithAllPeers(addrs)
 	}
+	s.connectToKnownPeers()
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -235,6 +253,8 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	runutil.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	runutil.RunEvery(s.ctx, peerRecordsSaveInterval, s.savePeerRecords)
+	runutil.RunEvery(s.ctx, trustedPeersCheckInterval, s.reconnectTrustedPeers)
 	runutil.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	runutil.RunEvery(s.ctx, refreshRate, func() {
 		s.RefreshENR()
@@ -272,6 +292,9 @@ func (s *Service) Stop() error {
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
 	}
+	if s.peers != nil {
+		s.savePeerRecords()
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/control"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -83,6 +84,11 @@ func (p *FakeP2P) Peers() *peers.Status {
 	return nil
 }
 
+// Bandwidth -- fake.
+func (p *FakeP2P) Bandwidth() metrics.Reporter {
+	return nil
+}
+
 // PublishToTopic -- fake.
 func (p *FakeP2P) PublishToTopic(_ context.Context, _ string, _ []byte, _ ...pubsub.PubOpt) error {
 	return nil
@@ -15,6 +15,7 @@ import (
 	core "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/control"
 	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/metrics"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
@@ -46,6 +47,7 @@ type TestP2P struct {
 	Digest          [4]byte
 	peers           *peers.Status
 	LocalMetadata   metadata.Metadata
+	bandwidth       *metrics.BandwidthCounter
 }
 
 // NewTestP2P initializes a new p2p test service.
@@ -74,6 +76,7 @@ func NewTestP2P(t *testing.T) *TestP2P {
 		pubsub:       ps,
 		joinedTopics: map[string]*pubsub.Topic{},
 		peers:        peerStatuses,
+		bandwidth:    metrics.NewBandwidthCounter(),
 	}
 }
 
@@ -348,6 +351,11 @@ func (p *TestP2P) Peers() *peers.Status {
 	return p.peers
 }
 
+// Bandwidth returns the bandwidth counter of the test peer.
+func (p *TestP2P) Bandwidth() metrics.Reporter {
+	return p.bandwidth
+}
+
 // FindPeersWithSubnet mocks the p2p func.
 func (p *TestP2P) FindPeersWithSubnet(_ context.Context, _ string, _, _ uint64) (bool, error) {
 	return false, nil
@@ -0,0 +1,144 @@
+package p2p
+
+import (
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// The interval at which disconnected trusted peers are redialed.
+var trustedPeersCheckInterval = 10 * time.Second
+
+const (
+	// The delay before redialing a trusted peer after a failed dial, which is doubled
+	// after each further failure.
+	trustedPeerMinBackoff = 10 * time.Second
+	// The maximum delay between two dials of a trusted peer.
+	trustedPeerMaxBackoff = 5 * time.Minute
+)
+
+// trustedPeerBackoff tracks the failed dials of a trusted peer.
+type trustedPeerBackoff struct {
+	backoff  time.Duration
+	nextDial time.Time
+}
+
+// ParseTrustedPeer parses a trusted peer given either as a multiaddr including its peer ID, as
+// an ENR, or as a bare peer ID. The returned address is nil if the peer is only given by its ID.
+func ParseTrustedPeer(addr string) (peer.ID, ma.Multiaddr, error) {
+	if !strings.HasPrefix(addr, "/") && !strings.HasPrefix(addr, "enr:") {
+		pid, err := peer.Decode(addr)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "could not decode peer ID %s", addr)
+		}
+		return pid, nil, nil
+	}
+	addrs, err := peersFromStringAddrs([]string{addr})
+	if err != nil {
+		return "", nil, err
+	}
+	if len(addrs) == 0 {
+		return "", nil, errors.Errorf("invalid peer address %s", addr)
+	}
+	info, err := peer.AddrInfoFromP2pAddr(addrs[0])
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "could not get peer ID from %s", addr)
+	}
+	var address ma.Multiaddr
+	if len(info.Addrs) > 0 {
+		address = info.Addrs[0]
+	}
+	return info.ID, address, nil
+}
+
+// trustedPeerInfos parses the configured trusted peers. The address infos of the peers only
+// given by their ID have no address.
+func trustedPeerInfos(addrs []string) []peer.AddrInfo {
+	infos := make([]peer.AddrInfo, 0, len(addrs))
+	for _, addr := range addrs {
+		pid, address, err := ParseTrustedPeer(addr)
+		if err != nil {
+			log.WithError(err).Error("Could not add trusted peer")
+			continue
+		}
+		info := peer.AddrInfo{ID: pid}
+		if address != nil {
+			info.Addrs = []ma.Multiaddr{address}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// addTrustedPeers marks the given peers as trusted. Static peers are only dialed on start, and
+// are not trusted unless also configured as trusted peers.
+func (s *Service) addTrustedPeers(infos []peer.AddrInfo) {
+	for _, info := range infos {
+		var address ma.Multiaddr
+		if len(info.Addrs) > 0 {
+			address = info.Addrs[0]
+		}
+		s.peers.AddTrustedPeer(info.ID, address)
+	}
+}
+
+// reservedSlots returns the number of connection slots reserved for the trusted peers
+// which are not currently connected.
+func (s *Service) reservedSlots() int {
+	return len(s.peers.TrustedPeers()) - len(s.peers.ActiveTrusted())
+}
+
+// reconnectTrustedPeers redials the trusted peers which are not connected, backing off
+// exponentially from the peers which cannot be reached. Trusted peers only known by their
+// ID are not dialed until an address of theirs is known.
+func (s *Service) reconnectTrustedPeers() {
+	now := time.Now()
+	// The backoffs are only accessed from this periodic routine, so they are not locked.
+	for pid := range s.trustedBackoffs {
+		if !s.peers.IsTrusted(pid) {
+			delete(s.trustedBackoffs, pid)
+		}
+	}
+	for _, pid := range s.peers.TrustedPeers() {
+		if pid == s.host.ID() || s.host.Network().Connectedness(pid) == network.Connected {
+			delete(s.trustedBackoffs, pid)
+			continue
+		}
+		backoff, ok := s.trustedBackoffs[pid]
+		if ok && now.Before(backoff.nextDial) {
+			continue
+		}
+		info := &peer.AddrInfo{ID: pid, Addrs: s.host.Peerstore().Addrs(pid)}
+		if addr, err := s.peers.Address(pid); err == nil && addr != nil {
+			info.Addrs = append(info.Addrs, addr)
+		}
+		if len(info.Addrs) == 0 {
+			continue
+		}
+		if err := connectWithTimeout(s.ctx, s.host, info); err != nil {
+			if !ok {
+				backoff = &trustedPeerBackoff{}
+				s.trustedBackoffs[pid] = backoff
+			}
+			backoff.backoff *= 2
+			if backoff.backoff < trustedPeerMinBackoff {
+				backoff.backoff = trustedPeerMinBackoff
+			}
+			if backoff.backoff > trustedPeerMaxBackoff {
+				backoff.backoff = trustedPeerMaxBackoff
+			}
+			backoff.nextDial = now.Add(backoff.backoff)
+			log.WithError(err).WithFields(logrus.Fields{
+				"peer":    pid,
+				"retryIn": backoff.backoff,
+			}).Debug("Could not reconnect to trusted peer")
+			continue
+		}
+		delete(s.trustedBackoffs, pid)
+	}
+}
@@ -0,0 +1,129 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestParseTrustedPeer(t *testing.T) {
+	pid, address, err := ParseTrustedPeer("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	assert.Equal(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", pid.String())
+	assert.Equal(t, nil, address)
+
+	pid, address, err = ParseTrustedPeer("/ip4/213.202.254.180/tcp/13000/p2p/16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	require.NoError(t, err)
+	assert.Equal(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", pid.String())
+	assert.Equal(t, "/ip4/213.202.254.180/tcp/13000", address.String())
+
+	_, _, err = ParseTrustedPeer("/ip4/213.202.254.180/tcp/13000")
+	assert.ErrorContains(t, "invalid peer address", err)
+	_, _, err = ParseTrustedPeer("not a peer")
+	assert.ErrorContains(t, "could not decode peer ID", err)
+}
+
+func TestTrustedPeerInfos(t *testing.T) {
+	infos := trustedPeerInfos([]string{
+		"16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR",
+		"not a peer",
+		"/ip4/213.202.254.180/tcp/13000/p2p/16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy",
+	})
+	require.Equal(t, 2, len(infos))
+	assert.Equal(t, "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR", infos[0].ID.String())
+	assert.Equal(t, 0, len(infos[0].Addrs))
+	assert.Equal(t, "16Uiu2HAm4HgJ9N1o222xK61o7LSgToYWoAy1wNTJRkh9gLZapVAy", infos[1].ID.String())
+	require.Equal(t, 1, len(infos[1].Addrs))
+	assert.Equal(t, "/ip4/213.202.254.180/tcp/13000", infos[1].Addrs[0].String())
+}
+
+func TestTrustedPeers_ReservedSlots(t *testing.T) {
+	limit := 30
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    limit,
+			ScorerParams: &scorers.Config{},
+		}),
+		host: mockp2p.NewTestP2P(t).BHost,
+		cfg:  &Config{MaxPeers: uint(limit)},
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{})
+	require.NoError(t, err)
+	multiAddress, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", "212.67.10.122", 3000))
+	require.NoError(t, err)
+	conn := &maEndpoints{raddr: multiAddress}
+
+	connected := peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED)
+	for i := 0; i < limit+highWatermarkBuffer; i++ {
+		addPeer(t, s.peers, connected)
+	}
+	require.Equal(t, true, s.isPeerAtLimit(true /* inbound */))
+	assert.Equal(t, false, s.InterceptAccept(conn), "Expected inbound connection to be rejected at the peer limit")
+
+	// Connected trusted peers do not count against the peer limit.
+	trustedPeer := addPeer(t, s.peers, connected)
+	s.peers.AddTrustedPeer(trustedPeer, nil)
+	assert.Equal(t, 0, s.reservedSlots())
+	assert.Equal(t, false, s.InterceptAccept(conn), "Expected inbound connection to be rejected at the peer limit")
+
+	// Inbound connections are accepted while a trusted peer is disconnected, but only kept
+	// once secured if they come from that trusted peer.
+	s.peers.SetConnectionState(trustedPeer, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	assert.Equal(t, 1, s.reservedSlots())
+	assert.Equal(t, true, s.InterceptAccept(conn), "Expected inbound connection to be accepted in a reserved slot")
+	otherPeer := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	assert.Equal(t, false, s.InterceptSecured(network.DirInbound, otherPeer, conn))
+	assert.Equal(t, true, s.InterceptSecured(network.DirOutbound, otherPeer, conn))
+	assert.Equal(t, true, s.InterceptSecured(network.DirInbound, trustedPeer, conn))
+}
+
+func TestReconnectTrustedPeers(t *testing.T) {
+	local := mockp2p.NewTestP2P(t)
+	remote := mockp2p.NewTestP2P(t)
+	s := &Service{
+		ctx: context.Background(),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		host:            local.BHost,
+		cfg:             &Config{MaxPeers: 30},
+		trustedBackoffs: make(map[peer.ID]*trustedPeerBackoff),
+	}
+	s.peers.AddTrustedPeer(remote.BHost.ID(), remote.BHost.Addrs()[0])
+
+	// Peers only known by their ID are not dialed.
+	unknownPeer := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_DISCONNECTED))
+	s.peers.AddTrustedPeer(unknownPeer, nil)
+
+	s.reconnectTrustedPeers()
+	assert.Equal(t, network.Connected, local.BHost.Network().Connectedness(remote.BHost.ID()))
+	assert.Equal(t, 0, len(s.trustedBackoffs))
+
+	// Unreachable peers are backed off from.
+	require.NoError(t, remote.BHost.Close())
+	require.NoError(t, local.BHost.Network().ClosePeer(remote.BHost.ID()))
+	s.reconnectTrustedPeers()
+	backoff, ok := s.trustedBackoffs[remote.BHost.ID()]
+	require.Equal(t, true, ok)
+	assert.Equal(t, trustedPeerMinBackoff, backoff.backoff)
+
+	// The backoff of peers which are no longer trusted is dropped.
+	s.peers.RemoveTrustedPeer(remote.BHost.ID())
+	s.reconnectTrustedPeers()
+	assert.Equal(t, 0, len(s.trustedBackoffs))
+}
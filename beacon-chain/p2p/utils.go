@@ -29,6 +29,7 @@ import (
 
 const keyPath = "network-keys"
 const metaDataPath = "metaData"
+const peerRecordsFile = "peers.json"
 
 const dialTimeout = 1 * time.Second
 
@@ -0,0 +1,67 @@
+package powchain
+
+import (
+	"net/url"
+
+	"github.com/ethereum/go-ethereum"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// The number of new heads which can be buffered while the previous head is processed.
+const newHeadsBufferSize = 16
+
+// supportsSubscriptions returns true if the eth1 endpoint is served over a transport which can
+// push notifications, namely a WebSocket or an IPC connection.
+func supportsSubscriptions(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+		return true
+	case "":
+		// Dialed as an IPC socket path.
+		return endpoint != ""
+	default:
+		return false
+	}
+}
+
+// subscribeNewHeads subscribes to the new heads of the current eth1 endpoint, and returns a nil
+// subscription if the endpoint can only be polled.
+func (s *Service) subscribeNewHeads(ch chan<- *gethTypes.Header) ethereum.Subscription {
+	if s.headSubscriber == nil {
+		return nil
+	}
+	sub, err := s.headSubscriber.SubscribeNewHead(s.ctx, ch)
+	if err != nil {
+		log.WithError(err).Debug("Could not subscribe to new eth1 heads, polling the latest header instead")
+		return nil
+	}
+	log.Debug("Subscribed to new eth1 heads")
+	return sub
+}
+
+// requestMissedHeaders requests the headers between the last processed head and the given
+// head, which were not received from the subscription after a reconnection, so that they
+// are available in the header cache for eth1 data voting.
+func (s *Service) requestMissedHeaders(head *gethTypes.Header) {
+	s.headLock.RLock()
+	lastHeight := s.latestEth1Data.BlockHeight
+	s.headLock.RUnlock()
+	if lastHeight == 0 || head.Number.Uint64() <= lastHeight+1 {
+		return
+	}
+	start, end := lastHeight+1, head.Number.Uint64()-1
+	if end-start+1 > s.cfg.Eth1HeaderReqLimit {
+		start = end - s.cfg.Eth1HeaderReqLimit + 1
+	}
+	if _, err := s.batchRequestHeaders(start, end); err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"start": start,
+			"end":   end,
+		}).Debug("Could not request missed eth1 headers")
+	}
+}
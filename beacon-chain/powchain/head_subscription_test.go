@@ -0,0 +1,203 @@
+package powchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSupportsSubscriptions(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     bool
+	}{
+		{endpoint: "http://localhost:8545", want: false},
+		{endpoint: "https://goerli.infura.io/v3/xxxx", want: false},
+		{endpoint: "ws://localhost:8546", want: true},
+		{endpoint: "wss://goerli.infura.io/ws/v3/xxxx", want: true},
+		{endpoint: "/home/user/.ethereum/geth.ipc", want: true},
+		{endpoint: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			assert.Equal(t, tt.want, supportsSubscriptions(tt.endpoint))
+		})
+	}
+}
+
+func TestRequestMissedHeaders(t *testing.T) {
+	testAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	beaconDB := dbutil.SetupDB(t)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:      []string{endpoint},
+		DepositContract:    testAcc.ContractAddr,
+		BeaconDB:           beaconDB,
+		Eth1HeaderReqLimit: 2,
+	})
+	require.NoError(t, err, "unable to setup web3 ETH1.0 chain service")
+	web3Service.rpcClient = &mockPOW.RPCClient{Backend: testAcc.Backend}
+	for i := 0; i < 5; i++ {
+		testAcc.Backend.Commit()
+	}
+	head := testAcc.Backend.Blockchain().CurrentHeader()
+	require.Equal(t, uint64(6), head.Number.Uint64())
+
+	web3Service.latestEth1Data.BlockHeight = 2
+	web3Service.requestMissedHeaders(head)
+	// Only the last headers within the request limit are requested.
+	for height, want := range map[int64]bool{3: false, 4: true, 5: true, 6: false} {
+		exists, _, err := web3Service.headerCache.HeaderInfoByHeight(big.NewInt(height))
+		require.NoError(t, err)
+		assert.Equal(t, want, exists, "unexpected header cache entry at height %d", height)
+	}
+}
+
+func TestRun_NewHeadsSubscription(t *testing.T) {
+	testAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	beaconDB := dbutil.SetupDB(t)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:   []string{endpoint},
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+	})
+	require.NoError(t, err, "unable to setup web3 ETH1.0 chain service")
+	web3Service = setDefaultMocks(web3Service)
+	web3Service.rpcClient = &mockPOW.RPCClient{Backend: testAcc.Backend}
+	web3Service.eth1DataFetcher = &goodFetcher{backend: testAcc.Backend}
+	web3Service.headSubscriber = testAcc.Backend
+	web3Service.depositContractCaller, err = contracts.NewDepositContractCaller(testAcc.ContractAddr, testAcc.Backend)
+	require.NoError(t, err)
+	testAcc.Backend.Commit()
+
+	tickerChan := make(chan time.Time)
+	web3Service.headTicker = &time.Ticker{C: tickerChan}
+	exitRoutine := make(chan bool)
+	go func() {
+		web3Service.run(web3Service.ctx.Done())
+		<-exitRoutine
+	}()
+	// Subscribes to new heads and polls the latest header on the first tick.
+	tickerChan <- time.Now()
+	waitForHeight(t, web3Service, testAcc.Backend.Blockchain().CurrentHeader().Number.Uint64())
+
+	// Further heads are received without any tick.
+	testAcc.Backend.Commit()
+	head := testAcc.Backend.Blockchain().CurrentHeader()
+	waitForHeight(t, web3Service, head.Number.Uint64())
+	web3Service.cancel()
+	exitRoutine <- true
+	assert.Equal(t, head.Hash(), web3Service.LatestBlockHash())
+}
+
+func TestRun_NewHeadsResubscription(t *testing.T) {
+	testAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	beaconDB := dbutil.SetupDB(t)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:   []string{endpoint},
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+	})
+	require.NoError(t, err, "unable to setup web3 ETH1.0 chain service")
+	web3Service = setDefaultMocks(web3Service)
+	web3Service.rpcClient = &mockPOW.RPCClient{Backend: testAcc.Backend}
+	web3Service.eth1DataFetcher = &goodFetcher{backend: testAcc.Backend}
+	subscriber := &droppableSubscriber{backend: testAcc.Backend}
+	web3Service.headSubscriber = subscriber
+	web3Service.depositContractCaller, err = contracts.NewDepositContractCaller(testAcc.ContractAddr, testAcc.Backend)
+	require.NoError(t, err)
+	testAcc.Backend.Commit()
+
+	tickerChan := make(chan time.Time)
+	web3Service.headTicker = &time.Ticker{C: tickerChan}
+	exitRoutine := make(chan bool)
+	go func() {
+		web3Service.run(web3Service.ctx.Done())
+		<-exitRoutine
+	}()
+	tickerChan <- time.Now()
+	waitForHeight(t, web3Service, testAcc.Backend.Blockchain().CurrentHeader().Number.Uint64())
+	require.Equal(t, 1, subscriber.count())
+
+	// The subscription drops, and is renewed on the next tick.
+	subscriber.drop(errors.New("connection lost"))
+	testAcc.Backend.Commit()
+	tickerChan <- time.Now()
+	waitForHeight(t, web3Service, testAcc.Backend.Blockchain().CurrentHeader().Number.Uint64())
+	require.Equal(t, 2, subscriber.count())
+
+	// Further heads are received from the new subscription without any tick.
+	testAcc.Backend.Commit()
+	head := testAcc.Backend.Blockchain().CurrentHeader()
+	waitForHeight(t, web3Service, head.Number.Uint64())
+	web3Service.cancel()
+	exitRoutine <- true
+	assert.Equal(t, head.Hash(), web3Service.LatestBlockHash())
+}
+
+func waitForHeight(t *testing.T, s *Service, height uint64) {
+	timeout := time.After(5 * time.Second)
+	for s.LatestBlockHeight().Uint64() != height {
+		select {
+		case <-timeout:
+			t.Fatalf("Timed out waiting for eth1 head at height %d", height)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// droppableSubscriber subscribes to the new heads of a backend, and can end the latest
+// subscription with an error as a lost connection would.
+type droppableSubscriber struct {
+	backend HeadSubscriber
+	lock    sync.Mutex
+	subs    []*droppableSubscription
+}
+
+type droppableSubscription struct {
+	ethereum.Subscription
+	err chan error
+}
+
+func (d *droppableSubscription) Err() <-chan error {
+	return d.err
+}
+
+func (d *droppableSubscriber) SubscribeNewHead(ctx context.Context, ch chan<- *gethTypes.Header) (ethereum.Subscription, error) {
+	sub, err := d.backend.SubscribeNewHead(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	s := &droppableSubscription{Subscription: sub, err: make(chan error)}
+	d.subs = append(d.subs, s)
+	return s, nil
+}
+
+func (d *droppableSubscriber) count() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return len(d.subs)
+}
+
+// drop sends an error on the latest subscription, and blocks until it is received.
+func (d *droppableSubscriber) drop(err error) {
+	d.lock.Lock()
+	s := d.subs[len(d.subs)-1]
+	d.lock.Unlock()
+	s.err <- err
+}
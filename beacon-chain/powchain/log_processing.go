@@ -493,12 +493,30 @@ func (s *Service) savePowchainData(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	// Deposits pruned from the deposit cache down to its deposit
+	// snapshot are also pruned from the persisted deposit trie.
+	snapshot := s.cfg.DepositCache.DepositSnapshot(ctx)
+	if snapshot != nil && int(snapshot.DepositCount) > s.depositTrie.FinalizedCount() {
+		if err := s.depositTrie.Finalize(int(snapshot.DepositCount)); err != nil {
+			return errors.Wrap(err, "could not finalize deposit trie")
+		}
+	}
+	// The head fields are updated concurrently by the head subscription.
+	s.headLock.RLock()
+	latestEth1Data := &protodb.LatestETH1Data{
+		BlockHeight:        s.latestEth1Data.BlockHeight,
+		BlockTime:          s.latestEth1Data.BlockTime,
+		BlockHash:          bytesutil.SafeCopyBytes(s.latestEth1Data.BlockHash),
+		LastRequestedBlock: s.latestEth1Data.LastRequestedBlock,
+	}
+	s.headLock.RUnlock()
 	eth1Data := &protodb.ETH1ChainData{
-		CurrentEth1Data:   s.latestEth1Data,
+		CurrentEth1Data:   latestEth1Data,
 		ChainstartData:    s.chainStartData,
 		BeaconState:       pbState, // I promise not to mutate it!
 		Trie:              s.depositTrie.ToProto(),
 		DepositContainers: s.cfg.DepositCache.AllDepositContainers(ctx),
+		DepositSnapshot:   snapshot,
 	}
 	return s.cfg.BeaconDB.SavePowchainData(ctx, eth1Data)
 }
@@ -0,0 +1,404 @@
+package powchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	quorumDisagreementsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "powchain_quorum_disagreements_total",
+		Help: "The number of eth1 responses which did not match the result agreed by the quorum of eth1 endpoints",
+	}, []string{"method", "endpoint"})
+	quorumEndpointErrorsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "powchain_quorum_endpoint_errors_total",
+		Help: "The number of eth1 requests which failed on an endpoint while querying the quorum of eth1 endpoints",
+	}, []string{"method", "endpoint"})
+	quorumFailuresCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "powchain_quorum_failures_total",
+		Help: "The number of eth1 requests for which no result was agreed by the quorum of eth1 endpoints",
+	}, []string{"method"})
+)
+
+var (
+	_ = RPCDataFetcher(&quorumClient{})
+	_ = RPCClient(&quorumClient{})
+	_ = bind.ContractFilterer(&quorumClient{})
+	_ = bind.ContractCaller(&quorumClient{})
+)
+
+// quorumEndpoint holds the clients of a single eth1 endpoint queried by the quorum client.
+type quorumEndpoint struct {
+	name    string // The url of the endpoint, with credentials masked.
+	fetcher RPCDataFetcher
+	logger  bind.ContractFilterer
+	caller  bind.ContractCaller
+	rpc     RPCClient
+}
+
+// quorumClient queries several eth1 endpoints concurrently, and only accepts the results
+// agreed by at least quorum of them, so that a single faulty or lagging endpoint cannot
+// feed wrong headers or deposit logs into the beacon node.
+type quorumClient struct {
+	quorum    int
+	endpoints []*quorumEndpoint
+}
+
+// A response of a single endpoint, along with the key responses are compared by.
+type quorumResponse struct {
+	value interface{}
+	key   string
+	err   error
+}
+
+func newQuorumClient(quorum int, endpoints []*quorumEndpoint) *quorumClient {
+	return &quorumClient{
+		quorum:    quorum,
+		endpoints: endpoints,
+	}
+}
+
+// HeaderByNumber returns the header agreed by the quorum of endpoints at the given height. As
+// endpoints rarely share the exact same head, the latest header is the header at the highest
+// height reached by a quorum of endpoints.
+func (q *quorumClient) HeaderByNumber(ctx context.Context, number *big.Int) (*gethTypes.Header, error) {
+	if number == nil {
+		height, err := q.quorumHeadHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		number = height
+	}
+	res, err := q.query(ctx, "HeaderByNumber", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		header, err := e.fetcher.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, "", err
+		}
+		if header == nil {
+			return nil, "", errors.New("nil header returned")
+		}
+		return header, header.Hash().Hex(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*gethTypes.Header), nil
+}
+
+// HeaderByHash returns the header with the given hash, if the quorum of endpoints agrees on it.
+func (q *quorumClient) HeaderByHash(ctx context.Context, hash common.Hash) (*gethTypes.Header, error) {
+	res, err := q.query(ctx, "HeaderByHash", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		header, err := e.fetcher.HeaderByHash(ctx, hash)
+		if err != nil {
+			return nil, "", err
+		}
+		if header == nil {
+			return nil, "", errors.New("nil header returned")
+		}
+		return header, header.Hash().Hex(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*gethTypes.Header), nil
+}
+
+// SyncProgress returns a nil progress if the quorum of endpoints has finished syncing.
+func (q *quorumClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	res, err := q.query(ctx, "SyncProgress", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		progress, err := e.fetcher.SyncProgress(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		// Endpoints which are still syncing rarely report the same progress.
+		if progress == nil {
+			return progress, "synced", nil
+		}
+		return progress, "syncing", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ethereum.SyncProgress), nil
+}
+
+// FilterLogs returns the logs matching the query, if the quorum of endpoints agrees on them.
+func (q *quorumClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethTypes.Log, error) {
+	res, err := q.query(ctx, "FilterLogs", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		logs, err := e.logger.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, "", err
+		}
+		key, err := jsonKey(logs)
+		if err != nil {
+			return nil, "", err
+		}
+		return logs, key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]gethTypes.Log), nil
+}
+
+// SubscribeFilterLogs is not supported, as the logs of a subscription cannot be compared
+// between endpoints as they are received.
+func (q *quorumClient) SubscribeFilterLogs(
+	_ context.Context, _ ethereum.FilterQuery, _ chan<- gethTypes.Log,
+) (ethereum.Subscription, error) {
+	return nil, errors.New("log subscriptions are not supported in eth1 quorum mode")
+}
+
+// CodeAt returns the code of the given account, if the quorum of endpoints agrees on it. The
+// code of the latest block is read at the height agreed by the quorum of endpoints.
+func (q *quorumClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil {
+		height, err := q.quorumHeadHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = height
+	}
+	res, err := q.query(ctx, "CodeAt", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		code, err := e.caller.CodeAt(ctx, contract, blockNumber)
+		if err != nil {
+			return nil, "", err
+		}
+		return code, common.Bytes2Hex(code), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]byte), nil
+}
+
+// CallContract executes the contract call, if the quorum of endpoints agrees on its result. Calls
+// on the latest block are executed at the height agreed by the quorum of endpoints, as endpoints
+// at different heights may otherwise disagree on the result.
+func (q *quorumClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if blockNumber == nil {
+		height, err := q.quorumHeadHeight(ctx)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = height
+	}
+	res, err := q.query(ctx, "CallContract", func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		out, err := e.caller.CallContract(ctx, call, blockNumber)
+		if err != nil {
+			return nil, "", err
+		}
+		return out, common.Bytes2Hex(out), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]byte), nil
+}
+
+// BatchCall sends the batch to every endpoint, and fills in the results and errors of the
+// batch elements agreed by the quorum of endpoints.
+func (q *quorumClient) BatchCall(b []gethRPC.BatchElem) error {
+	res, err := q.query(context.Background(), "BatchCall", func(_ context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		// Every endpoint decodes its results into its own copy of the batch.
+		elems := make([]gethRPC.BatchElem, len(b))
+		for i, elem := range b {
+			elems[i] = gethRPC.BatchElem{Method: elem.Method, Args: elem.Args}
+			if elem.Result != nil {
+				elems[i].Result = reflect.New(reflect.TypeOf(elem.Result).Elem()).Interface()
+			}
+		}
+		if err := e.rpc.BatchCall(elems); err != nil {
+			return nil, "", err
+		}
+		type elemResult struct {
+			Result interface{}
+			Error  string
+		}
+		results := make([]elemResult, len(elems))
+		for i, elem := range elems {
+			results[i].Result = elem.Result
+			if elem.Error != nil {
+				results[i].Error = elem.Error.Error()
+			}
+		}
+		key, err := jsonKey(results)
+		if err != nil {
+			return nil, "", err
+		}
+		return elems, key, nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, elem := range res.([]gethRPC.BatchElem) {
+		if elem.Result != nil {
+			reflect.ValueOf(b[i].Result).Elem().Set(reflect.ValueOf(elem.Result).Elem())
+		}
+		b[i].Error = elem.Error
+	}
+	return nil
+}
+
+// Closes the clients of all endpoints.
+func (q *quorumClient) close() {
+	for _, e := range q.endpoints {
+		if rpcClient, ok := e.rpc.(*gethRPC.Client); ok {
+			rpcClient.Close()
+		}
+		if httpClient, ok := e.fetcher.(*ethclient.Client); ok {
+			httpClient.Close()
+		}
+	}
+}
+
+// Determines the highest block height reached by at least quorum of the endpoints.
+func (q *quorumClient) quorumHeadHeight(ctx context.Context) (*big.Int, error) {
+	responses := q.queryAll(ctx, func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error) {
+		header, err := e.fetcher.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		if header == nil {
+			return nil, "", errors.New("nil header returned")
+		}
+		return header.Number, "", nil
+	})
+	heights := make([]*big.Int, 0, len(responses))
+	for i, r := range responses {
+		if r.err != nil {
+			q.recordEndpointError("HeaderByNumber", q.endpoints[i], r.err)
+			continue
+		}
+		heights = append(heights, r.value.(*big.Int))
+	}
+	if len(heights) < q.quorum {
+		quorumFailuresCount.WithLabelValues("HeaderByNumber").Inc()
+		return nil, fmt.Errorf("could not retrieve latest header: %d of %d eth1 endpoints responded, %d required",
+			len(heights), len(q.endpoints), q.quorum)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i].Cmp(heights[j]) > 0
+	})
+	return heights[q.quorum-1], nil
+}
+
+// Queries all endpoints, and returns the response of the endpoints whose key is shared by
+// at least quorum endpoints. Endpoints disagreeing with the quorum are logged and counted.
+func (q *quorumClient) query(
+	ctx context.Context,
+	method string,
+	call func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error),
+) (interface{}, error) {
+	responses := q.queryAll(ctx, call)
+	votes := make(map[string]int)
+	var firstErr error
+	numErrs := 0
+	for i, r := range responses {
+		if r.err != nil {
+			q.recordEndpointError(method, q.endpoints[i], r.err)
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			numErrs++
+			continue
+		}
+		votes[r.key]++
+	}
+	// Errors are returned as is when all endpoints fail, so that callers can still
+	// handle errors specific to the request.
+	if numErrs == len(responses) {
+		quorumFailuresCount.WithLabelValues(method).Inc()
+		return nil, firstErr
+	}
+	agreedKey, agreed := "", 0
+	for key, count := range votes {
+		if count > agreed {
+			agreedKey, agreed = key, count
+		}
+	}
+	if agreed < q.quorum {
+		quorumFailuresCount.WithLabelValues(method).Inc()
+		log.WithFields(logrus.Fields{
+			"method":    method,
+			"responses": len(responses) - numErrs,
+			"results":   len(votes),
+			"quorum":    q.quorum,
+		}).Warn("Eth1 endpoints did not reach a quorum")
+		return nil, fmt.Errorf("no quorum for %s: at most %d of %d eth1 endpoints agreed, %d required",
+			method, agreed, len(q.endpoints), q.quorum)
+	}
+	var agreedValue interface{}
+	found := false
+	for i, r := range responses {
+		if r.err != nil {
+			continue
+		}
+		if r.key != agreedKey {
+			quorumDisagreementsCount.WithLabelValues(method, q.endpoints[i].name).Inc()
+			log.WithFields(logrus.Fields{
+				"method":   method,
+				"endpoint": q.endpoints[i].name,
+			}).Warn("Eth1 endpoint disagrees with the quorum of eth1 endpoints")
+			continue
+		}
+		if !found {
+			agreedValue, found = r.value, true
+		}
+	}
+	return agreedValue, nil
+}
+
+// Queries all endpoints concurrently, returning their responses in the order of the endpoints.
+func (q *quorumClient) queryAll(
+	ctx context.Context,
+	call func(ctx context.Context, e *quorumEndpoint) (interface{}, string, error),
+) []*quorumResponse {
+	responses := make([]*quorumResponse, len(q.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range q.endpoints {
+		wg.Add(1)
+		go func(i int, e *quorumEndpoint) {
+			defer wg.Done()
+			value, key, err := call(ctx, e)
+			responses[i] = &quorumResponse{value: value, key: key, err: err}
+		}(i, e)
+	}
+	wg.Wait()
+	return responses
+}
+
+func (q *quorumClient) recordEndpointError(method string, e *quorumEndpoint, err error) {
+	quorumEndpointErrorsCount.WithLabelValues(method, e.name).Inc()
+	log.WithError(err).WithFields(logrus.Fields{
+		"method":   method,
+		"endpoint": e.name,
+	}).Debug("Eth1 endpoint request failed")
+}
+
+// Key of a response which is compared by its JSON encoding.
+func jsonKey(v interface{}) (string, error) {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	h := hashutil.Hash(enc)
+	return common.Bytes2Hex(h[:]), nil
+}
@@ -0,0 +1,235 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func quorumTestEndpoint(name string, backend *backends.SimulatedBackend) *quorumEndpoint {
+	return &quorumEndpoint{
+		name:    name,
+		fetcher: &goodFetcher{backend: backend},
+		logger:  &goodLogger{backend: backend},
+		caller:  backend,
+		rpc:     &mockPOW.RPCClient{Backend: backend},
+	}
+}
+
+// Sets up a quorum client over three endpoints with their own backend, the first two of which
+// serve the same chain. Transactions and blocks must be committed to both of their accounts.
+func setupQuorumClient(t *testing.T, quorum int) (*quorumClient, []*contracts.TestAccount, *contracts.TestAccount) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	testAcc, err := contracts.SetupWithKey(privKey)
+	require.NoError(t, err, "Unable to set up simulated backend")
+	mirrorAcc, err := contracts.SetupWithKey(privKey)
+	require.NoError(t, err, "Unable to set up simulated backend")
+	require.Equal(t, testAcc.Backend.Blockchain().CurrentHeader().Hash(), mirrorAcc.Backend.Blockchain().CurrentHeader().Hash())
+	otherAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	return newQuorumClient(quorum, []*quorumEndpoint{
+		quorumTestEndpoint("a", testAcc.Backend),
+		quorumTestEndpoint("b", mirrorAcc.Backend),
+		quorumTestEndpoint("c", otherAcc.Backend),
+	}), []*contracts.TestAccount{testAcc, mirrorAcc}, otherAcc
+}
+
+// Records the block numbers contract calls are made at, and executes them on the latest block.
+type recordingCaller struct {
+	backend      *backends.SimulatedBackend
+	blockNumbers []*big.Int
+}
+
+func (c *recordingCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.blockNumbers = append(c.blockNumbers, blockNumber)
+	return c.backend.CodeAt(ctx, contract, nil)
+}
+
+func (c *recordingCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.blockNumbers = append(c.blockNumbers, blockNumber)
+	return c.backend.CallContract(ctx, call, nil)
+}
+
+func TestQuorumClient_HeaderByNumber(t *testing.T) {
+	hook := logTest.NewGlobal()
+	qc, accs, _ := setupQuorumClient(t, 2)
+
+	header, err := qc.HeaderByNumber(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	want, err := accs[0].Backend.HeaderByNumber(context.Background(), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, want.Hash(), header.Hash())
+	assert.LogsContain(t, hook, "Eth1 endpoint disagrees with the quorum of eth1 endpoints")
+	assert.LogsContain(t, hook, "endpoint=c")
+}
+
+func TestQuorumClient_HeaderByNumber_LatestAtQuorumHeight(t *testing.T) {
+	qc, accs, otherAcc := setupQuorumClient(t, 2)
+	for _, acc := range accs {
+		acc.Backend.Commit()
+		acc.Backend.Commit()
+	}
+	otherAcc.Backend.Commit()
+
+	// The third endpoint has not reached the height agreed by the quorum.
+	header, err := qc.HeaderByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), header.Number.Uint64())
+	assert.Equal(t, accs[0].Backend.Blockchain().CurrentHeader().Hash(), header.Hash())
+}
+
+func TestQuorumClient_NoQuorum(t *testing.T) {
+	hook := logTest.NewGlobal()
+	testAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	otherAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	qc := newQuorumClient(2, []*quorumEndpoint{
+		quorumTestEndpoint("a", testAcc.Backend),
+		quorumTestEndpoint("b", otherAcc.Backend),
+	})
+
+	_, err = qc.HeaderByNumber(context.Background(), big.NewInt(1))
+	assert.ErrorContains(t, "no quorum for HeaderByNumber: at most 1 of 2 eth1 endpoints agreed, 2 required", err)
+	assert.LogsContain(t, hook, "Eth1 endpoints did not reach a quorum")
+}
+
+func TestQuorumClient_AllEndpointsFail(t *testing.T) {
+	qc, _, _ := setupQuorumClient(t, 2)
+	_, err := qc.HeaderByHash(context.Background(), common.BytesToHash([]byte{0}))
+	assert.ErrorContains(t, "expected block hash to be nonzero", err)
+}
+
+func TestQuorumClient_FilterLogsAndCallContract(t *testing.T) {
+	qc, accs, _ := setupQuorumClient(t, 2)
+	deposits, _, err := testutil.DeterministicDepositsAndKeys(1)
+	require.NoError(t, err)
+	_, depositRoots, err := testutil.DeterministicDepositTrie(len(deposits))
+	require.NoError(t, err)
+	data := deposits[0].Data
+
+	for _, acc := range accs {
+		acc.TxOpts.Value = contracts.Amount32Eth()
+		acc.TxOpts.GasLimit = 1000000
+		_, err = acc.Contract.Deposit(acc.TxOpts, data.PublicKey, data.WithdrawalCredentials, data.Signature, depositRoots[0])
+		require.NoError(t, err)
+		acc.Backend.Commit()
+	}
+
+	logs, err := qc.FilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: []common.Address{accs[0].ContractAddr},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(logs))
+
+	caller, err := contracts.NewDepositContractCaller(accs[0].ContractAddr, qc)
+	require.NoError(t, err)
+	count, err := caller.GetDepositCount(nil)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{1, 0, 0, 0, 0, 0, 0, 0}, count)
+}
+
+func TestQuorumClient_CallContract_QuorumHeight(t *testing.T) {
+	qc, accs, otherAcc := setupQuorumClient(t, 2)
+	// The first endpoint is ahead of the height agreed by the quorum.
+	accs[0].Backend.Commit()
+	callers := []*recordingCaller{
+		{backend: accs[0].Backend},
+		{backend: accs[1].Backend},
+		{backend: otherAcc.Backend},
+	}
+	for i, c := range callers {
+		qc.endpoints[i].caller = c
+	}
+
+	code, err := qc.CodeAt(context.Background(), accs[0].ContractAddr, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, len(code))
+	caller, err := contracts.NewDepositContractCaller(accs[0].ContractAddr, qc)
+	require.NoError(t, err)
+	count, err := caller.GetDepositCount(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte{0, 0, 0, 0, 0, 0, 0, 0}, count)
+	// Calls on the latest block are made at the height agreed by the quorum.
+	wanted := accs[1].Backend.Blockchain().CurrentHeader().Number
+	for _, c := range callers {
+		require.Equal(t, 2, len(c.blockNumbers))
+		for _, n := range c.blockNumbers {
+			assert.Equal(t, 0, wanted.Cmp(n))
+		}
+	}
+}
+
+func TestQuorumClient_BatchCall(t *testing.T) {
+	qc, accs, otherAcc := setupQuorumClient(t, 2)
+	for _, acc := range accs {
+		acc.Backend.Commit()
+	}
+	otherAcc.Backend.Commit()
+
+	headers := []*gethTypes.Header{{}, {}}
+	batch := make([]gethRPC.BatchElem, len(headers))
+	for i := range headers {
+		batch[i] = gethRPC.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeBig(big.NewInt(int64(i + 1))), false},
+			Result: headers[i],
+		}
+	}
+	require.NoError(t, qc.BatchCall(batch))
+	for i, header := range headers {
+		want, err := accs[0].Backend.HeaderByNumber(context.Background(), big.NewInt(int64(i+1)))
+		require.NoError(t, err)
+		assert.Equal(t, want.Hash(), header.Hash())
+	}
+}
+
+func TestNewService_Eth1Quorum(t *testing.T) {
+	testAcc, err := contracts.Setup()
+	require.NoError(t, err, "Unable to set up simulated backend")
+	beaconDB := dbutil.SetupDB(t)
+
+	_, err = NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:   []string{"http://a", "http://b"},
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+		Eth1Quorum:      1,
+	})
+	assert.ErrorContains(t, "eth1 quorum of 1 is not a majority of the 2 configured eth1 endpoints", err)
+
+	_, err = NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:   []string{"http://a", "http://b"},
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+		Eth1Quorum:      3,
+	})
+	assert.ErrorContains(t, "eth1 quorum of 3 is not a majority of the 2 configured eth1 endpoints", err)
+
+	s, err := NewService(context.Background(), &Web3ServiceConfig{
+		HttpEndpoints:   []string{"http://a", "http://b", "http://c"},
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+		Eth1Quorum:      2,
+	})
+	require.NoError(t, err)
+	// Endpoints are never switched in quorum mode.
+	s.fallbackToNextEndpoint()
+	assert.Equal(t, "http://a", s.currHttpEndpoint.Url)
+}
@@ -4,6 +4,7 @@
 package powchain
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -120,6 +121,12 @@ type RPCClient interface {
 	BatchCall(b []gethRPC.BatchElem) error
 }
 
+// HeadSubscriber defines the subscription to the new heads of the eth1 chain, which is only
+// available from eth1 nodes served over a WebSocket or IPC connection.
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *gethTypes.Header) (ethereum.Subscription, error)
+}
+
 // Service fetches important information about the canonical
 // Ethereum ETH1.0 chain via a web3 endpoint using an ethclient. The Random
 // Beacon Chain requires synchronization with the ETH1.0 chain's current
@@ -130,6 +137,7 @@ type Service struct {
 	connectedETH1           bool
 	isRunning               bool
 	processingLock          sync.RWMutex
+	headLock                sync.RWMutex // guards the head fields of latestEth1Data.
 	cfg                     *Web3ServiceConfig
 	ctx                     context.Context
 	cancel                  context.CancelFunc
@@ -139,6 +147,7 @@ type Service struct {
 	httpLogger              bind.ContractFilterer
 	eth1DataFetcher         RPCDataFetcher
 	rpcClient               RPCClient
+	headSubscriber          HeadSubscriber
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *protodb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
@@ -160,6 +169,8 @@ type Web3ServiceConfig struct {
 	StateGen               *stategen.State
 	Eth1HeaderReqLimit     uint64
 	BeaconNodeStatsUpdater BeaconNodeStatsUpdater
+	DepositSnapshot        *protodb.DepositSnapshot
+	Eth1Quorum             uint64
 }
 
 // NewService sets up a new instance with an ethclient when
@@ -186,6 +197,12 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 	for i, e := range stringEndpoints {
 		endpoints[i] = HttpEndpoint(e)
 	}
+	// A quorum must be a strict majority of the endpoints, so that two disjoint
+	// sets of endpoints can never both agree on conflicting results.
+	if config.Eth1Quorum > 0 && (config.Eth1Quorum > uint64(len(endpoints)) || 2*config.Eth1Quorum <= uint64(len(endpoints))) {
+		cancel()
+		return nil, fmt.Errorf("eth1 quorum of %d is not a majority of the %d configured eth1 endpoints", config.Eth1Quorum, len(endpoints))
+	}
 
 	// Select first http endpoint in the provided list.
 	var currEndpoint httputils.Endpoint
@@ -232,6 +249,11 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 	if err := s.initializeEth1Data(ctx, eth1Data); err != nil {
 		return nil, err
 	}
+	if config.DepositSnapshot != nil {
+		if err := s.initializeFromDepositSnapshot(ctx, config.DepositSnapshot); err != nil {
+			return nil, errors.Wrap(err, "could not initialize from deposit snapshot")
+		}
+	}
 
 	return s, nil
 }
@@ -356,11 +378,15 @@ func (s *Service) DepositTrie() *trieutil.SparseMerkleTrie {
 
 // LatestBlockHeight in the ETH1.0 chain.
 func (s *Service) LatestBlockHeight() *big.Int {
+	s.headLock.RLock()
+	defer s.headLock.RUnlock()
 	return big.NewInt(int64(s.latestEth1Data.BlockHeight))
 }
 
 // LatestBlockHash in the ETH1.0 chain.
 func (s *Service) LatestBlockHash() common.Hash {
+	s.headLock.RLock()
+	defer s.headLock.RUnlock()
 	return bytesutil.ToBytes32(s.latestEth1Data.BlockHash)
 }
 
@@ -374,8 +400,8 @@ func (s *Service) AreAllDepositsProcessed() (bool, error) {
 		return false, errors.Wrap(err, "could not get deposit count")
 	}
 	count := bytesutil.FromBytes8(countByte)
-	deposits := s.cfg.DepositCache.AllDeposits(s.ctx, nil)
-	if count != uint64(len(deposits)) {
+	// The deposit trie also accounts for the deposits pruned down to the deposit snapshot.
+	if count != uint64(s.depositTrie.NumOfItems()) {
 		return false, nil
 	}
 	return true, nil
@@ -384,14 +410,20 @@ func (s *Service) AreAllDepositsProcessed() (bool, error) {
 // refers to the latest eth1 block which follows the condition: eth1_timestamp +
 // SECONDS_PER_ETH1_BLOCK * ETH1_FOLLOW_DISTANCE <= current_unix_time
 func (s *Service) followBlockHeight(ctx context.Context) (uint64, error) {
+	s.headLock.RLock()
+	latestBlockHeight := s.latestEth1Data.BlockHeight
+	s.headLock.RUnlock()
 	latestValidBlock := uint64(0)
-	if s.latestEth1Data.BlockHeight > params.BeaconConfig().Eth1FollowDistance {
-		latestValidBlock = s.latestEth1Data.BlockHeight - params.BeaconConfig().Eth1FollowDistance
+	if latestBlockHeight > params.BeaconConfig().Eth1FollowDistance {
+		latestValidBlock = latestBlockHeight - params.BeaconConfig().Eth1FollowDistance
 	}
 	return latestValidBlock, nil
 }
 
 func (s *Service) connectToPowChain() error {
+	if s.cfg.Eth1Quorum > 0 {
+		return s.connectToQuorum()
+	}
 	httpClient, rpcClient, err := s.dialETH1Nodes(s.currHttpEndpoint)
 	if err != nil {
 		return errors.Wrap(err, "could not dial eth1 nodes")
@@ -410,6 +442,50 @@ func (s *Service) connectToPowChain() error {
 	return nil
 }
 
+// Connects to every configured eth1 endpoint, and queries them through a quorum client
+// which only accepts the results agreed by the configured quorum of endpoints.
+func (s *Service) connectToQuorum() error {
+	endpoints := make([]*quorumEndpoint, 0, len(s.httpEndpoints))
+	for _, endpoint := range s.httpEndpoints {
+		httpClient, rpcClient, err := s.dialETH1Nodes(endpoint)
+		if err != nil {
+			log.WithError(err).WithField("endpoint", logutil.MaskCredentialsLogging(endpoint.Url)).Warn(
+				"Could not dial eth1 endpoint of the quorum",
+			)
+			continue
+		}
+		endpoints = append(endpoints, &quorumEndpoint{
+			name:    logutil.MaskCredentialsLogging(endpoint.Url),
+			fetcher: httpClient,
+			logger:  httpClient,
+			caller:  httpClient,
+			rpc:     rpcClient,
+		})
+	}
+	qc := newQuorumClient(int(s.cfg.Eth1Quorum), endpoints)
+	if uint64(len(endpoints)) < s.cfg.Eth1Quorum {
+		qc.close()
+		return fmt.Errorf("only %d eth1 endpoints are available, %d required for a quorum", len(endpoints), s.cfg.Eth1Quorum)
+	}
+
+	depositContractCaller, err := contracts.NewDepositContractCaller(s.cfg.DepositContract, qc)
+	if err != nil {
+		qc.close()
+		return errors.Wrap(err, "could not create deposit contract caller")
+	}
+	s.httpLogger = qc
+	s.eth1DataFetcher = qc
+	s.depositContractCaller = depositContractCaller
+	s.rpcClient = qc
+	// Heads pushed by a single endpoint cannot be checked against the quorum.
+	s.headSubscriber = nil
+	log.WithFields(logrus.Fields{
+		"quorum":    s.cfg.Eth1Quorum,
+		"endpoints": len(endpoints),
+	}).Info("Connected to eth1 endpoints in quorum mode")
+	return nil
+}
+
 func (s *Service) dialETH1Nodes(endpoint httputils.Endpoint) (*ethclient.Client, *gethRPC.Client, error) {
 	httpRPCClient, err := gethRPC.Dial(endpoint.Url)
 	if err != nil {
@@ -470,10 +546,19 @@ func (s *Service) initializeConnection(
 	s.eth1DataFetcher = httpClient
 	s.depositContractCaller = contractCaller
 	s.rpcClient = rpcClient
+	// New heads are polled from endpoints which cannot push them.
+	s.headSubscriber = nil
+	if supportsSubscriptions(s.currHttpEndpoint.Url) {
+		s.headSubscriber = httpClient
+	}
 }
 
 // closes down our active eth1 clients.
 func (s *Service) closeClients() {
+	if qc, ok := s.rpcClient.(*quorumClient); ok {
+		qc.close()
+		return
+	}
 	gethClient, ok := s.rpcClient.(*gethRPC.Client)
 	if ok {
 		gethClient.Close()
@@ -618,12 +703,13 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*protodb.Deposit
 		currIndex = fState.Eth1DepositIndex()
 	}
 	validDepositsCount.Add(float64(currIndex))
-	// Only add pending deposits if the container slice length
-	// is more than the current index in state.
-	if uint64(len(ctrs)) > currIndex {
-		for _, c := range ctrs[currIndex:] {
-			s.cfg.DepositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
+	// Only add pending deposits for the containers
+	// past the current index in state.
+	for _, c := range ctrs {
+		if uint64(c.Index) < currIndex {
+			continue
 		}
+		s.cfg.DepositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
 	}
 	return nil
 }
@@ -633,12 +719,14 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*protodb.Deposit
 func (s *Service) processBlockHeader(header *gethTypes.Header) {
 	defer safelyHandlePanic()
 	blockNumberGauge.Set(float64(header.Number.Int64()))
+	s.headLock.Lock()
 	s.latestEth1Data.BlockHeight = header.Number.Uint64()
 	s.latestEth1Data.BlockHash = header.Hash().Bytes()
 	s.latestEth1Data.BlockTime = header.Time
+	s.headLock.Unlock()
 	log.WithFields(logrus.Fields{
-		"blockNumber": s.latestEth1Data.BlockHeight,
-		"blockHash":   hexutil.Encode(s.latestEth1Data.BlockHash),
+		"blockNumber": header.Number.Uint64(),
+		"blockHash":   header.Hash().Hex(),
 	}).Debug("Latest eth1 chain event")
 }
 
@@ -705,8 +793,12 @@ func (s *Service) handleETH1FollowDistance() {
 	// use a 5 minutes timeout for block time, because the max mining time is 278 sec (block 7208027)
 	// (analyzed the time of the block from 2018-09-01 to 2019-02-13)
 	fiveMinutesTimeout := timeutils.Now().Add(-5 * time.Minute)
+	s.headLock.RLock()
+	latestBlockHeight := s.latestEth1Data.BlockHeight
+	latestBlockTime := s.latestEth1Data.BlockTime
+	s.headLock.RUnlock()
 	// check that web3 client is syncing
-	if time.Unix(int64(s.latestEth1Data.BlockTime), 0).Before(fiveMinutesTimeout) {
+	if time.Unix(int64(latestBlockTime), 0).Before(fiveMinutesTimeout) {
 		log.Warn("eth1 client is not syncing")
 	}
 	if !s.chainStartData.Chainstarted {
@@ -721,7 +813,7 @@ func (s *Service) handleETH1FollowDistance() {
 	// logs for the powchain service to process. Also is a potential
 	// failure condition as would mean we have not respected the protocol
 	// threshold.
-	if s.latestEth1Data.LastRequestedBlock == s.latestEth1Data.BlockHeight {
+	if s.latestEth1Data.LastRequestedBlock == latestBlockHeight {
 		log.Error("Beacon node is not respecting the follow distance")
 		return
 	}
@@ -752,9 +844,11 @@ func (s *Service) initPOWService() {
 				continue
 			}
 
+			s.headLock.Lock()
 			s.latestEth1Data.BlockHeight = header.Number.Uint64()
 			s.latestEth1Data.BlockHash = header.Hash().Bytes()
 			s.latestEth1Data.BlockTime = header.Time
+			s.headLock.Unlock()
 
 			if err := s.processPastLogs(ctx); err != nil {
 				log.Errorf("Unable to process past logs %v", err)
@@ -795,6 +889,17 @@ func (s *Service) run(done <-chan struct{}) {
 	chainstartTicker := time.NewTicker(logPeriod)
 	defer chainstartTicker.Stop()
 
+	heads := make(chan *gethTypes.Header, newHeadsBufferSize)
+	var headSub ethereum.Subscription
+	var headSubErr <-chan error
+	unsubscribe := func() {
+		if headSub != nil {
+			headSub.Unsubscribe()
+			headSub, headSubErr = nil, nil
+		}
+	}
+	defer unsubscribe()
+
 	for {
 		select {
 		case <-done:
@@ -804,20 +909,49 @@ func (s *Service) run(done <-chan struct{}) {
 			log.Debug("Context closed, exiting goroutine")
 			return
 		case <-s.headTicker.C:
+			resubscribed := false
+			if headSub == nil {
+				if headSub = s.subscribeNewHeads(heads); headSub != nil {
+					headSubErr = headSub.Err()
+					resubscribed = true
+				}
+			}
+			// While subscribed, the latest header is only requested to catch up with the heads
+			// missed before (re)subscribing, or when no new head was received for too long.
+			s.headLock.RLock()
+			latestBlockTime := s.latestEth1Data.BlockTime
+			s.headLock.RUnlock()
+			if headSub != nil && !resubscribed && !eth1HeadIsBehind(latestBlockTime) {
+				s.checkDefaultEndpoint()
+				continue
+			}
 			head, err := s.eth1DataFetcher.HeaderByNumber(s.ctx, nil)
 			if err != nil {
 				log.WithError(err).Debug("Could not fetch latest eth1 header")
+				unsubscribe()
 				s.retryETH1Node(err)
 				continue
 			}
 			if eth1HeadIsBehind(head.Time) {
 				log.WithError(errFarBehind).Debug("Could not get an up to date eth1 header")
+				unsubscribe()
 				s.retryETH1Node(errFarBehind)
 				continue
 			}
+			if resubscribed {
+				s.requestMissedHeaders(head)
+			}
 			s.processBlockHeader(head)
 			s.handleETH1FollowDistance()
 			s.checkDefaultEndpoint()
+		case head := <-heads:
+			s.requestMissedHeaders(head)
+			s.processBlockHeader(head)
+			s.handleETH1FollowDistance()
+		case err := <-headSubErr:
+			// The subscription is renewed on the next tick, once the connection is back.
+			log.WithError(err).Debug("Eth1 new heads subscription ended")
+			unsubscribe()
 		case <-chainstartTicker.C:
 			if s.chainStartData.Chainstarted {
 				chainstartTicker.Stop()
@@ -908,6 +1042,10 @@ func (s *Service) determineEarliestVotingBlock(ctx context.Context, followBlock
 // is ready to serve we connect to it again. This method is only
 // relevant if we are on our backup endpoint.
 func (s *Service) checkDefaultEndpoint() {
+	// All endpoints are used at once in quorum mode.
+	if s.cfg.Eth1Quorum > 0 {
+		return
+	}
 	primaryEndpoint := s.httpEndpoints[0]
 	// Return early if we are running on our primary
 	// endpoint.
@@ -937,6 +1075,9 @@ func (s *Service) checkDefaultEndpoint() {
 // This is an inefficient way to search for the next endpoint, but given N is expected to be
 // small ( < 25), it is fine to search this way.
 func (s *Service) fallbackToNextEndpoint() {
+	if s.cfg.Eth1Quorum > 0 {
+		return
+	}
 	currEndpoint := s.currHttpEndpoint
 	currIndex := 0
 	totalEndpoints := len(s.httpEndpoints)
@@ -977,15 +1118,89 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *protodb.
 	s.latestEth1Data = eth1DataInDB.CurrentEth1Data
 	numOfItems := s.depositTrie.NumOfItems()
 	s.lastReceivedMerkleIndex = int64(numOfItems - 1)
+	// The deposits preceding the snapshot have been pruned from the deposit containers.
+	if eth1DataInDB.DepositSnapshot != nil {
+		if err := s.cfg.DepositCache.InsertDepositSnapshot(ctx, eth1DataInDB.DepositSnapshot); err != nil {
+			return errors.Wrap(err, "could not initialize deposit snapshot")
+		}
+	}
 	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers); err != nil {
 		return errors.Wrap(err, "could not initialize caches")
 	}
 	return nil
 }
 
+// initializes the deposit trie and caches from a deposit snapshot, so that deposit logs are only
+// processed from the eth1 block of the snapshot onwards rather than from the deployment block of
+// the deposit contract.
+func (s *Service) initializeFromDepositSnapshot(ctx context.Context, snapshot *protodb.DepositSnapshot) error {
+	if s.depositTrie.NumOfItems() > 0 {
+		log.Info("Deposits have already been processed, ignoring deposit snapshot")
+		return nil
+	}
+	if !s.chainStartData.Chainstarted {
+		return errors.New("a deposit snapshot can only be used once the genesis state is known")
+	}
+	if err := s.verifyDepositSnapshotAnchor(ctx, snapshot); err != nil {
+		return err
+	}
+	// The deposit cache verifies the snapshot root against its finalized roots.
+	if err := s.cfg.DepositCache.InsertDepositSnapshot(ctx, snapshot); err != nil {
+		return err
+	}
+	depositTrie, err := trieutil.TrieFromFinalizedRoots(
+		snapshot.Finalized, snapshot.DepositCount, params.BeaconConfig().DepositContractTreeDepth,
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit trie from snapshot")
+	}
+	s.depositTrie = depositTrie
+	s.lastReceivedMerkleIndex = int64(snapshot.DepositCount) - 1
+	if snapshot.ExecutionBlockHeight > s.latestEth1Data.LastRequestedBlock {
+		s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight
+	}
+	log.WithFields(logrus.Fields{
+		"depositCount": snapshot.DepositCount,
+		"depositRoot":  fmt.Sprintf("%#x", snapshot.DepositRoot),
+		"eth1Block":    snapshot.ExecutionBlockHeight,
+	}).Info("Initialized deposits from deposit snapshot")
+	return s.savePowchainData(ctx)
+}
+
+// verifyDepositSnapshotAnchor checks that the deposit snapshot is the one of the eth1 data voted
+// in the finalized state, so that a snapshot of another chain or of a deposit contract fork is
+// rejected. Nodes only take deposit snapshots once all the deposits of the finalized eth1 data
+// are processed, so a snapshot served at the same finalized checkpoint always matches it.
+func (s *Service) verifyDepositSnapshotAnchor(ctx context.Context, snapshot *protodb.DepositSnapshot) error {
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve finalized checkpoint")
+	}
+	var finalizedState state.BeaconState
+	if fRoot := bytesutil.ToBytes32(cp.Root); fRoot == params.BeaconConfig().ZeroHash {
+		finalizedState, err = s.cfg.BeaconDB.GenesisState(ctx)
+	} else {
+		finalizedState, err = s.cfg.BeaconDB.State(ctx, fRoot)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve finalized state")
+	}
+	if finalizedState == nil || finalizedState.IsNil() || finalizedState.Eth1Data() == nil {
+		return errors.New("finalized state not found")
+	}
+	eth1Data := finalizedState.Eth1Data()
+	if snapshot.DepositCount != eth1Data.DepositCount || !bytes.Equal(snapshot.DepositRoot, eth1Data.DepositRoot) {
+		return errors.Errorf(
+			"deposit snapshot with root %#x and count %d does not match the eth1 data of the finalized state, with root %#x and count %d",
+			snapshot.DepositRoot, snapshot.DepositCount, eth1Data.DepositRoot, eth1Data.DepositCount,
+		)
+	}
+	return nil
+}
+
 // validates that all deposit containers are valid and have their relevant indices
-// in order.
-func (s *Service) validateDepositContainers(ctrs []*protodb.DepositContainer) bool {
+// in order, starting at the first index following the deposit snapshot.
+func (s *Service) validateDepositContainers(ctrs []*protodb.DepositContainer, firstIndex int64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -995,7 +1210,7 @@ func (s *Service) validateDepositContainers(ctrs []*protodb.DepositContainer) bo
 	sort.Slice(ctrs, func(i, j int) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
-	startIndex := int64(0)
+	startIndex := firstIndex
 	for _, c := range ctrs {
 		if c.Index != startIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
@@ -1021,7 +1236,7 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !s.validateDepositContainers(eth1Data.DepositContainers) {
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !s.validateDepositContainers(eth1Data.DepositContainers, int64(eth1Data.DepositSnapshot.GetDepositCount())) {
 		pbState, err := v1.ProtobufBeaconState(s.preGenesisState.InnerStateUnsafe())
 		if err != nil {
 			return err
@@ -1039,6 +1254,7 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 			BeaconState:       pbState,
 			Trie:              s.depositTrie.ToProto(),
 			DepositContainers: s.cfg.DepositCache.AllDepositContainers(ctx),
+			DepositSnapshot:   s.cfg.DepositCache.DepositSnapshot(ctx),
 		}
 		return s.cfg.BeaconDB.SavePowchainData(ctx, eth1Data)
 	}
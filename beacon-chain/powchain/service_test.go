@@ -28,6 +28,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -660,6 +661,87 @@ func TestService_InitializeCorrectly(t *testing.T) {
 	assert.Equal(t, int64(-1), s1.lastReceivedMerkleIndex, "received incorrect last received merkle index")
 }
 
+func TestNewService_DepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	items := [][]byte{{1}, {2}, {3}, {4}, {5}}
+	depositTrie, err := trieutil.GenerateTrieFromItems(items, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	finalized, err := depositTrie.FinalizedRoots(len(items))
+	require.NoError(t, err)
+	root := depositTrie.HashTreeRoot()
+	// The snapshot is anchored to the eth1 data of the finalized state.
+	genState, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, genState.SetEth1Data(&ethpb.Eth1Data{DepositRoot: root[:], DepositCount: uint64(len(items))}))
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, genState))
+	snapshot := &protodb.DepositSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          root[:],
+		DepositCount:         uint64(len(items)),
+		ExecutionBlockHeight: 100,
+	}
+
+	cache, err := depositcache.New()
+	require.NoError(t, err)
+	s, err := NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:        beaconDB,
+		DepositCache:    cache,
+		DepositSnapshot: snapshot,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, root, s.DepositRoot())
+	assert.Equal(t, int64(4), s.lastReceivedMerkleIndex)
+	assert.Equal(t, uint64(100), s.latestEth1Data.LastRequestedBlock)
+	assert.DeepEqual(t, snapshot, cache.DepositSnapshot(ctx))
+
+	// The snapshot is persisted, and restored when restarting the node.
+	cache, err = depositcache.New()
+	require.NoError(t, err)
+	s, err = NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:     beaconDB,
+		DepositCache: cache,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, root, s.DepositRoot())
+	assert.Equal(t, int64(4), s.lastReceivedMerkleIndex)
+	assert.Equal(t, uint64(100), s.latestEth1Data.LastRequestedBlock)
+	assert.DeepEqual(t, snapshot, cache.DepositSnapshot(ctx))
+
+	// A snapshot with a root which does not match its finalized roots is rejected.
+	invalid := protodb.CopyDepositSnapshot(snapshot)
+	invalid.DepositRoot = make([]byte, 32)
+	invalidState, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, invalidState.SetEth1Data(&ethpb.Eth1Data{DepositRoot: invalid.DepositRoot, DepositCount: invalid.DepositCount}))
+	beaconDB = dbutil.SetupDB(t)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, invalidState))
+	cache, err = depositcache.New()
+	require.NoError(t, err)
+	_, err = NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:        beaconDB,
+		DepositCache:    cache,
+		DepositSnapshot: invalid,
+	})
+	assert.ErrorContains(t, "does not match", err)
+
+	// A snapshot which does not match the eth1 data of the finalized state is rejected.
+	otherState, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, otherState.SetEth1Data(&ethpb.Eth1Data{DepositRoot: root[:], DepositCount: uint64(len(items)) + 1}))
+	beaconDB = dbutil.SetupDB(t)
+	require.NoError(t, beaconDB.SaveGenesisData(ctx, otherState))
+	cache, err = depositcache.New()
+	require.NoError(t, err)
+	_, err = NewService(ctx, &Web3ServiceConfig{
+		BeaconDB:        beaconDB,
+		DepositCache:    cache,
+		DepositSnapshot: snapshot,
+	})
+	assert.ErrorContains(t, "does not match the eth1 data of the finalized state", err)
+	assert.Equal(t, true, cache.DepositSnapshot(ctx) == nil)
+}
+
 func TestService_EnsureValidPowchainData(t *testing.T) {
 	beaconDB := dbutil.SetupDB(t)
 	cache, err := depositcache.New()
@@ -752,7 +834,7 @@ func TestService_ValidateDepositContainers(t *testing.T) {
 	}
 
 	for _, test := range tt {
-		assert.Equal(t, test.expectedRes, s1.validateDepositContainers(test.ctrsFunc()))
+		assert.Equal(t, test.expectedRes, s1.validateDepositContainers(test.ctrsFunc(), 0))
 	}
 }
 
@@ -32,21 +32,28 @@ func wrapAttestationsArray(endpoint gateway.Endpoint, _ http.ResponseWriter, req
 	return nil
 }
 
-// https://ethereum.github.io/beacon-apis/#/Validator/getAttesterDuties expects posting a top-level array.
+// https://ethereum.github.io/beacon-apis/#/Validator/getAttesterDuties and
+// https://ethereum.github.io/beacon-apis/#/Validator/getLiveness expect posting a top-level array.
 // We make it more proto-friendly by wrapping it in a struct with an 'index' field.
 func wrapValidatorIndicesArray(endpoint gateway.Endpoint, _ http.ResponseWriter, req *http.Request) gateway.ErrorJson {
-	if _, ok := endpoint.PostRequest.(*attesterDutiesRequestJson); ok {
-		indices := make([]string, 0)
-		if err := json.NewDecoder(req.Body).Decode(&indices); err != nil {
-			return gateway.InternalServerErrorWithMessage(err, "could not decode body")
-		}
-		j := &attesterDutiesRequestJson{Index: indices}
-		b, err := json.Marshal(j)
-		if err != nil {
-			return gateway.InternalServerErrorWithMessage(err, "could not marshal wrapped body")
-		}
-		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	var wrap func(indices []string) interface{}
+	switch endpoint.PostRequest.(type) {
+	case *attesterDutiesRequestJson:
+		wrap = func(indices []string) interface{} { return &attesterDutiesRequestJson{Index: indices} }
+	case *livenessRequestJson:
+		wrap = func(indices []string) interface{} { return &livenessRequestJson{Index: indices} }
+	default:
+		return nil
+	}
+	indices := make([]string, 0)
+	if err := json.NewDecoder(req.Body).Decode(&indices); err != nil {
+		return gateway.InternalServerErrorWithMessage(err, "could not decode body")
 	}
+	b, err := json.Marshal(wrap(indices))
+	if err != nil {
+		return gateway.InternalServerErrorWithMessage(err, "could not marshal wrapped body")
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
 	return nil
 }
 
@@ -104,6 +111,37 @@ func wrapSyncCommitteeSignaturesArray(endpoint gateway.Endpoint, _ http.Response
 	return nil
 }
 
+// https://ethereum.github.io/beacon-APIs/#/Rewards expects posting a top-level array of validator indices,
+// which may be omitted to request rewards of all validators.
+// We make it more proto-friendly by wrapping it in a struct with an 'index' field.
+func wrapRewardsValidatorIndicesArray(endpoint gateway.Endpoint, _ http.ResponseWriter, req *http.Request) gateway.ErrorJson {
+	var wrap func(indices []string) interface{}
+	switch endpoint.PostRequest.(type) {
+	case *attestationRewardsRequestJson:
+		wrap = func(indices []string) interface{} { return &attestationRewardsRequestJson{Index: indices} }
+	case *syncCommitteeRewardsRequestJson:
+		wrap = func(indices []string) interface{} { return &syncCommitteeRewardsRequestJson{Index: indices} }
+	default:
+		return nil
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return gateway.InternalServerErrorWithMessage(err, "could not read body")
+	}
+	indices := make([]string, 0)
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &indices); err != nil {
+			return gateway.InternalServerErrorWithMessage(err, "could not decode body")
+		}
+	}
+	b, err := json.Marshal(wrap(indices))
+	if err != nil {
+		return gateway.InternalServerErrorWithMessage(err, "could not marshal wrapped body")
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return nil
+}
+
 // Posted graffiti needs to have length of 32 bytes, but client is allowed to send data of any length.
 func prepareGraffiti(endpoint gateway.Endpoint, _ http.ResponseWriter, _ *http.Request) gateway.ErrorJson {
 	if block, ok := endpoint.PostRequest.(*beaconBlockContainerJson); ok {
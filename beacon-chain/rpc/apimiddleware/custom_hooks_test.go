@@ -76,6 +76,75 @@ func TestWrapValidatorIndicesArray(t *testing.T) {
 		assert.Equal(t, "1", wrappedIndices.Index[0])
 		assert.Equal(t, "2", wrappedIndices.Index[1])
 	})
+
+	t.Run("liveness", func(t *testing.T) {
+		endpoint := gateway.Endpoint{
+			PostRequest: &livenessRequestJson{},
+		}
+		var body bytes.Buffer
+		_, err := body.WriteString(`["3"]`)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example", &body)
+
+		errJson := wrapValidatorIndicesArray(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		wrappedIndices := &livenessRequestJson{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(wrappedIndices))
+		require.Equal(t, 1, len(wrappedIndices.Index), "wrong number of wrapped items")
+		assert.Equal(t, "3", wrappedIndices.Index[0])
+	})
+}
+
+func TestWrapRewardsValidatorIndicesArray(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		endpoint := gateway.Endpoint{
+			PostRequest: &attestationRewardsRequestJson{},
+		}
+		unwrappedIndices := []string{"1", "2"}
+		unwrappedIndicesJson, err := json.Marshal(unwrappedIndices)
+		require.NoError(t, err)
+
+		var body bytes.Buffer
+		_, err = body.Write(unwrappedIndicesJson)
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example", &body)
+
+		errJson := wrapRewardsValidatorIndicesArray(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		wrappedIndices := &attestationRewardsRequestJson{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(wrappedIndices))
+		require.Equal(t, 2, len(wrappedIndices.Index), "wrong number of wrapped items")
+		assert.Equal(t, "1", wrappedIndices.Index[0])
+		assert.Equal(t, "2", wrappedIndices.Index[1])
+	})
+
+	t.Run("empty_body", func(t *testing.T) {
+		endpoint := gateway.Endpoint{
+			PostRequest: &syncCommitteeRewardsRequestJson{},
+		}
+		request := httptest.NewRequest("POST", "http://foo.example", &bytes.Buffer{})
+
+		errJson := wrapRewardsValidatorIndicesArray(endpoint, nil, request)
+		require.Equal(t, true, errJson == nil)
+		wrappedIndices := &syncCommitteeRewardsRequestJson{}
+		require.NoError(t, json.NewDecoder(request.Body).Decode(wrappedIndices))
+		assert.Equal(t, 0, len(wrappedIndices.Index))
+	})
+
+	t.Run("invalid_body", func(t *testing.T) {
+		endpoint := gateway.Endpoint{
+			PostRequest: &attestationRewardsRequestJson{},
+		}
+		var body bytes.Buffer
+		_, err := body.Write([]byte("invalid"))
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example", &body)
+
+		errJson := wrapRewardsValidatorIndicesArray(endpoint, nil, request)
+		require.Equal(t, false, errJson == nil)
+		assert.Equal(t, true, strings.Contains(errJson.Msg(), "could not decode body"))
+		assert.Equal(t, http.StatusInternalServerError, errJson.StatusCode())
+	})
 }
 
 func TestWrapSignedAggregateAndProofArray(t *testing.T) {
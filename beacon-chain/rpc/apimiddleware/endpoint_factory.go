@@ -37,6 +37,14 @@ func (f *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/beacon/pool/proposer_slashings",
 		"/eth/v1/beacon/pool/voluntary_exits",
 		"/eth/v1/beacon/pool/sync_committees",
+		"/eth/v1/beacon/rewards/blocks/{block_id}",
+		"/eth/v1/beacon/rewards/attestations/{epoch}",
+		"/eth/v1/beacon/rewards/sync_committee/{block_id}",
+		"/eth/v1/beacon/light_client/bootstrap/{block_root}",
+		"/eth/v1/beacon/light_client/updates",
+		"/eth/v1/beacon/light_client/finality_update",
+		"/eth/v1/beacon/light_client/optimistic_update",
+		"/eth/v1/beacon/deposit_snapshot",
 		"/eth/v1/node/identity",
 		"/eth/v1/node/peers",
 		"/eth/v1/node/peers/{peer_id}",
@@ -46,6 +54,7 @@ func (f *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/node/health",
 		"/eth/v1/debug/beacon/states/{state_id}",
 		"/eth/v1/debug/beacon/heads",
+		"/eth/v1/debug/fork_choice",
 		"/eth/v1/config/fork_schedule",
 		"/eth/v1/config/deposit_contract",
 		"/eth/v1/config/spec",
@@ -57,6 +66,7 @@ func (f *BeaconEndpointFactory) Paths() []string {
 		"/eth/v1/validator/aggregate_attestation",
 		"/eth/v1/validator/beacon_committee_subscriptions",
 		"/eth/v1/validator/aggregate_and_proofs",
+		"/eth/v1/validator/liveness/{epoch}",
 	}
 }
 
@@ -133,6 +143,32 @@ func (f *BeaconEndpointFactory) Create(path string) (*gateway.Endpoint, error) {
 		endpoint.Hooks = gateway.HookCollection{
 			OnPreDeserializeRequestBodyIntoContainer: []gateway.Hook{wrapSyncCommitteeSignaturesArray},
 		}
+	case "/eth/v1/beacon/rewards/blocks/{block_id}":
+		endpoint.GetResponse = &blockRewardsResponseJson{}
+	case "/eth/v1/beacon/rewards/attestations/{epoch}":
+		endpoint.PostRequest = &attestationRewardsRequestJson{}
+		endpoint.PostResponse = &attestationRewardsResponseJson{}
+		endpoint.RequestURLLiterals = []string{"epoch"}
+		endpoint.Hooks = gateway.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: []gateway.Hook{wrapRewardsValidatorIndicesArray},
+		}
+	case "/eth/v1/beacon/rewards/sync_committee/{block_id}":
+		endpoint.PostRequest = &syncCommitteeRewardsRequestJson{}
+		endpoint.PostResponse = &syncCommitteeRewardsResponseJson{}
+		endpoint.Hooks = gateway.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: []gateway.Hook{wrapRewardsValidatorIndicesArray},
+		}
+	case "/eth/v1/beacon/light_client/bootstrap/{block_root}":
+		endpoint.GetResponse = &lightClientBootstrapResponseJson{}
+	case "/eth/v1/beacon/light_client/updates":
+		endpoint.RequestQueryParams = []gateway.QueryParam{{Name: "start_period"}, {Name: "count"}}
+		endpoint.GetResponse = &lightClientUpdatesResponseJson{}
+	case "/eth/v1/beacon/light_client/finality_update":
+		endpoint.GetResponse = &lightClientFinalityUpdateResponseJson{}
+	case "/eth/v1/beacon/light_client/optimistic_update":
+		endpoint.GetResponse = &lightClientOptimisticUpdateResponseJson{}
+	case "/eth/v1/beacon/deposit_snapshot":
+		endpoint.GetResponse = &depositSnapshotResponseJson{}
 	case "/eth/v1/node/identity":
 		endpoint.GetResponse = &identityResponseJson{}
 	case "/eth/v1/node/peers":
@@ -154,6 +190,8 @@ func (f *BeaconEndpointFactory) Create(path string) (*gateway.Endpoint, error) {
 		endpoint.CustomHandlers = []gateway.CustomHandler{handleGetBeaconStateSSZ}
 	case "/eth/v1/debug/beacon/heads":
 		endpoint.GetResponse = &forkChoiceHeadsResponseJson{}
+	case "/eth/v1/debug/fork_choice":
+		endpoint.GetResponse = &forkChoiceResponseJson{}
 	case "/eth/v1/config/fork_schedule":
 		endpoint.GetResponse = &forkScheduleResponseJson{}
 	case "/eth/v1/config/deposit_contract":
@@ -192,6 +230,13 @@ func (f *BeaconEndpointFactory) Create(path string) (*gateway.Endpoint, error) {
 		endpoint.Hooks = gateway.HookCollection{
 			OnPreDeserializeRequestBodyIntoContainer: []gateway.Hook{wrapSignedAggregateAndProofArray},
 		}
+	case "/eth/v1/validator/liveness/{epoch}":
+		endpoint.PostRequest = &livenessRequestJson{}
+		endpoint.PostResponse = &livenessResponseJson{}
+		endpoint.RequestURLLiterals = []string{"epoch"}
+		endpoint.Hooks = gateway.HookCollection{
+			OnPreDeserializeRequestBodyIntoContainer: []gateway.Hook{wrapValidatorIndicesArray},
+		}
 	default:
 		return nil, errors.New("invalid path")
 	}
@@ -127,6 +127,59 @@ type submitSyncCommitteeSignaturesRequestJson struct {
 	Data []*syncCommitteeMessageJson `json:"data"`
 }
 
+// blockRewardsResponseJson is used in /beacon/rewards/blocks/{block_id} API endpoint.
+type blockRewardsResponseJson struct {
+	Data *blockRewardsJson `json:"data"`
+}
+
+// attestationRewardsRequestJson is used in /beacon/rewards/attestations/{epoch} API endpoint.
+type attestationRewardsRequestJson struct {
+	Index []string `json:"index"`
+}
+
+// attestationRewardsResponseJson is used in /beacon/rewards/attestations/{epoch} API endpoint.
+type attestationRewardsResponseJson struct {
+	Data *attestationRewardsJson `json:"data"`
+}
+
+// syncCommitteeRewardsRequestJson is used in /beacon/rewards/sync_committee/{block_id} API endpoint.
+type syncCommitteeRewardsRequestJson struct {
+	Index []string `json:"index"`
+}
+
+// syncCommitteeRewardsResponseJson is used in /beacon/rewards/sync_committee/{block_id} API endpoint.
+type syncCommitteeRewardsResponseJson struct {
+	Data []*syncCommitteeRewardJson `json:"data"`
+}
+
+// lightClientBootstrapResponseJson is used in /beacon/light_client/bootstrap/{block_root} API endpoint.
+type lightClientBootstrapResponseJson struct {
+	Version string                    `json:"version" enum:"true"`
+	Data    *lightClientBootstrapJson `json:"data"`
+}
+
+// lightClientUpdatesResponseJson is used in /beacon/light_client/updates API endpoint.
+type lightClientUpdatesResponseJson struct {
+	Data []*lightClientUpdateWithVersionJson `json:"data"`
+}
+
+// lightClientFinalityUpdateResponseJson is used in /beacon/light_client/finality_update API endpoint.
+type lightClientFinalityUpdateResponseJson struct {
+	Version string                         `json:"version" enum:"true"`
+	Data    *lightClientFinalityUpdateJson `json:"data"`
+}
+
+// lightClientOptimisticUpdateResponseJson is used in /beacon/light_client/optimistic_update API endpoint.
+type lightClientOptimisticUpdateResponseJson struct {
+	Version string                           `json:"version" enum:"true"`
+	Data    *lightClientOptimisticUpdateJson `json:"data"`
+}
+
+// depositSnapshotResponseJson is used in /beacon/deposit_snapshot API endpoint.
+type depositSnapshotResponseJson struct {
+	Data *depositSnapshotJson `json:"data"`
+}
+
 // identityResponseJson is used in /node/identity API endpoint.
 type identityResponseJson struct {
 	Data *identityJson `json:"data"`
@@ -175,6 +228,14 @@ type forkChoiceHeadsResponseJson struct {
 	Data []*forkChoiceHeadJson `json:"data"`
 }
 
+// forkChoiceResponseJson is used in /debug/fork_choice API endpoint.
+type forkChoiceResponseJson struct {
+	JustifiedCheckpoint *checkpointJson       `json:"justified_checkpoint"`
+	FinalizedCheckpoint *checkpointJson       `json:"finalized_checkpoint"`
+	VoteCount           string                `json:"vote_count"`
+	ForkChoiceNodes     []*forkChoiceNodeJson `json:"fork_choice_nodes"`
+}
+
 // forkScheduleResponseJson is used in /config/fork_schedule API endpoint.
 type forkScheduleResponseJson struct {
 	Data []*forkJson `json:"data"`
@@ -201,6 +262,16 @@ type attesterDutiesResponseJson struct {
 	Data          []*attesterDutyJson `json:"data"`
 }
 
+// livenessRequestJson is used in /validator/liveness/{epoch} API endpoint.
+type livenessRequestJson struct {
+	Index []string `json:"index"`
+}
+
+// livenessResponseJson is used in /validator/liveness/{epoch} API endpoint.
+type livenessResponseJson struct {
+	Data []*validatorLivenessJson `json:"data"`
+}
+
 // proposerDutiesResponseJson is used in /validator/duties/proposer/{epoch} API endpoint.
 type proposerDutiesResponseJson struct {
 	DependentRoot string              `json:"dependent_root" hex:"true"`
@@ -499,11 +570,106 @@ type pendingAttestationJson struct {
 	ProposerIndex   string               `json:"proposer_index"`
 }
 
+type blockRewardsJson struct {
+	ProposerIndex     string `json:"proposer_index"`
+	Total             string `json:"total"`
+	Attestations      string `json:"attestations"`
+	SyncAggregate     string `json:"sync_aggregate"`
+	ProposerSlashings string `json:"proposer_slashings"`
+	AttesterSlashings string `json:"attester_slashings"`
+}
+
+type attestationRewardsJson struct {
+	IdealRewards []*idealAttestationRewardJson `json:"ideal_rewards"`
+	TotalRewards []*totalAttestationRewardJson `json:"total_rewards"`
+}
+
+type idealAttestationRewardJson struct {
+	EffectiveBalance string `json:"effective_balance"`
+	Head             string `json:"head"`
+	Target           string `json:"target"`
+	Source           string `json:"source"`
+	Inactivity       string `json:"inactivity"`
+}
+
+type totalAttestationRewardJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	Inactivity     string `json:"inactivity"`
+}
+
+type syncCommitteeRewardJson struct {
+	ValidatorIndex string `json:"validator_index"`
+	Reward         string `json:"reward"`
+}
+
+type syncCommitteeJson struct {
+	Pubkeys         []string `json:"pubkeys" hex:"true"`
+	AggregatePubkey string   `json:"aggregate_pubkey" hex:"true"`
+}
+
+type lightClientBootstrapJson struct {
+	Header                     *beaconBlockHeaderJson `json:"header"`
+	CurrentSyncCommittee       *syncCommitteeJson     `json:"current_sync_committee"`
+	CurrentSyncCommitteeBranch []string               `json:"current_sync_committee_branch" hex:"true"`
+}
+
+type lightClientUpdateWithVersionJson struct {
+	Version string                 `json:"version" enum:"true"`
+	Data    *lightClientUpdateJson `json:"data"`
+}
+
+type lightClientUpdateJson struct {
+	AttestedHeader          *beaconBlockHeaderJson `json:"attested_header"`
+	NextSyncCommittee       *syncCommitteeJson     `json:"next_sync_committee"`
+	NextSyncCommitteeBranch []string               `json:"next_sync_committee_branch" hex:"true"`
+	FinalizedHeader         *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch          []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate           *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot           string                 `json:"signature_slot"`
+}
+
+type lightClientFinalityUpdateJson struct {
+	AttestedHeader  *beaconBlockHeaderJson `json:"attested_header"`
+	FinalizedHeader *beaconBlockHeaderJson `json:"finalized_header"`
+	FinalityBranch  []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate   *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot   string                 `json:"signature_slot"`
+}
+
+type lightClientOptimisticUpdateJson struct {
+	AttestedHeader *beaconBlockHeaderJson `json:"attested_header"`
+	SyncAggregate  *syncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot  string                 `json:"signature_slot"`
+}
+
+type depositSnapshotJson struct {
+	Finalized            []string `json:"finalized" hex:"true"`
+	DepositRoot          string   `json:"deposit_root" hex:"true"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash" hex:"true"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
+
 type forkChoiceHeadJson struct {
 	Root string `json:"root" hex:"true"`
 	Slot string `json:"slot"`
 }
 
+type forkChoiceNodeJson struct {
+	Slot           string `json:"slot"`
+	BlockRoot      string `json:"block_root" hex:"true"`
+	ParentRoot     string `json:"parent_root" hex:"true"`
+	JustifiedEpoch string `json:"justified_epoch"`
+	FinalizedEpoch string `json:"finalized_epoch"`
+	Weight         string `json:"weight"`
+	BestChild      string `json:"best_child" hex:"true"`
+	BestDescendant string `json:"best_descendant" hex:"true"`
+	ViableForHead  bool   `json:"viable_for_head"`
+}
+
 type depositContractJson struct {
 	ChainId string `json:"chain_id"`
 	Address string `json:"address"`
@@ -515,6 +681,12 @@ type syncInfoJson struct {
 	IsSyncing    bool   `json:"is_syncing"`
 }
 
+type validatorLivenessJson struct {
+	Index  string `json:"index"`
+	Epoch  string `json:"epoch"`
+	IsLive bool   `json:"is_live"`
+}
+
 type attesterDutyJson struct {
 	Pubkey                  string `json:"pubkey" hex:"true"`
 	ValidatorIndex          string `json:"validator_index"`
@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"context"
+	"math/big"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetDepositSnapshot retrieves the deposit tree snapshot at the last finalized deposit, which other
+// beacon nodes can start from instead of processing all deposit logs of the deposit contract.
+func (bs *Server) GetDepositSnapshot(ctx context.Context, _ *emptypb.Empty) (*ethpb.DepositSnapshotResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetDepositSnapshot")
+	defer span.End()
+
+	snapshot := bs.DepositFetcher.DepositSnapshot(ctx)
+	if snapshot == nil {
+		return nil, status.Error(codes.NotFound, "No finalized deposits have been pruned into a deposit snapshot yet")
+	}
+	// The deposit cache only tracks the height of the eth1 block of the last finalized deposit.
+	blockHash := snapshot.ExecutionBlockHash
+	if len(blockHash) == 0 {
+		hash, err := bs.BlockFetcher.BlockHashByHeight(ctx, new(big.Int).SetUint64(snapshot.ExecutionBlockHeight))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get eth1 block hash: %v", err)
+		}
+		blockHash = hash[:]
+	}
+
+	return &ethpb.DepositSnapshotResponse{
+		Data: &ethpb.DepositSnapshot{
+			Finalized:            snapshot.Finalized,
+			DepositRoot:          snapshot.DepositRoot,
+			DepositCount:         snapshot.DepositCount,
+			ExecutionBlockHash:   blockHash,
+			ExecutionBlockHeight: snapshot.ExecutionBlockHeight,
+		},
+	}, nil
+}
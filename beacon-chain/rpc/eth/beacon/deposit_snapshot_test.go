@@ -0,0 +1,60 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGetDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	depositTrie, err := trieutil.GenerateTrieFromItems(
+		[][]byte{{1}, {2}, {3}}, params.BeaconConfig().DepositContractTreeDepth,
+	)
+	require.NoError(t, err)
+	finalized, err := depositTrie.FinalizedRoots(3)
+	require.NoError(t, err)
+	root := depositTrie.HashTreeRoot()
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	require.NoError(t, depositCache.InsertDepositSnapshot(ctx, &ethpbalpha.DepositSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          root[:],
+		DepositCount:         3,
+		ExecutionBlockHeight: 100,
+	}))
+	blockHash := bytesutil.PadTo([]byte("hash"), 32)
+	powChain := mockPOW.NewPOWChain()
+	powChain.HashesByHeight[100] = blockHash
+
+	s := &Server{
+		DepositFetcher: depositCache,
+		BlockFetcher:   powChain,
+	}
+	resp, err := s.GetDepositSnapshot(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.DeepEqual(t, finalized, resp.Data.Finalized)
+	assert.DeepEqual(t, root[:], resp.Data.DepositRoot)
+	assert.Equal(t, uint64(3), resp.Data.DepositCount)
+	assert.DeepEqual(t, blockHash, resp.Data.ExecutionBlockHash)
+	assert.Equal(t, uint64(100), resp.Data.ExecutionBlockHeight)
+}
+
+func TestGetDepositSnapshot_NoSnapshot(t *testing.T) {
+	depositCache, err := depositcache.New()
+	require.NoError(t, err)
+	s := &Server{
+		DepositFetcher: depositCache,
+	}
+	_, err = s.GetDepositSnapshot(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "No finalized deposits have been pruned", err)
+}
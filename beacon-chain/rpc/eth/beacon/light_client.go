@@ -0,0 +1,124 @@
+package beacon
+
+import (
+	"context"
+
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/migration"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxRequestLightClientUpdates is the maximum number of light client updates returned by a single request.
+const maxRequestLightClientUpdates = 128
+
+// GetLightClientBootstrap retrieves the light client bootstrap data for the requested trusted block root.
+func (bs *Server) GetLightClientBootstrap(ctx context.Context, req *ethpbv2.LightClientBootstrapRequest) (*ethpbv2.LightClientBootstrapResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetLightClientBootstrap")
+	defer span.End()
+
+	root := bytesutil.ToBytes32(req.BlockRoot)
+	blk, err := bs.BeaconDB.Block(ctx, root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block: %v", err)
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, status.Error(codes.NotFound, "Could not find requested block")
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Light client bootstrap is not supported for Phase 0 blocks")
+	}
+	st, err := bs.StateGenService.StateByRoot(ctx, root)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block state: %v", err)
+	}
+	if st == nil || st.IsNil() {
+		return nil, status.Error(codes.NotFound, "Could not find the state of the requested block")
+	}
+	header, err := migration.BlockIfaceToV1BlockHeader(blk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block header: %v", err)
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get current sync committee: %v", err)
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute current sync committee proof: %v", err)
+	}
+
+	return &ethpbv2.LightClientBootstrapResponse{
+		Version: ethpbv2.Version_ALTAIR,
+		Data: &ethpbv2.LightClientBootstrap{
+			Header: header.Message,
+			CurrentSyncCommittee: &ethpbv2.SyncCommittee{
+				Pubkeys:         committee.Pubkeys,
+				AggregatePubkey: committee.AggregatePubkey,
+			},
+			CurrentSyncCommitteeBranch: branch,
+		},
+	}, nil
+}
+
+// ListLightClientUpdates retrieves the best light client update of each requested sync committee period.
+// Updates are returned for consecutive periods, stopping at the first period without an update.
+func (bs *Server) ListLightClientUpdates(ctx context.Context, req *ethpbv2.LightClientUpdatesByRangeRequest) (*ethpbv2.LightClientUpdatesByRangeResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.ListLightClientUpdates")
+	defer span.End()
+
+	if req.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Count must be greater than 0")
+	}
+	count := req.Count
+	if count > maxRequestLightClientUpdates {
+		count = maxRequestLightClientUpdates
+	}
+	updates, err := bs.BeaconDB.LightClientUpdates(ctx, req.StartPeriod, count)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get light client updates: %v", err)
+	}
+
+	data := make([]*ethpbv2.LightClientUpdateWithVersion, len(updates))
+	for i, u := range updates {
+		data[i] = &ethpbv2.LightClientUpdateWithVersion{
+			Version: ethpbv2.Version_ALTAIR,
+			Data:    u,
+		}
+	}
+	return &ethpbv2.LightClientUpdatesByRangeResponse{Data: data}, nil
+}
+
+// GetLightClientFinalityUpdate retrieves the latest light client finality update produced by the node.
+func (bs *Server) GetLightClientFinalityUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientFinalityUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientFinalityUpdate")
+	defer span.End()
+
+	update := bs.LightClientFetcher.LatestLightClientFinalityUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client finality update is available")
+	}
+	return &ethpbv2.LightClientFinalityUpdateResponse{
+		Version: ethpbv2.Version_ALTAIR,
+		Data:    update,
+	}, nil
+}
+
+// GetLightClientOptimisticUpdate retrieves the latest light client optimistic update produced by the node.
+func (bs *Server) GetLightClientOptimisticUpdate(ctx context.Context, _ *emptypb.Empty) (*ethpbv2.LightClientOptimisticUpdateResponse, error) {
+	_, span := trace.StartSpan(ctx, "beacon.GetLightClientOptimisticUpdate")
+	defer span.End()
+
+	update := bs.LightClientFetcher.LatestLightClientOptimisticUpdate()
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No light client optimistic update is available")
+	}
+	return &ethpbv2.LightClientOptimisticUpdateResponse{
+		Version: ethpbv2.Version_ALTAIR,
+		Data:    update,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServer_GetLightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	st, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+	blk := testutil.NewBeaconBlockAltair()
+	blk.Block.Slot = 1
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	signed, err := wrapper.WrappedAltairSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, signed))
+	phase0Blk := testutil.NewBeaconBlock()
+	phase0Root, err := phase0Blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(phase0Blk)))
+	noStateBlk := testutil.NewBeaconBlockAltair()
+	noStateBlk.Block.Slot = 2
+	noStateRoot, err := noStateBlk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	signed, err = wrapper.WrappedAltairSignedBeaconBlock(noStateBlk)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, signed))
+	stateGen := stategen.NewMockService()
+	stateGen.AddStateForRoot(st, root)
+	bs := &Server{
+		BeaconDB:        beaconDB,
+		StateGenService: stateGen,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		resp, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+		headerRoot, err := resp.Data.Header.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, root, headerRoot)
+		committee, err := st.CurrentSyncCommittee()
+		require.NoError(t, err)
+		assert.DeepEqual(t, committee.Pubkeys, resp.Data.CurrentSyncCommittee.Pubkeys)
+		assert.Equal(t, 5, len(resp.Data.CurrentSyncCommitteeBranch))
+	})
+	t.Run("phase 0 block", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: phase0Root[:]})
+		assert.ErrorContains(t, "not supported for Phase 0 blocks", err)
+	})
+	t.Run("unknown block", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: make([]byte, 32)})
+		assert.ErrorContains(t, "Could not find requested block", err)
+	})
+	t.Run("unknown state", func(t *testing.T) {
+		_, err := bs.GetLightClientBootstrap(ctx, &ethpbv2.LightClientBootstrapRequest{BlockRoot: noStateRoot[:]})
+		assert.ErrorContains(t, "Could not find the state of the requested block", err)
+	})
+}
+
+func TestServer_ListLightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	for period := uint64(0); period < 2; period++ {
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, &ethpbv2.LightClientUpdate{
+			AttestedHeader: &ethpbv1.BeaconBlockHeader{Slot: types.Slot(period * 8192)},
+		}))
+	}
+	bs := &Server{BeaconDB: beaconDB}
+
+	resp, err := bs.ListLightClientUpdates(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 0, Count: 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Data[0].Version)
+	assert.Equal(t, types.Slot(8192), resp.Data[1].Data.AttestedHeader.Slot)
+
+	resp, err = bs.ListLightClientUpdates(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(resp.Data))
+
+	_, err = bs.ListLightClientUpdates(ctx, &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 0, Count: 0})
+	assert.ErrorContains(t, "Count must be greater than 0", err)
+}
+
+func TestServer_GetLightClientFinalityUpdate(t *testing.T) {
+	ctx := context.Background()
+	update := &ethpbv2.LightClientFinalityUpdate{SignatureSlot: 5}
+	bs := &Server{LightClientFetcher: &mock.ChainService{LightClientFinalityUpdate: update}}
+	resp, err := bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+	assert.Equal(t, update, resp.Data)
+
+	bs = &Server{LightClientFetcher: &mock.ChainService{}}
+	_, err = bs.GetLightClientFinalityUpdate(ctx, &emptypb.Empty{})
+	assert.ErrorContains(t, "No light client finality update is available", err)
+}
+
+func TestServer_GetLightClientOptimisticUpdate(t *testing.T) {
+	ctx := context.Background()
+	update := &ethpbv2.LightClientOptimisticUpdate{SignatureSlot: 5}
+	bs := &Server{LightClientFetcher: &mock.ChainService{LightClientOptimisticUpdate: update}}
+	resp, err := bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, ethpbv2.Version_ALTAIR, resp.Version)
+	assert.Equal(t, update, resp.Data)
+
+	bs = &Server{LightClientFetcher: &mock.ChainService{}}
+	_, err = bs.GetLightClientOptimisticUpdate(ctx, &emptypb.Empty{})
+	assert.ErrorContains(t, "No light client optimistic update is available", err)
+}
@@ -434,6 +434,7 @@ func TestSubmitAttesterSlashing_Ok(t *testing.T) {
 	s := &Server{
 		ChainInfoFetcher: &chainMock.ChainService{State: state},
 		SlashingsPool:    &slashings.PoolMock{},
+		SlashingReceiver: &chainMock.ChainService{},
 		Broadcaster:      broadcaster,
 	}
 
@@ -0,0 +1,281 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	v "github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/version"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetBlockRewards retrieves the rewards earned by the proposer of the requested block, broken down by the
+// block's operations.
+func (bs *Server) GetBlockRewards(ctx context.Context, req *ethpbv2.BlockRequestV2) (*ethpbv2.BlockRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.GetBlockRewards")
+	defer span.End()
+
+	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
+	if err := handleGetBlock(blk, err); err != nil {
+		return nil, err
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Block rewards are not supported for Phase 0 blocks")
+	}
+	st, err := bs.blockPreState(ctx, blk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block pre-state: %v", err)
+	}
+	body := blk.Block().Body()
+	proposerIndex := blk.Block().ProposerIndex()
+
+	proposerSlashingsReward, err := proposerBalanceDelta(st, proposerIndex, func() (state.BeaconState, error) {
+		return b.ProcessProposerSlashings(ctx, st, body.ProposerSlashings(), v.SlashValidator)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process proposer slashings: %v", err)
+	}
+	attesterSlashingsReward, err := proposerBalanceDelta(st, proposerIndex, func() (state.BeaconState, error) {
+		return b.ProcessAttesterSlashings(ctx, st, body.AttesterSlashings(), v.SlashValidator)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process attester slashings: %v", err)
+	}
+	attestationsReward, err := proposerBalanceDelta(st, proposerIndex, func() (state.BeaconState, error) {
+		return altair.ProcessAttestationsNoVerifySignature(ctx, st, blk)
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not process attestations: %v", err)
+	}
+
+	syncAggregate, err := body.SyncAggregate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync aggregate: %v", err)
+	}
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get total active balance: %v", err)
+	}
+	proposerReward, _, err := altair.SyncRewards(totalBalance)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync rewards: %v", err)
+	}
+	syncAggregateReward := syncAggregate.SyncCommitteeBits.Count() * proposerReward
+
+	return &ethpbv2.BlockRewardsResponse{
+		Data: &ethpbv2.BlockRewards{
+			ProposerIndex:     proposerIndex,
+			Total:             attestationsReward + syncAggregateReward + proposerSlashingsReward + attesterSlashingsReward,
+			Attestations:      attestationsReward,
+			SyncAggregate:     syncAggregateReward,
+			ProposerSlashings: proposerSlashingsReward,
+			AttesterSlashings: attesterSlashingsReward,
+		},
+	}, nil
+}
+
+// ListAttestationRewards retrieves the attestation rewards of the requested validators for the requested epoch,
+// along with the rewards an ideal validator would have earned for each effective balance.
+func (bs *Server) ListAttestationRewards(ctx context.Context, req *ethpbv2.AttestationRewardsRequest) (*ethpbv2.AttestationRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.ListAttestationRewards")
+	defer span.End()
+
+	// Rewards for the attestations of an epoch are applied at the end of the next epoch.
+	currentEpoch := core.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	if req.Epoch+2 > currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Attestation rewards for epoch %d are not available before epoch %d, current epoch is %d",
+			req.Epoch,
+			req.Epoch+2,
+			currentEpoch,
+		)
+	}
+	startSlot, err := core.StartSlot(req.Epoch + 2)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid epoch: %v", err)
+	}
+	st, err := bs.StateGenService.StateBySlot(ctx, startSlot-1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+	}
+	if st.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Attestation rewards are not supported for Phase 0 epochs")
+	}
+
+	cfg := params.BeaconConfig()
+	idealBalances := make([]uint64, 0, cfg.MaxEffectiveBalance/cfg.EffectiveBalanceIncrement)
+	for bal := cfg.EffectiveBalanceIncrement; bal <= cfg.MaxEffectiveBalance; bal += cfg.EffectiveBalanceIncrement {
+		idealBalances = append(idealBalances, bal)
+	}
+	details, ideal, err := altair.EpochAttestationsDeltaDetails(ctx, st, idealBalances)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not compute attestation rewards: %v", err)
+	}
+
+	indices := req.Index
+	if len(indices) == 0 {
+		indices = make([]types.ValidatorIndex, len(details))
+		for i := range details {
+			indices[i] = types.ValidatorIndex(i)
+		}
+	}
+	totalRewards := make([]*ethpbv2.TotalAttestationReward, len(indices))
+	for i, idx := range indices {
+		if uint64(idx) >= uint64(len(details)) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid validator index %d", idx)
+		}
+		d := details[idx]
+		totalRewards[i] = &ethpbv2.TotalAttestationReward{
+			ValidatorIndex: idx,
+			Head:           int64(d.HeadReward),
+			Target:         int64(d.TargetReward) - int64(d.TargetPenalty),
+			Source:         int64(d.SourceReward) - int64(d.SourcePenalty),
+			Inactivity:     -int64(d.InactivityPenalty),
+		}
+	}
+	idealRewards := make([]*ethpbv2.IdealAttestationReward, len(ideal))
+	for i, d := range ideal {
+		idealRewards[i] = &ethpbv2.IdealAttestationReward{
+			EffectiveBalance: idealBalances[i],
+			Head:             int64(d.HeadReward),
+			Target:           int64(d.TargetReward) - int64(d.TargetPenalty),
+			Source:           int64(d.SourceReward) - int64(d.SourcePenalty),
+			Inactivity:       -int64(d.InactivityPenalty),
+		}
+	}
+
+	return &ethpbv2.AttestationRewardsResponse{
+		Data: &ethpbv2.AttestationRewards{
+			IdealRewards: idealRewards,
+			TotalRewards: totalRewards,
+		},
+	}, nil
+}
+
+// ListSyncCommitteeRewards retrieves the sync committee rewards of the requested validators for the requested block.
+// Members of the sync committee who did not participate have a negative reward.
+func (bs *Server) ListSyncCommitteeRewards(ctx context.Context, req *ethpbv2.SyncCommitteeRewardsRequest) (*ethpbv2.SyncCommitteeRewardsResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon.ListSyncCommitteeRewards")
+	defer span.End()
+
+	blk, err := bs.blockFromBlockID(ctx, req.BlockId)
+	if err := handleGetBlock(blk, err); err != nil {
+		return nil, err
+	}
+	if blk.Version() == version.Phase0 {
+		return nil, status.Error(codes.InvalidArgument, "Sync committee rewards are not supported for Phase 0 blocks")
+	}
+	st, err := bs.blockPreState(ctx, blk)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get block pre-state: %v", err)
+	}
+	syncAggregate, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync aggregate: %v", err)
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync committee: %v", err)
+	}
+	if committee == nil {
+		return nil, status.Error(codes.Internal, "Nil current sync committee in state")
+	}
+	totalBalance, err := helpers.TotalActiveBalance(st)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get total active balance: %v", err)
+	}
+	_, participantReward, err := altair.SyncRewards(totalBalance)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get sync rewards: %v", err)
+	}
+
+	// A validator may appear several times in the sync committee, its rewards are summed.
+	rewards := make(map[types.ValidatorIndex]int64, len(committee.Pubkeys))
+	order := make([]types.ValidatorIndex, 0, len(committee.Pubkeys))
+	for i, pubkey := range committee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "Could not find validator index for sync committee member %d", i)
+		}
+		if _, ok := rewards[idx]; !ok {
+			order = append(order, idx)
+		}
+		if syncAggregate.SyncCommitteeBits.BitAt(uint64(i)) {
+			rewards[idx] += int64(participantReward)
+		} else {
+			rewards[idx] -= int64(participantReward)
+		}
+	}
+
+	requested := make(map[types.ValidatorIndex]bool, len(req.Index))
+	for _, idx := range req.Index {
+		requested[idx] = true
+	}
+	data := make([]*ethpbv2.SyncCommitteeReward, 0, len(order))
+	for _, idx := range order {
+		if len(requested) > 0 && !requested[idx] {
+			continue
+		}
+		data = append(data, &ethpbv2.SyncCommitteeReward{
+			ValidatorIndex: idx,
+			Reward:         rewards[idx],
+		})
+	}
+	return &ethpbv2.SyncCommitteeRewardsResponse{Data: data}, nil
+}
+
+// blockPreState returns the state the block is applied to, which is the parent state advanced to the block's slot.
+func (bs *Server) blockPreState(ctx context.Context, blk block.SignedBeaconBlock) (state.BeaconState, error) {
+	parentRoot := bytesutil.ToBytes32(blk.Block().ParentRoot())
+	st, err := bs.StateGenService.StateByRoot(ctx, parentRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	if st == nil || st.IsNil() {
+		return nil, errors.New("nil parent state")
+	}
+	st, err = transition.ProcessSlots(ctx, st, blk.Block().Slot())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process slots")
+	}
+	return st, nil
+}
+
+// proposerBalanceDelta returns the increase of the proposer's balance caused by the processing function, which
+// mutates the state. A decrease of the balance is reported as no reward.
+func proposerBalanceDelta(
+	st state.BeaconState,
+	proposerIndex types.ValidatorIndex,
+	process func() (state.BeaconState, error),
+) (uint64, error) {
+	before, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, err
+	}
+	st, err = process()
+	if err != nil {
+		return 0, err
+	}
+	after, err := st.BalanceAtIndex(proposerIndex)
+	if err != nil {
+		return 0, err
+	}
+	if after < before {
+		return 0, nil
+	}
+	return after - before, nil
+}
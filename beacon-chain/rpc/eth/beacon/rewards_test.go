@@ -0,0 +1,200 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/transition"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// setupRewardsBlock saves an Altair block at slot 1 whose sync aggregate has all bits set but the first one,
+// and registers its pre-state with a mock state manager.
+func setupRewardsBlock(t *testing.T) (*Server, *ethpbalpha.SignedBeaconBlockAltair, uint64) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	st, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+	parentRoot := bytesutil.ToBytes32([]byte("parent"))
+	stateGen := stategen.NewMockService()
+	stateGen.AddStateForRoot(st.Copy(), parentRoot)
+
+	preState, err := transition.ProcessSlots(ctx, st, 1)
+	require.NoError(t, err)
+	proposerIndex, err := helpers.BeaconProposerIndex(preState)
+	require.NoError(t, err)
+	totalBalance, err := helpers.TotalActiveBalance(preState)
+	require.NoError(t, err)
+	proposerReward, _, err := altair.SyncRewards(totalBalance)
+	require.NoError(t, err)
+
+	blk := testutil.NewBeaconBlockAltair()
+	blk.Block.Slot = 1
+	blk.Block.ProposerIndex = proposerIndex
+	blk.Block.ParentRoot = parentRoot[:]
+	bits := bitfield.NewBitvector512()
+	for i := uint64(1); i < params.BeaconConfig().SyncCommitteeSize; i++ {
+		bits.SetBitAt(i, true)
+	}
+	blk.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+	signed, err := wrapper.WrappedAltairSignedBeaconBlock(blk)
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, signed))
+
+	bs := &Server{
+		BeaconDB:        beaconDB,
+		StateGenService: stateGen,
+	}
+	return bs, blk, proposerReward
+}
+
+func TestServer_GetBlockRewards(t *testing.T) {
+	bs, blk, proposerReward := setupRewardsBlock(t)
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	resp, err := bs.GetBlockRewards(context.Background(), &ethpbv2.BlockRequestV2{BlockId: root[:]})
+	require.NoError(t, err)
+	syncReward := (params.BeaconConfig().SyncCommitteeSize - 1) * proposerReward
+	assert.Equal(t, blk.Block.ProposerIndex, resp.Data.ProposerIndex)
+	assert.Equal(t, syncReward, resp.Data.SyncAggregate)
+	assert.Equal(t, uint64(0), resp.Data.Attestations)
+	assert.Equal(t, uint64(0), resp.Data.ProposerSlashings)
+	assert.Equal(t, uint64(0), resp.Data.AttesterSlashings)
+	assert.Equal(t, syncReward, resp.Data.Total)
+}
+
+func TestServer_GetBlockRewards_Phase0(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	blk := testutil.NewBeaconBlock()
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(blk)))
+
+	bs := &Server{BeaconDB: beaconDB}
+	_, err = bs.GetBlockRewards(ctx, &ethpbv2.BlockRequestV2{BlockId: root[:]})
+	assert.ErrorContains(t, "not supported for Phase 0 blocks", err)
+}
+
+func TestServer_ListSyncCommitteeRewards(t *testing.T) {
+	// The mock state manager does not copy states, a new block pre-state is set up for each request.
+	setup := func(t *testing.T) (*Server, [32]byte, types.ValidatorIndex, uint64) {
+		bs, blk, _ := setupRewardsBlock(t)
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		st := bs.StateGenService.(*stategen.MockStateManager).StatesByRoot[bytesutil.ToBytes32(blk.Block.ParentRoot)]
+		totalBalance, err := helpers.TotalActiveBalance(st)
+		require.NoError(t, err)
+		_, participantReward, err := altair.SyncRewards(totalBalance)
+		require.NoError(t, err)
+		committee, err := st.CurrentSyncCommittee()
+		require.NoError(t, err)
+		absent, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(committee.Pubkeys[0]))
+		require.Equal(t, true, ok)
+		return bs, root, absent, participantReward
+	}
+
+	t.Run("all members", func(t *testing.T) {
+		bs, root, absent, participantReward := setup(t)
+		resp, err := bs.ListSyncCommitteeRewards(context.Background(), &ethpbv2.SyncCommitteeRewardsRequest{BlockId: root[:]})
+		require.NoError(t, err)
+		assert.Equal(t, absent, resp.Data[0].ValidatorIndex)
+		seen := make(map[types.ValidatorIndex]bool)
+		total := int64(0)
+		for _, r := range resp.Data {
+			assert.Equal(t, false, seen[r.ValidatorIndex], "Duplicate validator %d", r.ValidatorIndex)
+			seen[r.ValidatorIndex] = true
+			total += r.Reward
+		}
+		// All members but the first one participated.
+		want := int64(params.BeaconConfig().SyncCommitteeSize-2) * int64(participantReward)
+		assert.Equal(t, want, total)
+	})
+	t.Run("requested indices", func(t *testing.T) {
+		bs, root, absent, _ := setup(t)
+		resp, err := bs.ListSyncCommitteeRewards(context.Background(), &ethpbv2.SyncCommitteeRewardsRequest{
+			BlockId: root[:],
+			Index:   []types.ValidatorIndex{absent},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, absent, resp.Data[0].ValidatorIndex)
+	})
+}
+
+func TestServer_ListAttestationRewards(t *testing.T) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+	slot, err := core.StartSlot(2)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot-1))
+	participation := make([]byte, st.NumValidators())
+	for i := range participation {
+		participation[i] = altair.AddValidatorFlag(participation[i], params.BeaconConfig().TimelySourceFlagIndex)
+		participation[i] = altair.AddValidatorFlag(participation[i], params.BeaconConfig().TimelyTargetFlagIndex)
+		participation[i] = altair.AddValidatorFlag(participation[i], params.BeaconConfig().TimelyHeadFlagIndex)
+	}
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+	stateGen := stategen.NewMockService()
+	stateGen.AddStateForSlot(st, slot-1)
+	currentSlot := slot
+	bs := &Server{
+		StateGenService:    stateGen,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+
+	t.Run("all validators", func(t *testing.T) {
+		resp, err := bs.ListAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 0})
+		require.NoError(t, err)
+		cfg := params.BeaconConfig()
+		require.Equal(t, int(cfg.MaxEffectiveBalance/cfg.EffectiveBalanceIncrement), len(resp.Data.IdealRewards))
+		require.Equal(t, 64, len(resp.Data.TotalRewards))
+		ideal := resp.Data.IdealRewards[len(resp.Data.IdealRewards)-1]
+		assert.Equal(t, cfg.MaxEffectiveBalance, ideal.EffectiveBalance)
+		for i, r := range resp.Data.TotalRewards {
+			assert.Equal(t, types.ValidatorIndex(i), r.ValidatorIndex)
+			// All validators attested perfectly and have the maximum effective balance.
+			assert.Equal(t, ideal.Head, r.Head)
+			assert.Equal(t, ideal.Target, r.Target)
+			assert.Equal(t, ideal.Source, r.Source)
+			assert.Equal(t, int64(0), r.Inactivity)
+		}
+		assert.Equal(t, true, ideal.Head > 0)
+	})
+	t.Run("requested indices", func(t *testing.T) {
+		resp, err := bs.ListAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{
+			Epoch: 0,
+			Index: []types.ValidatorIndex{3, 1},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(resp.Data.TotalRewards))
+		assert.Equal(t, types.ValidatorIndex(3), resp.Data.TotalRewards[0].ValidatorIndex)
+		assert.Equal(t, types.ValidatorIndex(1), resp.Data.TotalRewards[1].ValidatorIndex)
+	})
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := bs.ListAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{
+			Epoch: 0,
+			Index: []types.ValidatorIndex{64},
+		})
+		assert.ErrorContains(t, "Invalid validator index 64", err)
+	})
+	t.Run("epoch not finished", func(t *testing.T) {
+		_, err := bs.ListAttestationRewards(ctx, &ethpbv2.AttestationRewardsRequest{Epoch: 1})
+		assert.ErrorContains(t, "not available before epoch 3", err)
+	})
+}
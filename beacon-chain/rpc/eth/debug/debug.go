@@ -3,11 +3,14 @@ package debug
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/eth/helpers"
 	statev2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/proto/migration"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
@@ -130,3 +133,56 @@ func (ds *Server) ListForkChoiceHeads(ctx context.Context, _ *emptypb.Empty) (*e
 
 	return resp, nil
 }
+
+// GetForkChoice returns all the nodes of the fork choice store, along with the justified and finalized
+// checkpoints and the number of validator votes tracked by fork choice.
+func (ds *Server) GetForkChoice(ctx context.Context, _ *emptypb.Empty) (*ethpbv1.ForkChoiceResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "debug.GetForkChoice")
+	defer span.End()
+
+	store := ds.HeadFetcher.ProtoArrayStore()
+	if store == nil {
+		return nil, status.Error(codes.Internal, "Fork choice store is not initialized")
+	}
+	nodes := store.CopyNodes()
+	// The store's nodes are referenced by their index, map them to the block roots.
+	rootAt := func(i uint64) []byte {
+		if i == protoarray.NonExistentNode || i >= uint64(len(nodes)) {
+			return []byte{}
+		}
+		r := nodes[i].Root()
+		return r[:]
+	}
+	forkChoiceNodes := make([]*ethpbv1.ForkChoiceNode, len(nodes))
+	for i, n := range nodes {
+		r := n.Root()
+		forkChoiceNodes[i] = &ethpbv1.ForkChoiceNode{
+			Slot:           n.Slot(),
+			BlockRoot:      r[:],
+			ParentRoot:     rootAt(n.Parent()),
+			JustifiedEpoch: n.JustifiedEpoch(),
+			FinalizedEpoch: n.FinalizedEpoch(),
+			Weight:         n.Weight(),
+			BestChild:      rootAt(n.BestChild()),
+			BestDescendant: rootAt(n.BestDescendant()),
+			ViableForHead:  store.ViableForHead(n),
+		}
+	}
+
+	return &ethpbv1.ForkChoiceResponse{
+		JustifiedCheckpoint: checkpointToV1(ds.FinalizationFetcher.CurrentJustifiedCheckpt()),
+		FinalizedCheckpoint: checkpointToV1(ds.FinalizationFetcher.FinalizedCheckpt()),
+		VoteCount:           ds.HeadFetcher.ForkChoiceVotesCount(),
+		ForkChoiceNodes:     forkChoiceNodes,
+	}, nil
+}
+
+func checkpointToV1(c *ethpbalpha.Checkpoint) *ethpbv1.Checkpoint {
+	if c == nil {
+		return nil
+	}
+	return &ethpbv1.Checkpoint{
+		Epoch: c.Epoch,
+		Root:  bytesutil.SafeCopyBytes(c.Root),
+	}
+}
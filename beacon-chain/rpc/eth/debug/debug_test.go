@@ -6,9 +6,11 @@ import (
 
 	types "github.com/prysmaticlabs/eth2-types"
 	blockchainmock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/testutil"
 	ethpbv1 "github.com/prysmaticlabs/prysm/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/proto/eth/v2"
+	ethpbalpha "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	sharedtestutil "github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
@@ -154,3 +156,49 @@ func TestListForkChoiceHeads(t *testing.T) {
 		assert.Equal(t, true, found, "Expected head not found")
 	}
 }
+
+func TestGetForkChoice(t *testing.T) {
+	ctx := context.Background()
+	genesis := bytesutil.ToBytes32([]byte("genesis"))
+	a := bytesutil.ToBytes32([]byte("a"))
+	b := bytesutil.ToBytes32([]byte("b"))
+	fc := protoarray.New(0, 0, [32]byte{})
+	require.NoError(t, fc.ProcessBlock(ctx, 0, genesis, [32]byte{}, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 1, a, genesis, [32]byte{}, 0, 0))
+	require.NoError(t, fc.ProcessBlock(ctx, 2, b, a, [32]byte{}, 0, 0))
+
+	server := &Server{
+		HeadFetcher: &blockchainmock.ChainService{ForkChoiceStore: fc.Store(), VotesCount: 4},
+		FinalizationFetcher: &blockchainmock.ChainService{
+			CurrentJustifiedCheckPoint: &ethpbalpha.Checkpoint{Epoch: 1, Root: a[:]},
+			FinalizedCheckPoint:        &ethpbalpha.Checkpoint{Epoch: 0, Root: genesis[:]},
+		},
+	}
+	resp, err := server.GetForkChoice(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(1), resp.JustifiedCheckpoint.Epoch)
+	assert.DeepEqual(t, a[:], resp.JustifiedCheckpoint.Root)
+	assert.DeepEqual(t, genesis[:], resp.FinalizedCheckpoint.Root)
+	assert.Equal(t, uint64(4), resp.VoteCount)
+	require.Equal(t, 3, len(resp.ForkChoiceNodes))
+
+	root := resp.ForkChoiceNodes[0]
+	assert.DeepEqual(t, genesis[:], root.BlockRoot)
+	assert.DeepEqual(t, []byte{}, root.ParentRoot)
+	assert.DeepEqual(t, a[:], root.BestChild)
+	assert.DeepEqual(t, a[:], root.BestDescendant)
+	assert.Equal(t, true, root.ViableForHead)
+
+	leaf := resp.ForkChoiceNodes[2]
+	assert.Equal(t, types.Slot(2), leaf.Slot)
+	assert.DeepEqual(t, b[:], leaf.BlockRoot)
+	assert.DeepEqual(t, a[:], leaf.ParentRoot)
+	assert.DeepEqual(t, []byte{}, leaf.BestChild)
+	assert.DeepEqual(t, []byte{}, leaf.BestDescendant)
+}
+
+func TestGetForkChoice_NoStore(t *testing.T) {
+	server := &Server{HeadFetcher: &blockchainmock.ChainService{}}
+	_, err := server.GetForkChoice(context.Background(), &emptypb.Empty{})
+	assert.ErrorContains(t, "Fork choice store is not initialized", err)
+}
@@ -547,6 +547,36 @@ func (vs *Server) SubmitContributionAndProofs(ctx context.Context, req *ethpbv2.
 	return &empty.Empty{}, nil
 }
 
+// GetLiveness returns whether the beacon node saw attestations or blocks of the requested
+// validators in an epoch. The liveness of validators is only known for the current and
+// previous epochs.
+func (vs *Server) GetLiveness(ctx context.Context, req *ethpbv1.LivenessRequest) (*ethpbv1.LivenessResponse, error) {
+	_, span := trace.StartSpan(ctx, "validator.GetLiveness")
+	defer span.End()
+
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Error(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	currentEpoch := core.SlotToEpoch(vs.TimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch || req.Epoch+1 < currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Liveness is only available for the current epoch %d and the previous epoch, not for epoch %d",
+			currentEpoch,
+			req.Epoch,
+		)
+	}
+	data := make([]*ethpbv1.ValidatorLiveness, len(req.Index))
+	for i, index := range req.Index {
+		data[i] = &ethpbv1.ValidatorLiveness{
+			Index:  index,
+			Epoch:  req.Epoch,
+			IsLive: cache.Liveness.IsLive(req.Epoch, index),
+		}
+	}
+	return &ethpbv1.LivenessResponse{Data: data}, nil
+}
+
 // attestationDependentRoot is get_block_root_at_slot(state, compute_start_slot_at_epoch(epoch - 1) - 1)
 // or the genesis block root in the case of underflow.
 func attestationDependentRoot(s state.BeaconState, epoch types.Epoch) ([]byte, error) {
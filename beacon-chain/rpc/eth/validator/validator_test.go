@@ -1425,3 +1425,32 @@ func TestSubmitContributionAndProofs(t *testing.T) {
 		require.DeepEqual(t, expectedContributions, savedMsgs)
 	})
 }
+
+func TestGetLiveness(t *testing.T) {
+	epoch := types.Epoch(1000)
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)) + 1
+	vs := &Server{
+		TimeFetcher: &mockChain.ChainService{Slot: &slot},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+	cache.Liveness.MarkLive(epoch-1, []uint64{1, 2})
+
+	resp, err := vs.GetLiveness(context.Background(), &ethpbv1.LivenessRequest{Epoch: epoch - 1, Index: []types.ValidatorIndex{1, 3}})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Data))
+	assert.DeepEqual(t, &ethpbv1.ValidatorLiveness{Index: 1, Epoch: epoch - 1, IsLive: true}, resp.Data[0])
+	assert.DeepEqual(t, &ethpbv1.ValidatorLiveness{Index: 3, Epoch: epoch - 1, IsLive: false}, resp.Data[1])
+
+	_, err = vs.GetLiveness(context.Background(), &ethpbv1.LivenessRequest{Epoch: epoch + 1})
+	assert.ErrorContains(t, "Liveness is only available", err)
+	_, err = vs.GetLiveness(context.Background(), &ethpbv1.LivenessRequest{Epoch: epoch - 2})
+	assert.ErrorContains(t, "Liveness is only available", err)
+}
+
+func TestGetLiveness_SyncNotReady(t *testing.T) {
+	vs := &Server{
+		SyncChecker: &mockSync.Sync{IsSyncing: true},
+	}
+	_, err := vs.GetLiveness(context.Background(), &ethpbv1.LivenessRequest{})
+	assert.ErrorContains(t, "Syncing to latest head, not ready to respond", err)
+}
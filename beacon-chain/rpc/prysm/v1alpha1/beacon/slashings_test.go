@@ -62,8 +62,9 @@ func TestServer_SubmitAttesterSlashing(t *testing.T) {
 		HeadFetcher: &mock.ChainService{
 			State: st,
 		},
-		SlashingsPool: slashings.NewPool(),
-		Broadcaster:   mb,
+		SlashingsPool:    slashings.NewPool(),
+		SlashingReceiver: &mock.ChainService{},
+		Broadcaster:      mb,
 	}
 
 	slashing, err := testutil.GenerateAttesterSlashingForValidator(st, privs[2], types.ValidatorIndex(2))
@@ -140,8 +141,9 @@ func TestServer_SubmitAttesterSlashing_DontBroadcast(t *testing.T) {
 		HeadFetcher: &mock.ChainService{
 			State: st,
 		},
-		SlashingsPool: slashings.NewPool(),
-		Broadcaster:   mb,
+		SlashingsPool:    slashings.NewPool(),
+		SlashingReceiver: &mock.ChainService{},
+		Broadcaster:      mb,
 	}
 
 	slashing, err := testutil.GenerateAttesterSlashingForValidator(st, privs[2], types.ValidatorIndex(2))
@@ -163,3 +163,64 @@ func errorToString(err error) string {
 	}
 	return err.Error()
 }
+
+// ListTrustedPeers returns the trusted peers of the node, regardless of whether they are connected.
+func (ds *Server) ListTrustedPeers(_ context.Context, _ *empty.Empty) (*ethpb.Peers, error) {
+	peers := ds.PeersFetcher.Peers()
+	trusted := peers.TrustedPeers()
+	res := make([]*ethpb.Peer, 0, len(trusted))
+	for _, pid := range trusted {
+		pbPeer := &ethpb.Peer{
+			PeerId:          pid.String(),
+			ConnectionState: ethpb.ConnectionState_DISCONNECTED,
+			Trusted:         true,
+		}
+		// Trusted peers only known by their ID have no data until they connect.
+		if addr, err := peers.Address(pid); err == nil && addr != nil {
+			pbPeer.Address = addr.String()
+		}
+		if dir, err := peers.Direction(pid); err == nil {
+			switch dir {
+			case network.DirInbound:
+				pbPeer.Direction = ethpb.PeerDirection_INBOUND
+			case network.DirOutbound:
+				pbPeer.Direction = ethpb.PeerDirection_OUTBOUND
+			}
+		}
+		if connState, err := peers.ConnectionState(pid); err == nil {
+			pbPeer.ConnectionState = ethpb.ConnectionState(connState)
+		}
+		if record, err := peers.ENR(pid); err == nil && record != nil {
+			enr, err := p2p.SerializeENR(record)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "Unable to serialize enr: %v", err)
+			}
+			pbPeer.Enr = enr
+		}
+		res = append(res, pbPeer)
+	}
+	return &ethpb.Peers{Peers: res}, nil
+}
+
+// AddTrustedPeer marks the provided peer as trusted, so that it is redialed whenever it disconnects
+// and always has a connection slot available. Unlike the trusted peers set at startup, the peer
+// does not become a direct gossipsub peer.
+func (ds *Server) AddTrustedPeer(_ context.Context, req *ethpb.TrustedPeerRequest) (*empty.Empty, error) {
+	pid, addr, err := p2p.ParseTrustedPeer(req.Peer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer: %v", err)
+	}
+	ds.PeersFetcher.Peers().AddTrustedPeer(pid, addr)
+	return &empty.Empty{}, nil
+}
+
+// RemoveTrustedPeer removes the provided peer from the trusted peers. The peer is not disconnected,
+// and stays a direct gossipsub peer if it was trusted at startup.
+func (ds *Server) RemoveTrustedPeer(_ context.Context, req *ethpb.TrustedPeerRequest) (*empty.Empty, error) {
+	pid, _, err := p2p.ParseTrustedPeer(req.Peer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Unable to parse provided peer: %v", err)
+	}
+	ds.PeersFetcher.Peers().RemoveTrustedPeer(pid)
+	return &empty.Empty{}, nil
+}
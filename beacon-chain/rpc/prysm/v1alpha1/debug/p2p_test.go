@@ -49,3 +49,46 @@ func TestDebugServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2nd peer to have a multiaddress, instead they have no addresses")
 	}
 }
+
+func TestDebugServer_TrustedPeers(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	mP2P := mockP2p.NewTestP2P(t)
+	ds := &Server{
+		PeersFetcher: peersProvider,
+		PeerManager:  &mockP2p.MockPeerManager{BHost: mP2P.BHost},
+	}
+	connectedPeer := peersProvider.Peers().All()[0]
+	unknownPeer := "16Uiu2HAmQqFdEcHbSmQTQuLoAhnMUrgoWoraKK4cUJT6FuuqHqTU"
+
+	_, err := ds.AddTrustedPeer(context.Background(), &ethpb.TrustedPeerRequest{Peer: "foo"})
+	assert.ErrorContains(t, "Unable to parse provided peer", err)
+
+	_, err = ds.AddTrustedPeer(context.Background(), &ethpb.TrustedPeerRequest{Peer: connectedPeer.String()})
+	require.NoError(t, err)
+	_, err = ds.AddTrustedPeer(context.Background(), &ethpb.TrustedPeerRequest{Peer: "/ip4/127.0.0.1/tcp/13000/p2p/" + unknownPeer})
+	require.NoError(t, err)
+
+	res, err := ds.ListTrustedPeers(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(res.Peers))
+	for _, p := range res.Peers {
+		assert.Equal(t, true, p.Trusted)
+		switch p.PeerId {
+		case connectedPeer.String():
+			assert.Equal(t, ethpb.ConnectionState_CONNECTED, p.ConnectionState)
+			assert.NotEqual(t, "", p.Enr)
+		case unknownPeer:
+			assert.Equal(t, ethpb.ConnectionState_DISCONNECTED, p.ConnectionState)
+			assert.Equal(t, "/ip4/127.0.0.1/tcp/13000", p.Address)
+		default:
+			t.Errorf("Unexpected trusted peer %s", p.PeerId)
+		}
+	}
+
+	_, err = ds.RemoveTrustedPeer(context.Background(), &ethpb.TrustedPeerRequest{Peer: connectedPeer.String()})
+	require.NoError(t, err)
+	res, err = ds.ListTrustedPeers(context.Background(), &empty.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res.Peers))
+	assert.Equal(t, unknownPeer, res.Peers[0].PeerId)
+}
@@ -38,6 +38,7 @@ type Server struct {
 	BeaconDB             db.ReadOnlyDatabase
 	PeersFetcher         p2p.PeersProvider
 	PeerManager          p2p.PeerManager
+	BandwidthFetcher     p2p.BandwidthProvider
 	GenesisTimeFetcher   blockchain.TimeFetcher
 	GenesisFetcher       blockchain.GenesisFetcher
 	BeaconMonitoringHost string
@@ -158,12 +159,16 @@ func (ns *Server) GetPeer(_ context.Context, peerReq *ethpb.PeerRequest) (*ethpb
 			return nil, status.Errorf(codes.Internal, "Unable to serialize enr: %v", err)
 		}
 	}
+	bandwidth := ns.BandwidthFetcher.Bandwidth().GetBandwidthForPeer(pid)
 	return &ethpb.Peer{
 		Address:         addr.String(),
 		Direction:       pbDirection,
 		ConnectionState: ethpb.ConnectionState(connState),
 		PeerId:          peerReq.PeerId,
 		Enr:             enr,
+		Trusted:         ns.PeersFetcher.Peers().IsTrusted(pid),
+		BytesReceived:   uint64(bandwidth.TotalIn),
+		BytesSent:       uint64(bandwidth.TotalOut),
 	}, nil
 }
 
@@ -206,12 +211,16 @@ func (ns *Server) ListPeers(ctx context.Context, _ *empty.Empty) (*ethpb.Peers,
 		case network.DirOutbound:
 			pbDirection = ethpb.PeerDirection_OUTBOUND
 		}
+		bandwidth := ns.BandwidthFetcher.Bandwidth().GetBandwidthForPeer(pid)
 		res = append(res, &ethpb.Peer{
 			Address:         address,
 			Direction:       pbDirection,
 			ConnectionState: ethpb.ConnectionState_CONNECTED,
 			PeerId:          pid.String(),
 			Enr:             enr,
+			Trusted:         ns.PeersFetcher.Peers().IsTrusted(pid),
+			BytesReceived:   uint64(bandwidth.TotalIn),
+			BytesSent:       uint64(bandwidth.TotalOut),
 		})
 	}
 
@@ -118,25 +118,36 @@ func TestNodeServer_GetHost(t *testing.T) {
 func TestNodeServer_GetPeer(t *testing.T) {
 	server := grpc.NewServer()
 	peersProvider := &mockP2p.MockPeersProvider{}
+	mP2P := mockP2p.NewTestP2P(t)
 	ns := &Server{
-		PeersFetcher: peersProvider,
+		PeersFetcher:     peersProvider,
+		BandwidthFetcher: mP2P,
 	}
 	ethpb.RegisterNodeServer(server, ns)
 	reflection.Register(server)
 	firstPeer := peersProvider.Peers().All()[0]
+	mP2P.Bandwidth().LogRecvMessageStream(100, "/eth2/beacon_chain/req/status/1/ssz_snappy", firstPeer)
+	mP2P.Bandwidth().LogSentMessageStream(50, "/eth2/beacon_chain/req/status/1/ssz_snappy", firstPeer)
+	// Meters are only updated by the periodic sweep of the bandwidth counter.
+	for i := 0; i < 50 && mP2P.Bandwidth().GetBandwidthForPeer(firstPeer).TotalOut == 0; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
 
 	res, err := ns.GetPeer(context.Background(), &ethpb.PeerRequest{PeerId: firstPeer.String()})
 	require.NoError(t, err)
 	assert.Equal(t, firstPeer.String(), res.PeerId, "Unexpected peer ID")
 	assert.Equal(t, int(ethpb.PeerDirection_INBOUND), int(res.Direction), "Expected 1st peer to be an inbound connection")
 	assert.Equal(t, ethpb.ConnectionState_CONNECTED, res.ConnectionState, "Expected peer to be connected")
+	assert.Equal(t, uint64(100), res.BytesReceived)
+	assert.Equal(t, uint64(50), res.BytesSent)
 }
 
 func TestNodeServer_ListPeers(t *testing.T) {
 	server := grpc.NewServer()
 	peersProvider := &mockP2p.MockPeersProvider{}
 	ns := &Server{
-		PeersFetcher: peersProvider,
+		PeersFetcher:     peersProvider,
+		BandwidthFetcher: mockP2p.NewTestP2P(t),
 	}
 	ethpb.RegisterNodeServer(server, ns)
 	reflection.Register(server)
@@ -146,4 +157,13 @@ func TestNodeServer_ListPeers(t *testing.T) {
 	assert.Equal(t, 2, len(res.Peers))
 	assert.Equal(t, int(ethpb.PeerDirection_INBOUND), int(res.Peers[0].Direction))
 	assert.Equal(t, ethpb.PeerDirection_OUTBOUND, res.Peers[1].Direction)
+	assert.Equal(t, false, res.Peers[0].Trusted)
+
+	trusted := peersProvider.Peers().All()[0]
+	peersProvider.Peers().AddTrustedPeer(trusted, nil)
+	res, err = ns.ListPeers(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	for _, p := range res.Peers {
+		assert.Equal(t, p.PeerId == trusted.String(), p.Trusted, "Unexpected trust of peer %s", p.PeerId)
+	}
 }
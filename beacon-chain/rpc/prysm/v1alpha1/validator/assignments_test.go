@@ -92,8 +92,11 @@ func TestGetDuties_OK(t *testing.T) {
 	}
 	res, err = vs.GetDuties(context.Background(), req)
 	require.NoError(t, err, "Could not call epoch committee assignment")
+	committeesAtSlot := helpers.SlotCommitteeCount(depChainStart)
 	for i := 0; i < len(res.CurrentEpochDuties); i++ {
 		assert.Equal(t, types.ValidatorIndex(i), res.CurrentEpochDuties[i].ValidatorIndex)
+		assert.Equal(t, committeesAtSlot, res.CurrentEpochDuties[i].CommitteesAtSlot)
+		assert.Equal(t, committeesAtSlot, res.NextEpochDuties[i].CommitteesAtSlot)
 	}
 }
 
@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetLiveness returns whether the beacon node processed attestations or blocks of the requested
+// validators in an epoch. The liveness of validators is only known for the current and previous
+// epochs.
+func (vs *Server) GetLiveness(_ context.Context, req *ethpb.LivenessRequest) (*ethpb.LivenessResponse, error) {
+	if vs.SyncChecker.Syncing() {
+		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
+	}
+	currentEpoch := core.SlotToEpoch(vs.TimeFetcher.CurrentSlot())
+	if req.Epoch > currentEpoch || req.Epoch+1 < currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Liveness is only available for the current epoch %d and the previous epoch, not for epoch %d",
+			currentEpoch,
+			req.Epoch,
+		)
+	}
+	statuses := make([]*ethpb.ValidatorLiveness, len(req.Indices))
+	for i, index := range req.Indices {
+		statuses[i] = &ethpb.ValidatorLiveness{
+			Index:  index,
+			IsLive: cache.Liveness.IsLive(req.Epoch, index),
+		}
+	}
+	return &ethpb.LivenessResponse{Statuses: statuses}, nil
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestGetLiveness(t *testing.T) {
+	epoch := types.Epoch(1000)
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch)) + 1
+	vs := &Server{
+		TimeFetcher: &mockChain.ChainService{Slot: &slot},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+	cache.Liveness.MarkLive(epoch-1, []uint64{1, 2})
+	cache.Liveness.MarkLive(epoch, []uint64{3})
+
+	resp, err := vs.GetLiveness(context.Background(), &ethpb.LivenessRequest{Epoch: epoch - 1, Indices: []types.ValidatorIndex{1, 3}})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Statuses))
+	assert.DeepEqual(t, &ethpb.ValidatorLiveness{Index: 1, IsLive: true}, resp.Statuses[0])
+	assert.DeepEqual(t, &ethpb.ValidatorLiveness{Index: 3, IsLive: false}, resp.Statuses[1])
+
+	resp, err = vs.GetLiveness(context.Background(), &ethpb.LivenessRequest{Epoch: epoch, Indices: []types.ValidatorIndex{3}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Statuses))
+	assert.Equal(t, true, resp.Statuses[0].IsLive)
+}
+
+func TestGetLiveness_UnavailableEpoch(t *testing.T) {
+	epoch := types.Epoch(1000)
+	slot := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch))
+	vs := &Server{
+		TimeFetcher: &mockChain.ChainService{Slot: &slot},
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+
+	_, err := vs.GetLiveness(context.Background(), &ethpb.LivenessRequest{Epoch: epoch + 1})
+	assert.ErrorContains(t, "Liveness is only available", err)
+	_, err = vs.GetLiveness(context.Background(), &ethpb.LivenessRequest{Epoch: epoch - 2})
+	assert.ErrorContains(t, "Liveness is only available", err)
+}
+
+func TestGetLiveness_Syncing(t *testing.T) {
+	vs := &Server{SyncChecker: &mockSync.Sync{IsSyncing: true}}
+
+	_, err := vs.GetLiveness(context.Background(), &ethpb.LivenessRequest{})
+	assert.ErrorContains(t, "Syncing to latest head", err)
+}
@@ -728,7 +728,7 @@ func (vs *Server) depositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1
 }
 
 // rebuilds our deposit trie by recreating it from all processed deposits till
-// specified eth1 block height.
+// specified eth1 block height, on top of the deposit snapshot if deposits were pruned.
 func (vs *Server) rebuildDepositTrie(ctx context.Context, canonicalEth1Data *ethpb.Eth1Data, canonicalEth1DataHeight *big.Int) (*trieutil.SparseMerkleTrie, error) {
 	ctx, span := trace.StartSpan(ctx, "ProposerServer.rebuildDepositTrie")
 	defer span.End()
@@ -742,9 +742,25 @@ func (vs *Server) rebuildDepositTrie(ctx context.Context, canonicalEth1Data *eth
 		}
 		trieItems = append(trieItems, depHash[:])
 	}
-	depositTrie, err := trieutil.GenerateTrieFromItems(trieItems, params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, err
+	var depositTrie *trieutil.SparseMerkleTrie
+	var err error
+	// Deposits preceding the deposit snapshot have been pruned, in which case
+	// the trie is rebuilt on top of the snapshot.
+	if snapshot := vs.DepositFetcher.DepositSnapshot(ctx); snapshot != nil {
+		depositTrie, err = trieutil.TrieFromFinalizedRoots(
+			snapshot.Finalized, snapshot.DepositCount, params.BeaconConfig().DepositContractTreeDepth,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create deposit trie from snapshot")
+		}
+		for i, item := range trieItems {
+			depositTrie.Insert(item, int(snapshot.DepositCount)+i)
+		}
+	} else {
+		depositTrie, err = trieutil.GenerateTrieFromItems(trieItems, params.BeaconConfig().DepositContractTreeDepth)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	valid, err := vs.validateDepositTrie(depositTrie, canonicalEth1Data)
@@ -84,6 +84,8 @@ type Config struct {
 	FinalizationFetcher     blockchain.FinalizationFetcher
 	AttestationReceiver     blockchain.AttestationReceiver
 	BlockReceiver           blockchain.BlockReceiver
+	SlashingReceiver        blockchain.SlashingReceiver
+	LightClientFetcher      blockchain.LightClientUpdateFetcher
 	POWChainService         powchain.Chain
 	ChainStartFetcher       powchain.ChainStartFetcher
 	GenesisTimeFetcher      blockchain.TimeFetcher
@@ -98,6 +100,7 @@ type Config struct {
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
 	PeerManager             p2p.PeerManager
+	BandwidthFetcher        p2p.BandwidthProvider
 	MetadataProvider        p2p.MetadataProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
@@ -219,6 +222,7 @@ func (s *Service) Start() {
 		GenesisTimeFetcher:   s.cfg.GenesisTimeFetcher,
 		PeersFetcher:         s.cfg.PeersFetcher,
 		PeerManager:          s.cfg.PeerManager,
+		BandwidthFetcher:     s.cfg.BandwidthFetcher,
 		GenesisFetcher:       s.cfg.GenesisFetcher,
 		BeaconMonitoringHost: s.cfg.BeaconMonitoringHost,
 		BeaconMonitoringPort: s.cfg.BeaconMonitoringPort,
@@ -239,6 +243,7 @@ func (s *Service) Start() {
 		BeaconDB:                    s.cfg.BeaconDB,
 		AttestationsPool:            s.cfg.AttestationsPool,
 		SlashingsPool:               s.cfg.SlashingsPool,
+		SlashingReceiver:            s.cfg.SlashingReceiver,
 		HeadFetcher:                 s.cfg.HeadFetcher,
 		FinalizationFetcher:         s.cfg.FinalizationFetcher,
 		CanonicalFetcher:            s.cfg.CanonicalFetcher,
@@ -266,6 +271,7 @@ func (s *Service) Start() {
 		OperationNotifier:  s.cfg.OperationNotifier,
 		Broadcaster:        s.cfg.Broadcaster,
 		BlockReceiver:      s.cfg.BlockReceiver,
+		SlashingReceiver:   s.cfg.SlashingReceiver,
 		StateGenService:    s.cfg.StateGen,
 		StateFetcher: &statefetcher.StateProvider{
 			BeaconDB:           s.cfg.BeaconDB,
@@ -276,6 +282,9 @@ func (s *Service) Start() {
 		HeadFetcher:             s.cfg.HeadFetcher,
 		VoluntaryExitsPool:      s.cfg.ExitPool,
 		V1Alpha1ValidatorServer: validatorServer,
+		LightClientFetcher:      s.cfg.LightClientFetcher,
+		DepositFetcher:          s.cfg.DepositFetcher,
+		BlockFetcher:            s.cfg.POWChainService,
 	}
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
@@ -299,8 +308,9 @@ func (s *Service) Start() {
 			PeersFetcher:       s.cfg.PeersFetcher,
 		}
 		debugServerV1 := &debug.Server{
-			BeaconDB:    s.cfg.BeaconDB,
-			HeadFetcher: s.cfg.HeadFetcher,
+			BeaconDB:            s.cfg.BeaconDB,
+			HeadFetcher:         s.cfg.HeadFetcher,
+			FinalizationFetcher: s.cfg.FinalizationFetcher,
 			StateFetcher: &statefetcher.StateProvider{
 				BeaconDB:           s.cfg.BeaconDB,
 				ChainInfoFetcher:   s.cfg.ChainInfoFetcher,
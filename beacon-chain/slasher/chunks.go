@@ -0,0 +1,485 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// A struct encapsulating input arguments to functions used for attester
+// slashing detection and loading, saving, and updating min/max span chunks.
+type chunkUpdateArgs struct {
+	kind                slashertypes.ChunkKind
+	validatorChunkIndex uint64
+	currentEpoch        types.Epoch
+}
+
+// Chunker defines a struct which represents a slice containing a chunk for K different validator's
+// min or max spans used for surround vote detection in slasher. The interface defines methods used to check
+// if an attestation is slashable for a validator index based on the contents of
+// the chunk as well as the ability to update the data in the chunk with incoming information.
+type Chunker interface {
+	NeutralElement() uint16
+	Chunk() []uint16
+	CheckSlashable(
+		ctx context.Context,
+		slasherDB db.SlasherDatabase,
+		validatorIdx types.ValidatorIndex,
+		attestation *slashertypes.IndexedAttestationWrapper,
+	) (*ethpb.AttesterSlashing, error)
+	Update(
+		chunkIndex uint64,
+		currentEpoch types.Epoch,
+		validatorIndex types.ValidatorIndex,
+		startEpoch,
+		newTargetEpoch types.Epoch,
+	) (keepGoing bool, err error)
+	StartEpoch(sourceEpoch, currentEpoch types.Epoch) (epoch types.Epoch, exists bool)
+	NextChunkStartEpoch(startEpoch types.Epoch) types.Epoch
+}
+
+// MinSpanChunksSlice represents a slice containing a chunk for K different validator's min spans.
+//
+// For a given epoch, e, and attestations a validator index has produced, atts,
+// min_spans[e] is defined as min((att.target.epoch - e) for att in attestations)
+// where att.source.epoch > e. That is, it is the minimum distance between the
+// specified epoch and all attestation target epochs a validator has created
+// where att.source.epoch > e.
+//
+// Under ideal network conditions, where every target epoch immediately follows its source,
+// min spans for a validator will look as follows:
+//
+//  min_spans = [2, 2, 2, ..., 2]
+//
+// Next, we can chunk this list of min spans into chunks of length C. For C = 2, for example:
+//
+//                       chunk0  chunk1       chunkN
+//                        {  }   {   }         {  }
+//  chunked_min_spans = [[2, 2], [2, 2], ..., [2, 2]]
+//
+// Finally, we can store each chunk index for K validators into a single flat slice. For K = 3:
+//
+//                                    val0    val1    val2
+//                                    {  }    {  }    {  }
+//  chunk_0_for_validators_0_to_3 = [[2, 2], [2, 2], [2, 2]]
+//
+//                                    val0    val1    val2
+//                                    {  }    {  }    {  }
+//  chunk_1_for_validators_0_to_3 = [[2, 2], [2, 2], [2, 2]]
+//
+//                                    ...
+//
+//                                    val0    val1    val2
+//                                    {  }    {  }    {  }
+//  chunk_N_for_validators_0_to_3 = [[2, 2], [2, 2], [2, 2]]
+//
+// MinSpanChunksSlice represents the data structure above for a single chunk index.
+type MinSpanChunksSlice struct {
+	params *Parameters
+	data   []uint16
+}
+
+// MaxSpanChunksSlice represents the same data structure as MinSpanChunksSlice however
+// keeps track of validator max spans for slashing detection instead.
+type MaxSpanChunksSlice struct {
+	params *Parameters
+	data   []uint16
+}
+
+// EmptyMinSpanChunksSlice initializes a min span chunk of length C*K for
+// C = chunkSize and K = validatorChunkSize filled with neutral elements.
+// For min spans, the neutral element is `undefined`, represented by MaxUint16.
+func EmptyMinSpanChunksSlice(params *Parameters) *MinSpanChunksSlice {
+	m := &MinSpanChunksSlice{
+		params: params,
+	}
+	data := make([]uint16, params.chunkSize*params.validatorChunkSize)
+	for i := 0; i < len(data); i++ {
+		data[i] = m.NeutralElement()
+	}
+	m.data = data
+	return m
+}
+
+// EmptyMaxSpanChunksSlice initializes a max span chunk of length C*K for
+// C = chunkSize and K = validatorChunkSize filled with neutral elements.
+// For max spans, the neutral element is 0.
+func EmptyMaxSpanChunksSlice(params *Parameters) *MaxSpanChunksSlice {
+	m := &MaxSpanChunksSlice{
+		params: params,
+	}
+	data := make([]uint16, params.chunkSize*params.validatorChunkSize)
+	for i := 0; i < len(data); i++ {
+		data[i] = m.NeutralElement()
+	}
+	m.data = data
+	return m
+}
+
+// MinChunkSpansSliceFrom initializes a min span chunks slice from a slice of uint16 values.
+// Returns an error if the slice is not of length C*K for C = chunkSize and K = validatorChunkSize.
+func MinChunkSpansSliceFrom(params *Parameters, chunk []uint16) (*MinSpanChunksSlice, error) {
+	requiredLen := params.chunkSize * params.validatorChunkSize
+	if uint64(len(chunk)) != requiredLen {
+		return nil, fmt.Errorf("chunk has wrong length, %d, expected %d", len(chunk), requiredLen)
+	}
+	return &MinSpanChunksSlice{
+		params: params,
+		data:   chunk,
+	}, nil
+}
+
+// MaxChunkSpansSliceFrom initializes a max span chunks slice from a slice of uint16 values.
+// Returns an error if the slice is not of length C*K for C = chunkSize and K = validatorChunkSize.
+func MaxChunkSpansSliceFrom(params *Parameters, chunk []uint16) (*MaxSpanChunksSlice, error) {
+	requiredLen := params.chunkSize * params.validatorChunkSize
+	if uint64(len(chunk)) != requiredLen {
+		return nil, fmt.Errorf("chunk has wrong length, %d, expected %d", len(chunk), requiredLen)
+	}
+	return &MaxSpanChunksSlice{
+		params: params,
+		data:   chunk,
+	}, nil
+}
+
+// NeutralElement for a min span chunks slice is undefined, in this case
+// using MaxUint16 as a sane value given it is impossible we reach it.
+func (m *MinSpanChunksSlice) NeutralElement() uint16 {
+	return math.MaxUint16
+}
+
+// NeutralElement for a max span chunks slice is 0.
+func (m *MaxSpanChunksSlice) NeutralElement() uint16 {
+	return 0
+}
+
+// Chunk returns the underlying slice of uint16's for the min chunks slice.
+func (m *MinSpanChunksSlice) Chunk() []uint16 {
+	return m.data
+}
+
+// Chunk returns the underlying slice of uint16's for the max chunks slice.
+func (m *MaxSpanChunksSlice) Chunk() []uint16 {
+	return m.data
+}
+
+// CheckSlashable takes in a validator index and an incoming attestation
+// and checks if the validator is slashable depending on the data
+// within the min span chunks slice. Recall that for an incoming attestation, B, and an
+// existing attestation, A:
+//
+//  B surrounds A if and only if B.target > min_spans[B.source]
+//
+// That is, this condition is sufficient to check if an incoming attestation
+// is surrounding a previous one. We also check if we indeed have an existing
+// attestation record in the database if the condition holds true in order
+// to be confident of a slashable offense.
+func (m *MinSpanChunksSlice) CheckSlashable(
+	ctx context.Context,
+	slasherDB db.SlasherDatabase,
+	validatorIdx types.ValidatorIndex,
+	attestation *slashertypes.IndexedAttestationWrapper,
+) (*ethpb.AttesterSlashing, error) {
+	sourceEpoch := attestation.IndexedAttestation.Data.Source.Epoch
+	targetEpoch := attestation.IndexedAttestation.Data.Target.Epoch
+	minTarget, err := chunkDataAtEpoch(m.params, m.data, validatorIdx, sourceEpoch)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err, "could not get min target for validator %d at epoch %d", validatorIdx, sourceEpoch,
+		)
+	}
+	if targetEpoch <= minTarget {
+		return nil, nil
+	}
+	existingAttRecord, err := slasherDB.AttestationRecordForValidator(ctx, validatorIdx, minTarget)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err, "could not get existing attestation record at target %d", minTarget,
+		)
+	}
+	if existingAttRecord == nil {
+		return nil, nil
+	}
+	if sourceEpoch < existingAttRecord.IndexedAttestation.Data.Source.Epoch {
+		surroundingVotesTotal.Inc()
+		return &ethpb.AttesterSlashing{
+			Attestation_1: attestation.IndexedAttestation,
+			Attestation_2: existingAttRecord.IndexedAttestation,
+		}, nil
+	}
+	return nil, nil
+}
+
+// CheckSlashable takes in a validator index and an incoming attestation
+// and checks if the validator is slashable depending on the data
+// within the max span chunks slice. Recall that for an incoming attestation, B, and an
+// existing attestation, A:
+//
+//  B is surrounded by A if and only if B.target < max_spans[B.source]
+//
+// That is, this condition is sufficient to check if an incoming attestation
+// is surrounded by a previous one. We also check if we indeed have an existing
+// attestation record in the database if the condition holds true in order
+// to be confident of a slashable offense.
+func (m *MaxSpanChunksSlice) CheckSlashable(
+	ctx context.Context,
+	slasherDB db.SlasherDatabase,
+	validatorIdx types.ValidatorIndex,
+	attestation *slashertypes.IndexedAttestationWrapper,
+) (*ethpb.AttesterSlashing, error) {
+	sourceEpoch := attestation.IndexedAttestation.Data.Source.Epoch
+	targetEpoch := attestation.IndexedAttestation.Data.Target.Epoch
+	maxTarget, err := chunkDataAtEpoch(m.params, m.data, validatorIdx, sourceEpoch)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err, "could not get max target for validator %d at epoch %d", validatorIdx, sourceEpoch,
+		)
+	}
+	if targetEpoch >= maxTarget {
+		return nil, nil
+	}
+	existingAttRecord, err := slasherDB.AttestationRecordForValidator(ctx, validatorIdx, maxTarget)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err, "could not get existing attestation record at target %d", maxTarget,
+		)
+	}
+	if existingAttRecord == nil {
+		return nil, nil
+	}
+	if existingAttRecord.IndexedAttestation.Data.Source.Epoch < sourceEpoch {
+		surroundedVotesTotal.Inc()
+		return &ethpb.AttesterSlashing{
+			Attestation_1: existingAttRecord.IndexedAttestation,
+			Attestation_2: attestation.IndexedAttestation,
+		}, nil
+	}
+	return nil, nil
+}
+
+// Update a min span chunk for a validator index starting at the current epoch, e_c, then updating
+// down to e_c - H where H is the historyLength we keep for each span. This historyLength
+// corresponds to the weak subjectivity period of Ethereum consensus.
+// This means our updates are done in a sliding window manner. For example, if the current epoch
+// is 20 and the historyLength is 12, then we will update every value for the validator's
+// min span from epoch 20 down to 8.
+//
+// Recall that for an epoch, e, min((att.target - e) for all attestations where att.source > e)
+// is the definition of a min span. In this function, we update the chunk as long as the incoming
+// attestation's target is less than the existing target stored for an epoch. Once it is not,
+// updating further epochs will not change the chunk and we return false, signaling the
+// update is complete. If we run past the boundary of the chunk, we return true, signaling
+// that the update must continue on the previous chunk.
+func (m *MinSpanChunksSlice) Update(
+	chunkIndex uint64,
+	currentEpoch types.Epoch,
+	validatorIndex types.ValidatorIndex,
+	startEpoch,
+	newTargetEpoch types.Epoch,
+) (keepGoing bool, err error) {
+	// The lowest epoch we need to update.
+	minEpoch := types.Epoch(0)
+	if currentEpoch > (m.params.historyLength - 1) {
+		minEpoch = currentEpoch - (m.params.historyLength - 1)
+	}
+	epochInChunk := startEpoch
+	// We go down the chunk for the validator, updating every value starting at startEpoch down to minEpoch.
+	// As long as the epoch, e, is in the same chunk index and e >= minEpoch, we proceed with
+	// the update.
+	for m.params.chunkIndex(epochInChunk) == chunkIndex && epochInChunk >= minEpoch {
+		var chunkTarget types.Epoch
+		chunkTarget, err = chunkDataAtEpoch(m.params, m.data, validatorIndex, epochInChunk)
+		if err != nil {
+			err = errors.Wrapf(err, "could not get chunk data at epoch %d", epochInChunk)
+			return
+		}
+		// If the newly incoming value is >= the existing value, the definition of
+		// the min span already holds for this epoch and every epoch below it.
+		if newTargetEpoch >= chunkTarget {
+			return false, nil
+		}
+		if err = setChunkDataAtEpoch(m.params, m.data, validatorIndex, epochInChunk, newTargetEpoch); err != nil {
+			err = errors.Wrapf(err, "could not set chunk data at epoch %d", epochInChunk)
+			return
+		}
+		if epochInChunk == 0 {
+			return false, nil
+		}
+		epochInChunk--
+	}
+	// If the epoch to update now lies below the min epoch, we are done.
+	return epochInChunk >= minEpoch, nil
+}
+
+// Update a max span chunk for a validator index starting at a given start epoch, e_c, then updating
+// up to the current epoch according to the definition of max spans. If we need to continue updating
+// a next chunk, this function returns a boolean letting the caller know it should keep going. To understand
+// more about how update exactly works, refer to the detailed documentation for the Update function for
+// MinSpanChunksSlice.
+func (m *MaxSpanChunksSlice) Update(
+	chunkIndex uint64,
+	currentEpoch types.Epoch,
+	validatorIndex types.ValidatorIndex,
+	startEpoch,
+	newTargetEpoch types.Epoch,
+) (keepGoing bool, err error) {
+	epochInChunk := startEpoch
+	// We go up the chunk for the validator, updating every value starting at startEpoch up to
+	// and including the current epoch. As long as the epoch, e, is in the same chunk index and e <= currentEpoch,
+	// we proceed with the update.
+	for m.params.chunkIndex(epochInChunk) == chunkIndex && epochInChunk <= currentEpoch {
+		var chunkTarget types.Epoch
+		chunkTarget, err = chunkDataAtEpoch(m.params, m.data, validatorIndex, epochInChunk)
+		if err != nil {
+			err = errors.Wrapf(err, "could not get chunk data at epoch %d", epochInChunk)
+			return
+		}
+		// If the newly incoming value is <= the existing value, the definition of
+		// the max span already holds for this epoch and every epoch above it.
+		if newTargetEpoch <= chunkTarget {
+			return false, nil
+		}
+		if err = setChunkDataAtEpoch(m.params, m.data, validatorIndex, epochInChunk, newTargetEpoch); err != nil {
+			err = errors.Wrapf(err, "could not set chunk data at epoch %d", epochInChunk)
+			return
+		}
+		epochInChunk++
+	}
+	// If the epoch to update now lies beyond the current epoch, we are done.
+	return epochInChunk <= currentEpoch, nil
+}
+
+// StartEpoch given a source epoch and current epoch, determines the start epoch of
+// a min span chunk for use in chunk updates. To compute this value, we look at the difference between
+// H = historyLength and the current epoch. Then, we check if the source epoch > difference. If so,
+// then the start epoch is source epoch - 1. Otherwise, we return to the caller a boolean signifying
+// the input arguments are invalid for the chunk and the start epoch does not exist.
+func (m *MinSpanChunksSlice) StartEpoch(
+	sourceEpoch, currentEpoch types.Epoch,
+) (epoch types.Epoch, exists bool) {
+	// Given min span chunks are used for detecting surrounding votes, we have no need
+	// for a start epoch of the chunk if the source epoch is 0 in the input arguments.
+	// To further clarify, min span chunks are updated in reverse order [a, b, c, d] where
+	// if the start epoch is d, then we go down the chunk updating everything from d, c, b, to
+	// a. If the source epoch is 0, this means we would have a start epoch of -1, which is not possible.
+	if sourceEpoch == 0 {
+		return
+	}
+	var difference types.Epoch
+	if currentEpoch > m.params.historyLength {
+		difference = currentEpoch - m.params.historyLength
+	}
+	if sourceEpoch <= difference {
+		return
+	}
+	return sourceEpoch - 1, true
+}
+
+// StartEpoch given a source epoch and current epoch, determines the start epoch of
+// a max span chunk for use in chunk updates. The source epoch cannot be >= the current epoch.
+func (m *MaxSpanChunksSlice) StartEpoch(
+	sourceEpoch, currentEpoch types.Epoch,
+) (epoch types.Epoch, exists bool) {
+	if sourceEpoch >= currentEpoch {
+		return
+	}
+	return sourceEpoch + 1, true
+}
+
+// NextChunkStartEpoch given an epoch, determines the start epoch of the next chunk. For min
+// span chunks, this will be the last epoch of the chunk preceding the chunk of the input epoch.
+// For example:
+//
+//  chunkSize = 3
+//  epochs:  [0, 1, 2][3, 4, 5][6, 7, 8]
+//                  |     |-> input epoch (4)
+//                  |-> start of the next chunk (2)
+//
+func (m *MinSpanChunksSlice) NextChunkStartEpoch(startEpoch types.Epoch) types.Epoch {
+	firstEpoch := startEpoch - types.Epoch(m.params.chunkOffset(startEpoch))
+	if firstEpoch == 0 {
+		return 0
+	}
+	return firstEpoch - 1
+}
+
+// NextChunkStartEpoch given an epoch, determines the start epoch of the next chunk. For max
+// span chunks, this will be the first epoch of the chunk following the chunk of the input epoch.
+// For example:
+//
+//  chunkSize = 3
+//  epochs:  [0, 1, 2][3, 4, 5][6, 7, 8]
+//                        |     |-> start of the next chunk (6)
+//                        |-> input epoch (4)
+//
+func (m *MaxSpanChunksSlice) NextChunkStartEpoch(startEpoch types.Epoch) types.Epoch {
+	return startEpoch - types.Epoch(m.params.chunkOffset(startEpoch)) + types.Epoch(m.params.chunkSize)
+}
+
+// Given a validator index and epoch, retrieves the target epoch at our chunk's cell index
+// by adding the distance stored in the cell to the epoch.
+func chunkDataAtEpoch(
+	params *Parameters, chunk []uint16, validatorIdx types.ValidatorIndex, epoch types.Epoch,
+) (types.Epoch, error) {
+	requiredLen := params.chunkSize * params.validatorChunkSize
+	if uint64(len(chunk)) != requiredLen {
+		return 0, fmt.Errorf("chunk has wrong length, %d, expected %d", len(chunk), requiredLen)
+	}
+	cellIdx := params.cellIndex(validatorIdx, epoch)
+	if cellIdx >= uint64(len(chunk)) {
+		return 0, fmt.Errorf("cell index %d out of bounds (len(chunk) = %d)", cellIdx, len(chunk))
+	}
+	return epoch + types.Epoch(chunk[cellIdx]), nil
+}
+
+// Updates the value at a cell index in a chunk for a validator index and epoch with
+// the distance from the epoch to the given target epoch.
+func setChunkDataAtEpoch(
+	params *Parameters,
+	chunk []uint16,
+	validatorIdx types.ValidatorIndex,
+	epochInChunk,
+	targetEpoch types.Epoch,
+) error {
+	distance, err := epochDistance(targetEpoch, epochInChunk)
+	if err != nil {
+		return err
+	}
+	return setChunkRawDistance(params, chunk, validatorIdx, epochInChunk, distance)
+}
+
+// Updates the raw distance value at a cell index in a chunk for a validator index and epoch.
+func setChunkRawDistance(
+	params *Parameters,
+	chunk []uint16,
+	validatorIdx types.ValidatorIndex,
+	epochInChunk types.Epoch,
+	distance uint16,
+) error {
+	cellIdx := params.cellIndex(validatorIdx, epochInChunk)
+	if cellIdx >= uint64(len(chunk)) {
+		return fmt.Errorf("cell index %d out of bounds (len(chunk) = %d)", cellIdx, len(chunk))
+	}
+	chunk[cellIdx] = distance
+	return nil
+}
+
+// Computes a distance between two epochs. Given the result stored in
+// min/max spans is maximum WEAK_SUBJECTIVITY_PERIOD, we are guaranteed the
+// distance can be represented as a uint16 safely.
+func epochDistance(epoch, baseEpoch types.Epoch) (uint16, error) {
+	if baseEpoch > epoch {
+		return 0, fmt.Errorf("base epoch %d cannot be greater than epoch %d", baseEpoch, epoch)
+	}
+	distance := epoch - baseEpoch
+	if distance >= math.MaxUint16 {
+		return 0, fmt.Errorf("distance between epochs %d and %d does not fit in a span", baseEpoch, epoch)
+	}
+	return uint16(distance), nil
+}
@@ -0,0 +1,399 @@
+package slasher
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+var (
+	_ = Chunker(&MinSpanChunksSlice{})
+	_ = Chunker(&MaxSpanChunksSlice{})
+)
+
+func TestMinSpanChunksSlice_Chunk(t *testing.T) {
+	chunk := EmptyMinSpanChunksSlice(&Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 2,
+	})
+	wanted := []uint16{math.MaxUint16, math.MaxUint16, math.MaxUint16, math.MaxUint16}
+	require.DeepEqual(t, wanted, chunk.Chunk())
+}
+
+func TestMaxSpanChunksSlice_Chunk(t *testing.T) {
+	chunk := EmptyMaxSpanChunksSlice(&Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 2,
+	})
+	wanted := []uint16{0, 0, 0, 0}
+	require.DeepEqual(t, wanted, chunk.Chunk())
+}
+
+func TestChunkSpansSliceFrom_WrongLength(t *testing.T) {
+	params := &Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 2,
+	}
+	_, err := MinChunkSpansSliceFrom(params, []uint16{})
+	assert.ErrorContains(t, "chunk has wrong length", err)
+	_, err = MaxChunkSpansSliceFrom(params, []uint16{1, 2, 3})
+	assert.ErrorContains(t, "chunk has wrong length", err)
+
+	data := []uint16{1, 2, 3, 4}
+	minChunk, err := MinChunkSpansSliceFrom(params, data)
+	require.NoError(t, err)
+	assert.DeepEqual(t, data, minChunk.Chunk())
+	maxChunk, err := MaxChunkSpansSliceFrom(params, data)
+	require.NoError(t, err)
+	assert.DeepEqual(t, data, maxChunk.Chunk())
+}
+
+func TestMinSpanChunksSlice_CheckSlashable(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	params := &Parameters{
+		chunkSize:          3,
+		validatorChunkSize: 2,
+		historyLength:      3,
+	}
+	validatorIdx := types.ValidatorIndex(1)
+	source := types.Epoch(1)
+	target := types.Epoch(2)
+	att := createAttestationWrapper(t, source, target, nil, nil)
+
+	// A faulty chunk should lead to error.
+	chunk := &MinSpanChunksSlice{
+		params: params,
+		data:   []uint16{},
+	}
+	_, err := chunk.CheckSlashable(ctx, nil, validatorIdx, att)
+	require.ErrorContains(t, "could not get min target for validator", err)
+
+	// We initialize a proper slice with 2 chunks with chunk size 3, 2 validators, and
+	// a history length of 3 representing a perfect attesting history.
+	//
+	//     val0     val1
+	//   {     }  {     }
+	//  [2, 2, 2, 2, 2, 2]
+	data := []uint16{2, 2, 2, 2, 2, 2}
+	chunk, err = MinChunkSpansSliceFrom(params, data)
+	require.NoError(t, err)
+
+	// An attestation with source 1 and target 2 should not be slashable
+	// based on the data stored for validator 1 in the chunk.
+	slashing, err := chunk.CheckSlashable(ctx, slasherDB, validatorIdx, att)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.AttesterSlashing)(nil), slashing)
+
+	// Next up we initialize an empty chunks slice and mark an attestation
+	// with (source 1, target 2) as attested.
+	chunk = EmptyMinSpanChunksSlice(params)
+	source = types.Epoch(1)
+	target = types.Epoch(2)
+	att = createAttestationWrapper(t, source, target, nil, nil)
+	chunkIdx := uint64(0)
+	startEpoch := target
+	currentEpoch := target
+	_, err = chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+
+	// Next up, we create a surrounding vote, but it should NOT be slashable
+	// because we DO NOT have an existing attestation record in our database at the min target epoch.
+	source = types.Epoch(0)
+	target = types.Epoch(3)
+	surroundingVote := createAttestationWrapper(t, source, target, nil, nil)
+
+	slashing, err = chunk.CheckSlashable(ctx, slasherDB, validatorIdx, surroundingVote)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.AttesterSlashing)(nil), slashing)
+
+	// Next up, we save the old attestation record, then check if the
+	// surrounding vote is indeed slashable.
+	attData := att.IndexedAttestation.Data
+	attRecord := createAttestationWrapper(t, attData.Source.Epoch, attData.Target.Epoch, []uint64{uint64(validatorIdx)}, []byte{1})
+	err = slasherDB.SaveAttestationRecordsForValidators(
+		ctx,
+		[]*slashertypes.IndexedAttestationWrapper{attRecord},
+	)
+	require.NoError(t, err)
+
+	slashing, err = chunk.CheckSlashable(ctx, slasherDB, validatorIdx, surroundingVote)
+	require.NoError(t, err)
+	require.NotNil(t, slashing)
+	assert.DeepSSZEqual(t, surroundingVote.IndexedAttestation, slashing.Attestation_1)
+	assert.DeepSSZEqual(t, attRecord.IndexedAttestation, slashing.Attestation_2)
+}
+
+func TestMaxSpanChunksSlice_CheckSlashable(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	params := &Parameters{
+		chunkSize:          4,
+		validatorChunkSize: 2,
+		historyLength:      4,
+	}
+	validatorIdx := types.ValidatorIndex(1)
+	source := types.Epoch(1)
+	target := types.Epoch(2)
+	att := createAttestationWrapper(t, source, target, nil, nil)
+
+	// A faulty chunk should lead to error.
+	chunk := &MaxSpanChunksSlice{
+		params: params,
+		data:   []uint16{},
+	}
+	_, err := chunk.CheckSlashable(ctx, nil, validatorIdx, att)
+	require.ErrorContains(t, "could not get max target for validator", err)
+
+	// We initialize a proper slice with 2 chunks with chunk size 4, 2 validators, and
+	// a history length of 4 representing a perfect attesting history.
+	//
+	//      val0        val1
+	//   {        }  {        }
+	//  [0, 0, 0, 0, 0, 0, 0, 0]
+	data := []uint16{0, 0, 0, 0, 0, 0, 0, 0}
+	chunk, err = MaxChunkSpansSliceFrom(params, data)
+	require.NoError(t, err)
+
+	// An attestation with source 1 and target 2 should not be slashable
+	// based on the data stored for validator 1 in the chunk.
+	slashing, err := chunk.CheckSlashable(ctx, slasherDB, validatorIdx, att)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.AttesterSlashing)(nil), slashing)
+
+	// Next up we initialize an empty chunks slice and mark an attestation
+	// with (source 0, target 3) as attested.
+	chunk = EmptyMaxSpanChunksSlice(params)
+	source = types.Epoch(0)
+	target = types.Epoch(3)
+	att = createAttestationWrapper(t, source, target, nil, nil)
+	chunkIdx := uint64(0)
+	startEpoch := source + 1
+	currentEpoch := target
+	_, err = chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+
+	// Next up, we create a surrounded vote, but it should NOT be slashable
+	// because we DO NOT have an existing attestation record in our database at the max target epoch.
+	source = types.Epoch(1)
+	target = types.Epoch(2)
+	surroundedVote := createAttestationWrapper(t, source, target, nil, nil)
+
+	slashing, err = chunk.CheckSlashable(ctx, slasherDB, validatorIdx, surroundedVote)
+	require.NoError(t, err)
+	require.Equal(t, (*ethpb.AttesterSlashing)(nil), slashing)
+
+	// Next up, we save the old attestation record, then check if the
+	// surrounded vote is indeed slashable.
+	attData := att.IndexedAttestation.Data
+	attRecord := createAttestationWrapper(t, attData.Source.Epoch, attData.Target.Epoch, []uint64{uint64(validatorIdx)}, []byte{1})
+	err = slasherDB.SaveAttestationRecordsForValidators(
+		ctx,
+		[]*slashertypes.IndexedAttestationWrapper{attRecord},
+	)
+	require.NoError(t, err)
+
+	slashing, err = chunk.CheckSlashable(ctx, slasherDB, validatorIdx, surroundedVote)
+	require.NoError(t, err)
+	require.NotNil(t, slashing)
+	assert.DeepSSZEqual(t, attRecord.IndexedAttestation, slashing.Attestation_1)
+	assert.DeepSSZEqual(t, surroundedVote.IndexedAttestation, slashing.Attestation_2)
+}
+
+func TestMinSpanChunksSlice_Update_MultipleChunks(t *testing.T) {
+	// Let's set H = historyLength = 4, meaning a min span will hold 4 epochs worth of
+	// attesting history. Then we set C = 2 meaning we will chunk the min span into arrays
+	// each of length 2 and K = 3 meaning we store each chunk index for 3 validators at a time.
+	//
+	// We update validator 0 with a target of 3 starting at epoch 3, which falls into
+	// chunk_idx = (epoch % H) / C = (3 % 4) / 2 = 1
+	//
+	//                                       val0        val1        val2
+	//                                     {     }     {     }     {     }
+	//   chunk_1_for_validators_0_to_3 = [[nil, nil], [nil, nil], [nil, nil]]
+	//                                      |    |
+	//                                      |    |-> epoch 3 for validator 0
+	//                                      |
+	//                                      |-> epoch 2 for validator 0
+	//
+	// Once we finish updating chunk 1, the update is not done as epochs 1 and 0 remain,
+	// so Update signals the caller to keep going with chunk 0.
+	params := &Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 3,
+		historyLength:      4,
+	}
+	chunk := EmptyMinSpanChunksSlice(params)
+	target := types.Epoch(3)
+	chunkIdx := uint64(1)
+	validatorIdx := types.ValidatorIndex(0)
+	startEpoch := target
+	currentEpoch := target
+	keepGoing, err := chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+
+	// We should keep going! We still have to update the data for chunk index 0.
+	require.Equal(t, true, keepGoing)
+	want := []uint16{1, 0, math.MaxUint16, math.MaxUint16, math.MaxUint16, math.MaxUint16}
+	require.DeepEqual(t, want, chunk.Chunk())
+
+	// Now we update for chunk index 0.
+	chunk = EmptyMinSpanChunksSlice(params)
+	chunkIdx = uint64(0)
+	validatorIdx = types.ValidatorIndex(0)
+	startEpoch = chunk.NextChunkStartEpoch(startEpoch)
+	require.Equal(t, types.Epoch(1), startEpoch)
+	keepGoing, err = chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+	require.Equal(t, false, keepGoing)
+	want = []uint16{3, 2, math.MaxUint16, math.MaxUint16, math.MaxUint16, math.MaxUint16}
+	require.DeepEqual(t, want, chunk.Chunk())
+}
+
+func TestMaxSpanChunksSlice_Update_MultipleChunks(t *testing.T) {
+	params := &Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 3,
+		historyLength:      4,
+	}
+	chunk := EmptyMaxSpanChunksSlice(params)
+	target := types.Epoch(3)
+	chunkIdx := uint64(0)
+	validatorIdx := types.ValidatorIndex(0)
+	startEpoch := types.Epoch(0)
+	currentEpoch := target
+	keepGoing, err := chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+
+	// We should keep going! We still have to update the data for chunk index 1.
+	require.Equal(t, true, keepGoing)
+	want := []uint16{3, 2, 0, 0, 0, 0}
+	require.DeepEqual(t, want, chunk.Chunk())
+
+	// Now we update for chunk index 1.
+	chunk = EmptyMaxSpanChunksSlice(params)
+	chunkIdx = uint64(1)
+	validatorIdx = types.ValidatorIndex(0)
+	startEpoch = chunk.NextChunkStartEpoch(startEpoch)
+	require.Equal(t, types.Epoch(2), startEpoch)
+	keepGoing, err = chunk.Update(chunkIdx, currentEpoch, validatorIdx, startEpoch, target)
+	require.NoError(t, err)
+	require.Equal(t, false, keepGoing)
+	want = []uint16{1, 0, 0, 0, 0, 0}
+	require.DeepEqual(t, want, chunk.Chunk())
+}
+
+func TestMinSpanChunksSlice_StartEpoch(t *testing.T) {
+	type args struct {
+		sourceEpoch  types.Epoch
+		currentEpoch types.Epoch
+	}
+	tests := []struct {
+		name       string
+		params     *Parameters
+		args       args
+		wantEpoch  types.Epoch
+		wantExists bool
+	}{
+		{
+			name:       "source_epoch == 0 returns false",
+			params:     DefaultParams(),
+			args:       args{sourceEpoch: 0},
+			wantExists: false,
+		},
+		{
+			name:   "source_epoch == (current_epoch - HISTORY_LENGTH) returns false",
+			params: DefaultParams(),
+			args: args{
+				sourceEpoch:  1,
+				currentEpoch: 1 + DefaultParams().historyLength,
+			},
+			wantExists: false,
+		},
+		{
+			name:   "source_epoch > (current_epoch - HISTORY_LENGTH) returns true",
+			params: DefaultParams(),
+			args: args{
+				sourceEpoch:  2,
+				currentEpoch: 1 + DefaultParams().historyLength,
+			},
+			wantEpoch:  1,
+			wantExists: true,
+		},
+		{
+			name:   "source_epoch < (current_epoch - HISTORY_LENGTH) returns false",
+			params: DefaultParams(),
+			args: args{
+				sourceEpoch:  1,
+				currentEpoch: 2 + DefaultParams().historyLength,
+			},
+			wantExists: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MinSpanChunksSlice{
+				params: tt.params,
+			}
+			gotEpoch, gotExists := m.StartEpoch(tt.args.sourceEpoch, tt.args.currentEpoch)
+			assert.Equal(t, tt.wantExists, gotExists)
+			if tt.wantExists {
+				assert.Equal(t, tt.wantEpoch, gotEpoch)
+			}
+		})
+	}
+}
+
+func TestMaxSpanChunksSlice_StartEpoch(t *testing.T) {
+	m := &MaxSpanChunksSlice{params: DefaultParams()}
+	_, exists := m.StartEpoch(3, 3)
+	assert.Equal(t, false, exists)
+	_, exists = m.StartEpoch(4, 3)
+	assert.Equal(t, false, exists)
+	epoch, exists := m.StartEpoch(2, 3)
+	assert.Equal(t, true, exists)
+	assert.Equal(t, types.Epoch(3), epoch)
+}
+
+func TestChunkDataAtEpoch_SetChunkDataAtEpoch(t *testing.T) {
+	params := &Parameters{
+		chunkSize:          3,
+		validatorChunkSize: 2,
+	}
+	validatorIdx := types.ValidatorIndex(1)
+	epochInChunk := types.Epoch(0)
+	targetEpoch := types.Epoch(2)
+
+	// The target epoch is stored as a distance from the epoch of the cell.
+	chunk := EmptyMinSpanChunksSlice(params)
+	err := setChunkDataAtEpoch(params, chunk.data, validatorIdx, epochInChunk, targetEpoch)
+	require.NoError(t, err)
+	received, err := chunkDataAtEpoch(params, chunk.data, validatorIdx, epochInChunk)
+	require.NoError(t, err)
+	assert.Equal(t, targetEpoch, received)
+
+	// A chunk of the wrong size fails.
+	_, err = chunkDataAtEpoch(params, []uint16{}, validatorIdx, epochInChunk)
+	assert.ErrorContains(t, "chunk has wrong length", err)
+
+	// A target epoch lower than the epoch of the cell fails.
+	err = setChunkDataAtEpoch(params, chunk.data, validatorIdx, targetEpoch, epochInChunk)
+	assert.ErrorContains(t, "cannot be greater than epoch", err)
+}
+
+func TestEpochDistance(t *testing.T) {
+	distance, err := epochDistance(5, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(3), distance)
+	_, err = epochDistance(2, 5)
+	assert.ErrorContains(t, "cannot be greater than epoch", err)
+	_, err = epochDistance(math.MaxUint16, 0)
+	assert.ErrorContains(t, "does not fit in a span", err)
+}
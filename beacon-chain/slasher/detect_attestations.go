@@ -0,0 +1,412 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// Takes in a list of indexed attestation wrappers and returns any
+// found attester slashings to the caller.
+func (s *Service) checkSlashableAttestations(
+	ctx context.Context, currentEpoch types.Epoch, atts []*slashertypes.IndexedAttestationWrapper,
+) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.checkSlashableAttestations")
+	defer span.End()
+
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+
+	// Double votes are detected against the attestation records stored on disk
+	// and within the batch itself.
+	doubleVoteSlashings, err := s.checkDoubleVotes(ctx, atts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check slashable double votes")
+	}
+	slashings = append(slashings, doubleVoteSlashings...)
+
+	// The attestation records are saved before detecting surround votes, as surrounding or
+	// surrounded attestations are retrieved from disk by their target epoch, which
+	// includes the attestations of this batch.
+	if err := s.cfg.Database.SaveAttestationRecordsForValidators(ctx, atts); err != nil {
+		return nil, errors.Wrap(err, "could not save attestation records")
+	}
+
+	// Surround votes are detected using the min and max span chunks of each validator chunk index.
+	groupedAtts := s.groupByValidatorChunkIndex(atts)
+	for validatorChunkIdx, batch := range groupedAtts {
+		attSlashings, err := s.detectAllAttesterSlashings(ctx, validatorChunkIdx, currentEpoch, batch)
+		if err != nil {
+			return nil, err
+		}
+		slashings = append(slashings, attSlashings...)
+		indices := s.params.validatorIndicesInChunk(validatorChunkIdx)
+		for _, idx := range indices {
+			s.latestEpochWrittenForValidator[idx] = currentEpoch
+		}
+		if err := s.cfg.Database.SaveLastEpochWrittenForValidators(ctx, indices, currentEpoch); err != nil {
+			return nil, errors.Wrap(err, "could not save last epoch written for validators")
+		}
+	}
+
+	return slashings, nil
+}
+
+// Given a validator chunk index, a list of attestations for the validators of the chunk and
+// the current epoch, this function updates the min and max span chunks of the validators and
+// returns any surrounding or surrounded votes it detected. The updated chunks are written
+// to disk once detection is complete.
+func (s *Service) detectAllAttesterSlashings(
+	ctx context.Context,
+	validatorChunkIdx uint64,
+	currentEpoch types.Epoch,
+	attestations []*slashertypes.IndexedAttestationWrapper,
+) ([]*ethpb.AttesterSlashing, error) {
+	validatorIndices := s.params.validatorIndicesInChunk(validatorChunkIdx)
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		args := &chunkUpdateArgs{
+			kind:                kind,
+			validatorChunkIndex: validatorChunkIdx,
+			currentEpoch:        currentEpoch,
+		}
+		// Map of updated chunks by chunk index, which will be saved at the end.
+		updatedChunks := make(map[uint64]Chunker)
+
+		// Update the min or max span chunks of every validator for the change of current epoch.
+		for _, validatorIndex := range validatorIndices {
+			if err := s.epochUpdateForValidator(ctx, args, updatedChunks, validatorIndex); err != nil {
+				return nil, errors.Wrapf(
+					err, "could not update validator index %d chunks to epoch %d", validatorIndex, currentEpoch,
+				)
+			}
+		}
+
+		// Update min or max spans and retrieve any detected slashable offenses.
+		kindSlashings, err := s.updateSpans(ctx, updatedChunks, args, attestations)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err, "could not update spans for validator chunk index %d", validatorChunkIdx,
+			)
+		}
+		slashings = append(slashings, kindSlashings...)
+
+		// Write the updated chunks to disk.
+		if err := s.saveUpdatedChunks(ctx, args, updatedChunks); err != nil {
+			return nil, err
+		}
+	}
+	return slashings, nil
+}
+
+// Check for double votes in our database given a list of incoming attestations, and for
+// double votes among the attestations of the batch itself.
+func (s *Service) checkDoubleVotes(
+	ctx context.Context, attestations []*slashertypes.IndexedAttestationWrapper,
+) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.checkDoubleVotes")
+	defer span.End()
+
+	type attestationInfo struct {
+		validatorIndex types.ValidatorIndex
+		targetEpoch    types.Epoch
+	}
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	existingAtts := make(map[attestationInfo]*slashertypes.IndexedAttestationWrapper)
+	for _, att := range attestations {
+		for _, valIdx := range att.IndexedAttestation.AttestingIndices {
+			info := attestationInfo{
+				validatorIndex: types.ValidatorIndex(valIdx),
+				targetEpoch:    att.IndexedAttestation.Data.Target.Epoch,
+			}
+			existingAtt, ok := existingAtts[info]
+			if !ok {
+				existingAtts[info] = att
+				continue
+			}
+			if existingAtt.SigningRoot != att.SigningRoot {
+				doubleVotesTotal.Inc()
+				slashings = append(slashings, &ethpb.AttesterSlashing{
+					Attestation_1: existingAtt.IndexedAttestation,
+					Attestation_2: att.IndexedAttestation,
+				})
+			}
+		}
+	}
+
+	doubleVotes, err := s.cfg.Database.CheckAttesterDoubleVotes(ctx, attestations)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve potential double votes from disk")
+	}
+	for _, doubleVote := range doubleVotes {
+		doubleVotesTotal.Inc()
+		slashings = append(slashings, &ethpb.AttesterSlashing{
+			Attestation_1: doubleVote.PrevAttestationWrapper.IndexedAttestation,
+			Attestation_2: doubleVote.AttestationWrapper.IndexedAttestation,
+		})
+	}
+	return slashings, nil
+}
+
+// Mostly copied from Lighthouse's implementation of slasher:
+// https://github.com/sigp/lighthouse/blob/v1.4.0/slasher/src/array.rs#L431.
+// For each validator, we need to "fast-forward" its min and max span chunks
+// to the current epoch, setting neutral values for every epoch since the last
+// epoch written for the validator. Those cells may still contain the spans of
+// epochs which fell out of the history length.
+func (s *Service) epochUpdateForValidator(
+	ctx context.Context,
+	args *chunkUpdateArgs,
+	updatedChunks map[uint64]Chunker,
+	validatorIndex types.ValidatorIndex,
+) error {
+	latestEpochWritten, ok := s.latestEpochWrittenForValidator[validatorIndex]
+	if !ok {
+		return nil
+	}
+	epoch := latestEpochWritten + 1
+	// Only the last history length epochs are ever read from the chunks.
+	if args.currentEpoch >= s.params.historyLength && epoch <= args.currentEpoch-s.params.historyLength {
+		epoch = args.currentEpoch - s.params.historyLength + 1
+	}
+	for epoch <= args.currentEpoch {
+		chunkIdx := s.params.chunkIndex(epoch)
+		currentChunk, err := s.getChunk(ctx, args, updatedChunks, chunkIdx)
+		if err != nil {
+			return err
+		}
+		for s.params.chunkIndex(epoch) == chunkIdx && epoch <= args.currentEpoch {
+			if err := setChunkRawDistance(
+				s.params, currentChunk.Chunk(), validatorIndex, epoch, currentChunk.NeutralElement(),
+			); err != nil {
+				return err
+			}
+			epoch++
+		}
+		updatedChunks[chunkIdx] = currentChunk
+	}
+	return nil
+}
+
+// Updates spans and detects any slashable attester offenses along the way.
+// For every attestation by every validator index of the validator chunk index:
+//  - Check if the attestation is slashable, if so return a slashing object.
+//  - Otherwise, update the min or max span chunks for the validator.
+// Chunks are loaded from the database the first time they are needed and
+// the updated chunks are kept in the input map.
+func (s *Service) updateSpans(
+	ctx context.Context,
+	updatedChunks map[uint64]Chunker,
+	args *chunkUpdateArgs,
+	attestations []*slashertypes.IndexedAttestationWrapper,
+) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.updateSpans")
+	defer span.End()
+
+	// Apply the attestations to the related chunks and find any
+	// slashings along the way.
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	for _, att := range attestations {
+		for _, validatorIdx := range att.IndexedAttestation.AttestingIndices {
+			validatorIndex := types.ValidatorIndex(validatorIdx)
+			// Every validator chunk index represents a range of validators.
+			// It is possible that the validator index in this loop iteration is
+			// not part of the validator chunk index we are updating chunks for.
+			if s.params.validatorChunkIndex(validatorIndex) != args.validatorChunkIndex {
+				continue
+			}
+			slashing, err := s.applyAttestationForValidator(ctx, args, validatorIndex, updatedChunks, att)
+			if err != nil {
+				return nil, errors.Wrapf(
+					err,
+					"could not apply attestation for validator index %d",
+					validatorIndex,
+				)
+			}
+			if slashing != nil {
+				slashings = append(slashings, slashing)
+			}
+		}
+	}
+	return slashings, nil
+}
+
+// Checks if an incoming attestation is slashable based on the validator chunk it
+// corresponds to. If a slashable offense is found, we return it to the caller.
+// If not, then update every single chunk the attestation covers, starting from its
+// source epoch up to its target.
+func (s *Service) applyAttestationForValidator(
+	ctx context.Context,
+	args *chunkUpdateArgs,
+	validatorIndex types.ValidatorIndex,
+	chunksByChunkIdx map[uint64]Chunker,
+	attestation *slashertypes.IndexedAttestationWrapper,
+) (*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.applyAttestationForValidator")
+	defer span.End()
+	sourceEpoch := attestation.IndexedAttestation.Data.Source.Epoch
+	targetEpoch := attestation.IndexedAttestation.Data.Target.Epoch
+
+	chunkIdx := s.params.chunkIndex(sourceEpoch)
+	chunk, err := s.getChunk(ctx, args, chunksByChunkIdx, chunkIdx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get chunk at index %d", chunkIdx)
+	}
+
+	// Check slashable, if so, return the slashing.
+	slashing, err := chunk.CheckSlashable(ctx, s.cfg.Database, validatorIndex, attestation)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"could not check if attestation for validator index %d is slashable",
+			validatorIndex,
+		)
+	}
+	if slashing != nil {
+		return slashing, nil
+	}
+
+	// Get the first start epoch for the chunk. If it does not exist or
+	// is not possible based on the input arguments, do not continue with the update.
+	startEpoch, exists := chunk.StartEpoch(sourceEpoch, args.currentEpoch)
+	if !exists {
+		return nil, nil
+	}
+
+	// Given a single attestation could span across multiple chunks
+	// for a validator min or max span, we attempt to update the current chunk
+	// for the source epoch of the attestation. If the update function tells
+	// us we need to proceed to the next chunk, we continue by determining
+	// the start epoch of the next chunk. We exit once we no longer need to
+	// keep updating chunks.
+	for {
+		chunkIdx = s.params.chunkIndex(startEpoch)
+		chunk, err := s.getChunk(ctx, args, chunksByChunkIdx, chunkIdx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get chunk at index %d", chunkIdx)
+		}
+		keepGoing, err := chunk.Update(
+			chunkIdx,
+			args.currentEpoch,
+			validatorIndex,
+			startEpoch,
+			targetEpoch,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"could not update chunk at chunk index %d for validator index %d and current epoch %d",
+				chunkIdx,
+				validatorIndex,
+				args.currentEpoch,
+			)
+		}
+		// We update the chunksByChunkIdx map with the chunk we just updated.
+		chunksByChunkIdx[chunkIdx] = chunk
+		if !keepGoing {
+			break
+		}
+		// Move to first epoch of next chunk if needed.
+		startEpoch = chunk.NextChunkStartEpoch(startEpoch)
+	}
+	return nil, nil
+}
+
+// Retrieves a chunk at a chunk index from a map. If such chunk does not exist, which
+// should be rare (occurring when we receive an attestation with source and target epochs
+// that span multiple chunk indices), then we fallback to fetching from disk.
+func (s *Service) getChunk(
+	ctx context.Context,
+	args *chunkUpdateArgs,
+	chunksByChunkIdx map[uint64]Chunker,
+	chunkIdx uint64,
+) (Chunker, error) {
+	chunk, ok := chunksByChunkIdx[chunkIdx]
+	if ok {
+		return chunk, nil
+	}
+	// We can ensure we load the appropriate chunk we need by fetching from the DB.
+	diskChunks, err := s.loadChunks(ctx, args, []uint64{chunkIdx})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load chunk at index %d", chunkIdx)
+	}
+	if chunk, ok := diskChunks[chunkIdx]; ok {
+		return chunk, nil
+	}
+	return nil, fmt.Errorf("could not retrieve chunk at chunk index %d from disk", chunkIdx)
+}
+
+// Load chunks for a specified list of chunk indices. We attempt to load it from the database.
+// If the data exists, then we initialize a chunk of a specified kind. Otherwise, we create
+// an empty chunk, add it to our map, and then return it to the caller.
+func (s *Service) loadChunks(
+	ctx context.Context,
+	args *chunkUpdateArgs,
+	chunkIndices []uint64,
+) (map[uint64]Chunker, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.loadChunks")
+	defer span.End()
+	chunkKeys := make([][]byte, 0, len(chunkIndices))
+	for _, chunkIdx := range chunkIndices {
+		chunkKeys = append(chunkKeys, s.params.flatSliceID(args.validatorChunkIndex, chunkIdx))
+	}
+	rawChunks, chunksExist, err := s.cfg.Database.LoadSlasherChunks(ctx, args.kind, chunkKeys)
+	if err != nil {
+		return nil, errors.Wrapf(
+			err,
+			"could not load slasher chunk index for validator chunk index %d",
+			args.validatorChunkIndex,
+		)
+	}
+	chunksByChunkIdx := make(map[uint64]Chunker, len(rawChunks))
+	for i := 0; i < len(rawChunks); i++ {
+		// If the chunk exists in the database, we initialize it from the raw bytes data.
+		// If it does not exist, we initialize an empty chunk.
+		var chunk Chunker
+		switch args.kind {
+		case slashertypes.MinSpan:
+			if chunksExist[i] {
+				chunk, err = MinChunkSpansSliceFrom(s.params, rawChunks[i])
+			} else {
+				chunk = EmptyMinSpanChunksSlice(s.params)
+			}
+		case slashertypes.MaxSpan:
+			if chunksExist[i] {
+				chunk, err = MaxChunkSpansSliceFrom(s.params, rawChunks[i])
+			} else {
+				chunk = EmptyMaxSpanChunksSlice(s.params)
+			}
+		default:
+			return nil, fmt.Errorf("unknown chunk kind %d", args.kind)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "could not initialize chunk")
+		}
+		chunksByChunkIdx[chunkIndices[i]] = chunk
+	}
+	return chunksByChunkIdx, nil
+}
+
+// Saves updated chunks to disk given the required database schema.
+func (s *Service) saveUpdatedChunks(
+	ctx context.Context,
+	args *chunkUpdateArgs,
+	updatedChunksByChunkIdx map[uint64]Chunker,
+) error {
+	ctx, span := trace.StartSpan(ctx, "slasher.saveUpdatedChunks")
+	defer span.End()
+	chunkKeys := make([][]byte, 0, len(updatedChunksByChunkIdx))
+	chunks := make([][]uint16, 0, len(updatedChunksByChunkIdx))
+	for chunkIdx, chunk := range updatedChunksByChunkIdx {
+		chunkKeys = append(chunkKeys, s.params.flatSliceID(args.validatorChunkIndex, chunkIdx))
+		chunks = append(chunks, chunk.Chunk())
+	}
+	if err := s.cfg.Database.SaveSlasherChunks(ctx, args.kind, chunkKeys, chunks); err != nil {
+		return errors.Wrap(err, "could not save slasher chunks")
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_checkSlashableAttestations(t *testing.T) {
+	type attestationBatch struct {
+		currentEpoch types.Epoch
+		atts         []*slashertypes.IndexedAttestationWrapper
+	}
+	tests := []struct {
+		name           string
+		batches        []attestationBatch
+		wantSlashings  int
+		wantSourceAtt1 types.Epoch
+		wantTargetAtt1 types.Epoch
+	}{
+		{
+			name: "surrounding vote",
+			batches: []attestationBatch{
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0, 1}, nil),
+				}},
+				{currentEpoch: 3, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 0, 3, []uint64{1}, nil),
+				}},
+			},
+			wantSlashings:  1,
+			wantSourceAtt1: 0,
+			wantTargetAtt1: 3,
+		},
+		{
+			name: "surrounded vote",
+			batches: []attestationBatch{
+				{currentEpoch: 3, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 0, 3, []uint64{0, 1}, nil),
+				}},
+				{currentEpoch: 3, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{1}, nil),
+				}},
+			},
+			wantSlashings:  1,
+			wantSourceAtt1: 0,
+			wantTargetAtt1: 3,
+		},
+		{
+			name: "double vote against disk",
+			batches: []attestationBatch{
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{1}),
+				}},
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{2}),
+				}},
+			},
+			wantSlashings:  1,
+			wantSourceAtt1: 1,
+			wantTargetAtt1: 2,
+		},
+		{
+			name: "double vote within a batch",
+			batches: []attestationBatch{
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{1}),
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{2}),
+				}},
+			},
+			wantSlashings:  1,
+			wantSourceAtt1: 1,
+			wantTargetAtt1: 2,
+		},
+		{
+			name: "same attestation twice is not slashable",
+			batches: []attestationBatch{
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{1}),
+				}},
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0}, []byte{1}),
+				}},
+			},
+		},
+		{
+			name: "consecutive attestations are not slashable",
+			batches: []attestationBatch{
+				{currentEpoch: 2, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 1, 2, []uint64{0, 1}, nil),
+				}},
+				{currentEpoch: 3, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 2, 3, []uint64{0, 1}, nil),
+				}},
+				{currentEpoch: 5, atts: []*slashertypes.IndexedAttestationWrapper{
+					createAttestationWrapper(t, 3, 5, []uint64{0, 1}, nil),
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, _ := setupService(t)
+			var slashings int
+			for _, batch := range tt.batches {
+				found, err := s.checkSlashableAttestations(ctx, batch.currentEpoch, batch.atts)
+				require.NoError(t, err)
+				for _, slashing := range found {
+					slashings++
+					if tt.wantSlashings > 0 {
+						assert.Equal(t, tt.wantSourceAtt1, slashing.Attestation_1.Data.Source.Epoch)
+						assert.Equal(t, tt.wantTargetAtt1, slashing.Attestation_1.Data.Target.Epoch)
+					}
+				}
+			}
+			assert.Equal(t, tt.wantSlashings, slashings)
+		})
+	}
+}
+
+func TestService_processQueuedAttestations(t *testing.T) {
+	ctx := context.Background()
+	s, pool := setupService(t)
+
+	s.attsQueue.push(createAttestationWrapper(t, 1, 2, []uint64{0, 1}, nil))
+	// An attestation with a target epoch in the future is kept in the queue.
+	s.attsQueue.push(createAttestationWrapper(t, 0, 4, []uint64{2}, nil))
+	s.processQueuedAttestations(ctx, 3)
+	assert.Equal(t, 1, s.attsQueue.size())
+	assert.Equal(t, 0, len(pool.PendingAttSlashings))
+	assert.Equal(t, types.Epoch(3), s.latestEpochWrittenForValidator[0])
+
+	// The deferred attestation surrounds nothing for validator 2, while this
+	// one surrounds the attestation of validator 1.
+	s.attsQueue.push(createAttestationWrapper(t, 0, 3, []uint64{1}, nil))
+	s.processQueuedAttestations(ctx, 4)
+	assert.Equal(t, 0, s.attsQueue.size())
+	require.Equal(t, 1, len(pool.PendingAttSlashings))
+	assert.DeepEqual(t, []uint64{1}, pool.PendingAttSlashings[0].Attestation_1.AttestingIndices)
+	receiver, ok := s.cfg.SlashingReceiver.(*mockSlashingReceiver)
+	require.Equal(t, true, ok)
+	require.Equal(t, 1, len(receiver.slashings))
+	assert.DeepEqual(t, pool.PendingAttSlashings[0], receiver.slashings[0])
+}
@@ -0,0 +1,53 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"go.opencensus.io/trace"
+)
+
+// Detects double proposals among a list of signed block header wrappers, with respect
+// to each other and to the proposals stored on disk. The proposals are then saved so
+// they can be used to detect double proposals in later batches.
+func (s *Service) detectProposerSlashings(
+	ctx context.Context,
+	proposedBlocks []*slashertypes.SignedBlockHeaderWrapper,
+) ([]*ethpb.ProposerSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "slasher.detectProposerSlashings")
+	defer span.End()
+
+	// We check if there are any slashable double proposals in the input list
+	// of proposals with respect to each other.
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	existingProposals := make(map[string]*slashertypes.SignedBlockHeaderWrapper)
+	for _, proposal := range proposedBlocks {
+		key := proposalKey(proposal)
+		existingProposal, ok := existingProposals[key]
+		if !ok {
+			existingProposals[key] = proposal
+			continue
+		}
+		if existingProposal.SigningRoot != proposal.SigningRoot {
+			doubleProposalsTotal.Inc()
+			slashings = append(slashings, &ethpb.ProposerSlashing{
+				Header_1: existingProposal.SignedBeaconBlockHeader,
+				Header_2: proposal.SignedBeaconBlockHeader,
+			})
+		}
+	}
+
+	proposerSlashings, err := s.cfg.Database.CheckDoubleBlockProposals(ctx, proposedBlocks)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not check for double proposals on disk")
+	}
+	doubleProposalsTotal.Add(float64(len(proposerSlashings)))
+	slashings = append(slashings, proposerSlashings...)
+
+	if err := s.cfg.Database.SaveBlockProposals(ctx, proposedBlocks); err != nil {
+		return nil, errors.Wrap(err, "could not save block proposals")
+	}
+	return slashings, nil
+}
@@ -0,0 +1,52 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestService_detectProposerSlashings(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupService(t)
+
+	// Double proposals within a single batch are detected.
+	slashings, err := s.detectProposerSlashings(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 1, 1, []byte{1}),
+		createProposalWrapper(t, 1, 1, []byte{1}),
+		createProposalWrapper(t, 1, 2, []byte{1}),
+		createProposalWrapper(t, 1, 2, []byte{2}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	assert.Equal(t, types.ValidatorIndex(2), slashings[0].Header_1.Header.ProposerIndex)
+
+	// Double proposals with respect to the proposals saved on disk are detected.
+	slashings, err = s.detectProposerSlashings(ctx, []*slashertypes.SignedBlockHeaderWrapper{
+		createProposalWrapper(t, 1, 1, []byte{3}),
+		createProposalWrapper(t, 2, 2, []byte{3}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	assert.Equal(t, types.ValidatorIndex(1), slashings[0].Header_1.Header.ProposerIndex)
+	assert.Equal(t, types.Slot(1), slashings[0].Header_2.Header.Slot)
+}
+
+func TestService_processQueuedBlocks(t *testing.T) {
+	ctx := context.Background()
+	s, pool := setupService(t)
+
+	s.blksQueue.push(createProposalWrapper(t, 4, 1, []byte{1}))
+	s.processQueuedBlocks(ctx, 4)
+	assert.Equal(t, 0, s.blksQueue.size())
+	assert.Equal(t, 0, len(pool.PendingPropSlashings))
+
+	s.blksQueue.push(createProposalWrapper(t, 4, 1, []byte{2}))
+	s.processQueuedBlocks(ctx, 5)
+	require.Equal(t, 1, len(pool.PendingPropSlashings))
+	assert.Equal(t, types.Slot(4), pool.PendingPropSlashings[0].Header_1.Header.Slot)
+}
@@ -0,0 +1,125 @@
+package slasher
+
+import (
+	"fmt"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/sirupsen/logrus"
+)
+
+// Group a list of attestations into batches by validator chunk index.
+// This way, we can detect on the batch of attestations for each validator chunk index
+// separately, allowing us to effectively use a single 2D chunk for slashing detection
+// through this logical grouping.
+func (s *Service) groupByValidatorChunkIndex(
+	attestations []*slashertypes.IndexedAttestationWrapper,
+) map[uint64][]*slashertypes.IndexedAttestationWrapper {
+	groupedAttestations := make(map[uint64][]*slashertypes.IndexedAttestationWrapper)
+	for _, att := range attestations {
+		validatorChunkIndices := make(map[uint64]bool)
+		for _, validatorIdx := range att.IndexedAttestation.AttestingIndices {
+			validatorChunkIndex := s.params.validatorChunkIndex(types.ValidatorIndex(validatorIdx))
+			validatorChunkIndices[validatorChunkIndex] = true
+		}
+		for validatorChunkIndex := range validatorChunkIndices {
+			groupedAttestations[validatorChunkIndex] = append(
+				groupedAttestations[validatorChunkIndex],
+				att,
+			)
+		}
+	}
+	return groupedAttestations
+}
+
+// Filters a batch of attestations based on their target epoch. Attestations with a target
+// epoch in the future are deferred to a later batch, attestations older than the history
+// length slasher keeps track of are dropped, and the rest are valid for processing.
+func (s *Service) filterAttestations(
+	attWrappers []*slashertypes.IndexedAttestationWrapper, currentEpoch types.Epoch,
+) (valid, validInFuture []*slashertypes.IndexedAttestationWrapper, numDropped int) {
+	valid = make([]*slashertypes.IndexedAttestationWrapper, 0, len(attWrappers))
+	validInFuture = make([]*slashertypes.IndexedAttestationWrapper, 0)
+
+	for _, attWrapper := range attWrappers {
+		if attWrapper == nil || !validateAttestationIntegrity(attWrapper.IndexedAttestation) {
+			numDropped++
+			continue
+		}
+
+		// If an attestation's source epoch is older than the max history length
+		// we keep track of for slashing detection, we drop it.
+		if attWrapper.IndexedAttestation.Data.Source.Epoch+s.params.historyLength <= currentEpoch {
+			numDropped++
+			continue
+		}
+
+		// If an attestation's target epoch is in the future, we defer processing for later.
+		if attWrapper.IndexedAttestation.Data.Target.Epoch > currentEpoch {
+			validInFuture = append(validInFuture, attWrapper)
+		} else {
+			valid = append(valid, attWrapper)
+		}
+	}
+	return
+}
+
+// Validates the attestation data integrity, ensuring we have no nil values for
+// source and target epochs and that the source epoch of the attestation is less
+// than or equal to its target epoch, which is a requirement for slashing detection.
+func validateAttestationIntegrity(att *ethpb.IndexedAttestation) bool {
+	if att == nil ||
+		att.Data == nil ||
+		att.Data.Source == nil ||
+		att.Data.Target == nil {
+		return false
+	}
+
+	sourceEpoch := att.Data.Source.Epoch
+	targetEpoch := att.Data.Target.Epoch
+
+	// The genesis epoch is a special case, since all attestations formed in it
+	// will have source and target 0, and they should be considered valid.
+	if sourceEpoch == 0 && targetEpoch == 0 {
+		return true
+	}
+	return sourceEpoch < targetEpoch
+}
+
+// Validates the signed beacon block header integrity, ensuring we have no nil values.
+func validateBlockHeaderIntegrity(header *ethpb.SignedBeaconBlockHeader) bool {
+	// If a signed block header is malformed, we drop it.
+	if header == nil ||
+		header.Header == nil ||
+		len(header.Signature) != 96 {
+		return false
+	}
+	return true
+}
+
+// Unique key for a proposal by slot and proposer index, used for detecting double
+// proposals within a single batch of blocks.
+func proposalKey(proposal *slashertypes.SignedBlockHeaderWrapper) string {
+	header := proposal.SignedBeaconBlockHeader.Header
+	return fmt.Sprintf("%d:%d", header.Slot, header.ProposerIndex)
+}
+
+func logAttesterSlashing(slashing *ethpb.AttesterSlashing) {
+	indices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+	log.WithFields(logrus.Fields{
+		"validatorIndices":   indices,
+		"prevSourceEpoch":    slashing.Attestation_1.Data.Source.Epoch,
+		"prevTargetEpoch":    slashing.Attestation_1.Data.Target.Epoch,
+		"currentSourceEpoch": slashing.Attestation_2.Data.Source.Epoch,
+		"currentTargetEpoch": slashing.Attestation_2.Data.Target.Epoch,
+	}).Info("Attester slashing detected")
+}
+
+func logProposerSlashing(slashing *ethpb.ProposerSlashing) {
+	log.WithFields(logrus.Fields{
+		"validatorIndex": slashing.Header_1.Header.ProposerIndex,
+		"slot":           slashing.Header_1.Header.Slot,
+	}).Info("Proposer slashing detected")
+}
@@ -0,0 +1,174 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// Records the attester slashings handed to the chain.
+type mockSlashingReceiver struct {
+	slashings []*ethpb.AttesterSlashing
+}
+
+func (m *mockSlashingReceiver) ReceiveAttesterSlashing(_ context.Context, slashing *ethpb.AttesterSlashing) {
+	m.slashings = append(m.slashings, slashing)
+}
+
+func setupService(t *testing.T) (*Service, *slashings.PoolMock) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	pool := &slashings.PoolMock{}
+	s := &Service{
+		params: DefaultParams(),
+		cfg: &ServiceConfig{
+			Database:             dbtest.SetupSlasherDB(t),
+			HeadStateFetcher:     &mock.ChainService{State: beaconState},
+			SlashingPoolInserter: pool,
+			SlashingReceiver:     &mockSlashingReceiver{},
+		},
+		attsQueue:                      newAttestationsQueue(),
+		blksQueue:                      newBlocksQueue(),
+		latestEpochWrittenForValidator: make(map[types.ValidatorIndex]types.Epoch),
+	}
+	return s, pool
+}
+
+func createAttestationWrapper(
+	t *testing.T, source, target types.Epoch, indices []uint64, signingRoot []byte,
+) *slashertypes.IndexedAttestationWrapper {
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: bytesutil.PadTo(signingRoot, 32),
+		Source: &ethpb.Checkpoint{
+			Epoch: source,
+			Root:  params.BeaconConfig().ZeroHash[:],
+		},
+		Target: &ethpb.Checkpoint{
+			Epoch: target,
+			Root:  params.BeaconConfig().ZeroHash[:],
+		},
+	}
+	signRoot, err := data.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data:             data,
+			Signature:        params.BeaconConfig().EmptySignature[:],
+		},
+		SigningRoot: signRoot,
+	}
+}
+
+func createProposalWrapper(
+	t *testing.T, slot types.Slot, proposerIndex types.ValidatorIndex, signingRoot []byte,
+) *slashertypes.SignedBlockHeaderWrapper {
+	header := &ethpb.BeaconBlockHeader{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    params.BeaconConfig().ZeroHash[:],
+		StateRoot:     bytesutil.PadTo(signingRoot, 32),
+		BodyRoot:      params.BeaconConfig().ZeroHash[:],
+	}
+	signRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	return &slashertypes.SignedBlockHeaderWrapper{
+		SignedBeaconBlockHeader: &ethpb.SignedBeaconBlockHeader{
+			Header:    header,
+			Signature: params.BeaconConfig().EmptySignature[:],
+		},
+		SigningRoot: signRoot,
+	}
+}
+
+func TestService_groupByValidatorChunkIndex(t *testing.T) {
+	s := &Service{
+		params: &Parameters{validatorChunkSize: 2},
+	}
+	atts := []*slashertypes.IndexedAttestationWrapper{
+		createAttestationWrapper(t, 0, 1, []uint64{0, 1}, nil),
+		createAttestationWrapper(t, 0, 1, []uint64{1, 2, 3}, nil),
+		createAttestationWrapper(t, 0, 1, []uint64{4}, nil),
+	}
+	grouped := s.groupByValidatorChunkIndex(atts)
+	require.Equal(t, 3, len(grouped))
+	assert.DeepEqual(t, atts[:2], grouped[0])
+	assert.DeepEqual(t, atts[1:2], grouped[1])
+	assert.DeepEqual(t, atts[2:], grouped[2])
+}
+
+func TestService_filterAttestations(t *testing.T) {
+	s := &Service{
+		params: &Parameters{historyLength: 8},
+	}
+	valid := createAttestationWrapper(t, 1, 2, []uint64{0}, nil)
+	future := createAttestationWrapper(t, 2, 5, []uint64{0}, nil)
+	tooOld := createAttestationWrapper(t, 2, 3, []uint64{0}, nil)
+	sourceAfterTarget := createAttestationWrapper(t, 3, 2, []uint64{0}, nil)
+	nilData := &slashertypes.IndexedAttestationWrapper{IndexedAttestation: &ethpb.IndexedAttestation{}}
+
+	gotValid, gotFuture, numDropped := s.filterAttestations(
+		[]*slashertypes.IndexedAttestationWrapper{valid, future, sourceAfterTarget, nilData}, 4,
+	)
+	assert.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{valid}, gotValid)
+	assert.DeepEqual(t, []*slashertypes.IndexedAttestationWrapper{future}, gotFuture)
+	assert.Equal(t, 2, numDropped)
+
+	gotValid, gotFuture, numDropped = s.filterAttestations(
+		[]*slashertypes.IndexedAttestationWrapper{tooOld, future}, 10,
+	)
+	assert.Equal(t, 0, len(gotValid))
+	assert.Equal(t, 0, len(gotFuture))
+	assert.Equal(t, 2, numDropped)
+}
+
+func Test_validateAttestationIntegrity(t *testing.T) {
+	tests := []struct {
+		name string
+		att  *ethpb.IndexedAttestation
+		want bool
+	}{
+		{
+			name: "nil attestation",
+			att:  nil,
+			want: false,
+		},
+		{
+			name: "nil source",
+			att: &ethpb.IndexedAttestation{
+				Data: &ethpb.AttestationData{Target: &ethpb.Checkpoint{}},
+			},
+			want: false,
+		},
+		{
+			name: "genesis attestation",
+			att:  createAttestationWrapper(t, 0, 0, nil, nil).IndexedAttestation,
+			want: true,
+		},
+		{
+			name: "source equal to target",
+			att:  createAttestationWrapper(t, 1, 1, nil, nil).IndexedAttestation,
+			want: false,
+		},
+		{
+			name: "source lower than target",
+			att:  createAttestationWrapper(t, 1, 2, nil, nil).IndexedAttestation,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validateAttestationIntegrity(tt.att))
+		})
+	}
+}
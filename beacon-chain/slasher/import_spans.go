@@ -0,0 +1,158 @@
+package slasher
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+)
+
+// HistoryLength returns the number of epochs of min and max spans kept by slasher.
+func (p *Parameters) HistoryLength() types.Epoch {
+	return p.historyLength
+}
+
+// SpansImporter writes min and max spans computed by another slasher implementation,
+// such as the standalone slasher, into the span chunks of the slasher database.
+//
+// Spans are buffered for the epochs of a single chunk index at a time, and the buffered
+// chunks are written to disk whenever a span for an epoch of another chunk index is set.
+// Spans must therefore be set in increasing epoch order, for epochs within a single
+// history length, and Flush must be called once all spans have been set.
+type SpansImporter struct {
+	params        *Parameters
+	db            db.SlasherDatabase
+	chunkIdx      uint64
+	chunks        map[slashertypes.ChunkKind]map[uint64]Chunker
+	chunksWritten int
+}
+
+// NewSpansImporter creates a spans importer writing into the given slasher database.
+func NewSpansImporter(database db.SlasherDatabase, params *Parameters) *SpansImporter {
+	return &SpansImporter{
+		params: params,
+		db:     database,
+		chunks: map[slashertypes.ChunkKind]map[uint64]Chunker{
+			slashertypes.MinSpan: make(map[uint64]Chunker),
+			slashertypes.MaxSpan: make(map[uint64]Chunker),
+		},
+	}
+}
+
+// SetMinSpan sets the min span distance of a validator at an epoch, unless the
+// chunk already holds a lower distance for it.
+func (si *SpansImporter) SetMinSpan(
+	ctx context.Context, validatorIndex types.ValidatorIndex, epoch types.Epoch, distance uint16,
+) error {
+	return si.setSpan(ctx, slashertypes.MinSpan, validatorIndex, epoch, distance)
+}
+
+// SetMaxSpan sets the max span distance of a validator at an epoch, unless the
+// chunk already holds a higher distance for it.
+func (si *SpansImporter) SetMaxSpan(
+	ctx context.Context, validatorIndex types.ValidatorIndex, epoch types.Epoch, distance uint16,
+) error {
+	return si.setSpan(ctx, slashertypes.MaxSpan, validatorIndex, epoch, distance)
+}
+
+// Flush writes the buffered chunks to disk.
+func (si *SpansImporter) Flush(ctx context.Context) error {
+	for kind, chunksByValidatorChunkIdx := range si.chunks {
+		if len(chunksByValidatorChunkIdx) == 0 {
+			continue
+		}
+		chunkKeys := make([][]byte, 0, len(chunksByValidatorChunkIdx))
+		chunks := make([][]uint16, 0, len(chunksByValidatorChunkIdx))
+		for validatorChunkIdx, chunk := range chunksByValidatorChunkIdx {
+			chunkKeys = append(chunkKeys, si.params.flatSliceID(validatorChunkIdx, si.chunkIdx))
+			chunks = append(chunks, chunk.Chunk())
+		}
+		if err := si.db.SaveSlasherChunks(ctx, kind, chunkKeys, chunks); err != nil {
+			return errors.Wrap(err, "could not save slasher chunks")
+		}
+		si.chunksWritten += len(chunks)
+		si.chunks[kind] = make(map[uint64]Chunker)
+	}
+	return nil
+}
+
+// ChunksWritten returns the number of chunks written to disk so far.
+func (si *SpansImporter) ChunksWritten() int {
+	return si.chunksWritten
+}
+
+func (si *SpansImporter) setSpan(
+	ctx context.Context,
+	kind slashertypes.ChunkKind,
+	validatorIndex types.ValidatorIndex,
+	epoch types.Epoch,
+	distance uint16,
+) error {
+	chunkIdx := si.params.chunkIndex(epoch)
+	if chunkIdx != si.chunkIdx {
+		if err := si.Flush(ctx); err != nil {
+			return err
+		}
+		si.chunkIdx = chunkIdx
+	}
+	validatorChunkIdx := si.params.validatorChunkIndex(validatorIndex)
+	chunk, err := si.chunk(ctx, kind, validatorChunkIdx)
+	if err != nil {
+		return err
+	}
+	cellIdx := si.params.cellIndex(validatorIndex, epoch)
+	data := chunk.Chunk()
+	if cellIdx >= uint64(len(data)) {
+		return fmt.Errorf("cell index %d out of bounds (len(chunk) = %d)", cellIdx, len(data))
+	}
+	// Keep the most restrictive span of the existing and imported ones.
+	existing := data[cellIdx]
+	if kind == slashertypes.MinSpan && distance >= existing {
+		return nil
+	}
+	if kind == slashertypes.MaxSpan && distance <= existing {
+		return nil
+	}
+	data[cellIdx] = distance
+	return nil
+}
+
+// Retrieves the buffered chunk of a kind for a validator chunk index,
+// loading it from disk the first time it is needed.
+func (si *SpansImporter) chunk(
+	ctx context.Context, kind slashertypes.ChunkKind, validatorChunkIdx uint64,
+) (Chunker, error) {
+	if chunk, ok := si.chunks[kind][validatorChunkIdx]; ok {
+		return chunk, nil
+	}
+	chunkKey := si.params.flatSliceID(validatorChunkIdx, si.chunkIdx)
+	rawChunks, chunksExist, err := si.db.LoadSlasherChunks(ctx, kind, [][]byte{chunkKey})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not load slasher chunk for validator chunk index %d", validatorChunkIdx)
+	}
+	var chunk Chunker
+	switch kind {
+	case slashertypes.MinSpan:
+		if len(chunksExist) == 1 && chunksExist[0] {
+			chunk, err = MinChunkSpansSliceFrom(si.params, rawChunks[0])
+		} else {
+			chunk = EmptyMinSpanChunksSlice(si.params)
+		}
+	case slashertypes.MaxSpan:
+		if len(chunksExist) == 1 && chunksExist[0] {
+			chunk, err = MaxChunkSpansSliceFrom(si.params, rawChunks[0])
+		} else {
+			chunk = EmptyMaxSpanChunksSlice(si.params)
+		}
+	default:
+		return nil, fmt.Errorf("unknown chunk kind %d", kind)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize chunk")
+	}
+	si.chunks[kind][validatorChunkIdx] = chunk
+	return chunk, nil
+}
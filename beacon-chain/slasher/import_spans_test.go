@@ -0,0 +1,69 @@
+package slasher
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestSpansImporter(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupSlasherDB(t)
+	params := &Parameters{
+		chunkSize:          2,
+		validatorChunkSize: 2,
+		historyLength:      8,
+	}
+	importer := NewSpansImporter(beaconDB, params)
+
+	// Epochs 0 and 1 are in chunk index 0, epoch 2 in chunk index 1 which
+	// writes the chunks of chunk index 0 to disk.
+	require.NoError(t, importer.SetMinSpan(ctx, 1, 0, 3))
+	require.NoError(t, importer.SetMaxSpan(ctx, 1, 1, 2))
+	require.NoError(t, importer.SetMinSpan(ctx, 2, 1, 4))
+	assert.Equal(t, 0, importer.ChunksWritten())
+	require.NoError(t, importer.SetMinSpan(ctx, 1, 2, 1))
+	assert.Equal(t, 3, importer.ChunksWritten())
+	require.NoError(t, importer.Flush(ctx))
+	assert.Equal(t, 4, importer.ChunksWritten())
+
+	loadChunk := func(kind slashertypes.ChunkKind, validatorChunkIdx, chunkIdx uint64) []uint16 {
+		chunks, exist, err := beaconDB.LoadSlasherChunks(
+			ctx, kind, [][]byte{params.flatSliceID(validatorChunkIdx, chunkIdx)},
+		)
+		require.NoError(t, err)
+		require.Equal(t, true, exist[0])
+		return chunks[0]
+	}
+	minChunk := loadChunk(slashertypes.MinSpan, 0, 0)
+	assert.DeepEqual(t, []uint16{math.MaxUint16, math.MaxUint16, 3, math.MaxUint16}, minChunk)
+	maxChunk := loadChunk(slashertypes.MaxSpan, 0, 0)
+	assert.DeepEqual(t, []uint16{0, 0, 0, 2}, maxChunk)
+	minChunk = loadChunk(slashertypes.MinSpan, 1, 0)
+	assert.DeepEqual(t, []uint16{math.MaxUint16, 4, math.MaxUint16, math.MaxUint16}, minChunk)
+	minChunk = loadChunk(slashertypes.MinSpan, 0, 1)
+	assert.DeepEqual(t, []uint16{math.MaxUint16, math.MaxUint16, 1, math.MaxUint16}, minChunk)
+
+	// Imported spans are merged with the spans already stored on disk, keeping
+	// the lowest min span and the highest max span.
+	importer = NewSpansImporter(beaconDB, params)
+	require.NoError(t, importer.SetMinSpan(ctx, 1, 0, 5))
+	require.NoError(t, importer.SetMinSpan(ctx, 0, 0, 5))
+	require.NoError(t, importer.SetMaxSpan(ctx, 1, 1, 1))
+	require.NoError(t, importer.SetMaxSpan(ctx, 0, 1, 1))
+	require.NoError(t, importer.Flush(ctx))
+	minChunk = loadChunk(slashertypes.MinSpan, 0, 0)
+	assert.DeepEqual(t, []uint16{5, math.MaxUint16, 3, math.MaxUint16}, minChunk)
+	maxChunk = loadChunk(slashertypes.MaxSpan, 0, 0)
+	assert.DeepEqual(t, []uint16{0, 1, 0, 2}, maxChunk)
+
+	target, err := chunkDataAtEpoch(params, maxChunk, 1, types.Epoch(1))
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(3), target)
+}
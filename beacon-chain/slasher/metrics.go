@@ -0,0 +1,49 @@
+package slasher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	receivedAttsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_attestations_received_total",
+		Help: "The # of attestations received by slasher",
+	})
+	receivedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blocks_received_total",
+		Help: "The # of blocks received by slasher",
+	})
+	droppedAttsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_attestations_dropped_total",
+		Help: "The # of attestations dropped by slasher because they were invalid or too old",
+	})
+	droppedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blocks_dropped_total",
+		Help: "The # of blocks dropped by slasher because they were invalid or slasher could not keep up",
+	})
+	processedAttsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_attestations_processed_total",
+		Help: "The # of attestations processed by slasher",
+	})
+	processedBlocksTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_blocks_processed_total",
+		Help: "The # of blocks processed by slasher",
+	})
+	doubleProposalsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_double_proposals_total",
+		Help: "The # of double propose slashable events detected by slasher",
+	})
+	doubleVotesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_double_votes_total",
+		Help: "The # of double vote slashable events detected by slasher",
+	})
+	surroundingVotesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_surrounding_votes_total",
+		Help: "The # of surrounding slashable events detected by slasher",
+	})
+	surroundedVotesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_surrounded_votes_total",
+		Help: "The # of surrounded slashable events detected by slasher",
+	})
+)
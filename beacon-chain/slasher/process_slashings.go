@@ -0,0 +1,50 @@
+package slasher
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// Submits the detected attester slashings to the slashings operation pool, which verifies
+// them against the head state before they can be included in a block. Verified slashings
+// are also handed to the chain, so the slashed validators' votes are discarded by fork choice.
+func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	headState, err := s.cfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve head state")
+		return
+	}
+	for _, slashing := range slashings {
+		logAttesterSlashing(slashing)
+		if err := s.cfg.SlashingPoolInserter.InsertAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Could not insert attester slashing into operations pool")
+			continue
+		}
+		if s.cfg.SlashingReceiver != nil {
+			s.cfg.SlashingReceiver.ReceiveAttesterSlashing(ctx, slashing)
+		}
+	}
+}
+
+// Submits the detected proposer slashings to the slashings operation pool, which verifies
+// them against the head state before they can be included in a block.
+func (s *Service) processProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) {
+	if len(slashings) == 0 {
+		return
+	}
+	headState, err := s.cfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve head state")
+		return
+	}
+	for _, slashing := range slashings {
+		logProposerSlashing(slashing)
+		if err := s.cfg.SlashingPoolInserter.InsertProposerSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Could not insert proposer slashing into operations pool")
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/blockutil"
+	"github.com/prysmaticlabs/prysm/shared/p2putils"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// Receive attestations from the operation feed. The attestations are handed over to
+// the indexing routine without blocking the senders of the feed, dropping attestations
+// if slasher cannot keep up with the rate they are received at.
+func (s *Service) receiveAttestations(ctx context.Context) {
+	opChannel := make(chan *feed.Event, 1)
+	opSub := s.cfg.OperationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+	for {
+		select {
+		case event := <-opChannel:
+			var att *ethpb.Attestation
+			switch event.Type {
+			case operation.UnaggregatedAttReceived:
+				data, ok := event.Data.(*operation.UnAggregatedAttReceivedData)
+				if !ok {
+					continue
+				}
+				att = data.Attestation
+			case operation.AggregatedAttReceived:
+				data, ok := event.Data.(*operation.AggregatedAttReceivedData)
+				if !ok || data.Attestation == nil {
+					continue
+				}
+				att = data.Attestation.Aggregate
+			default:
+				continue
+			}
+			if helpers.ValidateNilAttestation(att) != nil {
+				continue
+			}
+			select {
+			case s.receivedAttsChan <- att:
+			default:
+				droppedAttsTotal.Inc()
+			}
+		case err := <-opSub.Err():
+			log.WithError(err).Debug("Subscriber closed with error")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Converts the received attestations to indexed attestations, verifying their signature,
+// and queues them for processing at the next slot tick.
+func (s *Service) indexAttestations(ctx context.Context) {
+	for {
+		select {
+		case att := <-s.receivedAttsChan:
+			attWrapper, err := s.indexedAttestationWrapper(ctx, att)
+			if err != nil {
+				log.WithError(err).Debug("Could not convert attestation to indexed attestation")
+				droppedAttsTotal.Inc()
+				continue
+			}
+			s.attsQueue.push(attWrapper)
+			receivedAttsTotal.Inc()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Receive beacon blocks from the block feed. The blocks are handed over to the
+// indexing routine without blocking the senders of the feed, dropping blocks
+// if slasher cannot keep up with the rate they are received at.
+func (s *Service) receiveBlocks(ctx context.Context) {
+	blockChannel := make(chan *feed.Event, 1)
+	blockSub := s.cfg.BlockNotifier.BlockFeed().Subscribe(blockChannel)
+	defer blockSub.Unsubscribe()
+	for {
+		select {
+		case event := <-blockChannel:
+			if event.Type != blockfeed.ReceivedBlock {
+				continue
+			}
+			data, ok := event.Data.(*blockfeed.ReceivedBlockData)
+			if !ok || data.SignedBlock == nil || data.SignedBlock.IsNil() {
+				continue
+			}
+			select {
+			case s.receivedBlocksChan <- data.SignedBlock:
+			default:
+				droppedBlocksTotal.Inc()
+			}
+		case err := <-blockSub.Err():
+			log.WithError(err).Debug("Subscriber closed with error")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Converts the received blocks to signed block headers, verifying their proposer
+// signature, and queues them for processing at the next slot tick.
+func (s *Service) indexBlocks(ctx context.Context) {
+	for {
+		select {
+		case blk := <-s.receivedBlocksChan:
+			blkWrapper, err := s.signedBlockHeaderWrapper(ctx, blk)
+			if err != nil {
+				log.WithError(err).Debug("Could not convert block to signed block header")
+				droppedBlocksTotal.Inc()
+				continue
+			}
+			s.blksQueue.push(blkWrapper)
+			receivedBlocksTotal.Inc()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Process queued attestations, blocks, and prune old slasher data at every slot tick.
+// Every step is performed sequentially, as they all read and write the slasher database.
+func (s *Service) processQueued(ctx context.Context, slotTicker <-chan types.Slot) {
+	for {
+		select {
+		case currentSlot := <-slotTicker:
+			currentEpoch := core.SlotToEpoch(currentSlot)
+			s.processQueuedBlocks(ctx, currentSlot)
+			s.processQueuedAttestations(ctx, currentEpoch)
+			if core.IsEpochStart(currentSlot) {
+				if err := s.pruneSlasherData(ctx, currentEpoch); err != nil {
+					log.WithError(err).Error("Could not prune slasher data")
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Process queued attestations, detecting and submitting any slashable offenses they
+// are part of. Attestations with a target epoch in the future are kept in the queue.
+func (s *Service) processQueuedAttestations(ctx context.Context, currentEpoch types.Epoch) {
+	ctx, span := trace.StartSpan(ctx, "slasher.processQueuedAttestations")
+	defer span.End()
+
+	attestations := s.attsQueue.dequeue()
+	validAtts, validInFuture, numDropped := s.filterAttestations(attestations, currentEpoch)
+	// Attestations with a target epoch in the future are processed at a later epoch.
+	s.attsQueue.extend(validInFuture)
+	droppedAttsTotal.Add(float64(numDropped))
+	if len(validAtts) == 0 {
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"currentEpoch":    currentEpoch,
+		"numAtts":         len(validAtts),
+		"numDeferredAtts": len(validInFuture),
+		"numDroppedAtts":  numDropped,
+	}).Debug("Processing queued attestations for slashing detection")
+
+	slashings, err := s.checkSlashableAttestations(ctx, currentEpoch, validAtts)
+	if err != nil {
+		log.WithError(err).Error("Could not check slashable attestations")
+		return
+	}
+	processedAttsTotal.Add(float64(len(validAtts)))
+	s.processAttesterSlashings(ctx, slashings)
+}
+
+// Process queued blocks, detecting and submitting any double proposals.
+func (s *Service) processQueuedBlocks(ctx context.Context, currentSlot types.Slot) {
+	ctx, span := trace.StartSpan(ctx, "slasher.processQueuedBlocks")
+	defer span.End()
+
+	blks := s.blksQueue.dequeue()
+	if len(blks) == 0 {
+		return
+	}
+
+	log.WithFields(logrus.Fields{
+		"currentSlot": currentSlot,
+		"numBlocks":   len(blks),
+	}).Debug("Processing queued blocks for slashing detection")
+
+	slashings, err := s.detectProposerSlashings(ctx, blks)
+	if err != nil {
+		log.WithError(err).Error("Could not detect proposer slashings")
+		return
+	}
+	processedBlocksTotal.Add(float64(len(blks)))
+	s.processProposerSlashings(ctx, slashings)
+}
+
+// Prunes the attestations and proposals which fell out of the history length slasher keeps.
+func (s *Service) pruneSlasherData(ctx context.Context, currentEpoch types.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "slasher.pruneSlasherData")
+	defer span.End()
+
+	if currentEpoch < s.params.historyLength {
+		return nil
+	}
+	maxPruningEpoch := currentEpoch - s.params.historyLength
+	numPrunedAtts, err := s.cfg.Database.PruneAttestationsAtEpoch(ctx, maxPruningEpoch)
+	if err != nil {
+		return errors.Wrap(err, "could not prune attestations")
+	}
+	numPrunedProposals, err := s.cfg.Database.PruneProposalsAtEpoch(ctx, maxPruningEpoch)
+	if err != nil {
+		return errors.Wrap(err, "could not prune proposals")
+	}
+	if numPrunedAtts > 0 || numPrunedProposals > 0 {
+		log.WithFields(logrus.Fields{
+			"maxPruningEpoch":    maxPruningEpoch,
+			"numPrunedAtts":      numPrunedAtts,
+			"numPrunedProposals": numPrunedProposals,
+		}).Debug("Pruned old slasher data")
+	}
+	return nil
+}
+
+// Converts an attestation to an indexed attestation wrapper using the committee of its
+// target state, verifying the signature of the attestation along the way. Only attestations
+// with a valid signature are considered for slashing detection.
+func (s *Service) indexedAttestationWrapper(
+	ctx context.Context, att *ethpb.Attestation,
+) (*slashertypes.IndexedAttestationWrapper, error) {
+	preState, err := s.cfg.AttestationStateFetcher.AttestationPreState(ctx, att)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve attestation pre state")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(preState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve beacon committee")
+	}
+	indexedAtt, err := attestationutil.ConvertToIndexed(ctx, att, committee)
+	if err != nil {
+		return nil, err
+	}
+	if err := blocks.VerifyIndexedAttestation(ctx, preState, indexedAtt); err != nil {
+		return nil, errors.Wrap(err, "could not verify indexed attestation")
+	}
+	signingRoot, err := att.Data.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &slashertypes.IndexedAttestationWrapper{
+		IndexedAttestation: indexedAtt,
+		SigningRoot:        signingRoot,
+	}, nil
+}
+
+// Converts a beacon block to a signed block header wrapper, verifying the proposer
+// signature of the block with the validator set of the head state.
+func (s *Service) signedBlockHeaderWrapper(
+	ctx context.Context, blk block.SignedBeaconBlock,
+) (*slashertypes.SignedBlockHeaderWrapper, error) {
+	header, err := blockutil.SignedBeaconBlockHeaderFromBlockInterface(blk)
+	if err != nil {
+		return nil, err
+	}
+	if !validateBlockHeaderIntegrity(header) {
+		return nil, errors.New("malformed signed block header")
+	}
+	pubKey, err := s.cfg.HeadStateFetcher.HeadValidatorIndexToPublicKey(ctx, header.Header.ProposerIndex)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve proposer public key")
+	}
+	epoch := core.SlotToEpoch(header.Header.Slot)
+	fork, err := p2putils.Fork(epoch)
+	if err != nil {
+		return nil, err
+	}
+	genesisValidatorsRoot := s.cfg.HeadStateFetcher.HeadGenesisValidatorRoot()
+	domain, err := helpers.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, genesisValidatorsRoot[:])
+	if err != nil {
+		return nil, err
+	}
+	if err := helpers.VerifyBlockHeaderSigningRoot(header.Header, pubKey[:], header.Signature, domain); err != nil {
+		return nil, errors.Wrap(err, "could not verify block header signature")
+	}
+	signingRoot, err := header.Header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &slashertypes.SignedBlockHeaderWrapper{
+		SignedBeaconBlockHeader: header,
+		SigningRoot:             signingRoot,
+	}, nil
+}
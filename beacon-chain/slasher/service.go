@@ -1,11 +1,189 @@
+// Package slasher defines an optional service of the beacon node which detects slashable
+// offenses from the attestations and blocks the node receives, and submits the resulting
+// slashings to the slashings operation pool and the chain.
 package slasher
 
 import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/sirupsen/logrus"
 )
 
-var log = logrus.WithField("prefix", "slasher")
+var _ shared.Service = (*Service)(nil)
+
+// Maximum number of received attestations waiting to be converted to indexed
+// attestations before slasher starts dropping them.
+const receivedAttsBufferSize = 10000
+
+// Maximum number of received blocks waiting for their proposer signature to be
+// verified before slasher starts dropping them.
+const receivedBlocksBufferSize = 1000
 
+// ServiceConfig for the slasher service in the beacon node.
+// This struct allows us to specify required dependencies and
+// parameters for slasher to function as needed.
+type ServiceConfig struct {
+	Database                db.SlasherDatabase
+	StateNotifier           statefeed.Notifier
+	BlockNotifier           blockfeed.Notifier
+	OperationNotifier       operation.Notifier
+	HeadStateFetcher        blockchain.HeadFetcher
+	AttestationStateFetcher blockchain.AttestationReceiver
+	SlashingPoolInserter    slashings.PoolManager
+	SlashingReceiver        blockchain.SlashingReceiver
+}
+
+// Service defining a slasher implementation as part of
+// the beacon node, able to detect eth2 slashable offenses.
 type Service struct {
-	params *Parameters
+	params                         *Parameters
+	cfg                            *ServiceConfig
+	ctx                            context.Context
+	cancel                         context.CancelFunc
+	genesisTimeChan                chan time.Time
+	receivedAttsChan               chan *ethpb.Attestation
+	receivedBlocksChan             chan block.SignedBeaconBlock
+	attsQueue                      *attestationsQueue
+	blksQueue                      *blocksQueue
+	latestEpochWrittenForValidator map[types.ValidatorIndex]types.Epoch
+}
+
+// NewService instantiates a new slasher from configuration values.
+func NewService(ctx context.Context, cfg *ServiceConfig) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		params:                         DefaultParams(),
+		cfg:                            cfg,
+		ctx:                            ctx,
+		cancel:                         cancel,
+		genesisTimeChan:                make(chan time.Time, 1),
+		receivedAttsChan:               make(chan *ethpb.Attestation, receivedAttsBufferSize),
+		receivedBlocksChan:             make(chan block.SignedBeaconBlock, receivedBlocksBufferSize),
+		attsQueue:                      newAttestationsQueue(),
+		blksQueue:                      newBlocksQueue(),
+		latestEpochWrittenForValidator: make(map[types.ValidatorIndex]types.Epoch),
+	}
+	// The state initialized event may be sent before the service is started.
+	go s.waitForChainInitialization()
+	return s
+}
+
+// Start listening for received indexed attestations and blocks
+// and perform slashing detection on them.
+func (s *Service) Start() {
+	go s.run()
+}
+
+// Stop the slasher service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the slasher service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) run() {
+	var genesisTime time.Time
+	select {
+	case genesisTime = <-s.genesisTimeChan:
+	case <-s.ctx.Done():
+		return
+	}
+	if genesisTime.IsZero() {
+		return
+	}
+
+	if err := s.loadLatestEpochsWritten(s.ctx); err != nil {
+		log.WithError(err).Error("Could not read the last epoch written for validators, slasher is not running")
+		return
+	}
+
+	go s.receiveAttestations(s.ctx)
+	go s.indexAttestations(s.ctx)
+	go s.receiveBlocks(s.ctx)
+	go s.indexBlocks(s.ctx)
+
+	slotTicker := slotutil.NewSlotTicker(genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer slotTicker.Done()
+	log.Info("Started slasher, detecting slashable offenses")
+	s.processQueued(s.ctx, slotTicker.C())
+}
+
+// Reads the last epoch written for each validator of the head state from disk, so the
+// min and max spans of the validators are fast-forwarded correctly after a restart.
+func (s *Service) loadLatestEpochsWritten(ctx context.Context) error {
+	headState, err := s.cfg.HeadStateFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head state")
+	}
+	if headState == nil || headState.IsNil() {
+		return errors.New("nil head state")
+	}
+	numVals := headState.NumValidators()
+	validatorIndices := make([]types.ValidatorIndex, numVals)
+	for i := 0; i < numVals; i++ {
+		validatorIndices[i] = types.ValidatorIndex(i)
+	}
+	start := time.Now()
+	epochsByValidator, err := s.cfg.Database.LastEpochWrittenForValidators(ctx, validatorIndices)
+	if err != nil {
+		return err
+	}
+	for _, item := range epochsByValidator {
+		s.latestEpochWrittenForValidator[item.ValidatorIndex] = item.Epoch
+	}
+	log.WithFields(logrus.Fields{
+		"numValidators": numVals,
+		"elapsed":       time.Since(start),
+	}).Debug("Read last epoch written for each validator")
+	return nil
+}
+
+// Waits for the state initialized event to retrieve the genesis time of the chain,
+// from which the slot ticker of slasher is derived.
+func (s *Service) waitForChainInitialization() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type == statefeed.Initialized {
+				data, ok := event.Data.(*statefeed.InitializedData)
+				if !ok {
+					log.Error("Event feed data is not type *statefeed.InitializedData")
+					continue
+				}
+				log.WithField("starttime", data.StartTime).Debug("Received state initialized event")
+				s.genesisTimeChan <- data.StartTime
+				return
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			// Send a zero time so slasher does not start.
+			s.genesisTimeChan <- time.Time{}
+			return
+		}
+	}
 }
@@ -0,0 +1,93 @@
+// Package detect decodes SSZ encoded beacon states and blocks whose fork is not known
+// in advance, by inspecting the fork related fields of the encoding.
+package detect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const (
+	// The fork of a serialized beacon state comes after the genesis time (8 bytes), the genesis
+	// validators root (32 bytes) and the slot (8 bytes). Its current version follows the previous version.
+	stateForkCurrentVersionOffset = 8 + 32 + 8 + 4
+	stateForkCurrentVersionEnd    = stateForkCurrentVersionOffset + 4
+	// A serialized signed block starts with the offset of the message (4 bytes) and the
+	// signature (96 bytes), the slot is the first field of the message.
+	signedBlockSlotOffset = 4 + 96
+	signedBlockSlotEnd    = signedBlockSlotOffset + 8
+)
+
+// StateForkVersion returns the current fork version of a SSZ encoded beacon state.
+func StateForkVersion(enc []byte) ([]byte, error) {
+	if len(enc) < stateForkCurrentVersionEnd {
+		return nil, errors.New("encoded state is too short")
+	}
+	return enc[stateForkCurrentVersionOffset:stateForkCurrentVersionEnd], nil
+}
+
+// UnmarshalBeaconState decodes a SSZ encoded beacon state of any supported fork.
+func UnmarshalBeaconState(enc []byte) (state.BeaconState, error) {
+	forkVersion, err := StateForkVersion(enc)
+	if err != nil {
+		return nil, err
+	}
+	cfg := params.BeaconConfig()
+	switch {
+	case bytes.Equal(forkVersion, cfg.GenesisForkVersion):
+		protoState := &ethpb.BeaconState{}
+		if err := protoState.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase 0 state")
+		}
+		return v1.InitializeFromProtoUnsafe(protoState)
+	case bytes.Equal(forkVersion, cfg.AltairForkVersion):
+		protoState := &ethpb.BeaconStateAltair{}
+		if err := protoState.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair state")
+		}
+		return v2.InitializeFromProtoUnsafe(protoState)
+	default:
+		return nil, fmt.Errorf("unsupported state fork version %#x", forkVersion)
+	}
+}
+
+// SignedBlockSlot returns the slot of a SSZ encoded signed beacon block.
+func SignedBlockSlot(enc []byte) (types.Slot, error) {
+	if len(enc) < signedBlockSlotEnd {
+		return 0, errors.New("encoded block is too short")
+	}
+	return types.Slot(binary.LittleEndian.Uint64(enc[signedBlockSlotOffset:signedBlockSlotEnd])), nil
+}
+
+// UnmarshalSignedBeaconBlock decodes a SSZ encoded signed beacon block, using the fork schedule
+// at the slot of the block to determine its type.
+func UnmarshalSignedBeaconBlock(enc []byte) (block.SignedBeaconBlock, error) {
+	slot, err := SignedBlockSlot(enc)
+	if err != nil {
+		return nil, err
+	}
+	if core.SlotToEpoch(slot) < params.BeaconConfig().AltairForkEpoch {
+		blk := &ethpb.SignedBeaconBlock{}
+		if err := blk.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase 0 block")
+		}
+		return wrapper.WrappedPhase0SignedBeaconBlock(blk), nil
+	}
+	blk := &ethpb.SignedBeaconBlockAltair{}
+	if err := blk.UnmarshalSSZ(enc); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal altair block")
+	}
+	return wrapper.WrappedAltairSignedBeaconBlock(blk)
+}
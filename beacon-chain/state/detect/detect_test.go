@@ -0,0 +1,83 @@
+package detect
+
+import (
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/version"
+)
+
+func TestUnmarshalBeaconState_Phase0(t *testing.T) {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(100))
+	enc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalBeaconState(enc)
+	require.NoError(t, err)
+	assert.Equal(t, version.Phase0, decoded.Version())
+	assert.Equal(t, types.Slot(100), decoded.Slot())
+}
+
+func TestUnmarshalBeaconState_Altair(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisStateAltair(t, 16)
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: params.BeaconConfig().GenesisForkVersion,
+		CurrentVersion:  params.BeaconConfig().AltairForkVersion,
+	}))
+	enc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalBeaconState(enc)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, decoded.Version())
+}
+
+func TestUnmarshalBeaconState_UnknownFork(t *testing.T) {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetFork(&ethpb.Fork{
+		PreviousVersion: []byte{0xff, 0xff, 0xff, 0xff},
+		CurrentVersion:  []byte{0xff, 0xff, 0xff, 0xff},
+	}))
+	enc, err := st.MarshalSSZ()
+	require.NoError(t, err)
+
+	_, err = UnmarshalBeaconState(enc)
+	assert.ErrorContains(t, "unsupported state fork version", err)
+	_, err = UnmarshalBeaconState(enc[:10])
+	assert.ErrorContains(t, "too short", err)
+}
+
+func TestUnmarshalSignedBeaconBlock(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.AltairForkEpoch = 10
+	params.OverrideBeaconConfig(cfg)
+
+	phase0Blk := testutil.NewBeaconBlock()
+	phase0Blk.Block.Slot = 5
+	enc, err := phase0Blk.MarshalSSZ()
+	require.NoError(t, err)
+	slot, err := SignedBlockSlot(enc)
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(5), slot)
+	blk, err := UnmarshalSignedBeaconBlock(enc)
+	require.NoError(t, err)
+	assert.Equal(t, version.Phase0, blk.Version())
+
+	altairBlk := testutil.NewBeaconBlockAltair()
+	altairBlk.Block.Slot = params.BeaconConfig().SlotsPerEpoch * 10
+	enc, err = altairBlk.MarshalSSZ()
+	require.NoError(t, err)
+	blk, err = UnmarshalSignedBeaconBlock(enc)
+	require.NoError(t, err)
+	assert.Equal(t, version.Altair, blk.Version())
+	assert.Equal(t, altairBlk.Block.Slot, blk.Block().Slot())
+}
@@ -214,4 +214,7 @@ type FutureForkStub interface {
 	SetCurrentParticipationBits(val []byte) error
 	NextSyncCommittee() (*ethpb.SyncCommittee, error)
 	SetNextSyncCommittee(val *ethpb.SyncCommittee) error
+	CurrentSyncCommitteeProof(ctx context.Context) ([][]byte, error)
+	NextSyncCommitteeProof(ctx context.Context) ([][]byte, error)
+	FinalizedRootProof(ctx context.Context) ([][]byte, error)
 }
@@ -0,0 +1,205 @@
+package statediff
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// listsFromState returns the list fields of a state.
+func listsFromState(st state.ReadOnlyBeaconState) (listFields, error) {
+	switch raw := st.InnerStateUnsafe().(type) {
+	case *ethpb.BeaconState:
+		return phase0Lists(raw)
+	case *ethpb.BeaconStateAltair:
+		return altairLists(raw)
+	default:
+		return listFields{}, errors.New("invalid state type")
+	}
+}
+
+// splitState returns the kind of the state, the protobuf encoding of the fields which are not stored
+// as patches, and the list fields of the state.
+func splitState(st state.ReadOnlyBeaconState) (byte, []byte, listFields, error) {
+	switch raw := st.InnerStateUnsafe().(type) {
+	case *ethpb.BeaconState:
+		lists, err := phase0Lists(raw)
+		if err != nil {
+			return 0, nil, listFields{}, err
+		}
+		fixed, err := proto.Marshal(&ethpb.BeaconState{
+			GenesisTime:                 raw.GenesisTime,
+			GenesisValidatorsRoot:       raw.GenesisValidatorsRoot,
+			Slot:                        raw.Slot,
+			Fork:                        raw.Fork,
+			LatestBlockHeader:           raw.LatestBlockHeader,
+			Eth1Data:                    raw.Eth1Data,
+			Eth1DataVotes:               raw.Eth1DataVotes,
+			Eth1DepositIndex:            raw.Eth1DepositIndex,
+			PreviousEpochAttestations:   raw.PreviousEpochAttestations,
+			CurrentEpochAttestations:    raw.CurrentEpochAttestations,
+			JustificationBits:           raw.JustificationBits,
+			PreviousJustifiedCheckpoint: raw.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:  raw.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:         raw.FinalizedCheckpoint,
+		})
+		return phase0Kind, fixed, lists, err
+	case *ethpb.BeaconStateAltair:
+		lists, err := altairLists(raw)
+		if err != nil {
+			return 0, nil, listFields{}, err
+		}
+		fixed, err := proto.Marshal(&ethpb.BeaconStateAltair{
+			GenesisTime:                 raw.GenesisTime,
+			GenesisValidatorsRoot:       raw.GenesisValidatorsRoot,
+			Slot:                        raw.Slot,
+			Fork:                        raw.Fork,
+			LatestBlockHeader:           raw.LatestBlockHeader,
+			Eth1Data:                    raw.Eth1Data,
+			Eth1DataVotes:               raw.Eth1DataVotes,
+			Eth1DepositIndex:            raw.Eth1DepositIndex,
+			JustificationBits:           raw.JustificationBits,
+			PreviousJustifiedCheckpoint: raw.PreviousJustifiedCheckpoint,
+			CurrentJustifiedCheckpoint:  raw.CurrentJustifiedCheckpoint,
+			FinalizedCheckpoint:         raw.FinalizedCheckpoint,
+			CurrentSyncCommittee:        raw.CurrentSyncCommittee,
+			NextSyncCommittee:           raw.NextSyncCommittee,
+		})
+		return altairKind, fixed, lists, err
+	default:
+		return 0, nil, listFields{}, errors.New("invalid state type")
+	}
+}
+
+func phase0Lists(pb *ethpb.BeaconState) (listFields, error) {
+	var lists listFields
+	validators, err := marshalValidators(pb.Validators)
+	if err != nil {
+		return lists, err
+	}
+	lists[blockRootsField] = pb.BlockRoots
+	lists[stateRootsField] = pb.StateRoots
+	lists[historicalRootsField] = pb.HistoricalRoots
+	lists[validatorsField] = validators
+	lists[balancesField] = uint64sToElements(pb.Balances)
+	lists[randaoMixesField] = pb.RandaoMixes
+	lists[slashingsField] = uint64sToElements(pb.Slashings)
+	return lists, nil
+}
+
+func altairLists(pb *ethpb.BeaconStateAltair) (listFields, error) {
+	var lists listFields
+	validators, err := marshalValidators(pb.Validators)
+	if err != nil {
+		return lists, err
+	}
+	lists[blockRootsField] = pb.BlockRoots
+	lists[stateRootsField] = pb.StateRoots
+	lists[historicalRootsField] = pb.HistoricalRoots
+	lists[validatorsField] = validators
+	lists[balancesField] = uint64sToElements(pb.Balances)
+	lists[randaoMixesField] = pb.RandaoMixes
+	lists[slashingsField] = uint64sToElements(pb.Slashings)
+	lists[previousParticipationField] = bytesToElements(pb.PreviousEpochParticipation)
+	lists[currentParticipationField] = bytesToElements(pb.CurrentEpochParticipation)
+	lists[inactivityScoresField] = uint64sToElements(pb.InactivityScores)
+	return lists, nil
+}
+
+func setPhase0Lists(pb *ethpb.BeaconState, lists listFields) error {
+	for _, i := range []int{previousParticipationField, currentParticipationField, inactivityScoresField} {
+		if len(lists[i]) != 0 {
+			return errors.New("phase 0 state diff contains altair fields")
+		}
+	}
+	validators, err := unmarshalValidators(lists[validatorsField])
+	if err != nil {
+		return err
+	}
+	pb.BlockRoots = lists[blockRootsField]
+	pb.StateRoots = lists[stateRootsField]
+	pb.HistoricalRoots = lists[historicalRootsField]
+	pb.Validators = validators
+	pb.Balances = elementsToUint64s(lists[balancesField])
+	pb.RandaoMixes = lists[randaoMixesField]
+	pb.Slashings = elementsToUint64s(lists[slashingsField])
+	return nil
+}
+
+func setAltairLists(pb *ethpb.BeaconStateAltair, lists listFields) error {
+	validators, err := unmarshalValidators(lists[validatorsField])
+	if err != nil {
+		return err
+	}
+	pb.BlockRoots = lists[blockRootsField]
+	pb.StateRoots = lists[stateRootsField]
+	pb.HistoricalRoots = lists[historicalRootsField]
+	pb.Validators = validators
+	pb.Balances = elementsToUint64s(lists[balancesField])
+	pb.RandaoMixes = lists[randaoMixesField]
+	pb.Slashings = elementsToUint64s(lists[slashingsField])
+	pb.PreviousEpochParticipation = elementsToBytes(lists[previousParticipationField])
+	pb.CurrentEpochParticipation = elementsToBytes(lists[currentParticipationField])
+	pb.InactivityScores = elementsToUint64s(lists[inactivityScoresField])
+	return nil
+}
+
+func marshalValidators(validators []*ethpb.Validator) ([][]byte, error) {
+	elems := make([][]byte, len(validators))
+	for i, v := range validators {
+		enc, err := v.MarshalSSZ()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal validator %d", i)
+		}
+		elems[i] = enc
+	}
+	return elems, nil
+}
+
+func unmarshalValidators(elems [][]byte) ([]*ethpb.Validator, error) {
+	validators := make([]*ethpb.Validator, len(elems))
+	for i, enc := range elems {
+		v := &ethpb.Validator{}
+		if err := v.UnmarshalSSZ(enc); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal validator %d", i)
+		}
+		validators[i] = v
+	}
+	return validators, nil
+}
+
+func uint64sToElements(values []uint64) [][]byte {
+	elems := make([][]byte, len(values))
+	for i, v := range values {
+		elems[i] = make([]byte, 8)
+		binary.LittleEndian.PutUint64(elems[i], v)
+	}
+	return elems
+}
+
+func elementsToUint64s(elems [][]byte) []uint64 {
+	values := make([]uint64, len(elems))
+	for i, e := range elems {
+		values[i] = binary.LittleEndian.Uint64(e)
+	}
+	return values
+}
+
+func bytesToElements(values []byte) [][]byte {
+	elems := make([][]byte, len(values))
+	for i, v := range values {
+		elems[i] = []byte{v}
+	}
+	return elems
+}
+
+func elementsToBytes(elems [][]byte) []byte {
+	values := make([]byte, len(elems))
+	for i, e := range elems {
+		values[i] = e[0]
+	}
+	return values
+}
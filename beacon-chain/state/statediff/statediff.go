@@ -0,0 +1,211 @@
+// Package statediff encodes a beacon state as a field level difference against a base
+// state, allowing historical states to be stored as small diffs against periodic snapshots.
+//
+// Large list and vector fields (validators, balances, randao mixes, block and state roots, etc.)
+// are stored as sparse patches of the elements which differ from the base state, while the other
+// fields of the state are stored in full.
+package statediff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	v1 "github.com/prysmaticlabs/prysm/beacon-chain/state/v1"
+	v2 "github.com/prysmaticlabs/prysm/beacon-chain/state/v2"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	phase0Kind byte = iota
+	altairKind
+)
+
+// Indices of the list fields of a beacon state which are stored as patches.
+const (
+	blockRootsField = iota
+	stateRootsField
+	historicalRootsField
+	validatorsField
+	balancesField
+	randaoMixesField
+	slashingsField
+	previousParticipationField
+	currentParticipationField
+	inactivityScoresField
+	numListFields
+)
+
+// elementSizes defines the serialized size of a single element of each list field.
+var elementSizes = [numListFields]int{
+	blockRootsField:            32,
+	stateRootsField:            32,
+	historicalRootsField:       32,
+	validatorsField:            (&ethpb.Validator{}).SizeSSZ(),
+	balancesField:              8,
+	randaoMixesField:           32,
+	slashingsField:             8,
+	previousParticipationField: 1,
+	currentParticipationField:  1,
+	inactivityScoresField:      8,
+}
+
+// listFields holds the elements of every list field of a state, serialized to bytes.
+type listFields [numListFields][][]byte
+
+// Diff returns the encoded difference of the target state against the base state. The target state
+// can be rebuilt by applying the result to the same base state with Apply.
+func Diff(base, target state.ReadOnlyBeaconState) ([]byte, error) {
+	if base == nil || base.IsNil() || target == nil || target.IsNil() {
+		return nil, errors.New("nil state")
+	}
+	baseLists, err := listsFromState(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read base state")
+	}
+	kind, fixed, targetLists, err := splitState(target)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read target state")
+	}
+
+	buf := bytes.NewBuffer([]byte{kind})
+	writeUvarint(buf, uint64(len(fixed)))
+	buf.Write(fixed)
+	for i := 0; i < numListFields; i++ {
+		writePatch(buf, baseLists[i], targetLists[i])
+	}
+	return snappy.Encode(nil, buf.Bytes()), nil
+}
+
+// Apply rebuilds a state from the base state it was diffed against and the encoded diff.
+func Apply(base state.ReadOnlyBeaconState, enc []byte) (state.BeaconState, error) {
+	if base == nil || base.IsNil() {
+		return nil, errors.New("nil base state")
+	}
+	dec, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	baseLists, err := listsFromState(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read base state")
+	}
+
+	r := bytes.NewReader(dec)
+	kind, err := r.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state diff kind")
+	}
+	fixedLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read state diff length")
+	}
+	if fixedLen > uint64(r.Len()) {
+		return nil, errors.New("state diff is too short")
+	}
+	fixed := make([]byte, fixedLen)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+	var lists listFields
+	for i := 0; i < numListFields; i++ {
+		lists[i], err = readPatch(r, baseLists[i], elementSizes[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not apply patch of field %d", i)
+		}
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes in state diff", r.Len())
+	}
+
+	switch kind {
+	case phase0Kind:
+		pb := &ethpb.BeaconState{}
+		if err := proto.Unmarshal(fixed, pb); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal phase 0 state fields")
+		}
+		if err := setPhase0Lists(pb, lists); err != nil {
+			return nil, err
+		}
+		return v1.InitializeFromProtoUnsafe(pb)
+	case altairKind:
+		pb := &ethpb.BeaconStateAltair{}
+		if err := proto.Unmarshal(fixed, pb); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal altair state fields")
+		}
+		if err := setAltairLists(pb, lists); err != nil {
+			return nil, err
+		}
+		return v2.InitializeFromProtoUnsafe(pb)
+	default:
+		return nil, fmt.Errorf("unknown state diff kind %d", kind)
+	}
+}
+
+// writePatch encodes the length of the target list followed by the elements of the target list
+// which differ from the base list.
+func writePatch(buf *bytes.Buffer, base, target [][]byte) {
+	var indices []int
+	for i := range target {
+		if i >= len(base) || !bytes.Equal(base[i], target[i]) {
+			indices = append(indices, i)
+		}
+	}
+	writeUvarint(buf, uint64(len(target)))
+	writeUvarint(buf, uint64(len(indices)))
+	for _, i := range indices {
+		writeUvarint(buf, uint64(i))
+		buf.Write(target[i])
+	}
+}
+
+// readPatch decodes a patch written by writePatch and applies it on top of the base list.
+func readPatch(r *bytes.Reader, base [][]byte, elemSize int) ([][]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if count > length || count*uint64(elemSize) > uint64(r.Len()) {
+		return nil, errors.New("invalid patch size")
+	}
+	list := make([][]byte, length)
+	for i := 0; i < len(base) && uint64(i) < length; i++ {
+		list[i] = bytesutil.SafeCopyBytes(base[i])
+	}
+	for j := uint64(0); j < count; j++ {
+		i, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if i >= length {
+			return nil, fmt.Errorf("patch index %d out of range %d", i, length)
+		}
+		elem := make([]byte, elemSize)
+		if _, err := io.ReadFull(r, elem); err != nil {
+			return nil, err
+		}
+		list[i] = elem
+	}
+	for i := range list {
+		if list[i] == nil {
+			return nil, fmt.Errorf("missing element %d", i)
+		}
+	}
+	return list, nil
+}
+
+func writeUvarint(buf *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	buf.Write(tmp[:n])
+}
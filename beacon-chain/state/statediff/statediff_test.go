@@ -0,0 +1,125 @@
+package statediff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// mutate applies changes to most of the list fields of a state, as happens across epochs.
+func mutate(t *testing.T, st state.BeaconState) {
+	require.NoError(t, st.SetSlot(st.Slot()+64))
+	require.NoError(t, st.UpdateBalancesAtIndex(3, 12345))
+	require.NoError(t, st.UpdateRandaoMixesAtIndex(7, bytesutil.PadTo([]byte("mix"), 32)))
+	require.NoError(t, st.UpdateBlockRootAtIndex(9, bytesutil.ToBytes32([]byte("block"))))
+	require.NoError(t, st.UpdateSlashingsAtIndex(2, 99))
+	require.NoError(t, st.AppendHistoricalRoots(bytesutil.ToBytes32([]byte("historical"))))
+	v, err := st.ValidatorAtIndex(1)
+	require.NoError(t, err)
+	v.ExitEpoch = 100
+	require.NoError(t, st.UpdateValidatorAtIndex(1, v))
+	require.NoError(t, st.AppendValidator(&ethpb.Validator{
+		PublicKey:             bytesutil.PadTo([]byte("new"), 48),
+		WithdrawalCredentials: make([]byte, 32),
+		EffectiveBalance:      32,
+	}))
+	require.NoError(t, st.AppendBalance(32))
+}
+
+func assertSameState(t *testing.T, want, got state.BeaconState) {
+	wantRoot, err := want.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	gotRoot, err := got.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, wantRoot, gotRoot)
+	assert.Equal(t, want.Version(), got.Version())
+}
+
+func TestDiffApply_Phase0(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target := base.Copy()
+	mutate(t, target)
+
+	enc, err := Diff(base, target)
+	require.NoError(t, err)
+	full, err := target.MarshalSSZ()
+	require.NoError(t, err)
+	assert.Equal(t, true, len(enc) < len(snappy.Encode(nil, full)), "diff is not smaller than the state")
+
+	got, err := Apply(base, enc)
+	require.NoError(t, err)
+	assertSameState(t, target, got)
+}
+
+func TestDiffApply_Altair(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisStateAltair(t, 32)
+	target := base.Copy()
+	mutate(t, target)
+	require.NoError(t, target.AppendCurrentParticipationBits(7))
+	require.NoError(t, target.AppendPreviousParticipationBits(1))
+	require.NoError(t, target.AppendInactivityScore(4))
+
+	enc, err := Diff(base, target)
+	require.NoError(t, err)
+	got, err := Apply(base, enc)
+	require.NoError(t, err)
+	assertSameState(t, target, got)
+}
+
+func TestDiffApply_AcrossForks(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target, _ := testutil.DeterministicGenesisStateAltair(t, 40)
+
+	enc, err := Diff(base, target)
+	require.NoError(t, err)
+	got, err := Apply(base, enc)
+	require.NoError(t, err)
+	assertSameState(t, target, got)
+}
+
+func TestDiffApply_Identical(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+
+	enc, err := Diff(base, base)
+	require.NoError(t, err)
+	got, err := Apply(base, enc)
+	require.NoError(t, err)
+	assertSameState(t, base, got)
+}
+
+func TestApply_WrongBase(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target := base.Copy()
+	mutate(t, target)
+	enc, err := Diff(base, target)
+	require.NoError(t, err)
+
+	// A shorter base state lacks validators which were not part of the patch.
+	other, _ := testutil.DeterministicGenesisState(t, 16)
+	_, err = Apply(other, enc)
+	assert.ErrorContains(t, "missing element", err)
+}
+
+func TestApply_Corrupted(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target := base.Copy()
+	mutate(t, target)
+	enc, err := Diff(base, target)
+	require.NoError(t, err)
+	dec, err := snappy.Decode(nil, enc)
+	require.NoError(t, err)
+
+	_, err = Apply(base, snappy.Encode(nil, dec[:len(dec)-10]))
+	assert.NotNil(t, err)
+	_, err = Apply(base, snappy.Encode(nil, append(dec, 0)))
+	assert.ErrorContains(t, "unexpected trailing bytes", err)
+	_, err = Apply(base, []byte("not a diff"))
+	assert.NotNil(t, err)
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -95,7 +97,7 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 				continue
 			}
 
-			if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
+			if err := s.saveColdState(ctx, slot, aState, aRoot); err != nil {
 				return err
 			}
 			log.WithFields(
@@ -117,3 +119,29 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// saveColdState saves an archived state to the DB. When state snapshots are enabled, only the
+// archived states on a snapshot slot are saved in full, the others are saved as diffs against
+// the last snapshot.
+func (s *State) saveColdState(ctx context.Context, slot types.Slot, st state.BeaconState, root [32]byte) error {
+	if s.slotsPerSnapshot == 0 || slot%s.slotsPerSnapshot == 0 {
+		if err := s.beaconDB.SaveState(ctx, st, root); err != nil {
+			return err
+		}
+		s.lastSnapshot.slot = slot
+		s.lastSnapshot.root = root
+		return nil
+	}
+
+	snapshotSlot := slot - slot%s.slotsPerSnapshot
+	baseRoot := s.lastSnapshot.root
+	if s.lastSnapshot.slot != snapshotSlot {
+		baseRoot = s.beaconDB.ArchivedPointRoot(ctx, snapshotSlot)
+	}
+	// Fall back to saving the state in full if the snapshot is missing,
+	// e.g. the node started syncing after the snapshot slot.
+	if baseRoot == params.BeaconConfig().ZeroHash || !s.beaconDB.HasState(ctx, baseRoot) {
+		return s.beaconDB.SaveState(ctx, st, root)
+	}
+	return s.beaconDB.SaveStateDiff(ctx, st, root, baseRoot)
+}
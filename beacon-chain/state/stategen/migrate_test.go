@@ -135,3 +135,41 @@ func TestMigrateToCold_StateExistsInDB(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{{1}, {2}, {3}, {4}}, service.saveHotStateDB.savedStateRoots)
 	assert.LogsDoNotContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_SavesStateDiffs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB)
+	service.slotsPerArchivedPoint = 1
+	service.slotsPerSnapshot = 2
+	service.finalizedInfo.slot = 2
+	beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+	snapshotState := beaconState.Copy()
+	require.NoError(t, snapshotState.SetSlot(2))
+	snapshotRoot := [32]byte{'a'}
+	require.NoError(t, service.epochBoundaryStateCache.put(snapshotRoot, snapshotState))
+	diffState := beaconState.Copy()
+	require.NoError(t, diffState.SetSlot(3))
+	require.NoError(t, diffState.UpdateBalancesAtIndex(0, 1))
+	diffRoot := [32]byte{'b'}
+	require.NoError(t, service.epochBoundaryStateCache.put(diffRoot, diffState))
+
+	b := testutil.NewBeaconBlock()
+	b.Block.Slot = 4
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, service.beaconDB.SaveBlock(ctx, wrapper.WrappedPhase0SignedBeaconBlock(b)))
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+
+	assert.Equal(t, types.Slot(2), service.lastSnapshot.slot)
+	assert.Equal(t, snapshotRoot, service.lastSnapshot.root)
+	gotState, err := service.beaconDB.State(ctx, diffRoot)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, diffState.InnerStateUnsafe(), gotState.InnerStateUnsafe(), "Did not save state")
+
+	// The state saved as a diff can't be rebuilt without its snapshot.
+	require.NoError(t, service.beaconDB.DeleteState(ctx, snapshotRoot))
+	_, err = service.beaconDB.State(ctx, diffRoot)
+	assert.ErrorContains(t, "missing base state", err)
+}
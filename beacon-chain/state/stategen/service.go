@@ -44,6 +44,8 @@ type StateManager interface {
 type State struct {
 	beaconDB                db.NoHeadAccessDatabase
 	slotsPerArchivedPoint   types.Slot
+	slotsPerSnapshot        types.Slot
+	lastSnapshot            *lastSnapshot
 	hotStateCache           *hotStateCache
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
@@ -60,6 +62,13 @@ type saveHotStateDbConfig struct {
 	savedStateRoots [][32]byte
 }
 
+// This tracks the last archived state saved in full, which the archived states
+// up to the next snapshot are diffed against.
+type lastSnapshot struct {
+	slot types.Slot
+	root [32]byte
+}
+
 // This tracks the finalized point. It's also the point where slot and the block root of
 // cold and hot sections of the DB splits.
 type finalizedInfo struct {
@@ -76,6 +85,8 @@ func New(beaconDB db.NoHeadAccessDatabase) *State {
 		hotStateCache:           newHotStateCache(),
 		finalizedInfo:           &finalizedInfo{slot: 0, root: params.BeaconConfig().ZeroHash},
 		slotsPerArchivedPoint:   params.BeaconConfig().SlotsPerArchivedPoint,
+		slotsPerSnapshot:        params.BeaconConfig().SlotsPerStateSnapshot,
+		lastSnapshot:            &lastSnapshot{},
 		epochBoundaryStateCache: newBoundaryStateCache(),
 		saveHotStateDB: &saveHotStateDbConfig{
 			duration: defaultHotStateDBInterval,
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"context"
+
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 )
@@ -29,3 +31,18 @@ func (b *BeaconState) CurrentSyncCommittee() (*ethpb.SyncCommittee, error) {
 func (b *BeaconState) NextSyncCommittee() (*ethpb.SyncCommittee, error) {
 	return nil, errors.New("NextSyncCommittee is not supported for phase 0 beacon state")
 }
+
+// CurrentSyncCommitteeProof is not supported for phase 0 beacon state.
+func (b *BeaconState) CurrentSyncCommitteeProof(_ context.Context) ([][]byte, error) {
+	return nil, errors.New("CurrentSyncCommitteeProof is not supported for phase 0 beacon state")
+}
+
+// NextSyncCommitteeProof is not supported for phase 0 beacon state.
+func (b *BeaconState) NextSyncCommitteeProof(_ context.Context) ([][]byte, error) {
+	return nil, errors.New("NextSyncCommitteeProof is not supported for phase 0 beacon state")
+}
+
+// FinalizedRootProof is not supported for phase 0 beacon state.
+func (b *BeaconState) FinalizedRootProof(_ context.Context) ([][]byte, error) {
+	return nil, errors.New("FinalizedRootProof is not supported for phase 0 beacon state")
+}
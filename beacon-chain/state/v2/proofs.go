@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/types"
+	"github.com/prysmaticlabs/prysm/shared/htrutils"
+	"go.opencensus.io/trace"
+)
+
+// CurrentSyncCommitteeProof returns the Merkle branch of the current sync committee against the
+// hash tree root of the state.
+func (b *BeaconState) CurrentSyncCommitteeProof(ctx context.Context) ([][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconStateAltair.CurrentSyncCommitteeProof")
+	defer span.End()
+	return b.fieldProof(ctx, currentSyncCommittee)
+}
+
+// NextSyncCommitteeProof returns the Merkle branch of the next sync committee against the
+// hash tree root of the state.
+func (b *BeaconState) NextSyncCommitteeProof(ctx context.Context) ([][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconStateAltair.NextSyncCommitteeProof")
+	defer span.End()
+	return b.fieldProof(ctx, nextSyncCommittee)
+}
+
+// FinalizedRootProof returns the Merkle branch of the finalized checkpoint root against the
+// hash tree root of the state. The first element of the branch is the root of the checkpoint's
+// epoch, which is the sibling of the root in the checkpoint container.
+func (b *BeaconState) FinalizedRootProof(ctx context.Context) ([][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconStateAltair.FinalizedRootProof")
+	defer span.End()
+	if !b.hasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	branch, err := b.fieldProof(ctx, finalizedCheckpoint)
+	if err != nil {
+		return nil, err
+	}
+	epochRoot := htrutils.Uint64Root(uint64(b.FinalizedCheckpointEpoch()))
+	return append([][]byte{epochRoot[:]}, branch...), nil
+}
+
+// fieldProof returns the Merkle branch of the field at the given index, from the field's root
+// up to the hash tree root of the state.
+func (b *BeaconState) fieldProof(ctx context.Context, field types.FieldIndex) ([][]byte, error) {
+	if !b.hasInnerState() {
+		return nil, ErrNilInnerState
+	}
+	// Computing the hash tree root brings the Merkle layers of the state up to date.
+	if _, err := b.HashTreeRoot(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not compute state root")
+	}
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	branch := make([][]byte, 0, len(b.merkleLayers)-1)
+	idx := int(field)
+	for i := 0; i < len(b.merkleLayers)-1; i++ {
+		sibling := make([]byte, 32)
+		if neighborIdx := idx ^ 1; neighborIdx < len(b.merkleLayers[i]) {
+			copy(sibling, b.merkleLayers[i][neighborIdx])
+		}
+		branch = append(branch, sibling)
+		idx /= 2
+	}
+	return branch, nil
+}
@@ -0,0 +1,62 @@
+package v2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestBeaconStateProofs(t *testing.T) {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte("finalized"), 32)}))
+
+	t.Run("current sync committee", func(t *testing.T) {
+		proof, err := st.CurrentSyncCommitteeProof(ctx)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		committee, err := st.CurrentSyncCommittee()
+		require.NoError(t, err)
+		leaf, err := committee.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, trieutil.VerifyMerkleBranch(root[:], leaf[:], int(types.CurrentSyncCommittee), proof, uint64(len(proof)-1)))
+	})
+	t.Run("next sync committee", func(t *testing.T) {
+		proof, err := st.NextSyncCommitteeProof(ctx)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		committee, err := st.NextSyncCommittee()
+		require.NoError(t, err)
+		leaf, err := committee.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, true, trieutil.VerifyMerkleBranch(root[:], leaf[:], int(types.NextSyncCommittee), proof, uint64(len(proof)-1)))
+	})
+	t.Run("finalized root", func(t *testing.T) {
+		proof, err := st.FinalizedRootProof(ctx)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		// The root is the second field of the checkpoint container.
+		index := int(types.FinalizedCheckpoint)*2 + 1
+		leaf := st.FinalizedCheckpoint().Root
+		assert.Equal(t, true, trieutil.VerifyMerkleBranch(root[:], leaf, index, proof, uint64(len(proof)-1)))
+	})
+	t.Run("proof follows state updates", func(t *testing.T) {
+		require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 4, Root: bytesutil.PadTo([]byte("new"), 32)}))
+		proof, err := st.FinalizedRootProof(ctx)
+		require.NoError(t, err)
+		root, err := st.HashTreeRoot(ctx)
+		require.NoError(t, err)
+		index := int(types.FinalizedCheckpoint)*2 + 1
+		assert.Equal(t, true, trieutil.VerifyMerkleBranch(root[:], bytesutil.PadTo([]byte("new"), 32), index, proof, uint64(len(proof)-1)))
+	})
+}
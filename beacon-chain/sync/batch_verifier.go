@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// The maximum number of signature sets verified in a single batch.
+	verifierLimit = 50
+	// The window over which signature sets are collected before being verified in a batch.
+	batchVerifierInterval = 50 * time.Millisecond
+)
+
+// signatureVerifier is a signature set waiting to be verified by the batch verifier, along with
+// the channel its result is sent to.
+type signatureVerifier struct {
+	set      *bls.SignatureSet
+	resChan  chan error
+	received time.Time
+}
+
+// verifierRoutine collects the signature sets sent by the gossip validators, and verifies them
+// in a batch once verifierLimit sets are collected or the batch interval has elapsed.
+func (s *Service) verifierRoutine() {
+	ticker := time.NewTicker(batchVerifierInterval)
+	defer ticker.Stop()
+	verifierBatch := make([]*signatureVerifier, 0, verifierLimit)
+	for {
+		select {
+		case <-s.ctx.Done():
+			// Release the validators still waiting on a result.
+			for _, sig := range verifierBatch {
+				sig.resChan <- s.ctx.Err()
+			}
+			return
+		case sig := <-s.signatureChan:
+			verifierBatch = append(verifierBatch, sig)
+			signatureVerificationQueueDepth.Set(float64(len(verifierBatch)))
+			if len(verifierBatch) >= verifierLimit {
+				verifyBatch(verifierBatch)
+				verifierBatch = make([]*signatureVerifier, 0, verifierLimit)
+				signatureVerificationQueueDepth.Set(0)
+			}
+		case <-ticker.C:
+			if len(verifierBatch) > 0 {
+				verifyBatch(verifierBatch)
+				verifierBatch = make([]*signatureVerifier, 0, verifierLimit)
+				signatureVerificationQueueDepth.Set(0)
+			}
+		}
+	}
+}
+
+// validateWithBatchVerifier sends the signature set to the batch verifier, and waits for its
+// result. The message is ignored if no result is received before the validation context ends,
+// so that a slow batch never holds a validator past the pubsub validation timeout.
+func (s *Service) validateWithBatchVerifier(ctx context.Context, message string, set *bls.SignatureSet) pubsub.ValidationResult {
+	ctx, span := trace.StartSpan(ctx, "sync.validateWithBatchVerifier")
+	defer span.End()
+
+	// The result channel is buffered, so that the verifier never blocks on a validator which
+	// has already timed out.
+	verifier := &signatureVerifier{set: set, resChan: make(chan error, 1), received: time.Now()}
+	select {
+	case s.signatureChan <- verifier:
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	}
+	select {
+	case <-ctx.Done():
+		return pubsub.ValidationIgnore
+	case err := <-verifier.resChan:
+		if err != nil {
+			log.WithError(err).Debugf("Could not verify %s", message)
+			traceutil.AnnotateError(span, err)
+			return pubsub.ValidationReject
+		}
+	}
+	return pubsub.ValidationAccept
+}
+
+// verifyBatch verifies all the signature sets of the batch at once, and falls back to verifying
+// each set on its own if the batch is invalid, so that a single invalid signature does not fail
+// the other messages of the batch.
+func verifyBatch(verifierBatch []*signatureVerifier) {
+	signatureVerificationBatchSize.Observe(float64(len(verifierBatch)))
+	aggSet := bls.NewSet()
+	for _, sig := range verifierBatch {
+		aggSet.Join(sig.set)
+	}
+	var verificationErr error
+	verified, err := aggSet.Verify()
+	switch {
+	case err != nil:
+		verificationErr = errors.Wrap(err, "could not batch verify signatures")
+	case !verified:
+		verificationErr = errors.New("batch signature verification failed")
+	}
+	if verificationErr != nil {
+		signatureBatchFailureCounter.Inc()
+	}
+	for _, sig := range verifierBatch {
+		var resErr error
+		if verificationErr != nil {
+			resErr = verifySet(sig.set)
+		}
+		signatureVerificationLatency.Observe(float64(time.Since(sig.received).Milliseconds()))
+		sig.resChan <- resErr
+	}
+}
+
+// verifySet verifies a single signature set.
+func verifySet(set *bls.SignatureSet) error {
+	verified, err := set.Verify()
+	if err != nil {
+		return errors.Wrap(err, "could not verify signature set")
+	}
+	if !verified {
+		return errors.New("invalid signature in signature set")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func testSignatureSet(t *testing.T, msg [32]byte, valid bool) *bls.SignatureSet {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	sig := key.Sign(msg[:])
+	if !valid {
+		otherKey, err := bls.RandKey()
+		require.NoError(t, err)
+		sig = otherKey.Sign(msg[:])
+	}
+	return &bls.SignatureSet{
+		Signatures: [][]byte{sig.Marshal()},
+		PublicKeys: []bls.PublicKey{key.PublicKey()},
+		Messages:   [][32]byte{msg},
+	}
+}
+
+func TestValidateWithBatchVerifier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:           ctx,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go s.verifierRoutine()
+
+	assert.Equal(t, pubsub.ValidationAccept, s.validateWithBatchVerifier(ctx, "valid", testSignatureSet(t, [32]byte{'a'}, true)))
+	assert.Equal(t, pubsub.ValidationReject, s.validateWithBatchVerifier(ctx, "invalid", testSignatureSet(t, [32]byte{'a'}, false)))
+	malformed := testSignatureSet(t, [32]byte{'a'}, true)
+	malformed.Signatures[0] = []byte{'b'}
+	assert.Equal(t, pubsub.ValidationReject, s.validateWithBatchVerifier(ctx, "malformed", malformed))
+}
+
+func TestValidateWithBatchVerifier_InvalidSetInBatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := &Service{
+		ctx:           ctx,
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	go s.verifierRoutine()
+
+	// Sets verified concurrently are batched together, and a single invalid set only fails
+	// its own message.
+	numSets := 2 * verifierLimit
+	results := make([]pubsub.ValidationResult, numSets)
+	var wg sync.WaitGroup
+	for i := 0; i < numSets; i++ {
+		set := testSignatureSet(t, [32]byte{byte(i)}, i != 7)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = s.validateWithBatchVerifier(ctx, "batched", set)
+		}(i)
+	}
+	wg.Wait()
+	for i, result := range results {
+		if i == 7 {
+			assert.Equal(t, pubsub.ValidationReject, result)
+			continue
+		}
+		assert.Equal(t, pubsub.ValidationAccept, result, "unexpected result for set %d", i)
+	}
+}
+
+func TestValidateWithBatchVerifier_Timeout(t *testing.T) {
+	// Without a running verifier, the message is ignored once the validation context ends.
+	s := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, pubsub.ValidationIgnore, s.validateWithBatchVerifier(ctx, "timeout", testSignatureSet(t, [32]byte{'a'}, true)))
+}
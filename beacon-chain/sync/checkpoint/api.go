@@ -0,0 +1,141 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/detect"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	stateSSZPath = "/eth/v2/debug/beacon/states/%s/ssz"
+	blockSSZPath = "/eth/v2/beacon/blocks/%s/ssz"
+	// Full mainnet states are large, the timeout leaves room for slow connections.
+	defaultRequestTimeout = 10 * time.Minute
+)
+
+// sszResponse is the JSON envelope of the SSZ endpoints served by the gRPC gateway,
+// where the SSZ bytes are base64 encoded.
+type sszResponse struct {
+	Data []byte `json:"data"`
+}
+
+// APIInitializer fetches the finalized state and block from the beacon API of another
+// beacon node.
+type APIInitializer struct {
+	baseURL      *url.URL
+	client       *http.Client
+	wsCheckpoint *ethpb.Checkpoint
+}
+
+// NewAPIInitializer creates an APIInitializer for the gRPC gateway at the given url. If a weak
+// subjectivity checkpoint is provided, the state at its epoch is requested and the derived block root
+// must match the checkpoint root. Otherwise the current finalized state of the remote node is used.
+func NewAPIInitializer(baseURL string, wsCheckpoint *ethpb.Checkpoint) (*APIInitializer, error) {
+	u, err := url.ParseRequestURI(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid checkpoint sync url %s", baseURL)
+	}
+	if wsCheckpoint != nil && len(wsCheckpoint.Root) == 0 {
+		wsCheckpoint = nil
+	}
+	return &APIInitializer{
+		baseURL:      u,
+		client:       &http.Client{Timeout: defaultRequestTimeout},
+		wsCheckpoint: wsCheckpoint,
+	}, nil
+}
+
+// Initialize downloads the checkpoint state and block and saves them as the origin of the database.
+func (a *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
+	stateID := "finalized"
+	if a.wsCheckpoint != nil {
+		slot, err := core.StartSlot(a.wsCheckpoint.Epoch)
+		if err != nil {
+			return err
+		}
+		stateID = fmt.Sprintf("%d", slot)
+	}
+	log.WithFields(logrus.Fields{
+		"url":   a.baseURL.String(),
+		"state": stateID,
+	}).Info("Downloading checkpoint state, this may take a while")
+	serState, err := a.fetchSSZ(ctx, fmt.Sprintf(stateSSZPath, stateID))
+	if err != nil {
+		return errors.Wrap(err, "could not fetch checkpoint state")
+	}
+	st, err := detect.UnmarshalBeaconState(serState)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal checkpoint state")
+	}
+	blockRoot, err := helpers.LatestBlockRoot(ctx, st)
+	if err != nil {
+		return err
+	}
+	if a.wsCheckpoint != nil && !bytes.Equal(blockRoot[:], a.wsCheckpoint.Root) {
+		return fmt.Errorf("block root %#x of the downloaded state does not match the weak subjectivity "+
+			"checkpoint root %#x", blockRoot, a.wsCheckpoint.Root)
+	}
+
+	log.WithFields(logrus.Fields{
+		"slot": st.Slot(),
+		"root": fmt.Sprintf("%#x", blockRoot),
+	}).Info("Downloading checkpoint block")
+	serBlock, err := a.fetchSSZ(ctx, fmt.Sprintf(blockSSZPath, fmt.Sprintf("%#x", blockRoot)))
+	if err != nil {
+		return errors.Wrap(err, "could not fetch checkpoint block")
+	}
+	return d.SaveOrigin(ctx, serState, serBlock)
+}
+
+func (a *APIInitializer) fetchSSZ(ctx context.Context, path string) ([]byte, error) {
+	r := &sszResponse{}
+	if err := getJSON(ctx, a.client, a.baseURL, path, r); err != nil {
+		return nil, err
+	}
+	if len(r.Data) == 0 {
+		return nil, fmt.Errorf("empty response from %s%s", a.baseURL.String(), path)
+	}
+	return r.Data, nil
+}
+
+// getJSON decodes the JSON response of the gRPC gateway endpoint at the given path into v.
+func getJSON(ctx context.Context, client *http.Client, baseURL *url.URL, path string, v interface{}) error {
+	u := *baseURL
+	u.Path = u.Path + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "request to %s failed with status %d", u.String(), resp.StatusCode)
+		}
+		return fmt.Errorf("request to %s failed with status %d: %s", u.String(), resp.StatusCode, body)
+	}
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(v), "could not decode response")
+}
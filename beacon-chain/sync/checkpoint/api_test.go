@@ -0,0 +1,70 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func checkpointServer(t *testing.T, stateID string, serState, serBlock []byte, root [32]byte) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(fmt.Sprintf(stateSSZPath, stateID), func(w http.ResponseWriter, _ *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&sszResponse{Data: serState}))
+	})
+	mux.HandleFunc(fmt.Sprintf(blockSSZPath, fmt.Sprintf("%#x", root)), func(w http.ResponseWriter, _ *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&sszResponse{Data: serBlock}))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestAPIInitializer_Finalized(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	serState, serBlock, root := checkpointPair(t, 64)
+	srv := checkpointServer(t, "finalized", serState, serBlock, root)
+
+	ai, err := NewAPIInitializer(srv.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, ai.Initialize(ctx, beaconDB))
+
+	originRoot, err := beaconDB.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, originRoot)
+}
+
+func TestAPIInitializer_WeakSubjectivityCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	serState, serBlock, root := checkpointPair(t, 64)
+	srv := checkpointServer(t, "64", serState, serBlock, root)
+
+	ai, err := NewAPIInitializer(srv.URL, &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)})
+	require.NoError(t, err)
+	assert.ErrorContains(t, "does not match the weak subjectivity checkpoint", ai.Initialize(ctx, testDB.SetupDB(t)))
+
+	beaconDB := testDB.SetupDB(t)
+	ai, err = NewAPIInitializer(srv.URL, &ethpb.Checkpoint{Epoch: 2, Root: root[:]})
+	require.NoError(t, err)
+	require.NoError(t, ai.Initialize(ctx, beaconDB))
+	originRoot, err := beaconDB.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, originRoot)
+}
+
+func TestAPIInitializer_RequestFailure(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	ai, err := NewAPIInitializer(srv.URL, nil)
+	require.NoError(t, err)
+	assert.ErrorContains(t, "failed with status 404", ai.Initialize(context.Background(), testDB.SetupDB(t)))
+}
@@ -0,0 +1,15 @@
+// Package checkpoint provides the sources a beacon node can use to start from a trusted
+// finalized state and block, rather than syncing the chain from genesis.
+package checkpoint
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+)
+
+// Initializer prepares an empty database so that the node can begin syncing from
+// a finalized checkpoint.
+type Initializer interface {
+	Initialize(ctx context.Context, d db.Database) error
+}
@@ -0,0 +1,40 @@
+package checkpoint
+
+import (
+	"context"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+// checkpointPair returns a serialized state at the given slot, the serialized block the state
+// was derived from and the root of that block.
+func checkpointPair(t *testing.T, slot types.Slot) ([]byte, []byte, [32]byte) {
+	st, err := testutil.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	blk := testutil.NewBeaconBlock()
+	blk.Block.Slot = slot
+	bodyRoot, err := blk.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(&ethpb.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: blk.Block.ParentRoot,
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   bodyRoot[:],
+	}))
+	stateRoot, err := st.HashTreeRoot(context.Background())
+	require.NoError(t, err)
+	blk.Block.StateRoot = stateRoot[:]
+	root, err := blk.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	serState, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	serBlock, err := blk.MarshalSSZ()
+	require.NoError(t, err)
+	return serState, serBlock, root
+}
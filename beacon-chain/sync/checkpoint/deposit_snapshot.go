@@ -0,0 +1,133 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	depositSnapshotPath           = "/eth/v1/beacon/deposit_snapshot"
+	depositSnapshotRequestTimeout = time.Minute
+)
+
+// depositSnapshotResponse is the JSON response of the deposit snapshot endpoint served by the gRPC gateway.
+type depositSnapshotResponse struct {
+	Data *depositSnapshotJSON `json:"data"`
+}
+
+type depositSnapshotJSON struct {
+	Finalized            []string `json:"finalized"`
+	DepositRoot          string   `json:"deposit_root"`
+	DepositCount         string   `json:"deposit_count"`
+	ExecutionBlockHash   string   `json:"execution_block_hash"`
+	ExecutionBlockHeight string   `json:"execution_block_height"`
+}
+
+// LoadDepositSnapshotFile reads a deposit snapshot from a JSON file, in the format served by the
+// deposit snapshot endpoint of the beacon API.
+func LoadDepositSnapshotFile(path string) (*ethpb.DepositSnapshot, error) {
+	path, err := fileutil.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fileutil.FileExists(path) {
+		return nil, errors.Errorf("deposit snapshot file %s does not exist", path)
+	}
+	enc, err := fileutil.ReadFileAsBytes(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read deposit snapshot file %s", path)
+	}
+	r := &depositSnapshotResponse{}
+	if err := json.Unmarshal(enc, r); err != nil {
+		return nil, errors.Wrapf(err, "could not decode deposit snapshot file %s", path)
+	}
+	snapshot, err := r.Data.toProto()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid deposit snapshot file %s", path)
+	}
+	log.WithFields(logrus.Fields{
+		"file":         path,
+		"depositCount": snapshot.DepositCount,
+	}).Info("Loaded deposit snapshot")
+	return snapshot, nil
+}
+
+// FetchDepositSnapshot downloads the deposit snapshot of the beacon node at the given gRPC gateway url.
+func FetchDepositSnapshot(ctx context.Context, baseURL string) (*ethpb.DepositSnapshot, error) {
+	u, err := url.ParseRequestURI(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid deposit snapshot url %s", baseURL)
+	}
+	r := &depositSnapshotResponse{}
+	client := &http.Client{Timeout: depositSnapshotRequestTimeout}
+	if err := getJSON(ctx, client, u, depositSnapshotPath, r); err != nil {
+		return nil, errors.Wrap(err, "could not fetch deposit snapshot")
+	}
+	snapshot, err := r.Data.toProto()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit snapshot")
+	}
+	log.WithFields(logrus.Fields{
+		"url":          u.String(),
+		"depositCount": snapshot.DepositCount,
+	}).Info("Downloaded deposit snapshot")
+	return snapshot, nil
+}
+
+func (d *depositSnapshotJSON) toProto() (*ethpb.DepositSnapshot, error) {
+	if d == nil {
+		return nil, errors.New("empty deposit snapshot")
+	}
+	finalized := make([][]byte, len(d.Finalized))
+	for i, root := range d.Finalized {
+		var err error
+		if finalized[i], err = decodeRoot(root); err != nil {
+			return nil, errors.Wrapf(err, "invalid finalized root at index %d", i)
+		}
+	}
+	depositRoot, err := decodeRoot(d.DepositRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit root")
+	}
+	depositCount, err := strconv.ParseUint(d.DepositCount, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit count")
+	}
+	blockHash, err := decodeRoot(d.ExecutionBlockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution block hash")
+	}
+	blockHeight, err := strconv.ParseUint(d.ExecutionBlockHeight, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid execution block height")
+	}
+	return &ethpb.DepositSnapshot{
+		Finalized:            finalized,
+		DepositRoot:          depositRoot,
+		DepositCount:         depositCount,
+		ExecutionBlockHash:   blockHash,
+		ExecutionBlockHeight: blockHeight,
+	}, nil
+}
+
+func decodeRoot(s string) ([]byte, error) {
+	root, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(root) != 32 {
+		return nil, errors.Errorf("expected 32 bytes, got %d", len(root))
+	}
+	return root, nil
+}
@@ -0,0 +1,74 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func testDepositSnapshot() (*ethpb.DepositSnapshot, *depositSnapshotResponse) {
+	snapshot := &ethpb.DepositSnapshot{
+		Finalized:            [][]byte{bytesutil.PadTo([]byte{1}, 32), bytesutil.PadTo([]byte{2}, 32)},
+		DepositRoot:          bytesutil.PadTo([]byte{3}, 32),
+		DepositCount:         5,
+		ExecutionBlockHash:   bytesutil.PadTo([]byte{4}, 32),
+		ExecutionBlockHeight: 100,
+	}
+	return snapshot, &depositSnapshotResponse{
+		Data: &depositSnapshotJSON{
+			Finalized:            []string{hexutil.Encode(snapshot.Finalized[0]), hexutil.Encode(snapshot.Finalized[1])},
+			DepositRoot:          hexutil.Encode(snapshot.DepositRoot),
+			DepositCount:         "5",
+			ExecutionBlockHash:   hexutil.Encode(snapshot.ExecutionBlockHash),
+			ExecutionBlockHeight: "100",
+		},
+	}
+}
+
+func TestLoadDepositSnapshotFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deposit_snapshot.json")
+	_, err := LoadDepositSnapshotFile(path)
+	assert.ErrorContains(t, "does not exist", err)
+
+	want, resp := testDepositSnapshot()
+	enc, err := json.Marshal(resp)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, enc, 0600))
+	snapshot, err := LoadDepositSnapshotFile(path)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, snapshot)
+
+	resp.Data.DepositRoot = "0x01"
+	enc, err = json.Marshal(resp)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, enc, 0600))
+	_, err = LoadDepositSnapshotFile(path)
+	assert.ErrorContains(t, "invalid deposit root", err)
+}
+
+func TestFetchDepositSnapshot(t *testing.T) {
+	want, resp := testDepositSnapshot()
+	mux := http.NewServeMux()
+	mux.HandleFunc(depositSnapshotPath, func(w http.ResponseWriter, _ *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	snapshot, err := FetchDepositSnapshot(context.Background(), srv.URL+"/")
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, snapshot)
+
+	_, err = FetchDepositSnapshot(context.Background(), srv.URL+"/prefix")
+	assert.ErrorContains(t, "failed with status 404", err)
+}
@@ -0,0 +1,52 @@
+package checkpoint
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/sirupsen/logrus"
+)
+
+// FileInitializer loads a SSZ encoded finalized state and the block it was derived from
+// from the local filesystem.
+type FileInitializer struct {
+	statePath string
+	blockPath string
+}
+
+// NewFileInitializer creates a FileInitializer for the given state and block file paths.
+func NewFileInitializer(statePath, blockPath string) (*FileInitializer, error) {
+	var err error
+	if statePath, err = fileutil.ExpandPath(statePath); err != nil {
+		return nil, err
+	}
+	if blockPath, err = fileutil.ExpandPath(blockPath); err != nil {
+		return nil, err
+	}
+	if !fileutil.FileExists(statePath) {
+		return nil, errors.Errorf("checkpoint state file %s does not exist", statePath)
+	}
+	if !fileutil.FileExists(blockPath) {
+		return nil, errors.Errorf("checkpoint block file %s does not exist", blockPath)
+	}
+	return &FileInitializer{statePath: statePath, blockPath: blockPath}, nil
+}
+
+// Initialize saves the state and block read from the files as the origin of the database.
+func (f *FileInitializer) Initialize(ctx context.Context, d db.Database) error {
+	serState, err := fileutil.ReadFileAsBytes(f.statePath)
+	if err != nil {
+		return errors.Wrapf(err, "could not read checkpoint state file %s", f.statePath)
+	}
+	serBlock, err := fileutil.ReadFileAsBytes(f.blockPath)
+	if err != nil {
+		return errors.Wrapf(err, "could not read checkpoint block file %s", f.blockPath)
+	}
+	log.WithFields(logrus.Fields{
+		"state": f.statePath,
+		"block": f.blockPath,
+	}).Info("Initializing database from checkpoint files")
+	return d.SaveOrigin(ctx, serState, serBlock)
+}
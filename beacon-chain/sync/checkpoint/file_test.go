@@ -0,0 +1,44 @@
+package checkpoint
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+func TestNewFileInitializer_MissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.ssz")
+	blockPath := filepath.Join(dir, "block.ssz")
+
+	_, err := NewFileInitializer(statePath, blockPath)
+	assert.ErrorContains(t, "checkpoint state file", err)
+	require.NoError(t, ioutil.WriteFile(statePath, []byte("state"), 0600))
+	_, err = NewFileInitializer(statePath, blockPath)
+	assert.ErrorContains(t, "checkpoint block file", err)
+}
+
+func TestFileInitializer_Initialize(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+	serState, serBlock, root := checkpointPair(t, 96)
+
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "state.ssz")
+	blockPath := filepath.Join(dir, "block.ssz")
+	require.NoError(t, ioutil.WriteFile(statePath, serState, 0600))
+	require.NoError(t, ioutil.WriteFile(blockPath, serBlock, 0600))
+
+	fi, err := NewFileInitializer(statePath, blockPath)
+	require.NoError(t, err)
+	require.NoError(t, fi.Initialize(ctx, beaconDB))
+
+	originRoot, err := beaconDB.OriginBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root, originRoot)
+}
@@ -25,7 +25,9 @@ func NewRegularSyncFuzz(cfg *Config) *Service {
 		seenPendingBlocks:    make(map[[32]byte]bool),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		rateLimiter:          rLimiter,
+		signatureChan:        make(chan *signatureVerifier, verifierLimit),
 	}
+	go r.verifierRoutine()
 
 	return r
 }
@@ -0,0 +1,332 @@
+package initialsync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/abool"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+var _ shared.Service = (*BackfillService)(nil)
+
+// backfillRetryDelay is a delay before a failed backfill request is retried.
+const backfillRetryDelay = 5 * time.Second
+
+var (
+	errBackfillNotLinked     = errors.New("block is not the parent of the lowest backfilled block")
+	errBackfillSigsNotVerify = errors.New("proposer signatures of backfilled blocks did not verify")
+)
+
+// BackfillConfig to set up the backfill service.
+type BackfillConfig struct {
+	P2P           p2p.P2P
+	DB            db.NoHeadAccessDatabase
+	Chain         blockchainService
+	StateNotifier statefeed.Notifier
+}
+
+// BackfillService fetches the blocks preceding the origin block of a node that was started from a
+// checkpoint, walking backwards from the origin block until the genesis block is reached.
+type BackfillService struct {
+	cfg         *BackfillConfig
+	ctx         context.Context
+	cancel      context.CancelFunc
+	complete    *abool.AtomicBool
+	initialized chan bool
+}
+
+// NewBackfillService configures the service responsible for backfilling the history of a
+// checkpoint synced node.
+func NewBackfillService(ctx context.Context, cfg *BackfillConfig) *BackfillService {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &BackfillService{
+		cfg:         cfg,
+		ctx:         ctx,
+		cancel:      cancel,
+		complete:    abool.New(),
+		initialized: make(chan bool, 1),
+	}
+	go s.waitForStateInitialization()
+	return s
+}
+
+// Start the backfill service.
+func (s *BackfillService) Start() {
+	if !<-s.initialized {
+		log.Debug("Exiting backfill service")
+		return
+	}
+	originRoot, err := s.cfg.DB.OriginBlockRoot(s.ctx)
+	if errors.Is(err, db.ErrNotFoundOriginBlockRoot) {
+		log.Debug("Node was synced from genesis, no blocks to backfill")
+		s.complete.Set()
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve origin block root")
+		return
+	}
+	if err := s.backfill(originRoot); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Could not backfill blocks")
+		return
+	}
+	s.complete.Set()
+	log.Info("Backfilled all blocks down to genesis")
+}
+
+// Stop the backfill service.
+func (s *BackfillService) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *BackfillService) Status() error {
+	return nil
+}
+
+// Complete returns true once all blocks preceding the origin block are in the database.
+func (s *BackfillService) Complete() bool {
+	return s.complete.IsSet()
+}
+
+// backfill requests blocks from peers in batches going backwards from the lowest block in the
+// database, and saves every batch which links to it until the genesis block is reached.
+func (s *BackfillService) backfill(originRoot [32]byte) error {
+	genesisBlock, err := s.cfg.DB.GenesisBlock(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve genesis block")
+	}
+	if genesisBlock == nil || genesisBlock.IsNil() {
+		return errors.New("genesis block is required to backfill blocks")
+	}
+	genesisRoot, err := genesisBlock.Block().HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not compute genesis block root")
+	}
+	// Proposers of earlier blocks are part of the origin state registry, as validators are never removed.
+	originState, err := s.cfg.DB.State(s.ctx, originRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve origin state")
+	}
+	if originState == nil || originState.IsNil() {
+		return errors.New("origin state is required to backfill blocks")
+	}
+	lowestRoot, err := s.cfg.DB.BackfillBlockRoot(s.ctx)
+	if errors.Is(err, db.ErrNotFoundBackfillBlockRoot) {
+		lowestRoot = originRoot
+	} else if err != nil {
+		return errors.Wrap(err, "could not retrieve backfill progress")
+	}
+	lowest, err := s.cfg.DB.Block(s.ctx, lowestRoot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve lowest block")
+	}
+	if lowest == nil || lowest.IsNil() {
+		return fmt.Errorf("missing block in database: block root=%#x", lowestRoot)
+	}
+	// Blocks older than the history retained by a pruned node are not backfilled.
+	earliestSlot, err := s.cfg.DB.EarliestAvailableSlot(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve earliest available slot")
+	}
+	if isBackfillComplete(lowest, genesisRoot) || lowest.Block().Slot() <= earliestSlot {
+		return nil
+	}
+
+	fetcher := newBlocksFetcher(s.ctx, &blocksFetcherConfig{
+		chain: s.cfg.Chain,
+		p2p:   s.cfg.P2P,
+		db:    s.cfg.DB,
+		mode:  modeStopOnFinalizedEpoch,
+	})
+	if err := fetcher.start(); err != nil {
+		return err
+	}
+	defer fetcher.stop()
+
+	log.WithField("slot", lowest.Block().Slot()).Info("Backfilling blocks preceding the origin block")
+	batchSize := types.Slot(flags.Get().BlockBatchLimit)
+	// The genesis block is never requested, it is already in the database.
+	cursor := lowest.Block().Slot()
+	for !isBackfillComplete(lowest, genesisRoot) && lowest.Block().Slot() > earliestSlot {
+		// Peers claimed all remaining slots are empty, which contradicts the parent of the lowest block.
+		if cursor <= 1 {
+			cursor = lowest.Block().Slot()
+			if err := s.waitBeforeRetry(); err != nil {
+				return err
+			}
+		}
+		start := types.Slot(1)
+		if cursor > batchSize+1 {
+			start = cursor - batchSize
+		}
+		if err := fetcher.scheduleRequest(s.ctx, start, uint64(cursor-start)); err != nil {
+			return err
+		}
+		var resp *fetchRequestResponse
+		select {
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		case r, ok := <-fetcher.requestResponses():
+			if !ok {
+				return errFetcherCtxIsDone
+			}
+			resp = r
+		}
+		if resp.err != nil {
+			log.WithError(resp.err).Debug("Could not fetch blocks to backfill")
+			if err := s.waitBeforeRetry(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := verifyBackfillBlocks(lowest, resp.blocks, originState); err != nil {
+			log.WithError(err).WithField("peer", resp.pid).Debug("Discarding invalid backfill response")
+			if resp.pid != "" {
+				s.cfg.P2P.Peers().Scorers().BadResponsesScorer().Increment(resp.pid)
+			}
+			cursor = lowest.Block().Slot()
+			continue
+		}
+		// Every returned block belongs to the chain, so all the other slots in the range are empty.
+		cursor = start
+		if len(resp.blocks) == 0 {
+			continue
+		}
+		if err := s.cfg.DB.SaveBackfillBlocks(s.ctx, resp.blocks); err != nil {
+			return errors.Wrap(err, "could not save backfilled blocks")
+		}
+		lowest = resp.blocks[0]
+		log.WithFields(logrus.Fields{
+			"slot":   lowest.Block().Slot(),
+			"blocks": len(resp.blocks),
+		}).Debug("Backfilled blocks")
+	}
+	return nil
+}
+
+// waitBeforeRetry pauses the backfill loop before a request is retried.
+func (s *BackfillService) waitBeforeRetry() error {
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-time.After(backfillRetryDelay):
+		return nil
+	}
+}
+
+// waitForStateInitialization blocks until the state initialized event is received, and notifies
+// the service whether it should start.
+func (s *BackfillService) waitForStateInitialization() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.cfg.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type == statefeed.Initialized {
+				s.initialized <- true
+				return
+			}
+		case <-s.ctx.Done():
+			s.initialized <- false
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state notifier failed")
+			s.initialized <- false
+			return
+		}
+	}
+}
+
+// isBackfillComplete returns true if the given block is the first block after genesis.
+func isBackfillComplete(lowest block.SignedBeaconBlock, genesisRoot [32]byte) bool {
+	return lowest.Block().Slot() == 0 || bytes.Equal(lowest.Block().ParentRoot(), genesisRoot[:])
+}
+
+// verifyBackfillBlocks checks that the blocks, sorted by ascending slot, form the chain of ancestors
+// of the given child block, and batch verifies their proposer signatures.
+func verifyBackfillBlocks(child block.SignedBeaconBlock, blks []block.SignedBeaconBlock, st state.ReadOnlyBeaconState) error {
+	expected := child.Block().ParentRoot()
+	for i := len(blks) - 1; i >= 0; i-- {
+		if blks[i] == nil || blks[i].IsNil() {
+			return errors.New("nil block")
+		}
+		root, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(root[:], expected) {
+			return errors.Wrapf(errBackfillNotLinked, "block root %#x at slot %d, expected %#x",
+				root, blks[i].Block().Slot(), expected)
+		}
+		expected = blks[i].Block().ParentRoot()
+	}
+	if len(blks) == 0 {
+		return nil
+	}
+
+	set := bls.NewSet()
+	for _, blk := range blks {
+		proposer, err := st.ValidatorAtIndexReadOnly(blk.Block().ProposerIndex())
+		if err != nil {
+			return errors.Wrapf(err, "could not retrieve proposer of block at slot %d", blk.Block().Slot())
+		}
+		proposerPubKey := proposer.PublicKey()
+		domain, err := helpers.ComputeDomain(
+			params.BeaconConfig().DomainBeaconProposer,
+			forkVersionAtEpoch(core.SlotToEpoch(blk.Block().Slot())),
+			st.GenesisValidatorRoot(),
+		)
+		if err != nil {
+			return err
+		}
+		blkSet, err := helpers.BlockSignatureSet(proposerPubKey[:], blk.Signature(), domain, blk.Block().HashTreeRoot)
+		if err != nil {
+			return err
+		}
+		set.Join(blkSet)
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		return errors.Wrap(err, "could not batch verify proposer signatures")
+	}
+	if !verified {
+		return errBackfillSigsNotVerify
+	}
+	return nil
+}
+
+// forkVersionAtEpoch returns the fork version scheduled for the given epoch.
+func forkVersionAtEpoch(epoch types.Epoch) []byte {
+	version := params.BeaconConfig().GenesisForkVersion
+	var activation types.Epoch
+	for v, e := range params.BeaconConfig().ForkVersionSchedule {
+		if e <= epoch && e >= activation {
+			version, activation = bytesutil.SafeCopyBytes(v[:]), e
+		}
+	}
+	return version
+}
@@ -0,0 +1,144 @@
+package initialsync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	p2pt "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/block"
+	"github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/wrapper"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+// signedChain returns a chain of blocks at the given slots signed by their proposers.
+func signedChain(t *testing.T, st state.ReadOnlyBeaconState, privs []bls.SecretKey, slots ...types.Slot) []block.SignedBeaconBlock {
+	blks := make([]block.SignedBeaconBlock, len(slots))
+	parent := bytesutil.PadTo([]byte("genesis"), 32)
+	for i, slot := range slots {
+		b := testutil.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex = types.ValidatorIndex(i)
+		b.Block.ParentRoot = parent
+		domain, err := helpers.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, nil, st.GenesisValidatorRoot())
+		require.NoError(t, err)
+		signingRoot, err := helpers.ComputeSigningRoot(b.Block, domain)
+		require.NoError(t, err)
+		b.Signature = privs[i].Sign(signingRoot[:]).Marshal()
+		root, err := b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		blks[i] = wrapper.WrappedPhase0SignedBeaconBlock(b)
+		parent = root[:]
+	}
+	return blks
+}
+
+func TestVerifyBackfillBlocks(t *testing.T) {
+	st, privs := testutil.DeterministicGenesisState(t, 8)
+	blks := signedChain(t, st, privs, 1, 3, 4, 6)
+	child := blks[3]
+
+	t.Run("valid chain", func(t *testing.T) {
+		require.NoError(t, verifyBackfillBlocks(child, blks[:3], st))
+	})
+	t.Run("no blocks", func(t *testing.T) {
+		require.NoError(t, verifyBackfillBlocks(child, []block.SignedBeaconBlock{}, st))
+	})
+	t.Run("missing block", func(t *testing.T) {
+		err := verifyBackfillBlocks(child, []block.SignedBeaconBlock{blks[0], blks[2]}, st)
+		assert.ErrorContains(t, errBackfillNotLinked.Error(), err)
+	})
+	t.Run("not a parent of the child", func(t *testing.T) {
+		err := verifyBackfillBlocks(child, blks[:2], st)
+		assert.ErrorContains(t, errBackfillNotLinked.Error(), err)
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		other := signedChain(t, st, privs, 1, 3, 4, 6)
+		b, err := other[0].PbPhase0Block()
+		require.NoError(t, err)
+		b.Signature = blks[1].Signature()
+		err = verifyBackfillBlocks(other[1], other[:1], st)
+		assert.ErrorContains(t, errBackfillSigsNotVerify.Error(), err)
+	})
+}
+
+func TestForkVersionAtEpoch(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig()
+	cfg.GenesisForkVersion = []byte{0, 0, 0, 1}
+	cfg.AltairForkVersion = []byte{1, 0, 0, 1}
+	cfg.AltairForkEpoch = 10
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	assert.DeepEqual(t, []byte{0, 0, 0, 1}, forkVersionAtEpoch(0))
+	assert.DeepEqual(t, []byte{0, 0, 0, 1}, forkVersionAtEpoch(9))
+	assert.DeepEqual(t, []byte{1, 0, 0, 1}, forkVersionAtEpoch(10))
+	assert.DeepEqual(t, []byte{1, 0, 0, 1}, forkVersionAtEpoch(100))
+}
+
+func TestBackfillService_NoOrigin(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier := &mock.MockStateNotifier{}
+	s := NewBackfillService(ctx, &BackfillConfig{
+		P2P:           p2pt.NewTestP2P(t),
+		DB:            dbtest.SetupDB(t),
+		Chain:         &mock.ChainService{},
+		StateNotifier: notifier,
+	})
+	time.Sleep(100 * time.Millisecond)
+	notifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.Initialized,
+		Data: &statefeed.InitializedData{StartTime: time.Now()},
+	})
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		s.Start()
+		wg.Done()
+	}()
+	if testutil.WaitTimeout(wg, time.Second) {
+		t.Fatalf("Test should have exited by now, timed out")
+	}
+	assert.Equal(t, true, s.Complete())
+	assert.LogsContain(t, hook, "no blocks to backfill")
+}
+
+func TestBackfillService_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := NewBackfillService(ctx, &BackfillConfig{
+		P2P:           p2pt.NewTestP2P(t),
+		DB:            dbtest.SetupDB(t),
+		Chain:         &mock.ChainService{},
+		StateNotifier: &mock.MockStateNotifier{},
+	})
+	cancel()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		s.Start()
+		wg.Done()
+	}()
+	if testutil.WaitTimeout(wg, time.Second) {
+		t.Fatalf("Test should have exited by now, timed out")
+	}
+	assert.Equal(t, false, s.Complete())
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	types "github.com/prysmaticlabs/eth2-types"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers/scorers"
 	"github.com/prysmaticlabs/prysm/cmd/beacon-chain/flags"
@@ -120,6 +121,18 @@ func (f *blocksFetcher) filterPeers(ctx context.Context, peers []peer.ID, peersP
 	return trimPeers(peers, peersPercentage)
 }
 
+// peersWithHistory returns the peers which did not prune the blocks from the start slot from
+// their history.
+func (f *blocksFetcher) peersWithHistory(peers []peer.ID, start types.Slot) []peer.ID {
+	filtered := make([]peer.ID, 0, len(peers))
+	for _, pid := range peers {
+		if f.p2p.Peers().EarliestAvailableSlot(pid) <= start {
+			filtered = append(filtered, pid)
+		}
+	}
+	return filtered
+}
+
 // trimPeers limits peer list, returning only specified percentage of peers.
 // Takes system constraints into account (min/max peers to sync).
 func trimPeers(peers []peer.ID, peersPercentage float64) []peer.ID {
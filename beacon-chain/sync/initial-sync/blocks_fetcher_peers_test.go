@@ -301,6 +301,20 @@ func TestBlocksFetcher_filterPeers(t *testing.T) {
 	}
 }
 
+func TestBlocksFetcher_peersWithHistory(t *testing.T) {
+	mc, p2p, _ := initializeTestServices(t, []types.Slot{}, []*peerData{})
+	fetcher := newBlocksFetcher(context.Background(), &blocksFetcherConfig{
+		chain: mc,
+		p2p:   p2p,
+	})
+	p2p.Peers().SetEarliestAvailableSlot("b", 64)
+	p2p.Peers().SetEarliestAvailableSlot("c", 65)
+
+	peers := []peer.ID{"a", "b", "c"}
+	assert.DeepEqual(t, []peer.ID{"a", "b"}, fetcher.peersWithHistory(peers, 64))
+	assert.DeepEqual(t, []peer.ID{"a"}, fetcher.peersWithHistory(peers, 0))
+}
+
 func TestBlocksFetcher_removeStalePeerLocks(t *testing.T) {
 	type peerData struct {
 		peerID   peer.ID
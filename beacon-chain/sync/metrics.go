@@ -70,6 +70,32 @@ var (
 			Buckets: []float64{250, 500, 1000, 1500, 2000, 4000, 8000, 16000},
 		},
 	)
+	signatureVerificationQueueDepth = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "gossip_signature_verification_queue_depth",
+			Help: "The number of gossip signature sets waiting for the next batch verification.",
+		},
+	)
+	signatureVerificationBatchSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "gossip_signature_verification_batch_size",
+			Help:    "The number of gossip signature sets verified in a single batch.",
+			Buckets: []float64{1, 2, 5, 10, 20, 30, 40, 50},
+		},
+	)
+	signatureVerificationLatency = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "gossip_signature_verification_latency_milliseconds",
+			Help:    "Captures the time between a gossip signature set being queued and its batch verification result.",
+			Buckets: []float64{5, 10, 25, 50, 75, 100, 250, 500, 1000},
+		},
+	)
+	signatureBatchFailureCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "gossip_signature_batch_failures_total",
+			Help: "Count of gossip signature batches which failed verification and were verified set by set.",
+		},
+	)
 )
 
 func (s *Service) updateMetrics() {
@@ -107,6 +107,8 @@ func TestProcessPendingAtts_HasBlockSaveUnAggregatedAtt(t *testing.T) {
 	require.NoError(t, beaconState.SetGenesisTime(uint64(time.Now().Unix())))
 
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P: p1,
 			DB:  db,
@@ -121,6 +123,7 @@ func TestProcessPendingAtts_HasBlockSaveUnAggregatedAtt(t *testing.T) {
 		blkRootToPendingAtts:             make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenUnAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 
 	sb = testutil.NewBeaconBlock()
 	r32, err := sb.Block.HashTreeRoot()
@@ -147,6 +150,8 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 
 	s, _ := testutil.DeterministicGenesisState(t, 256)
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P:     p1,
 			DB:      db,
@@ -155,6 +160,7 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 		},
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 	}
+	go r.verifierRoutine()
 
 	priv, err := bls.RandKey()
 	require.NoError(t, err)
@@ -222,6 +228,8 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 
 	require.NoError(t, s.SetGenesisTime(uint64(time.Now().Unix())))
 	r = &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P: p1,
 			DB:  db,
@@ -236,6 +244,7 @@ func TestProcessPendingAtts_NoBroadcastWithBadSignature(t *testing.T) {
 		blkRootToPendingAtts:             make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenUnAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 
 	r.blkRootToPendingAtts[r32] = []*ethpb.SignedAggregateAttestationAndProof{{Message: aggregateAndProof, Signature: aggreSig}}
 	require.NoError(t, r.processPendingAtts(context.Background()))
@@ -300,6 +309,8 @@ func TestProcessPendingAtts_HasBlockSaveAggregatedAtt(t *testing.T) {
 	require.NoError(t, beaconState.SetGenesisTime(uint64(time.Now().Unix())))
 
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P: p1,
 			DB:  db,
@@ -314,6 +325,7 @@ func TestProcessPendingAtts_HasBlockSaveAggregatedAtt(t *testing.T) {
 		blkRootToPendingAtts:           make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 
 	sb = testutil.NewBeaconBlock()
 	r32, err := sb.Block.HashTreeRoot()
@@ -40,6 +40,19 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		traceutil.AnnotateError(span, err)
 		return err
 	}
+	// Blocks older than the retained history of a pruned node can't be served.
+	earliestSlot, err := s.cfg.DB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	if m.StartSlot < earliestSlot {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		err := p2ptypes.ErrResourceUnavailable
+		traceutil.AnnotateError(span, err)
+		return err
+	}
 
 	// The initial count for the first batch to be returned back.
 	count := m.Count
@@ -73,7 +73,7 @@ func readFirstChunkedBlock(stream libp2pcore.Stream, chain blockchain.ChainInfoF
 		return nil, err
 	}
 	if code != 0 {
-		return nil, errors.New(errMsg)
+		return nil, responseCodeError(code, errMsg)
 	}
 	rpcCtx, err := readContextFromStream(stream, chain)
 	if err != nil {
@@ -96,7 +96,7 @@ func readResponseChunk(stream libp2pcore.Stream, chain blockchain.ChainInfoFetch
 		return nil, err
 	}
 	if code != 0 {
-		return nil, errors.New(errMsg)
+		return nil, responseCodeError(code, errMsg)
 	}
 	// No-op for now with the rpc context.
 	rpcCtx, err := readContextFromStream(stream, chain)
@@ -134,3 +134,13 @@ func extractBlockDataType(digest []byte, chain blockchain.ChainInfoFetcher) (blo
 	}
 	return nil, errors.New("no valid digest matched")
 }
+
+// responseCodeError returns the error of a response with a non-success code. The error of a
+// resource unavailable response wraps types.ErrResourceUnavailable, for the requester to tell
+// the peer does not have the blocks it asked for.
+func responseCodeError(code byte, errMsg string) error {
+	if code == responseCodeResourceUnavailable {
+		return errors.Wrap(types.ErrResourceUnavailable, errMsg)
+	}
+	return errors.New(errMsg)
+}
@@ -42,6 +42,35 @@ func TestSendRequest_SendBeaconBlocksByRangeRequest(t *testing.T) {
 		assert.ErrorContains(t, "protocol not supported", err)
 	})
 
+	t.Run("resource unavailable", func(t *testing.T) {
+		p1 := p2ptest.NewTestP2P(t)
+		p2 := p2ptest.NewTestP2P(t)
+		p1.Connect(p2)
+		p2.SetStreamHandler(pcl, func(stream network.Stream) {
+			defer func() {
+				assert.NoError(t, stream.Close())
+			}()
+			req := &pb.BeaconBlocksByRangeRequest{}
+			assert.NoError(t, p2.Encoding().DecodeWithMaxLength(stream, req))
+			_, err := stream.Write([]byte{responseCodeResourceUnavailable})
+			assert.NoError(t, err)
+			msg := p2pTypes.ErrorMessage(p2pTypes.ErrResourceUnavailable.Error())
+			_, err = p2.Encoding().EncodeWithMaxLength(stream, &msg)
+			assert.NoError(t, err)
+		})
+
+		req := &pb.BeaconBlocksByRangeRequest{
+			StartSlot: 20,
+			Count:     128,
+			Step:      1,
+		}
+		chain := &mock.ChainService{Genesis: time.Now(), ValidatorsRoot: [32]byte{}}
+		_, err := SendBeaconBlocksByRangeRequest(ctx, chain, p1, p2.PeerID(), req, nil)
+		assert.Equal(t, true, errors.Is(err, p2pTypes.ErrResourceUnavailable))
+		// Only the earliest slot advertised by the peer is recorded.
+		assert.Equal(t, types.Slot(0), p1.Peers().EarliestAvailableSlot(p2.PeerID()))
+	})
+
 	knownBlocks := make([]*eth.SignedBeaconBlock, 0)
 	genesisBlk := testutil.NewBeaconBlock()
 	genesisBlkRoot, err := genesisBlk.Block.HashTreeRoot()
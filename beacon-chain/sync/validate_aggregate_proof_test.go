@@ -348,6 +348,8 @@ func TestValidateAggregateAndProof_CanValidate(t *testing.T) {
 
 	require.NoError(t, beaconState.SetGenesisTime(uint64(time.Now().Unix())))
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P:         p,
 			DB:          db,
@@ -364,6 +366,7 @@ func TestValidateAggregateAndProof_CanValidate(t *testing.T) {
 		},
 		seenAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 	r.initCaches()
 
 	buf := new(bytes.Buffer)
@@ -440,6 +443,8 @@ func TestVerifyIndexInCommittee_SeenAggregatorEpoch(t *testing.T) {
 	require.NoError(t, beaconState.SetGenesisTime(uint64(time.Now().Unix())))
 
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P:         p,
 			DB:          db,
@@ -458,6 +463,7 @@ func TestVerifyIndexInCommittee_SeenAggregatorEpoch(t *testing.T) {
 		},
 		seenAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 	r.initCaches()
 
 	buf := new(bytes.Buffer)
@@ -641,6 +647,8 @@ func TestValidateAggregateAndProof_RejectWhenAttEpochDoesntEqualTargetEpoch(t *t
 
 	require.NoError(t, beaconState.SetGenesisTime(uint64(time.Now().Unix())))
 	r := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			P2P:         p,
 			DB:          db,
@@ -657,6 +665,7 @@ func TestValidateAggregateAndProof_RejectWhenAttEpochDoesntEqualTargetEpoch(t *t
 		},
 		seenAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go r.verifierRoutine()
 	r.initCaches()
 
 	buf := new(bytes.Buffer)
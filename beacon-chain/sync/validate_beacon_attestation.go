@@ -187,13 +187,13 @@ func (s *Service) validateUnaggregatedAttWithState(ctx context.Context, a *eth.A
 		return pubsub.ValidationReject
 	}
 
-	if err := blocks.VerifyAttestationSignature(ctx, bs, a); err != nil {
+	set, err := blocks.AttestationSignatureSet(ctx, bs, []*eth.Attestation{a})
+	if err != nil {
 		log.WithError(err).Debug("Could not verify attestation")
 		traceutil.AnnotateError(span, err)
 		return pubsub.ValidationReject
 	}
-
-	return pubsub.ValidationAccept
+	return s.validateWithBatchVerifier(ctx, "attestation", set)
 }
 
 // Returns true if the attestation was already seen for the participating validator for the slot.
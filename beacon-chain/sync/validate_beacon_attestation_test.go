@@ -36,6 +36,8 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 	}
 
 	s := &Service{
+		ctx:           context.Background(),
+		signatureChan: make(chan *signatureVerifier, verifierLimit),
 		cfg: &Config{
 			InitialSync:       &mockSync.Sync{IsSyncing: false},
 			P2P:               p,
@@ -46,6 +48,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 		blkRootToPendingAtts:             make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
 		seenUnAggregatedAttestationCache: lruwrpr.New(10),
 	}
+	go s.verifierRoutine()
 	s.initCaches()
 
 	invalidRoot := [32]byte{'A', 'B', 'C', 'D'}
@@ -232,8 +232,7 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 		}
 
 		// We reject a malformed signature from bytes according to the p2p specification.
-		blsSig, err := bls.SignatureFromBytes(m.Signature)
-		if err != nil {
+		if _, err := bls.SignatureFromBytes(m.Signature); err != nil {
 			traceutil.AnnotateError(span, err)
 			return pubsub.ValidationReject
 		}
@@ -245,11 +244,12 @@ func (s *Service) rejectInvalidSyncCommitteeSignature(m *ethpb.SyncCommitteeMess
 			return pubsub.ValidationIgnore
 		}
 
-		verified := blsSig.Verify(pKey, sigRoot[:])
-		if !verified {
-			return pubsub.ValidationReject
+		set := &bls.SignatureSet{
+			Signatures: [][]byte{m.Signature},
+			PublicKeys: []bls.PublicKey{pKey},
+			Messages:   [][32]byte{sigRoot},
 		}
-		return pubsub.ValidationAccept
+		return s.validateWithBatchVerifier(ctx, "sync committee message", set)
 	}
 }
 
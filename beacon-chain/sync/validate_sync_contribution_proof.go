@@ -176,20 +176,21 @@ func (s *Service) rejectInvalidIndexInSubCommittee(m *ethpb.SignedContributionAn
 
 func (s *Service) rejectInvalidSelectionProof(m *ethpb.SignedContributionAndProof) validationFn {
 	return func(ctx context.Context) pubsub.ValidationResult {
-		_, span := trace.StartSpan(ctx, "sync.rejectInvalidSelectionProof")
+		ctx, span := trace.StartSpan(ctx, "sync.rejectInvalidSelectionProof")
 		defer span.End()
 		// The `contribution_and_proof.selection_proof` is a valid signature of the `SyncAggregatorSelectionData`.
-		if err := s.verifySyncSelectionData(ctx, m.Message); err != nil {
+		set, err := s.syncSelectionDataSignatureSet(ctx, m.Message)
+		if err != nil {
 			traceutil.AnnotateError(span, err)
 			return pubsub.ValidationReject
 		}
-		return pubsub.ValidationAccept
+		return s.validateWithBatchVerifier(ctx, "sync contribution selection proof", set)
 	}
 }
 
 func (s *Service) rejectInvalidContributionSignature(m *ethpb.SignedContributionAndProof) validationFn {
 	return func(ctx context.Context) pubsub.ValidationResult {
-		_, span := trace.StartSpan(ctx, "sync.rejectInvalidContributionSignature")
+		ctx, span := trace.StartSpan(ctx, "sync.rejectInvalidContributionSignature")
 		defer span.End()
 		// The aggregator signature, `signed_contribution_and_proof.signature`, is valid.
 		d, err := s.cfg.Chain.HeadSyncContributionProofDomain(ctx, m.Message.Contribution.Slot)
@@ -201,17 +202,18 @@ func (s *Service) rejectInvalidContributionSignature(m *ethpb.SignedContribution
 		if err != nil {
 			return pubsub.ValidationIgnore
 		}
-		if err := helpers.VerifySigningRoot(m.Message, pubkey[:], m.Signature, d); err != nil {
+		set, err := helpers.BlockSignatureSet(pubkey[:], m.Signature, d, m.Message.HashTreeRoot)
+		if err != nil {
 			traceutil.AnnotateError(span, err)
 			return pubsub.ValidationReject
 		}
-		return pubsub.ValidationAccept
+		return s.validateWithBatchVerifier(ctx, "sync contribution signature", set)
 	}
 }
 
 func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributionAndProof) validationFn {
 	return func(ctx context.Context) pubsub.ValidationResult {
-		_, span := trace.StartSpan(ctx, "sync.rejectInvalidSyncAggregateSignature")
+		ctx, span := trace.StartSpan(ctx, "sync.rejectInvalidSyncAggregateSignature")
 		defer span.End()
 		// The aggregate signature is valid for the message `beacon_block_root` and aggregate pubkey
 		// derived from the participation info in `aggregation_bits` for the subcommittee specified by the `contribution.subcommittee_index`.
@@ -236,8 +238,12 @@ func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributio
 				activePubkeys = append(activePubkeys, pubK)
 			}
 		}
-		sig, err := bls.SignatureFromBytes(m.Message.Contribution.Signature)
-		if err != nil {
+		// The bits set may all be beyond the pubkeys of the subcommittee, in which case there is
+		// no participant to verify the signature against.
+		if len(activePubkeys) == 0 {
+			return pubsub.ValidationReject
+		}
+		if _, err := bls.SignatureFromBytes(m.Message.Contribution.Signature); err != nil {
 			traceutil.AnnotateError(span, err)
 			return pubsub.ValidationReject
 		}
@@ -252,11 +258,18 @@ func (s *Service) rejectInvalidSyncAggregateSignature(m *ethpb.SignedContributio
 			traceutil.AnnotateError(span, err)
 			return pubsub.ValidationIgnore
 		}
-		verified := sig.Eth2FastAggregateVerify(activePubkeys, sigRoot)
-		if !verified {
-			return pubsub.ValidationReject
+		// Verifying the signature against the aggregate of the participants' public keys is
+		// equivalent to a fast aggregate verification, and can be batched with other sets.
+		aggKey := activePubkeys[0].Copy()
+		for _, pubK := range activePubkeys[1:] {
+			aggKey = aggKey.Aggregate(pubK)
 		}
-		return pubsub.ValidationAccept
+		set := &bls.SignatureSet{
+			Signatures: [][]byte{m.Message.Contribution.Signature},
+			PublicKeys: []bls.PublicKey{aggKey},
+			Messages:   [][32]byte{sigRoot},
+		}
+		return s.validateWithBatchVerifier(ctx, "sync contribution aggregate signature", set)
 	}
 }
 
@@ -280,17 +293,17 @@ func (s *Service) setSyncContributionIndexSlotSeen(slot types.Slot, aggregatorIn
 	s.seenSyncContributionCache.Add(string(b), true)
 }
 
-// verifySyncSelectionData verifies that the provided sync contribution has a valid
-// selection proof.
-func (s *Service) verifySyncSelectionData(ctx context.Context, m *ethpb.ContributionAndProof) error {
+// syncSelectionDataSignatureSet returns the signature set of the selection proof of the
+// provided sync contribution.
+func (s *Service) syncSelectionDataSignatureSet(ctx context.Context, m *ethpb.ContributionAndProof) (*bls.SignatureSet, error) {
 	selectionData := &ethpb.SyncAggregatorSelectionData{Slot: m.Contribution.Slot, SubcommitteeIndex: uint64(m.Contribution.SubcommitteeIndex)}
 	domain, err := s.cfg.Chain.HeadSyncSelectionProofDomain(ctx, m.Contribution.Slot)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	pubkey, err := s.cfg.Chain.HeadValidatorIndexToPublicKey(ctx, m.AggregatorIndex)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return helpers.VerifySigningRoot(selectionData, pubkey[:], m.SelectionProof, domain)
+	return helpers.BlockSignatureSet(pubkey[:], m.SelectionProof, domain, selectionData.HashTreeRoot)
 }
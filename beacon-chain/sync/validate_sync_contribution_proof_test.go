@@ -914,6 +914,30 @@ func fillUpBlocksAndState(ctx context.Context, t *testing.T, beaconDB db.Databas
 	return hRoot, keys
 }
 
+func TestService_RejectInvalidSyncAggregateSignature_NoParticipant(t *testing.T) {
+	pubkeys := make([][]byte, 4)
+	for i := range pubkeys {
+		sk, err := bls.RandKey()
+		require.NoError(t, err)
+		pubkeys[i] = sk.PublicKey().Marshal()
+	}
+	s := &Service{cfg: &Config{Chain: &mockChain.ChainService{SyncCommitteePubkeys: pubkeys}}}
+	// Only a bit beyond the pubkeys of the subcommittee is set.
+	bits := bitfield.NewBitvector128()
+	bits.SetBitAt(uint64(len(pubkeys)), true)
+	infiniteSig := [96]byte{0xC0}
+	msg := &ethpb.SignedContributionAndProof{
+		Message: &ethpb.ContributionAndProof{
+			Contribution: &ethpb.SyncCommitteeContribution{
+				BlockRoot:       params.BeaconConfig().ZeroHash[:],
+				AggregationBits: bits,
+				Signature:       infiniteSig[:],
+			},
+		},
+	}
+	assert.Equal(t, pubsub.ValidationReject, s.rejectInvalidSyncAggregateSignature(msg)(context.Background()))
+}
+
 func syncSelectionProofSigningRoot(st state.BeaconState, slot types.Slot, comIdx types.CommitteeIndex) ([32]byte, error) {
 	dom, err := helpers.Domain(st.Fork(), core.SlotToEpoch(slot), params.BeaconConfig().DomainSyncCommitteeSelectionProof, st.GenesisValidatorRoot())
 	if err != nil {
@@ -1,7 +1,15 @@
 package db
 
 import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
 	beacondb "github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/archive"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/tos"
 	"github.com/sirupsen/logrus"
@@ -31,5 +39,132 @@ var Commands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name:        "export",
+			Description: `exports the finalized history of a database to portable archive files`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.ArchiveDirFlag,
+				cmd.EpochsPerArchiveFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := exportHistory(cliCtx); err != nil {
+					log.Fatalf("Could not export database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "import",
+			Description: `imports the finalized history of archive files into an empty database`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.ArchiveDirFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := importHistory(cliCtx); err != nil {
+					log.Fatalf("Could not import database: %v", err)
+				}
+				return nil
+			},
+		},
+		{
+			Name:        "verify",
+			Description: `verifies the integrity of a database and optionally repairs it`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.RepairDBFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := verifyDB(cliCtx); err != nil {
+					log.Fatalf("Database verification failed: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
+
+func verifyDB(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	d, err := openDB(ctx, cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB(d)
+	report, err := d.VerifyIntegrity(ctx, cliCtx.Bool(cmd.RepairDBFlag.Name))
+	if err != nil {
+		return err
+	}
+	for _, issue := range report.Issues {
+		log.WithFields(logrus.Fields{
+			"check":    issue.Check,
+			"key":      fmt.Sprintf("%#x", issue.Key),
+			"repaired": issue.Repaired,
+		}).Warn(issue.Message)
+	}
+	log.WithFields(logrus.Fields{
+		"blocks":         report.Blocks,
+		"states":         report.States,
+		"stateSummaries": report.StateSummaries,
+		"issues":         len(report.Issues),
+		"repaired":       len(report.Issues) - report.Unrepaired(),
+	}).Info("Verified database")
+	if n := report.Unrepaired(); n > 0 {
+		return fmt.Errorf("%d issues remain in the database", n)
+	}
+	return nil
+}
+
+func exportHistory(cliCtx *cli.Context) error {
+	archiveDir := cliCtx.String(cmd.ArchiveDirFlag.Name)
+	if archiveDir == "" {
+		return errors.New("--archive-dir is required")
+	}
+	ctx := context.Background()
+	d, err := openDB(ctx, cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB(d)
+	return archive.Export(ctx, &archive.ExportConfig{
+		DB:            d,
+		OutputDir:     archiveDir,
+		EpochsPerFile: types.Epoch(cliCtx.Uint64(cmd.EpochsPerArchiveFlag.Name)),
+	})
+}
+
+func importHistory(cliCtx *cli.Context) error {
+	archiveDir := cliCtx.String(cmd.ArchiveDirFlag.Name)
+	if archiveDir == "" {
+		return errors.New("--archive-dir is required")
+	}
+	ctx := context.Background()
+	d, err := openDB(ctx, cliCtx)
+	if err != nil {
+		return err
+	}
+	defer closeDB(d)
+	return archive.Import(ctx, &archive.ImportConfig{
+		DB:       d,
+		InputDir: archiveDir,
+	})
+}
+
+func openDB(ctx context.Context, cliCtx *cli.Context) (*kv.Store, error) {
+	dbPath := filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.BeaconNodeDbDirName)
+	d, err := kv.NewKVStore(ctx, dbPath, &kv.Config{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open database at %s", dbPath)
+	}
+	return d, nil
+}
+
+func closeDB(d *kv.Store) {
+	if err := d.Close(); err != nil {
+		log.WithError(err).Error("Could not close database")
+	}
+}
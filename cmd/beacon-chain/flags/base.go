@@ -115,6 +115,32 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the DB.",
 		Value: 2048,
 	}
+	// SlotsPerStateSnapshot specifies the number of slots between the archived states saved in full in the cold
+	// section of DB. Archived states in between are saved as diffs against the last full state.
+	SlotsPerStateSnapshot = &cli.IntFlag{
+		Name: "slots-per-state-snapshot",
+		Usage: "The slot durations of when an archived state gets saved in full in the DB. The archived states in " +
+			"between are saved as diffs against the last full state, which reduces the size of archival databases. " +
+			"Must be a multiple of --slots-per-archive-point, 0 saves every archived state in full.",
+	}
+	// HistoryRetention specifies the number of epochs of chain history kept in the DB.
+	HistoryRetention = &cli.Uint64Flag{
+		Name: "history-retention",
+		Usage: "Prunes the blocks and states older than this number of epochs behind the finalized checkpoint " +
+			"from the DB. The retained history is never shorter than the weak subjectivity period. " +
+			"0 keeps the full history of the chain.",
+	}
+	// SlasherFlag enables the slasher service of the beacon node.
+	SlasherFlag = &cli.BoolFlag{
+		Name: "slasher",
+		Usage: "Runs a slasher in the beacon node, detecting slashable offenses from the attestations and blocks " +
+			"the node receives and submitting the resulting slashings to the operations pool.",
+	}
+	// SlasherDirFlag specifies the directory of the slasher database.
+	SlasherDirFlag = &cli.StringFlag{
+		Name:  "slasher-datadir",
+		Usage: "Directory for the slasher database, defaults to the data directory of the beacon node.",
+	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
@@ -176,10 +202,49 @@ var (
 		Usage: "Sets the maximum number of headers that a deposit log query can fetch.",
 		Value: uint64(1000),
 	}
+	// Eth1Quorum defines a flag to only accept eth1 data agreed by a quorum of the configured eth1 endpoints.
+	Eth1Quorum = &cli.Uint64Flag{
+		Name: "eth1-quorum",
+		Usage: "Queries all the eth1 endpoints given by --http-web3provider at once, and only accepts the headers, " +
+			"deposit logs and deposit contract state agreed by at least this many of them. The quorum must be " +
+			"a majority of the endpoints. 0 disables quorum mode and uses the endpoints as fallbacks of each other.",
+	}
 	// GenesisStatePath defines a flag to start the beacon chain from a give genesis state file.
 	GenesisStatePath = &cli.StringFlag{
 		Name: "genesis-state",
 		Usage: "Load a genesis state from ssz file. Testnet genesis files can be found in the " +
 			"eth2-clients/eth2-testnets repository on github.",
 	}
+	// CheckpointStatePath defines a flag to start the beacon chain from a finalized state file.
+	CheckpointStatePath = &cli.StringFlag{
+		Name: "checkpoint-state",
+		Usage: "Starts the beacon node from the given ssz encoded finalized beacon state instead of genesis. " +
+			"Must be used together with --checkpoint-block, and only on an empty database.",
+	}
+	// CheckpointBlockPath defines a flag to provide the block of the finalized checkpoint state.
+	CheckpointBlockPath = &cli.StringFlag{
+		Name:  "checkpoint-block",
+		Usage: "The ssz encoded signed beacon block the --checkpoint-state was derived from.",
+	}
+	// CheckpointSyncURL defines a flag to fetch the finalized checkpoint state and block from another beacon node.
+	CheckpointSyncURL = &cli.StringFlag{
+		Name: "checkpoint-sync-url",
+		Usage: "URL of the gRPC gateway of a trusted beacon node to download the finalized state and block from, " +
+			"to start the beacon node from instead of genesis (ex: http://localhost:3500). " +
+			"If --weak-subjectivity-checkpoint is set, the state at that checkpoint is used.",
+	}
+	// DepositSnapshotPath defines a flag to start processing deposits from a deposit snapshot file.
+	DepositSnapshotPath = &cli.StringFlag{
+		Name: "deposit-snapshot",
+		Usage: "Starts processing eth1 deposit logs from the given JSON deposit snapshot, in the format served by " +
+			"/eth/v1/beacon/deposit_snapshot, instead of from the deployment of the deposit contract. " +
+			"Only used when no deposits have been processed yet.",
+	}
+	// DepositSnapshotURL defines a flag to fetch the deposit snapshot from another beacon node.
+	DepositSnapshotURL = &cli.StringFlag{
+		Name: "deposit-snapshot-url",
+		Usage: "URL of the gRPC gateway of a trusted beacon node to download the deposit snapshot from, " +
+			"to start processing eth1 deposit logs from instead of the deployment of the deposit contract " +
+			"(ex: http://localhost:3500). Only used when no deposits have been processed yet.",
+	}
 )
@@ -54,6 +54,10 @@ var appFlags = []cli.Flag{
 	flags.InteropNumValidatorsFlag,
 	flags.InteropGenesisTimeFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.SlotsPerStateSnapshot,
+	flags.HistoryRetention,
+	flags.SlasherFlag,
+	flags.SlasherDirFlag,
 	flags.EnableDebugRPCEndpoints,
 	flags.SubscribeToAllSubnets,
 	flags.HistoricalSlasherNode,
@@ -61,7 +65,13 @@ var appFlags = []cli.Flag{
 	flags.NetworkID,
 	flags.WeakSubjectivityCheckpt,
 	flags.Eth1HeaderReqLimit,
+	flags.Eth1Quorum,
 	flags.GenesisStatePath,
+	flags.CheckpointStatePath,
+	flags.CheckpointBlockPath,
+	flags.CheckpointSyncURL,
+	flags.DepositSnapshotPath,
+	flags.DepositSnapshotURL,
 	cmd.EnableBackupWebhookFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
@@ -70,6 +80,7 @@ var appFlags = []cli.Flag{
 	cmd.BootstrapNode,
 	cmd.NoDiscovery,
 	cmd.StaticPeers,
+	cmd.TrustedPeers,
 	cmd.RelayNode,
 	cmd.P2PUDPPort,
 	cmd.P2PTCPPort,
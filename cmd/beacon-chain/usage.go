@@ -110,6 +110,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.HeadSync,
 			flags.DisableSync,
 			flags.SlotsPerArchivedPoint,
+			flags.SlotsPerStateSnapshot,
+			flags.HistoryRetention,
+			flags.SlasherFlag,
+			flags.SlasherDirFlag,
 			flags.DisableDiscv5,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
@@ -120,7 +124,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.NetworkID,
 			flags.WeakSubjectivityCheckpt,
 			flags.Eth1HeaderReqLimit,
+			flags.Eth1Quorum,
 			flags.GenesisStatePath,
+			flags.CheckpointStatePath,
+			flags.CheckpointBlockPath,
+			flags.CheckpointSyncURL,
+			flags.DepositSnapshotPath,
+			flags.DepositSnapshotURL,
 		},
 	},
 	{
@@ -135,6 +145,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PAllowList,
 			cmd.P2PDenyList,
 			cmd.StaticPeers,
+			cmd.TrustedPeers,
 			cmd.EnableUPnPFlag,
 			flags.MinSyncPeers,
 		},
@@ -28,8 +28,9 @@ var (
 	}
 	// BeaconRPCProviderFlag defines a beacon node RPC endpoint.
 	BeaconRPCProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rpc-provider",
-		Usage: "Beacon node RPC provider endpoint",
+		Name: "beacon-rpc-provider",
+		Usage: "Beacon node RPC provider endpoint. Several comma separated endpoints can be given, in which case " +
+			"the validator client performs its duties with the healthiest beacon node, preferring the first ones",
 		Value: "127.0.0.1:4000",
 	}
 	// BeaconRPCGatewayProviderFlag defines a beacon node JSON-RPC endpoint.
@@ -38,6 +39,27 @@ var (
 		Usage: "Beacon node RPC gateway provider endpoint",
 		Value: "127.0.0.1:3500",
 	}
+	// BeaconRESTApiProviderFlag defines a beacon node endpoint serving the standard Beacon API.
+	BeaconRESTApiProviderFlag = &cli.StringFlag{
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint, such as http://127.0.0.1:3500. If set, the validator " +
+			"client performs its duties through the standard Beacon API instead of the beacon node RPC provider, " +
+			"which lets it run with any consensus client. Several comma separated endpoints can be given, as with " +
+			"the beacon node RPC provider",
+	}
+	// BeaconRESTApiTimeoutFlag defines the timeout of the requests to the beacon node REST API.
+	BeaconRESTApiTimeoutFlag = &cli.DurationFlag{
+		Name:  "beacon-rest-api-timeout",
+		Usage: "Timeout of the requests to the beacon node REST API provider",
+		Value: 10 * time.Second,
+	}
+	// EnableBeaconNodeBroadcastFlag enables sending signed attestations, aggregates and blocks to
+	// all the healthy beacon nodes rather than only to the one in use.
+	EnableBeaconNodeBroadcastFlag = &cli.BoolFlag{
+		Name: "enable-beacon-node-broadcast",
+		Usage: "Sends signed attestations, aggregates and blocks to all the healthy beacon nodes when several " +
+			"beacon node endpoints are configured, rather than only to the one in use",
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -84,8 +106,9 @@ var (
 	}
 	// GrpcRetriesFlag defines the number of times to retry a failed gRPC request.
 	GrpcRetriesFlag = &cli.UintFlag{
-		Name:  "grpc-retries",
-		Usage: "Number of attempts to retry gRPC requests",
+		Name: "grpc-retries",
+		Usage: "Number of attempts to retry gRPC requests. Requests are not retried against the same beacon " +
+			"node when several beacon node endpoints are given, as they are retried on the next beacon node instead",
 		Value: 5,
 	}
 	// GrpcRetryDelayFlag defines the interval to retry a failed gRPC request.
@@ -118,6 +141,12 @@ var (
 		Usage: "Comma separated list of domains from which to accept cross origin requests " +
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4242,http://127.0.0.1:4242,http://localhost:4200,http://0.0.0.0:4242,http://0.0.0.0:4200"}
+	// KeymanagerAuthTokenFileFlag defines the path the bearer token of the standard keymanager API is written to.
+	KeymanagerAuthTokenFileFlag = &cli.StringFlag{
+		Name: "keymanager-auth-token-file",
+		Usage: "Path to the file the bearer token of the standard keymanager API, served on the gRPC gateway, " +
+			"is written to at startup. Defaults to an auth-token file in the wallet directory.",
+	}
 	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
 	MonitoringPortFlag = &cli.IntFlag{
 		Name:  "monitoring-port",
@@ -303,6 +332,54 @@ var (
 		Usage: "Enables more verbose logging for counting down to duty",
 		Value: false,
 	}
+	// DoppelgangerEpochsFlag defines the number of epochs for which new validator keys are checked
+	// for liveness in the network before they sign anything, when doppelganger protection is enabled.
+	DoppelgangerEpochsFlag = &cli.Uint64Flag{
+		Name: "doppelganger-epochs",
+		Usage: "Number of epochs for which validator keys are held back from signing after being loaded, " +
+			"while the beacon node is polled for other instances of them in the network. " +
+			"Only used with --enable-doppelganger",
+		Value: 2,
+	}
+	// Web3SignerURLFlag defines the URL of a remote signer implementing the HTTP remote signing API,
+	// such as Web3Signer, which the validator client signs with instead of a wallet.
+	Web3SignerURLFlag = &cli.StringFlag{
+		Name:  "web3signer-url",
+		Usage: "URL of a remote signer implementing the HTTP remote signing API, such as Web3Signer, to sign with instead of a wallet",
+	}
+	// Web3SignerGenesisValidatorsRootFlag defines the genesis validators root of the network,
+	// which is sent to the remote signer as part of the fork information of sign requests.
+	Web3SignerGenesisValidatorsRootFlag = &cli.StringFlag{
+		Name:  "web3signer-genesis-validators-root",
+		Usage: "Hex encoded genesis validators root of the network, which is required to sign with a remote signer via --web3signer-url",
+	}
+	// Web3SignerCACertPathFlag defines the path to a ca.crt file to verify the certificate of a remote signer.
+	Web3SignerCACertPathFlag = &cli.StringFlag{
+		Name:  "web3signer-ca-crt-path",
+		Usage: "/path/to/ca.crt for verifying the TLS certificate of the remote signer given by --web3signer-url",
+	}
+	// Web3SignerCertPathFlag defines the path to a client.crt file to authenticate with a remote signer via TLS.
+	Web3SignerCertPathFlag = &cli.StringFlag{
+		Name:  "web3signer-crt-path",
+		Usage: "/path/to/client.crt for authenticating with the remote signer given by --web3signer-url via TLS",
+	}
+	// Web3SignerKeyPathFlag defines the path to a client.key file to authenticate with a remote signer via TLS.
+	Web3SignerKeyPathFlag = &cli.StringFlag{
+		Name:  "web3signer-key-path",
+		Usage: "/path/to/client.key for authenticating with the remote signer given by --web3signer-url via TLS",
+	}
+	// Web3SignerTimeoutFlag defines the timeout of the requests sent to a remote signer.
+	Web3SignerTimeoutFlag = &cli.DurationFlag{
+		Name:  "web3signer-timeout",
+		Usage: "The amount of time after which a request to the remote signer is cancelled",
+		Value: 5 * time.Second,
+	}
+	// Web3SignerRefreshIntervalFlag defines the interval at which the public keys of a remote signer are reloaded.
+	Web3SignerRefreshIntervalFlag = &cli.DurationFlag{
+		Name:  "web3signer-refresh-interval",
+		Usage: "The interval at which the public keys of the remote signer are reloaded",
+		Value: time.Minute,
+	}
 )
 
 // DefaultValidatorDir returns OS-specific default validator directory.
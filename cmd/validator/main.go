@@ -48,6 +48,9 @@ func startNode(ctx *cli.Context) error {
 var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.BeaconRPCGatewayProviderFlag,
+	flags.BeaconRESTApiProviderFlag,
+	flags.BeaconRESTApiTimeoutFlag,
+	flags.EnableBeaconNodeBroadcastFlag,
 	flags.CertFlag,
 	flags.GraffitiFlag,
 	flags.DisablePenaltyRewardLogFlag,
@@ -62,6 +65,7 @@ var appFlags = []cli.Flag{
 	flags.GrpcRetryDelayFlag,
 	flags.GrpcHeadersFlag,
 	flags.GPRCGatewayCorsDomain,
+	flags.KeymanagerAuthTokenFileFlag,
 	flags.DisableAccountMetricsFlag,
 	cmd.MonitoringHostFlag,
 	flags.MonitoringPortFlag,
@@ -73,6 +77,14 @@ var appFlags = []cli.Flag{
 	flags.EnableWebFlag,
 	flags.GraffitiFileFlag,
 	flags.EnableDutyCountDown,
+	flags.DoppelgangerEpochsFlag,
+	flags.Web3SignerURLFlag,
+	flags.Web3SignerGenesisValidatorsRootFlag,
+	flags.Web3SignerCACertPathFlag,
+	flags.Web3SignerCertPathFlag,
+	flags.Web3SignerKeyPathFlag,
+	flags.Web3SignerTimeoutFlag,
+	flags.Web3SignerRefreshIntervalFlag,
 	cmd.BackupWebhookOutputDir,
 	cmd.EnableBackupWebhookFlag,
 	cmd.MinimalConfigFlag,
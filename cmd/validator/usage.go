@@ -86,6 +86,9 @@ var appHelpFlagGroups = []flagGroup{
 		Flags: []cli.Flag{
 			flags.BeaconRPCProviderFlag,
 			flags.BeaconRPCGatewayProviderFlag,
+			flags.BeaconRESTApiProviderFlag,
+			flags.BeaconRESTApiTimeoutFlag,
+			flags.EnableBeaconNodeBroadcastFlag,
 			flags.CertFlag,
 			flags.EnableWebFlag,
 			flags.DisablePenaltyRewardLogFlag,
@@ -98,6 +101,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GrpcRetriesFlag,
 			flags.GrpcRetryDelayFlag,
 			flags.GPRCGatewayCorsDomain,
+			flags.KeymanagerAuthTokenFileFlag,
 			flags.GrpcHeadersFlag,
 			flags.SlasherRPCProviderFlag,
 			flags.SlasherCertFlag,
@@ -106,6 +110,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.WalletPasswordFileFlag,
 			flags.GraffitiFileFlag,
 			flags.EnableDutyCountDown,
+			flags.DoppelgangerEpochsFlag,
+			flags.Web3SignerURLFlag,
+			flags.Web3SignerGenesisValidatorsRootFlag,
+			flags.Web3SignerCACertPathFlag,
+			flags.Web3SignerCertPathFlag,
+			flags.Web3SignerKeyPathFlag,
+			flags.Web3SignerTimeoutFlag,
+			flags.Web3SignerRefreshIntervalFlag,
 		},
 	},
 	{
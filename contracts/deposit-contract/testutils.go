@@ -29,11 +29,18 @@ type TestAccount struct {
 
 // Setup creates the simulated backend with the deposit contract deployed
 func Setup() (*TestAccount, error) {
-	genesis := make(core.GenesisAlloc)
 	privKey, err := crypto.GenerateKey()
 	if err != nil {
 		return nil, err
 	}
+	return SetupWithKey(privKey)
+}
+
+// SetupWithKey creates the simulated backend with the deposit contract deployed by the
+// account of the given key. Backends set up with the same key serve the same chain, as
+// long as the same transactions are committed to them.
+func SetupWithKey(privKey *ecdsa.PrivateKey) (*TestAccount, error) {
+	genesis := make(core.GenesisAlloc)
 	pubKeyECDSA, ok := privKey.Public().(*ecdsa.PublicKey)
 	if !ok {
 		return nil, fmt.Errorf("error casting public key to ECDSA")
@@ -295,6 +295,20 @@ func CopyDepositData(depData *Deposit_Data) *Deposit_Data {
 	}
 }
 
+// CopyDepositSnapshot copies the provided deposit snapshot.
+func CopyDepositSnapshot(snapshot *DepositSnapshot) *DepositSnapshot {
+	if snapshot == nil {
+		return nil
+	}
+	return &DepositSnapshot{
+		Finalized:            bytesutil.SafeCopy2dBytes(snapshot.Finalized),
+		DepositRoot:          bytesutil.SafeCopyBytes(snapshot.DepositRoot),
+		DepositCount:         snapshot.DepositCount,
+		ExecutionBlockHash:   bytesutil.SafeCopyBytes(snapshot.ExecutionBlockHash),
+		ExecutionBlockHeight: snapshot.ExecutionBlockHeight,
+	}
+}
+
 // CopySignedVoluntaryExits copies the provided SignedVoluntaryExits array.
 func CopySignedVoluntaryExits(exits []*SignedVoluntaryExit) []*SignedVoluntaryExit {
 	if exits == nil {
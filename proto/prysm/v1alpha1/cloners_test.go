@@ -217,6 +217,16 @@ func TestCopyDepositData(t *testing.T) {
 	assert.NotEmpty(t, got, "Copied deposit data has empty fields")
 }
 
+func TestCopyDepositSnapshot(t *testing.T) {
+	ds := genDepositSnapshot()
+
+	got := CopyDepositSnapshot(ds)
+	if !reflect.DeepEqual(got, ds) {
+		t.Errorf("CopyDepositSnapshot() = %v, want %v", got, ds)
+	}
+	assert.NotEmpty(t, got, "Copied deposit snapshot has empty fields")
+}
+
 func TestCopySignedVoluntaryExits(t *testing.T) {
 	sv := genSignedVoluntaryExits(10)
 
@@ -480,6 +490,16 @@ func genDeposits(num int) []*Deposit {
 	return d
 }
 
+func genDepositSnapshot() *DepositSnapshot {
+	return &DepositSnapshot{
+		Finalized:            [][]byte{bytes(), bytes()},
+		DepositRoot:          bytes(),
+		DepositCount:         3,
+		ExecutionBlockHash:   bytes(),
+		ExecutionBlockHeight: 1000,
+	}
+}
+
 func genVoluntaryExit() *VoluntaryExit {
 	return &VoluntaryExit{
 		Epoch:          5432,
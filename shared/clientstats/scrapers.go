@@ -225,6 +225,24 @@ func populateBeaconNodeStats(pf metricMap) BeaconNodeStats {
 		}
 	}
 
+	f, err = pf.getFamily("p2p_bandwidth_bytes")
+	if err != nil {
+		log.WithError(err).Debug("Failed to get p2p_bandwidth_bytes")
+	} else {
+		for _, m := range f.Metric {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "direction" {
+					switch l.GetValue() {
+					case "received":
+						bs.NetworkLibp2pBytesTotalReceive = int64(m.Gauge.GetValue())
+					case "sent":
+						bs.NetworkLibp2pBytesTotalTransmit = int64(m.Gauge.GetValue())
+					}
+				}
+			}
+		}
+	}
+
 	f, err = pf.getFamily("powchain_sync_eth1_connected")
 	if err != nil {
 		log.WithError(err).Debug("Failed to get powchain_sync_eth1_connected")
@@ -54,6 +54,8 @@ func TestBeaconNodeScraper(t *testing.T) {
 	require.Equal(t, true, bs.SyncEth2Synced)
 	require.Equal(t, int64(7365341184), bs.DiskBeaconchainBytesTotal)
 	require.Equal(t, int64(37), bs.NetworkPeersConnected)
+	require.Equal(t, int64(1073741824), bs.NetworkLibp2pBytesTotalReceive)
+	require.Equal(t, int64(536870912), bs.NetworkLibp2pBytesTotalTransmit)
 	require.Equal(t, true, bs.SyncEth1Connected)
 	require.Equal(t, true, bs.SyncEth1FallbackConfigured)
 	require.Equal(t, true, bs.SyncEth1FallbackConnected)
@@ -255,6 +257,10 @@ p2p_peer_count{state="Connected"} 37
 p2p_peer_count{state="Connecting"} 0
 p2p_peer_count{state="Disconnected"} 62
 p2p_peer_count{state="Disconnecting"} 0
+# HELP p2p_bandwidth_bytes The number of bytes received or sent by the libp2p host.
+# TYPE p2p_bandwidth_bytes gauge
+p2p_bandwidth_bytes{direction="received"} 1.073741824e+09
+p2p_bandwidth_bytes{direction="sent"} 5.36870912e+08
 # HELP powchain_sync_eth1_connected Boolean indicating whether a fallback eth1 endpoint is currently connected: 0=false, 1=true.
 # TYPE powchain_sync_eth1_connected gauge
 powchain_sync_eth1_connected 1
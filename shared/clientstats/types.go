@@ -55,9 +55,9 @@ type BeaconNodeStats struct {
 	SyncEth1Connected          bool  `json:"sync_eth1_connected"`
 	SyncEth2Synced             bool  `json:"sync_eth2_synced"`
 	DiskBeaconchainBytesTotal  int64 `json:"disk_beaconchain_bytes_total"`
-	// N/A -- would require significant network code changes at this time
+	// p2p_bandwidth_bytes where label "direction" == "received"
 	NetworkLibp2pBytesTotalReceive int64 `json:"network_libp2p_bytes_total_receive"`
-	// N/A -- would require significant network code changes at this time
+	// p2p_bandwidth_bytes where label "direction" == "sent"
 	NetworkLibp2pBytesTotalTransmit int64 `json:"network_libp2p_bytes_total_transmit"`
 	// p2p_peer_count where label "state" == "Connected"
 	NetworkPeersConnected int64 `json:"network_peers_connected"`
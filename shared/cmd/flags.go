@@ -94,6 +94,15 @@ var (
 		Name:  "peer",
 		Usage: "Connect with this peer. This flag may be used multiple times.",
 	}
+	// TrustedPeers specifies a set of peers which are kept connected through reserved slots.
+	TrustedPeers = &cli.StringSliceFlag{
+		Name: "trusted-peer",
+		Usage: "Trust this peer, given by multiaddr, ENR or peer ID. Trusted peers are never disconnected for their score, " +
+			"do not count against the peer limits, are redialed when they drop and are direct gossipsub peers. " +
+			"Trusted peers added at runtime through the debug API are not direct gossipsub peers. " +
+			"Peers passed with --peer are only dialed on start and are not trusted. " +
+			"This flag may be used multiple times.",
+	}
 	// BootstrapNode tells the beacon node which bootstrap node to connect to
 	BootstrapNode = &cli.StringSliceFlag{
 		Name:  "bootstrap-node",
@@ -236,6 +245,33 @@ var (
 		Usage: "Target directory of the restored database",
 		Value: DefaultDataDir(),
 	}
+	// ArchiveDirFlag specifies the directory of the archive files written by db export and read by db import.
+	ArchiveDirFlag = &cli.StringFlag{
+		Name:  "archive-dir",
+		Usage: "Directory of the history archive files written by db export and read by db import",
+	}
+	// EpochsPerArchiveFlag specifies the number of epochs covered by each archive file written by db export.
+	EpochsPerArchiveFlag = &cli.Uint64Flag{
+		Name:  "epochs-per-archive",
+		Usage: "Number of epochs covered by each archive file written by db export. Every archive file starts with a state snapshot",
+		Value: 256,
+	}
+	// RepairDBFlag specifies whether db verify should repair the issues it finds.
+	RepairDBFlag = &cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Repair the database issues which can be fixed safely, such as indices which can be rebuilt from blocks",
+	}
+	// MigrationTargetDirFlag specifies the data directory of the slasher database written by db migrate.
+	MigrationTargetDirFlag = &cli.StringFlag{
+		Name:  "migration-target-dir",
+		Usage: "Data directory of the beacon node slasher database written by db migrate, as set by --slasher-datadir",
+		Value: DefaultDataDir(),
+	}
+	// DryRunFlag specifies whether a command should only report the changes it would make.
+	DryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Report the records which would be converted without writing them",
+	}
 	// BoltMMapInitialSizeFlag specifies the initial size in bytes of boltdb's mmap syscall.
 	BoltMMapInitialSizeFlag = &cli.IntFlag{
 		Name:  "bolt-mmap-initial-size",
@@ -49,8 +49,9 @@ type Flags struct {
 	UpdateHeadTimely                    bool // UpdateHeadTimely updates head right after state transition.
 	ProposerAttsSelectionUsingMaxCover  bool // ProposerAttsSelectionUsingMaxCover enables max-cover algorithm when selecting attestations for proposing.
 	EnableOptimizedBalanceUpdate        bool // EnableOptimizedBalanceUpdate uses an updated method of performing balance updates.
-	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup for the validator.
+	EnableDoppelGanger                  bool // EnableDoppelGanger enables doppelganger protection on startup and on key reload for the validator.
 	EnableHistoricalSpaceRepresentation bool // EnableHistoricalSpaceRepresentation enables the saving of registry validators in separate buckets to save space
+	EnableLightClientServer             bool // EnableLightClientServer produces light client updates from imported blocks and serves them.
 	// Logging related toggles.
 	DisableGRPCConnectionLogs bool // Disables logging when a new grpc client has connected.
 
@@ -197,6 +198,10 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.WithField(enableHistoricalSpaceRepresentation.Name, enableHistoricalSpaceRepresentation.Usage).Warn(enabledFeatureFlag)
 		cfg.EnableHistoricalSpaceRepresentation = true
 	}
+	if ctx.Bool(enableLightClientServer.Name) {
+		logEnabled(enableLightClientServer)
+		cfg.EnableLightClientServer = true
+	}
 	if ctx.Bool(correctlyInsertOrphanedAtts.Name) {
 		logEnabled(correctlyInsertOrphanedAtts)
 		cfg.CorrectlyInsertOrphanedAtts = true
@@ -111,7 +111,8 @@ var (
 	}
 	enableDoppelGangerProtection = &cli.BoolFlag{
 		Name: "enable-doppelganger",
-		Usage: "Enables the validator to perform a doppelganger check. (Warning): This is not " +
+		Usage: "Enables the validator to perform a doppelganger check, and to hold back newly loaded keys from " +
+			"signing until the beacon node reports them not live for --doppelganger-epochs epochs. (Warning): This is not " +
 			"a foolproof method to find duplicate instances in the network. Your validator will still be" +
 			" vulnerable if it is being run in unsafe configurations.",
 	}
@@ -135,6 +136,10 @@ var (
 		Name:  "enable-active-balance-cache",
 		Usage: "This enables active balance cache cache to improve node performance during block processing",
 	}
+	enableLightClientServer = &cli.BoolFlag{
+		Name:  "enable-light-client-server",
+		Usage: "Enables producing Altair light client updates from imported blocks and serving them through the Beacon API",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -196,6 +201,7 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	correctlyInsertOrphanedAtts,
 	correctlyPruneCanonicalAtts,
 	enableActiveBalanceCache,
+	enableLightClientServer,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -158,6 +158,26 @@ func (mr *MockBeaconNodeValidatorClientMockRecorder) GetDuties(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuties", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).GetDuties), varargs...)
 }
 
+// GetLiveness mocks base method
+func (m *MockBeaconNodeValidatorClient) GetLiveness(arg0 context.Context, arg1 *eth.LivenessRequest, arg2 ...grpc.CallOption) (*eth.LivenessResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLiveness", varargs...)
+	ret0, _ := ret[0].(*eth.LivenessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveness indicates an expected call of GetLiveness
+func (mr *MockBeaconNodeValidatorClientMockRecorder) GetLiveness(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveness", reflect.TypeOf((*MockBeaconNodeValidatorClient)(nil).GetLiveness), varargs...)
+}
+
 // GetSyncCommitteeContribution mocks base method
 func (m *MockBeaconNodeValidatorClient) GetSyncCommitteeContribution(arg0 context.Context, arg1 *eth.SyncCommitteeContributionRequest, arg2 ...grpc.CallOption) (*eth.SyncCommitteeContribution, error) {
 	m.ctrl.T.Helper()
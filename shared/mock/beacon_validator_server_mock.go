@@ -127,6 +127,21 @@ func (mr *MockBeaconNodeValidatorServerMockRecorder) GetDuties(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuties", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).GetDuties), arg0, arg1)
 }
 
+// GetLiveness mocks base method
+func (m *MockBeaconNodeValidatorServer) GetLiveness(arg0 context.Context, arg1 *eth.LivenessRequest) (*eth.LivenessResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveness", arg0, arg1)
+	ret0, _ := ret[0].(*eth.LivenessResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveness indicates an expected call of GetLiveness
+func (mr *MockBeaconNodeValidatorServerMockRecorder) GetLiveness(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveness", reflect.TypeOf((*MockBeaconNodeValidatorServer)(nil).GetLiveness), arg0, arg1)
+}
+
 // GetSyncCommitteeContribution mocks base method
 func (m *MockBeaconNodeValidatorServer) GetSyncCommitteeContribution(arg0 context.Context, arg1 *eth.SyncCommitteeContributionRequest) (*eth.SyncCommitteeContribution, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +62,10 @@ type BeaconChainConfig struct {
 	SafeSlotsToUpdateJustified       types.Slot  `yaml:"SAFE_SLOTS_TO_UPDATE_JUSTIFIED" spec:"true"`      // SafeSlotsToUpdateJustified is the minimal slots needed to update justified check point.
 	SecondsPerETH1Block              uint64      `yaml:"SECONDS_PER_ETH1_BLOCK" spec:"true"`              // SecondsPerETH1Block is the approximate time for a single eth1 block to be produced.
 
+	// Fork choice parameters.
+	ProposerScoreBoost uint64 `yaml:"PROPOSER_SCORE_BOOST"` // ProposerScoreBoost is the percentage of the committee weight given to a timely block in fork choice.
+	IntervalsPerSlot   uint64 `yaml:"INTERVALS_PER_SLOT"`   // IntervalsPerSlot is the number of fork choice intervals in a slot, a block is timely if it arrives in the first one.
+
 	// Ethereum PoW parameters.
 	DepositChainID         uint64 `yaml:"DEPOSIT_CHAIN_ID" spec:"true"`         // DepositChainID of the eth1 network. This used for replay protection.
 	DepositNetworkID       uint64 `yaml:"DEPOSIT_NETWORK_ID" spec:"true"`       // DepositNetworkID of the eth1 network. This used for replay protection.
@@ -117,6 +121,7 @@ type BeaconChainConfig struct {
 	DefaultPageSize             int           // DefaultPageSize defines the default page size for RPC server request.
 	MaxPeersToSync              int           // MaxPeersToSync describes the limit for number of peers in round robin sync.
 	SlotsPerArchivedPoint       types.Slot    // SlotsPerArchivedPoint defines the number of slots per one archived point.
+	SlotsPerStateSnapshot       types.Slot    // SlotsPerStateSnapshot defines the number of slots per full archived state, other archived states are saved as diffs. Zero disables diffs.
 	GenesisCountdownInterval    time.Duration // How often to log the countdown until the genesis time is reached.
 	BeaconStateFieldCount       int           // BeaconStateFieldCount defines how many fields are in beacon state.
 	BeaconStateAltairFieldCount int           // BeaconStateAltairFieldCount defines how many fields are in beacon state hard fork 1.
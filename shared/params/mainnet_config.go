@@ -40,6 +40,7 @@ var mainnetNetworkConfig = &NetworkConfig{
 	ETH2Key:                         "eth2",
 	AttSubnetKey:                    "attnets",
 	SyncCommsSubnetKey:              "syncnets",
+	EarliestSlotKey:                 "prysm-eas",
 	MinimumPeersInSubnet:            4,
 	MinimumPeersInSubnetSearch:      20,
 	ContractDeploymentBlock:         11184524, // Note: contract was deployed in block 11052984 but no transactions were sent until 11184524.
@@ -109,6 +110,10 @@ var mainnetBeaconConfig = &BeaconChainConfig{
 	Eth1FollowDistance:               2048,
 	SafeSlotsToUpdateJustified:       8,
 
+	// Fork choice parameters.
+	ProposerScoreBoost: 40,
+	IntervalsPerSlot:   3,
+
 	// Ethereum PoW parameters.
 	DepositChainID:         1, // Chain ID of eth1 mainnet.
 	DepositNetworkID:       1, // Network ID of eth1 mainnet.
@@ -24,6 +24,7 @@ type NetworkConfig struct {
 	ETH2Key                    string // ETH2Key is the ENR key of the Ethereum consensus object in an enr.
 	AttSubnetKey               string // AttSubnetKey is the ENR key of the subnet bitfield in the enr.
 	SyncCommsSubnetKey         string // SyncCommsSubnetKey is the ENR key of the sync committee subnet bitfield in the enr.
+	EarliestSlotKey            string // EarliestSlotKey is the Prysm specific ENR key of the earliest slot a node serves blocks from in the enr.
 	MinimumPeersInSubnet       uint64 // MinimumPeersInSubnet is the required amount of peers that a node is to have its in subnet.
 	MinimumPeersInSubnetSearch uint64 // PeersInSubnetSearch is the required amount of peers that we need to be able to lookup in a subnet search.
 
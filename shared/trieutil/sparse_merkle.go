@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math/bits"
 
 	protodb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -15,10 +16,16 @@ import (
 
 // SparseMerkleTrie implements a sparse, general purpose Merkle trie to be used
 // across Ethereum consensus functionality.
+//
+// A trie can be finalized up to a number of leaves, pruning those leaves and keeping only
+// the roots of the largest full subtrees covering them. The layers of a finalized trie
+// start at the first node which is not fully covered by its finalized leaves.
 type SparseMerkleTrie struct {
-	depth         uint
-	branches      [][][]byte
-	originalItems [][]byte // list of provided items before hashing them into leaves.
+	depth          uint
+	branches       [][][]byte
+	originalItems  [][]byte // list of provided items before hashing them into leaves.
+	finalizedCount int      // number of leaves pruned from the trie.
+	finalizedRoots [][]byte // roots of the finalized subtrees, ordered from left to right.
 }
 
 // NewTrie returns a new merkle trie filled with zerohashes to use.
@@ -31,8 +38,10 @@ func NewTrie(depth uint64) (*SparseMerkleTrie, error) {
 // CreateTrieFromProto creates a Sparse Merkle Trie from its corresponding merkle trie.
 func CreateTrieFromProto(trieObj *protodb.SparseMerkleTrie) *SparseMerkleTrie {
 	trie := &SparseMerkleTrie{
-		depth:         uint(trieObj.Depth),
-		originalItems: trieObj.OriginalItems,
+		depth:          uint(trieObj.Depth),
+		originalItems:  trieObj.OriginalItems,
+		finalizedCount: int(trieObj.FinalizedCount),
+		finalizedRoots: trieObj.FinalizedRoots,
 	}
 	branches := make([][][]byte, len(trieObj.Layers))
 	for i, layer := range trieObj.Layers {
@@ -73,7 +82,44 @@ func GenerateTrieFromItems(items [][]byte, depth uint64) (*SparseMerkleTrie, err
 	}, nil
 }
 
+// TrieFromFinalizedRoots constructs a Merkle trie finalized up to count leaves from the roots
+// of the finalized subtrees, ordered from left to right, as returned by FinalizedRoots.
+func TrieFromFinalizedRoots(finalizedRoots [][]byte, count, depth uint64) (*SparseMerkleTrie, error) {
+	if count >= 1<<depth {
+		return nil, fmt.Errorf("count %d exceeds the capacity of a trie of depth %d", count, depth)
+	}
+	if len(finalizedRoots) != bits.OnesCount64(count) {
+		return nil, fmt.Errorf("wanted %d finalized roots for a count of %d, received %d",
+			bits.OnesCount64(count), count, len(finalizedRoots))
+	}
+	roots := make([][]byte, len(finalizedRoots))
+	for i, r := range finalizedRoots {
+		if len(r) != 32 {
+			return nil, fmt.Errorf("finalized root %d has length %d, wanted 32", i, len(r))
+		}
+		root := bytesutil.ToBytes32(r)
+		roots[i] = root[:]
+	}
+	m := &SparseMerkleTrie{
+		depth:          uint(depth),
+		branches:       make([][][]byte, depth+1),
+		originalItems:  [][]byte{},
+		finalizedCount: int(count),
+		finalizedRoots: roots,
+	}
+	// Each layer starts with the ancestor of the first non-finalized leaf, which is
+	// computed from the finalized roots on its left and zero hashes on its right.
+	m.branches[0] = [][]byte{ZeroHashes[0][:]}
+	for i := uint(0); i < m.depth; i++ {
+		parentIdx := m.finalizedCount >> (i + 1)
+		parent := hashutil.Hash(append(m.node(i, 2*parentIdx), m.node(i, 2*parentIdx+1)...))
+		m.branches[i+1] = [][]byte{parent[:]}
+	}
+	return m, nil
+}
+
 // Items returns the original items passed in when creating the Merkle trie.
+// Items of finalized leaves are not part of the returned items.
 func (m *SparseMerkleTrie) Items() [][]byte {
 	return m.originalItems
 }
@@ -83,8 +129,8 @@ func (m *SparseMerkleTrie) Items() [][]byte {
 //   sha256(concat(node, self.to_little_endian_64(self.deposit_count), slice(zero_bytes32, start=0, len=24)))
 func (m *SparseMerkleTrie) HashTreeRoot() [32]byte {
 	enc := [32]byte{}
-	depositCount := uint64(len(m.originalItems))
-	if len(m.originalItems) == 1 && bytes.Equal(m.originalItems[0], ZeroHashes[0][:]) {
+	depositCount := uint64(m.finalizedCount + len(m.originalItems))
+	if m.finalizedCount == 0 && len(m.originalItems) == 1 && bytes.Equal(m.originalItems[0], ZeroHashes[0][:]) {
 		// Accounting for empty tries
 		depositCount = 0
 	}
@@ -92,29 +138,28 @@ func (m *SparseMerkleTrie) HashTreeRoot() [32]byte {
 	return hashutil.Hash(append(m.branches[len(m.branches)-1][0], enc[:]...))
 }
 
-// Insert an item into the trie.
+// Insert an item into the trie. Items at the index of a finalized leaf are ignored, as
+// finalized leaves can no longer be updated.
 func (m *SparseMerkleTrie) Insert(item []byte, index int) {
-	for index >= len(m.branches[0]) {
+	if index < m.finalizedCount {
+		return
+	}
+	localIndex := index - m.finalizedCount
+	for localIndex >= len(m.branches[0]) {
 		m.branches[0] = append(m.branches[0], ZeroHashes[0][:])
 	}
 	someItem := bytesutil.ToBytes32(item)
-	m.branches[0][index] = someItem[:]
-	if index >= len(m.originalItems) {
+	m.branches[0][localIndex] = someItem[:]
+	if localIndex >= len(m.originalItems) {
 		m.originalItems = append(m.originalItems, someItem[:])
 	} else {
-		m.originalItems[index] = someItem[:]
+		m.originalItems[localIndex] = someItem[:]
 	}
 	currentIndex := index
 	root := bytesutil.ToBytes32(item)
 	for i := 0; i < int(m.depth); i++ {
 		isLeft := currentIndex%2 == 0
-		neighborIdx := currentIndex ^ 1
-		var neighbor []byte
-		if neighborIdx >= len(m.branches[i]) {
-			neighbor = ZeroHashes[i][:]
-		} else {
-			neighbor = m.branches[i][neighborIdx]
-		}
+		neighbor := m.node(uint(i), currentIndex^1)
 		if isLeft {
 			parentHash := hashutil.Hash(append(root[:], neighbor...))
 			root = parentHash
@@ -123,36 +168,34 @@ func (m *SparseMerkleTrie) Insert(item []byte, index int) {
 			root = parentHash
 		}
 		parentIdx := currentIndex / 2
-		if len(m.branches[i+1]) == 0 || parentIdx >= len(m.branches[i+1]) {
-			newItem := root
-			m.branches[i+1] = append(m.branches[i+1], newItem[:])
-		} else {
-			newItem := root
-			m.branches[i+1][parentIdx] = newItem[:]
+		localParentIdx := parentIdx - m.finalizedCount>>(i+1)
+		for localParentIdx >= len(m.branches[i+1]) {
+			m.branches[i+1] = append(m.branches[i+1], ZeroHashes[i+1][:])
 		}
+		newItem := root
+		m.branches[i+1][localParentIdx] = newItem[:]
 		currentIndex = parentIdx
 	}
 }
 
 // MerkleProof computes a proof from a trie's branches using a Merkle index.
 func (m *SparseMerkleTrie) MerkleProof(index int) ([][]byte, error) {
+	if index < m.finalizedCount {
+		return nil, fmt.Errorf("merkle index %d is finalized in trie, first non-finalized index: %d", index, m.finalizedCount)
+	}
 	merkleIndex := uint(index)
 	leaves := m.branches[0]
-	if index >= len(leaves) {
-		return nil, fmt.Errorf("merkle index out of range in trie, max range: %d, received: %d", len(leaves), index)
+	if index-m.finalizedCount >= len(leaves) {
+		return nil, fmt.Errorf("merkle index out of range in trie, max range: %d, received: %d", m.finalizedCount+len(leaves), index)
 	}
 	proof := make([][]byte, m.depth+1)
 	for i := uint(0); i < m.depth; i++ {
 		subIndex := (merkleIndex / (1 << i)) ^ 1
-		if subIndex < uint(len(m.branches[i])) {
-			item := bytesutil.ToBytes32(m.branches[i][subIndex])
-			proof[i] = item[:]
-		} else {
-			proof[i] = ZeroHashes[i][:]
-		}
+		item := bytesutil.ToBytes32(m.node(i, int(subIndex)))
+		proof[i] = item[:]
 	}
 	enc := [32]byte{}
-	binary.LittleEndian.PutUint64(enc[:], uint64(len(m.originalItems)))
+	binary.LittleEndian.PutUint64(enc[:], uint64(m.finalizedCount+len(m.originalItems)))
 	proof[len(proof)-1] = enc[:]
 	return proof, nil
 }
@@ -161,9 +204,11 @@ func (m *SparseMerkleTrie) MerkleProof(index int) ([][]byte, error) {
 // proto object
 func (m *SparseMerkleTrie) ToProto() *protodb.SparseMerkleTrie {
 	trie := &protodb.SparseMerkleTrie{
-		Depth:         uint64(m.depth),
-		Layers:        make([]*protodb.TrieLayer, len(m.branches)),
-		OriginalItems: m.originalItems,
+		Depth:          uint64(m.depth),
+		Layers:         make([]*protodb.TrieLayer, len(m.branches)),
+		OriginalItems:  m.originalItems,
+		FinalizedCount: uint64(m.finalizedCount),
+		FinalizedRoots: m.finalizedRoots,
 	}
 	for i, l := range m.branches {
 		trie.Layers[i] = &protodb.TrieLayer{
@@ -198,20 +243,86 @@ func (m *SparseMerkleTrie) Copy() *SparseMerkleTrie {
 	}
 
 	return &SparseMerkleTrie{
-		depth:         m.depth,
-		branches:      dstBranches,
-		originalItems: bytesutil.SafeCopy2dBytes(m.originalItems),
+		depth:          m.depth,
+		branches:       dstBranches,
+		originalItems:  bytesutil.SafeCopy2dBytes(m.originalItems),
+		finalizedCount: m.finalizedCount,
+		finalizedRoots: bytesutil.SafeCopy2dBytes(m.finalizedRoots),
 	}
 }
 
 // NumOfItems returns the num of items stored in
-// the sparse merkle trie. We handle a special case
-// where if there is only one item stored and it is a
-// empty 32-byte root.
+// the sparse merkle trie, including finalized items.
+// We handle a special case where if there is only one
+// item stored and it is a empty 32-byte root.
 func (m *SparseMerkleTrie) NumOfItems() int {
 	var zeroBytes [32]byte
-	if len(m.originalItems) == 1 && bytes.Equal(m.originalItems[0], zeroBytes[:]) {
+	if m.finalizedCount == 0 && len(m.originalItems) == 1 && bytes.Equal(m.originalItems[0], zeroBytes[:]) {
 		return 0
 	}
-	return len(m.originalItems)
+	return m.finalizedCount + len(m.originalItems)
+}
+
+// FinalizedCount returns the number of leaves the trie has been finalized up to.
+func (m *SparseMerkleTrie) FinalizedCount() int {
+	return m.finalizedCount
+}
+
+// FinalizedRoots returns the roots of the largest full subtrees covering the first count
+// leaves of the trie, ordered from left to right. Together with the count, they are
+// enough to rebuild a trie which can insert and prove the leaves following them.
+func (m *SparseMerkleTrie) FinalizedRoots(count int) ([][]byte, error) {
+	if count < m.finalizedCount || count > m.NumOfItems() {
+		return nil, fmt.Errorf("count %d out of range in trie, min: %d, max: %d", count, m.finalizedCount, m.NumOfItems())
+	}
+	roots := make([][]byte, 0, bits.OnesCount64(uint64(count)))
+	for i := int(m.depth); i >= 0; i-- {
+		// A full subtree at this layer covers the leaves up to count if
+		// the corresponding bit of count is set.
+		if (count>>uint(i))%2 == 1 {
+			root := bytesutil.ToBytes32(m.node(uint(i), count>>uint(i)-1))
+			roots = append(roots, root[:])
+		}
+	}
+	return roots, nil
+}
+
+// Finalize prunes the first count leaves from the trie, along with all nodes which are
+// not needed anymore to insert and prove the following leaves.
+func (m *SparseMerkleTrie) Finalize(count int) error {
+	roots, err := m.FinalizedRoots(count)
+	if err != nil {
+		return err
+	}
+	for i := range m.branches {
+		pruned := count>>uint(i) - m.finalizedCount>>uint(i)
+		if pruned > len(m.branches[i]) {
+			pruned = len(m.branches[i])
+		}
+		m.branches[i] = append([][]byte{}, m.branches[i][pruned:]...)
+	}
+	m.originalItems = append([][]byte{}, m.originalItems[count-m.finalizedCount:]...)
+	m.finalizedCount = count
+	m.finalizedRoots = roots
+	return nil
+}
+
+// node returns the node at an index of a layer, which is either a node of the layer
+// branches, the root of a finalized subtree, or a zero hash for the nodes to the right
+// of the last inserted leaf.
+func (m *SparseMerkleTrie) node(layer uint, index int) []byte {
+	offset := m.finalizedCount >> layer
+	if index >= offset {
+		if index-offset < len(m.branches[layer]) {
+			return m.branches[layer][index-offset]
+		}
+		return ZeroHashes[layer][:]
+	}
+	// Only the finalized subtree directly on the left of the layer branches is ever
+	// needed, as the sibling of an ancestor of the first non-finalized leaf. Nodes
+	// further left are covered by the finalized subtrees of the upper layers.
+	if index == offset-1 && offset%2 == 1 {
+		return m.finalizedRoots[bits.OnesCount64(uint64(m.finalizedCount>>(layer+1)))]
+	}
+	return ZeroHashes[layer][:]
 }
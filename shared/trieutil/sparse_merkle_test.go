@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 )
 
@@ -178,6 +179,95 @@ func TestCopy_OK(t *testing.T) {
 	require.DeepEqual(t, copyHash, copiedTrie.HashTreeRoot())
 }
 
+func TestFinalize_ProofsMatchFullTrie(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := make([][]byte, 13)
+	for i := range items {
+		items[i] = []byte{byte(i + 1)}
+	}
+	full, err := GenerateTrieFromItems(items, depth)
+	require.NoError(t, err)
+	finalized := full.Copy()
+	require.NoError(t, finalized.Finalize(5))
+	assert.Equal(t, 5, finalized.FinalizedCount())
+	assert.Equal(t, full.NumOfItems(), finalized.NumOfItems())
+	assert.Equal(t, full.HashTreeRoot(), finalized.HashTreeRoot())
+	_, err = finalized.MerkleProof(4)
+	require.ErrorContains(t, "is finalized in trie", err)
+
+	// Finalized leaves are ignored, and the following leaves are proven as in the full trie.
+	finalized.Insert([]byte{100}, 2)
+	for i := 13; i < 20; i++ {
+		full.Insert([]byte{byte(i + 1)}, i)
+		finalized.Insert([]byte{byte(i + 1)}, i)
+	}
+	assert.Equal(t, full.HashTreeRoot(), finalized.HashTreeRoot())
+	for i := 5; i < 20; i++ {
+		wanted, err := full.MerkleProof(i)
+		require.NoError(t, err)
+		proof, err := finalized.MerkleProof(i)
+		require.NoError(t, err)
+		assert.DeepEqual(t, wanted, proof)
+	}
+
+	// Finalizing further keeps the trie consistent.
+	require.NoError(t, finalized.Finalize(12))
+	assert.Equal(t, full.HashTreeRoot(), finalized.HashTreeRoot())
+	require.ErrorContains(t, "out of range", finalized.Finalize(8))
+	require.ErrorContains(t, "out of range", finalized.Finalize(21))
+}
+
+func TestTrieFromFinalizedRoots(t *testing.T) {
+	depth := params.BeaconConfig().DepositContractTreeDepth
+	items := make([][]byte, 11)
+	for i := range items {
+		items[i] = []byte{byte(i + 1)}
+	}
+	full, err := GenerateTrieFromItems(items[:7], depth)
+	require.NoError(t, err)
+	roots, err := full.FinalizedRoots(7)
+	require.NoError(t, err)
+	// 7 leaves are covered by subtrees of 4, 2 and 1 leaves.
+	require.Equal(t, 3, len(roots))
+
+	m, err := TrieFromFinalizedRoots(roots, 7, depth)
+	require.NoError(t, err)
+	assert.Equal(t, 7, m.NumOfItems())
+	assert.Equal(t, full.HashTreeRoot(), m.HashTreeRoot())
+	for i := 7; i < len(items); i++ {
+		full.Insert(items[i], i)
+		m.Insert(items[i], i)
+	}
+	root := m.HashTreeRoot()
+	assert.Equal(t, full.HashTreeRoot(), root)
+	for i := 7; i < len(items); i++ {
+		proof, err := m.MerkleProof(i)
+		require.NoError(t, err)
+		assert.Equal(t, true, VerifyMerkleBranch(root[:], items[i], i, proof, depth))
+	}
+
+	newTrie := CreateTrieFromProto(m.ToProto())
+	assert.Equal(t, root, newTrie.HashTreeRoot())
+	assert.Equal(t, 7, newTrie.FinalizedCount())
+}
+
+func TestTrieFromFinalizedRoots_EmptyTrie(t *testing.T) {
+	m, err := TrieFromFinalizedRoots(nil, 0, params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	empty, err := NewTrie(params.BeaconConfig().DepositContractTreeDepth)
+	require.NoError(t, err)
+	assert.Equal(t, 0, m.NumOfItems())
+	assert.Equal(t, empty.HashTreeRoot(), m.HashTreeRoot())
+}
+
+func TestTrieFromFinalizedRoots_InvalidRoots(t *testing.T) {
+	root := hashutil.Hash([]byte("hi"))
+	_, err := TrieFromFinalizedRoots([][]byte{root[:]}, 3, params.BeaconConfig().DepositContractTreeDepth)
+	require.ErrorContains(t, "wanted 2 finalized roots", err)
+	_, err = TrieFromFinalizedRoots([][]byte{root[:2]}, 1, params.BeaconConfig().DepositContractTreeDepth)
+	require.ErrorContains(t, "has length 2", err)
+}
+
 func BenchmarkGenerateTrieFromItems(b *testing.B) {
 	items := [][]byte{
 		[]byte("A"),
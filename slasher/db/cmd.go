@@ -27,5 +27,22 @@ var DatabaseCommands = &cli.Command{
 				return nil
 			},
 		},
+		{
+			Name: "migrate",
+			Description: `converts the attestations, block headers and min-max spans of the slasher database ` +
+				`to the slasher database of the beacon node`,
+			Flags: cmd.WrapFlags([]cli.Flag{
+				cmd.DataDirFlag,
+				cmd.MigrationTargetDirFlag,
+				cmd.DryRunFlag,
+			}),
+			Before: tos.VerifyTosAcceptedOrPrompt,
+			Action: func(cliCtx *cli.Context) error {
+				if err := migrate(cliCtx); err != nil {
+					log.Fatalf("Could not migrate database: %v", err)
+				}
+				return nil
+			},
+		},
 	},
 }
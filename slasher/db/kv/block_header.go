@@ -3,6 +3,7 @@ package kv
 import (
 	"bytes"
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
 	types "github.com/prysmaticlabs/eth2-types"
@@ -47,6 +48,33 @@ func (s *Store) BlockHeaders(ctx context.Context, slot types.Slot, validatorInde
 	return blockHeaders, err
 }
 
+// BlockHeadersFromSlot returns all the block headers with a slot greater than or equal
+// to the given slot, ordered by slot.
+func (s *Store) BlockHeadersFromSlot(ctx context.Context, slot types.Slot) ([]*ethpb.SignedBeaconBlockHeader, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.BlockHeadersFromSlot")
+	defer span.End()
+	var blockHeaders []*ethpb.SignedBeaconBlockHeader
+	err := s.view(func(tx *bolt.Tx) error {
+		// Slots are encoded in little endian in the keys, so the keys are not ordered by slot.
+		c := tx.Bucket(historicBlockHeadersBucket).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if types.Slot(bytesutil.FromBytes8(k[:8])) < slot {
+				continue
+			}
+			bh, err := unmarshalBlockHeader(ctx, v)
+			if err != nil {
+				return err
+			}
+			blockHeaders = append(blockHeaders, bh)
+		}
+		return nil
+	})
+	sort.SliceStable(blockHeaders, func(i, j int) bool {
+		return blockHeaders[i].Header.Slot < blockHeaders[j].Header.Slot
+	})
+	return blockHeaders, err
+}
+
 // HasBlockHeader accepts a slot and validator id and returns true if the block header exists.
 func (s *Store) HasBlockHeader(ctx context.Context, slot types.Slot, validatorIndex types.ValidatorIndex) bool {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.HasBlockHeader")
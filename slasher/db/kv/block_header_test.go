@@ -59,6 +59,35 @@ func TestSaveHistoryBlkHdr(t *testing.T) {
 	}
 }
 
+func TestBlockHeadersFromSlot(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	// Slots spanning a byte boundary, for keys encoding the slots in little endian not to be
+	// ordered by slot.
+	headers := []*ethpb.SignedBeaconBlockHeader{
+		{Signature: bytesutil.PadTo([]byte("let me in"), 96), Header: &ethpb.BeaconBlockHeader{Slot: 1, ProposerIndex: 0}},
+		{Signature: bytesutil.PadTo([]byte("let me in 2nd"), 96), Header: &ethpb.BeaconBlockHeader{Slot: 255, ProposerIndex: 1}},
+		{Signature: bytesutil.PadTo([]byte("let me in 3rd"), 96), Header: &ethpb.BeaconBlockHeader{Slot: 256, ProposerIndex: 3}},
+		{Signature: bytesutil.PadTo([]byte("let me in 4th"), 96), Header: &ethpb.BeaconBlockHeader{Slot: 512, ProposerIndex: 2}},
+	}
+	for _, bh := range headers {
+		require.NoError(t, db.SaveBlockHeader(ctx, bh))
+	}
+
+	bha, err := db.BlockHeadersFromSlot(ctx, 255)
+	require.NoError(t, err)
+	require.DeepEqual(t, headers[1:], bha)
+
+	bha, err = db.BlockHeadersFromSlot(ctx, 256)
+	require.NoError(t, err)
+	require.DeepEqual(t, headers[2:], bha)
+
+	bha, err = db.BlockHeadersFromSlot(ctx, 513)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(bha))
+}
+
 func TestDeleteHistoryBlkHdr(t *testing.T) {
 	db := setupDB(t)
 	ctx := context.Background()
@@ -0,0 +1,261 @@
+package db
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	beacondb "github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	dbtypes "github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Configuration of a migration from the standalone slasher database to
+// the slasher database of the beacon node.
+type migrationConfig struct {
+	source *kv.Store
+	target beacondb.SlasherDatabase
+	params *slasher.Parameters
+	dryRun bool
+}
+
+// Records read from the standalone slasher database, and written to the
+// slasher database of the beacon node unless running a dry run.
+type migrationReport struct {
+	startEpoch    types.Epoch
+	endEpoch      types.Epoch
+	attestations  int
+	proposals     int
+	minSpans      int
+	maxSpans      int
+	validators    int
+	chunksWritten int
+}
+
+func migrate(cliCtx *cli.Context) error {
+	ctx := context.Background()
+	sourceDir := path.Join(cliCtx.String(cmd.DataDirFlag.Name), kv.SlasherDbDirName)
+	if !fileutil.FileExists(path.Join(sourceDir, kv.DatabaseFileName)) {
+		return errors.Errorf("no slasher database found in %s", sourceDir)
+	}
+	source, err := NewDB(sourceDir, &kv.Config{})
+	if err != nil {
+		return errors.Wrapf(err, "could not open slasher database at %s", sourceDir)
+	}
+	defer func() {
+		if err := source.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher database")
+		}
+	}()
+	targetDir := path.Join(cliCtx.String(cmd.MigrationTargetDirFlag.Name), slasherkv.SlasherDbDirName)
+	dryRun := cliCtx.Bool(cmd.DryRunFlag.Name)
+	cfg := &migrationConfig{
+		source: source,
+		params: slasher.DefaultParams(),
+		dryRun: dryRun,
+	}
+	// The target database is neither opened nor created by a dry run.
+	if !dryRun {
+		target, err := slasherkv.NewKVStore(ctx, targetDir, &slasherkv.Config{})
+		if err != nil {
+			return errors.Wrapf(err, "could not open slasher database at %s", targetDir)
+		}
+		defer func() {
+			if err := target.Close(); err != nil {
+				log.WithError(err).Error("Could not close slasher database")
+			}
+		}()
+		cfg.target = target
+	}
+
+	report, err := migrateToSlasherKV(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch":    report.startEpoch,
+		"endEpoch":      report.endEpoch,
+		"attestations":  report.attestations,
+		"proposals":     report.proposals,
+		"minSpans":      report.minSpans,
+		"maxSpans":      report.maxSpans,
+		"validators":    report.validators,
+		"chunksWritten": report.chunksWritten,
+	}).Info("Converted slasher database records")
+	if dryRun {
+		log.Info("Dry run, no records were written to the target database")
+	} else {
+		log.WithField("targetDir", targetDir).Info("Migration completed successfully")
+	}
+	return nil
+}
+
+// Reads the attestations, block headers and min-max spans of the standalone slasher
+// database for the last history length epochs of attestations it recorded, and writes
+// them as attestation records, proposal records and min-max span chunks of the slasher
+// database of the beacon node. The highest attestations of the standalone slasher are
+// not converted, as they are derived from the attestations.
+func migrateToSlasherKV(ctx context.Context, cfg *migrationConfig) (*migrationReport, error) {
+	latestTarget, err := cfg.source.LatestIndexedAttestationsTargetEpoch(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve latest attestation target epoch")
+	}
+	report := &migrationReport{endEpoch: types.Epoch(latestTarget)}
+	// Spans older than the history length would be overwritten by the newer
+	// spans in the circular span chunks of the slasher database.
+	if report.endEpoch >= cfg.params.HistoryLength() {
+		report.startEpoch = report.endEpoch - cfg.params.HistoryLength() + 1
+	}
+
+	if err := migrateAttestations(ctx, cfg, report); err != nil {
+		return nil, err
+	}
+	if err := migrateProposals(ctx, cfg, report); err != nil {
+		return nil, err
+	}
+	if err := migrateSpans(ctx, cfg, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func migrateAttestations(ctx context.Context, cfg *migrationConfig, report *migrationReport) error {
+	for target := report.startEpoch; target <= report.endEpoch; target++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		atts, err := cfg.source.IndexedAttestationsForTarget(ctx, target)
+		if err != nil {
+			return errors.Wrapf(err, "could not retrieve attestations for target epoch %d", target)
+		}
+		if len(atts) == 0 {
+			continue
+		}
+		wrappers := make([]*slashertypes.IndexedAttestationWrapper, 0, len(atts))
+		for _, att := range atts {
+			if att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+				continue
+			}
+			signingRoot, err := att.Data.HashTreeRoot()
+			if err != nil {
+				return err
+			}
+			wrappers = append(wrappers, &slashertypes.IndexedAttestationWrapper{
+				IndexedAttestation: att,
+				SigningRoot:        signingRoot,
+			})
+		}
+		report.attestations += len(wrappers)
+		if cfg.dryRun {
+			continue
+		}
+		if err := cfg.target.SaveAttestationRecordsForValidators(ctx, wrappers); err != nil {
+			return errors.Wrapf(err, "could not save attestation records for target epoch %d", target)
+		}
+	}
+	return nil
+}
+
+func migrateProposals(ctx context.Context, cfg *migrationConfig, report *migrationReport) error {
+	startSlot, err := core.StartSlot(report.startEpoch)
+	if err != nil {
+		return err
+	}
+	headers, err := cfg.source.BlockHeadersFromSlot(ctx, startSlot)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve block headers")
+	}
+	wrappers := make([]*slashertypes.SignedBlockHeaderWrapper, 0, len(headers))
+	for _, header := range headers {
+		if header.Header == nil {
+			continue
+		}
+		signingRoot, err := header.Header.HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		wrappers = append(wrappers, &slashertypes.SignedBlockHeaderWrapper{
+			SignedBeaconBlockHeader: header,
+			SigningRoot:             signingRoot,
+		})
+	}
+	report.proposals = len(wrappers)
+	if cfg.dryRun || len(wrappers) == 0 {
+		return nil
+	}
+	return errors.Wrap(cfg.target.SaveBlockProposals(ctx, wrappers), "could not save proposal records")
+}
+
+func migrateSpans(ctx context.Context, cfg *migrationConfig, report *migrationReport) error {
+	importer := slasher.NewSpansImporter(cfg.target, cfg.params)
+	numValidators := uint64(0)
+	for epoch := report.startEpoch; epoch <= report.endEpoch; epoch++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		epochStore, err := cfg.source.EpochSpans(ctx, epoch, dbtypes.UseDB)
+		if err != nil {
+			return errors.Wrapf(err, "could not retrieve spans for epoch %d", epoch)
+		}
+		if len(epochStore.Bytes()) == 0 {
+			continue
+		}
+		for idx := uint64(0); idx <= epochStore.HighestObservedIdx(); idx++ {
+			span, err := epochStore.GetValidatorSpan(idx)
+			if err != nil {
+				return errors.Wrapf(err, "could not retrieve span of validator %d at epoch %d", idx, epoch)
+			}
+			// Spans of 0 have not been set by the standalone slasher.
+			if span.MinSpan == 0 && span.MaxSpan == 0 {
+				continue
+			}
+			if idx+1 > numValidators {
+				numValidators = idx + 1
+			}
+			if span.MinSpan != 0 {
+				report.minSpans++
+				if !cfg.dryRun {
+					if err := importer.SetMinSpan(ctx, types.ValidatorIndex(idx), epoch, span.MinSpan); err != nil {
+						return err
+					}
+				}
+			}
+			if span.MaxSpan != 0 {
+				report.maxSpans++
+				if !cfg.dryRun {
+					if err := importer.SetMaxSpan(ctx, types.ValidatorIndex(idx), epoch, span.MaxSpan); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	report.validators = int(numValidators)
+	if cfg.dryRun || numValidators == 0 {
+		return nil
+	}
+	if err := importer.Flush(ctx); err != nil {
+		return err
+	}
+	report.chunksWritten = importer.ChunksWritten()
+
+	// The spans of the validators are up to date until the last epoch converted, so the
+	// slasher of the beacon node only resets the spans of the epochs which came after it.
+	validatorIndices := make([]types.ValidatorIndex, numValidators)
+	for i := range validatorIndices {
+		validatorIndices[i] = types.ValidatorIndex(i)
+	}
+	if err := cfg.target.SaveLastEpochWrittenForValidators(ctx, validatorIndices, report.endEpoch); err != nil {
+		return errors.Wrap(err, "could not save last epoch written for validators")
+	}
+	return nil
+}
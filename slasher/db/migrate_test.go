@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path"
+	"testing"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/slasherkv"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
+	beaconslashertypes "github.com/prysmaticlabs/prysm/beacon-chain/slasher/types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	dbtypes "github.com/prysmaticlabs/prysm/slasher/db/types"
+	slashertypes "github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"github.com/urfave/cli/v2"
+)
+
+func setupLegacyDB(t *testing.T) *kv.Store {
+	ctx := context.Background()
+	legacyDB, err := kv.NewKVStore(t.TempDir(), &kv.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, legacyDB.Close())
+	})
+
+	att := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+		},
+		Signature: bytesutil.PadTo([]byte{1}, 96),
+	}
+	require.NoError(t, legacyDB.SaveIndexedAttestation(ctx, att))
+	header := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          params.BeaconConfig().SlotsPerEpoch + 1,
+			ProposerIndex: 3,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			BodyRoot:      make([]byte, 32),
+		},
+		Signature: bytesutil.PadTo([]byte{2}, 96),
+	}
+	require.NoError(t, legacyDB.SaveBlockHeader(ctx, header))
+
+	// Spans of the attestation above, as set by the span detector of the standalone slasher.
+	minSpans, err := slashertypes.EpochStoreFromMap(map[uint64]slashertypes.Span{
+		1: {MinSpan: 2},
+		2: {MinSpan: 2},
+	})
+	require.NoError(t, err)
+	require.NoError(t, legacyDB.SaveEpochSpans(ctx, 0, minSpans, dbtypes.UseDB))
+	return legacyDB
+}
+
+func TestMigrateToSlasherKV(t *testing.T) {
+	ctx := context.Background()
+	legacyDB := setupLegacyDB(t)
+	targetDB := dbtest.SetupSlasherDB(t)
+
+	report, err := migrateToSlasherKV(ctx, &migrationConfig{
+		source: legacyDB,
+		target: targetDB,
+		params: slasher.DefaultParams(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.Epoch(0), report.startEpoch)
+	assert.Equal(t, types.Epoch(2), report.endEpoch)
+	assert.Equal(t, 1, report.attestations)
+	assert.Equal(t, 1, report.proposals)
+	assert.Equal(t, 2, report.minSpans)
+	assert.Equal(t, 0, report.maxSpans)
+	assert.Equal(t, 3, report.validators)
+	assert.Equal(t, 1, report.chunksWritten)
+
+	record, err := targetDB.AttestationRecordForValidator(ctx, 2, 2)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, types.Epoch(1), record.IndexedAttestation.Data.Source.Epoch)
+
+	// A conflicting proposal is detected against the converted proposal records.
+	conflicting := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          params.BeaconConfig().SlotsPerEpoch + 1,
+			ProposerIndex: 3,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     bytesutil.PadTo([]byte{1}, 32),
+			BodyRoot:      make([]byte, 32),
+		},
+		Signature: bytesutil.PadTo([]byte{3}, 96),
+	}
+	signingRoot, err := conflicting.Header.HashTreeRoot()
+	require.NoError(t, err)
+	doubleProposals, err := targetDB.CheckDoubleBlockProposals(ctx, []*beaconslashertypes.SignedBlockHeaderWrapper{
+		{SignedBeaconBlockHeader: conflicting, SigningRoot: signingRoot},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(doubleProposals))
+
+	epochsWritten, err := targetDB.LastEpochWrittenForValidators(ctx, []types.ValidatorIndex{0, 1, 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(epochsWritten))
+	for _, epochWritten := range epochsWritten {
+		assert.Equal(t, types.Epoch(2), epochWritten.Epoch)
+	}
+}
+
+func TestMigrateToSlasherKV_DryRun(t *testing.T) {
+	ctx := context.Background()
+	legacyDB := setupLegacyDB(t)
+	targetDB := dbtest.SetupSlasherDB(t)
+
+	report, err := migrateToSlasherKV(ctx, &migrationConfig{
+		source: legacyDB,
+		target: targetDB,
+		params: slasher.DefaultParams(),
+		dryRun: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.attestations)
+	assert.Equal(t, 1, report.proposals)
+	assert.Equal(t, 2, report.minSpans)
+	assert.Equal(t, 0, report.chunksWritten)
+
+	record, err := targetDB.AttestationRecordForValidator(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, true, record == nil)
+	epochsWritten, err := targetDB.LastEpochWrittenForValidators(ctx, []types.ValidatorIndex{1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(epochsWritten))
+}
+
+func TestMigrate_DryRunDoesNotCreateTarget(t *testing.T) {
+	dataDir := t.TempDir()
+	legacyDB, err := kv.NewKVStore(path.Join(dataDir, kv.SlasherDbDirName), &kv.Config{})
+	require.NoError(t, err)
+	require.NoError(t, legacyDB.Close())
+
+	targetDir := path.Join(t.TempDir(), "target")
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.DataDirFlag.Name, "", "")
+	set.String(cmd.MigrationTargetDirFlag.Name, "", "")
+	set.Bool(cmd.DryRunFlag.Name, false, "")
+	require.NoError(t, set.Set(cmd.DataDirFlag.Name, dataDir))
+	require.NoError(t, set.Set(cmd.MigrationTargetDirFlag.Name, targetDir))
+	require.NoError(t, set.Set(cmd.DryRunFlag.Name, "true"))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, migrate(cliCtx))
+	_, err = os.Stat(path.Join(targetDir, slasherkv.SlasherDbDirName))
+	assert.Equal(t, true, os.IsNotExist(err), "Dry run created the target database")
+}
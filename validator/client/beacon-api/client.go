@@ -0,0 +1,167 @@
+package beaconapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ = iface.ValidatorClient(&Client{})
+	_ = iface.NodeClient(&Client{})
+	_ = iface.BeaconChainClient(&Client{})
+)
+
+// Client of the standard Beacon API of a beacon node. It implements the beacon node
+// interfaces of the validator client, so the validator can perform its duties with
+// any consensus client.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+
+	genesisLock sync.RWMutex
+	genesis     *genesisJson
+
+	indicesLock sync.RWMutex
+	indices     map[[48]byte]types.ValidatorIndex
+
+	dutiesLock sync.RWMutex
+	committees map[committeeKey][]types.ValidatorIndex
+}
+
+// committeeKey identifies a beacon committee by its slot and index.
+type committeeKey struct {
+	slot  types.Slot
+	index types.CommitteeIndex
+}
+
+// apiError is returned for the error responses of the beacon node.
+type apiError struct {
+	path       string
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("request to %s failed with status %d: %s", e.path, e.statusCode, e.message)
+}
+
+// GRPCStatus returns the gRPC status equivalent to the HTTP status of the response, so
+// errors of the client are handled the same way as errors of the gRPC client.
+func (e *apiError) GRPCStatus() *status.Status {
+	code := codes.Unknown
+	switch e.statusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusInternalServerError:
+		code = codes.Internal
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.New(code, e.Error())
+}
+
+// NewClient creates a client of the Beacon API served at the base endpoint, such as
+// http://localhost:3500. Requests are cancelled if no response is received within the
+// timeout, unless it is zero.
+func NewClient(baseEndpoint string, timeout time.Duration) (*Client, error) {
+	if _, err := url.ParseRequestURI(baseEndpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid beacon node URL %s", baseEndpoint)
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseEndpoint, "/"),
+		httpClient: &http.Client{},
+		timeout:    timeout,
+		indices:    make(map[[48]byte]types.ValidatorIndex),
+		committees: make(map[committeeKey][]types.ValidatorIndex),
+	}, nil
+}
+
+// BaseEndpoint returns the URL of the beacon node.
+func (c *Client) BaseEndpoint() string {
+	return c.baseURL
+}
+
+// get sends a GET request to the beacon node, and decodes the response into resp.
+func (c *Client) get(ctx context.Context, path string, query url.Values, resp interface{}) error {
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+	return c.do(ctx, http.MethodGet, path, nil, resp)
+}
+
+// post sends a POST request with a JSON encoded body to the beacon node, and decodes
+// the response into resp, if any.
+func (c *Client) post(ctx context.Context, path string, req, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not encode request to %s", path)
+	}
+	return c.do(ctx, http.MethodPost, path, body, resp)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte, resp interface{}) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not send request to %s", path)
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "could not read response of %s", path)
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		apiErr := &apiError{path: path, statusCode: httpResp.StatusCode, message: string(bytes.TrimSpace(respBody))}
+		errJson := &errorJson{}
+		if err := json.Unmarshal(respBody, errJson); err == nil && errJson.Message != "" {
+			apiErr.message = errJson.Message
+		}
+		return apiErr
+	}
+	if resp == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return errors.Wrapf(err, "could not decode response of %s", path)
+	}
+	return nil
+}
+
+// isNotFound returns true if the error is a not found response of the beacon node.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound
+}
@@ -0,0 +1,410 @@
+package beaconapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// stubBeaconNode is an in-process beacon node serving canned responses of the Beacon API.
+type stubBeaconNode struct {
+	lock      sync.Mutex
+	responses map[string]interface{}
+	handlers  map[string]http.HandlerFunc
+	bodies    map[string][]byte
+}
+
+func newStubBeaconNode() *stubBeaconNode {
+	return &stubBeaconNode{
+		responses: make(map[string]interface{}),
+		handlers:  make(map[string]http.HandlerFunc),
+		bodies:    make(map[string][]byte),
+	}
+}
+
+// respond sets the JSON response of requests to the path with the method.
+func (s *stubBeaconNode) respond(method, path string, resp interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.responses[method+" "+path] = resp
+}
+
+// handle sets the handler of requests to the path with the method.
+func (s *stubBeaconNode) handle(method, path string, handler http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// body returns the body of the latest request to the path with the method.
+func (s *stubBeaconNode) body(method, path string) []byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.bodies[method+" "+path]
+}
+
+func (s *stubBeaconNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	s.bodies[key] = body
+	handler, hasHandler := s.handlers[key]
+	resp, hasResp := s.responses[key]
+	s.lock.Unlock()
+	switch {
+	case hasHandler:
+		handler(w, r)
+	case hasResp:
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		if err := json.NewEncoder(w).Encode(&errorJson{Code: http.StatusNotFound, Message: "not found"}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func setupClient(t *testing.T) (*Client, *stubBeaconNode) {
+	node := newStubBeaconNode()
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+	client, err := NewClient(srv.URL+"/", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL, client.BaseEndpoint())
+	return client, node
+}
+
+func TestHexBytes_JSON(t *testing.T) {
+	enc, err := json.Marshal(hexBytes{0x01, 0xab})
+	require.NoError(t, err)
+	assert.Equal(t, `"0x01ab"`, string(enc))
+
+	var decoded hexBytes
+	require.NoError(t, json.Unmarshal(enc, &decoded))
+	assert.DeepEqual(t, hexBytes{0x01, 0xab}, decoded)
+	assert.ErrorContains(t, "invalid hex value", json.Unmarshal([]byte(`"01ab"`), &decoded))
+}
+
+func TestUint64String_JSON(t *testing.T) {
+	enc, err := json.Marshal(uint64String(18446744073709551615))
+	require.NoError(t, err)
+	assert.Equal(t, `"18446744073709551615"`, string(enc))
+
+	var decoded uint64String
+	require.NoError(t, json.Unmarshal(enc, &decoded))
+	assert.Equal(t, uint64String(18446744073709551615), decoded)
+	assert.ErrorContains(t, "invalid integer value", json.Unmarshal([]byte(`"-1"`), &decoded))
+}
+
+func TestNewClient_InvalidURL(t *testing.T) {
+	_, err := NewClient("localhost 3500", time.Second)
+	assert.ErrorContains(t, "invalid beacon node URL", err)
+}
+
+func TestClient_ErrorResponse(t *testing.T) {
+	client, node := setupClient(t)
+	node.handle(http.MethodGet, syncingPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, err := w.Write([]byte(`{"code":503,"message":"beacon node is starting"}`))
+		assert.NoError(t, err)
+	})
+
+	_, err := client.GetSyncStatus(context.Background(), nil)
+	assert.ErrorContains(t, "failed with status 503: beacon node is starting", err)
+	assert.Equal(t, false, isNotFound(err))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = client.GetGenesis(context.Background(), nil)
+	assert.Equal(t, true, isNotFound(err))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClient_GetDuties(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 100
+	params.OverrideBeaconConfig(cfg)
+
+	client, node := setupClient(t)
+	pubKey := bytesutil.PadTo([]byte("validator"), 48)
+	unknownPubKey := bytesutil.PadTo([]byte("unknown"), 48)
+	node.respond(http.MethodGet, headValidatorsPath, &stateValidatorsResponseJson{
+		Data: []*validatorContainerJson{{
+			Index:     5,
+			Status:    "active_ongoing",
+			Validator: &validatorJson{PublicKey: pubKey, ActivationEpoch: 0},
+		}},
+	})
+	node.respond(http.MethodPost, attesterDutiesPath+"0", &attesterDutiesResponseJson{
+		Data: []*attesterDutyJson{{
+			Pubkey:           pubKey,
+			ValidatorIndex:   5,
+			CommitteeIndex:   1,
+			CommitteesAtSlot: 2,
+			Slot:             3,
+		}},
+	})
+	node.respond(http.MethodPost, attesterDutiesPath+"1", &attesterDutiesResponseJson{})
+	node.respond(http.MethodGet, proposerDutiesPath+"0", &proposerDutiesResponseJson{
+		Data: []*proposerDutyJson{{Pubkey: pubKey, ValidatorIndex: 5, Slot: 7}},
+	})
+	node.respond(http.MethodGet, headCommitteesPath, &stateCommitteesResponseJson{
+		Data: []*committeeJson{{Index: 1, Slot: 3, Validators: []uint64String{4, 5, 6}}},
+	})
+
+	resp, err := client.GetDuties(context.Background(), &ethpb.DutiesRequest{
+		Epoch:      0,
+		PublicKeys: [][]byte{pubKey, unknownPubKey},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.CurrentEpochDuties))
+	duty := resp.CurrentEpochDuties[0]
+	assert.Equal(t, types.ValidatorIndex(5), duty.ValidatorIndex)
+	assert.Equal(t, ethpb.ValidatorStatus_ACTIVE, duty.Status)
+	assert.Equal(t, types.Slot(3), duty.AttesterSlot)
+	assert.Equal(t, types.CommitteeIndex(1), duty.CommitteeIndex)
+	assert.Equal(t, uint64(2), duty.CommitteesAtSlot)
+	assert.DeepEqual(t, []types.ValidatorIndex{4, 5, 6}, duty.Committee)
+	assert.DeepEqual(t, []types.Slot{7}, duty.ProposerSlots)
+	assert.Equal(t, ethpb.ValidatorStatus_UNKNOWN_STATUS, resp.CurrentEpochDuties[1].Status)
+	require.Equal(t, 2, len(resp.NextEpochDuties))
+	assert.Equal(t, 0, len(resp.NextEpochDuties[0].ProposerSlots))
+	assert.Equal(t, `["5"]`, string(node.body(http.MethodPost, attesterDutiesPath+"0")))
+}
+
+func TestClient_SubscribeCommitteeSubnets(t *testing.T) {
+	// The subscriptions only depend on the request, so a node which did not serve the
+	// duties, such as the node of a failover, subscribes to the subnets as well.
+	client, node := setupClient(t)
+	node.respond(http.MethodPost, committeeSubscriptionsPath, struct{}{})
+	_, err := client.SubscribeCommitteeSubnets(context.Background(), &ethpb.CommitteeSubnetsSubscribeRequest{
+		Slots:            []types.Slot{3},
+		CommitteeIds:     []types.CommitteeIndex{1},
+		IsAggregator:     []bool{true},
+		ValidatorIndices: []types.ValidatorIndex{5},
+		CommitteesAtSlot: []uint64{2},
+	})
+	require.NoError(t, err)
+	var subscriptions []*beaconCommitteeSubscribeJson
+	require.NoError(t, json.Unmarshal(node.body(http.MethodPost, committeeSubscriptionsPath), &subscriptions))
+	require.Equal(t, 1, len(subscriptions))
+	assert.DeepEqual(t, &beaconCommitteeSubscribeJson{
+		ValidatorIndex:   5,
+		CommitteeIndex:   1,
+		CommitteesAtSlot: 2,
+		Slot:             3,
+		IsAggregator:     true,
+	}, subscriptions[0])
+
+	_, err = client.SubscribeCommitteeSubnets(context.Background(), &ethpb.CommitteeSubnetsSubscribeRequest{
+		Slots:        []types.Slot{3},
+		CommitteeIds: []types.CommitteeIndex{1},
+		IsAggregator: []bool{true},
+	})
+	assert.ErrorContains(t, "not the same length", err)
+}
+
+func TestClient_MultipleValidatorStatus(t *testing.T) {
+	client, node := setupClient(t)
+	pubKey := bytesutil.PadTo([]byte("validator"), 48)
+	unknownPubKey := bytesutil.PadTo([]byte("unknown"), 48)
+	node.respond(http.MethodGet, headValidatorsPath, &stateValidatorsResponseJson{
+		Data: []*validatorContainerJson{{
+			Index:     5,
+			Status:    "pending_queued",
+			Validator: &validatorJson{PublicKey: pubKey, ActivationEpoch: 10},
+		}},
+	})
+
+	resp, err := client.MultipleValidatorStatus(context.Background(), &ethpb.MultipleValidatorStatusRequest{
+		PublicKeys: [][]byte{pubKey, unknownPubKey},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(resp.Statuses))
+	assert.Equal(t, ethpb.ValidatorStatus_PENDING, resp.Statuses[0].Status)
+	assert.Equal(t, types.Epoch(10), resp.Statuses[0].ActivationEpoch)
+	assert.Equal(t, types.ValidatorIndex(5), resp.Indices[0])
+	assert.Equal(t, ethpb.ValidatorStatus_UNKNOWN_STATUS, resp.Statuses[1].Status)
+	assert.Equal(t, nonexistentIndex, resp.Indices[1])
+
+	// The index of the validator is cached.
+	index, err := client.ValidatorIndex(context.Background(), &ethpb.ValidatorIndexRequest{PublicKey: pubKey})
+	require.NoError(t, err)
+	assert.Equal(t, types.ValidatorIndex(5), index.Index)
+}
+
+func TestClient_GetBeaconBlock_Altair(t *testing.T) {
+	client, node := setupClient(t)
+	blk := &ethpb.BeaconBlockAltair{
+		Slot:          10,
+		ProposerIndex: 3,
+		ParentRoot:    bytesutil.PadTo([]byte("parent"), 32),
+		StateRoot:     bytesutil.PadTo([]byte("state"), 32),
+		Body: &ethpb.BeaconBlockBodyAltair{
+			RandaoReveal: bytesutil.PadTo([]byte("randao"), 96),
+			Eth1Data: &ethpb.Eth1Data{
+				DepositRoot: bytesutil.PadTo([]byte("deposits"), 32),
+				BlockHash:   bytesutil.PadTo([]byte("hash"), 32),
+			},
+			Graffiti:          bytesutil.PadTo([]byte("graffiti"), 32),
+			ProposerSlashings: []*ethpb.ProposerSlashing{},
+			AttesterSlashings: []*ethpb.AttesterSlashing{},
+			Attestations: []*ethpb.Attestation{{
+				AggregationBits: bitfield.Bitlist{0x03},
+				Data: &ethpb.AttestationData{
+					Slot:            9,
+					BeaconBlockRoot: bytesutil.PadTo([]byte("root"), 32),
+					Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+					Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+				},
+				Signature: make([]byte, 96),
+			}},
+			Deposits:       []*ethpb.Deposit{},
+			VoluntaryExits: []*ethpb.SignedVoluntaryExit{},
+			SyncAggregate: &ethpb.SyncAggregate{
+				SyncCommitteeBits:      bitfield.NewBitvector512(),
+				SyncCommitteeSignature: make([]byte, 96),
+			},
+		},
+	}
+	data, err := json.Marshal(altairBlockToJson(blk))
+	require.NoError(t, err)
+	node.respond(http.MethodGet, produceBlockPath+"10", &produceBlockResponseJson{Version: "ALTAIR", Data: data})
+
+	resp, err := client.GetBeaconBlock(context.Background(), &ethpb.BlockRequest{
+		Slot:         10,
+		RandaoReveal: blk.Body.RandaoReveal,
+		Graffiti:     blk.Body.Graffiti,
+	})
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, blk, resp.GetAltair())
+
+	_, err = client.GetBlock(context.Background(), &ethpb.BlockRequest{Slot: 10})
+	assert.ErrorContains(t, "did not produce a phase 0 block", err)
+}
+
+func TestClient_ProposeAttestation(t *testing.T) {
+	client, node := setupClient(t)
+	node.respond(http.MethodPost, attestationsPoolPath, struct{}{})
+	att := &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist{0x05},
+		Data: &ethpb.AttestationData{
+			Slot:            9,
+			CommitteeIndex:  2,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("root"), 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: bytesutil.PadTo([]byte("signature"), 96),
+	}
+
+	resp, err := client.ProposeAttestation(context.Background(), att)
+	require.NoError(t, err)
+	root, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	assert.DeepEqual(t, root[:], resp.AttestationDataRoot)
+
+	var posted []*attestationJson
+	require.NoError(t, json.Unmarshal(node.body(http.MethodPost, attestationsPoolPath), &posted))
+	require.Equal(t, 1, len(posted))
+	assert.DeepSSZEqual(t, att, attestationFromJson(posted[0]))
+}
+
+func TestClient_ListPeers(t *testing.T) {
+	client, node := setupClient(t)
+	node.handle(http.MethodGet, peersPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "connected", r.URL.Query().Get("state"))
+		assert.NoError(t, json.NewEncoder(w).Encode(&peersResponseJson{
+			Data: []*peerJson{{PeerId: "peer", Address: "/ip4/127.0.0.1/tcp/13000", State: "connected", Direction: "outbound"}},
+		}))
+	})
+
+	resp, err := client.ListPeers(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(resp.Peers))
+	assert.DeepEqual(t, &ethpb.Peer{
+		Address:         "/ip4/127.0.0.1/tcp/13000",
+		Direction:       ethpb.PeerDirection_OUTBOUND,
+		ConnectionState: ethpb.ConnectionState_CONNECTED,
+		PeerId:          "peer",
+	}, resp.Peers[0])
+}
+
+func TestClient_GetLiveness(t *testing.T) {
+	client, node := setupClient(t)
+	node.respond(http.MethodPost, livenessPath+"5", &livenessResponseJson{
+		Data: []*validatorLivenessJson{{Index: 1, IsLive: true}, {Index: 4, IsLive: false}},
+	})
+
+	resp, err := client.GetLiveness(context.Background(), &ethpb.LivenessRequest{Epoch: 5, Indices: []types.ValidatorIndex{1, 4}})
+	require.NoError(t, err)
+	assert.DeepEqual(t, []*ethpb.ValidatorLiveness{{Index: 1, IsLive: true}, {Index: 4, IsLive: false}}, resp.Statuses)
+	assert.Equal(t, `["1","4"]`, string(node.body(http.MethodPost, livenessPath+"5")))
+}
+
+func TestClient_StreamBlocksAltair(t *testing.T) {
+	client, node := setupClient(t)
+	blk := &ethpb.SignedBeaconBlockAltair{
+		Block: &ethpb.BeaconBlockAltair{
+			Slot:       12,
+			ParentRoot: make([]byte, 32),
+			StateRoot:  make([]byte, 32),
+			Body: &ethpb.BeaconBlockBodyAltair{
+				RandaoReveal:      make([]byte, 96),
+				Eth1Data:          &ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)},
+				Graffiti:          make([]byte, 32),
+				ProposerSlashings: []*ethpb.ProposerSlashing{},
+				AttesterSlashings: []*ethpb.AttesterSlashing{},
+				Attestations:      []*ethpb.Attestation{},
+				Deposits:          []*ethpb.Deposit{},
+				VoluntaryExits:    []*ethpb.SignedVoluntaryExit{},
+				SyncAggregate: &ethpb.SyncAggregate{
+					SyncCommitteeBits:      bitfield.NewBitvector512(),
+					SyncCommitteeSignature: make([]byte, 96),
+				},
+			},
+		},
+		Signature: make([]byte, 96),
+	}
+	root := bytesutil.PadTo([]byte("block"), 32)
+	data, err := json.Marshal(&signedBeaconBlockAltairJson{Message: altairBlockToJson(blk.Block), Signature: blk.Signature})
+	require.NoError(t, err)
+	node.respond(http.MethodGet, blocksV2Path+fmt.Sprintf("%#x", root), &blockResponseJson{Version: altairVersion, Data: data})
+	node.handle(http.MethodGet, eventsPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, blockTopic, r.URL.Query().Get("topics"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, err := fmt.Fprintf(w, "event: head\ndata: {}\n\nevent: block\ndata: {\"slot\":\"12\",\"block\":\"%#x\"}\n\n", root)
+		assert.NoError(t, err)
+	})
+
+	stream, err := client.StreamBlocksAltair(context.Background(), &ethpb.StreamBlocksRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, blk, resp.GetAltairBlock())
+	_, err = stream.Recv()
+	assert.ErrorContains(t, "EOF", err)
+}
@@ -0,0 +1,485 @@
+package beaconapi
+
+import (
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/go-bitfield"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+)
+
+// Conversions between the v1alpha1 types of the validator client and the JSON
+// types of the Beacon API. All of them tolerate nil values, which are kept nil.
+
+func checkpointToJson(c *ethpb.Checkpoint) *checkpointJson {
+	if c == nil {
+		return nil
+	}
+	return &checkpointJson{
+		Epoch: uint64String(c.Epoch),
+		Root:  c.Root,
+	}
+}
+
+func checkpointFromJson(c *checkpointJson) *ethpb.Checkpoint {
+	if c == nil {
+		return nil
+	}
+	return &ethpb.Checkpoint{
+		Epoch: types.Epoch(c.Epoch),
+		Root:  c.Root,
+	}
+}
+
+func attestationDataToJson(d *ethpb.AttestationData) *attestationDataJson {
+	if d == nil {
+		return nil
+	}
+	return &attestationDataJson{
+		Slot:            uint64String(d.Slot),
+		CommitteeIndex:  uint64String(d.CommitteeIndex),
+		BeaconBlockRoot: d.BeaconBlockRoot,
+		Source:          checkpointToJson(d.Source),
+		Target:          checkpointToJson(d.Target),
+	}
+}
+
+func attestationDataFromJson(d *attestationDataJson) *ethpb.AttestationData {
+	if d == nil {
+		return nil
+	}
+	return &ethpb.AttestationData{
+		Slot:            types.Slot(d.Slot),
+		CommitteeIndex:  types.CommitteeIndex(d.CommitteeIndex),
+		BeaconBlockRoot: d.BeaconBlockRoot,
+		Source:          checkpointFromJson(d.Source),
+		Target:          checkpointFromJson(d.Target),
+	}
+}
+
+func attestationToJson(a *ethpb.Attestation) *attestationJson {
+	if a == nil {
+		return nil
+	}
+	return &attestationJson{
+		AggregationBits: hexBytes(a.AggregationBits),
+		Data:            attestationDataToJson(a.Data),
+		Signature:       a.Signature,
+	}
+}
+
+func attestationFromJson(a *attestationJson) *ethpb.Attestation {
+	if a == nil {
+		return nil
+	}
+	return &ethpb.Attestation{
+		AggregationBits: bitfield.Bitlist(a.AggregationBits),
+		Data:            attestationDataFromJson(a.Data),
+		Signature:       a.Signature,
+	}
+}
+
+func attestationsToJson(atts []*ethpb.Attestation) []*attestationJson {
+	result := make([]*attestationJson, len(atts))
+	for i, a := range atts {
+		result[i] = attestationToJson(a)
+	}
+	return result
+}
+
+func attestationsFromJson(atts []*attestationJson) []*ethpb.Attestation {
+	result := make([]*ethpb.Attestation, len(atts))
+	for i, a := range atts {
+		result[i] = attestationFromJson(a)
+	}
+	return result
+}
+
+func indexedAttestationToJson(a *ethpb.IndexedAttestation) *indexedAttestationJson {
+	if a == nil {
+		return nil
+	}
+	indices := make([]uint64String, len(a.AttestingIndices))
+	for i, idx := range a.AttestingIndices {
+		indices[i] = uint64String(idx)
+	}
+	return &indexedAttestationJson{
+		AttestingIndices: indices,
+		Data:             attestationDataToJson(a.Data),
+		Signature:        a.Signature,
+	}
+}
+
+func indexedAttestationFromJson(a *indexedAttestationJson) *ethpb.IndexedAttestation {
+	if a == nil {
+		return nil
+	}
+	indices := make([]uint64, len(a.AttestingIndices))
+	for i, idx := range a.AttestingIndices {
+		indices[i] = uint64(idx)
+	}
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             attestationDataFromJson(a.Data),
+		Signature:        a.Signature,
+	}
+}
+
+func blockHeaderToJson(h *ethpb.SignedBeaconBlockHeader) *signedBeaconBlockHeaderJson {
+	if h == nil || h.Header == nil {
+		return nil
+	}
+	return &signedBeaconBlockHeaderJson{
+		Header: &beaconBlockHeaderJson{
+			Slot:          uint64String(h.Header.Slot),
+			ProposerIndex: uint64String(h.Header.ProposerIndex),
+			ParentRoot:    h.Header.ParentRoot,
+			StateRoot:     h.Header.StateRoot,
+			BodyRoot:      h.Header.BodyRoot,
+		},
+		Signature: h.Signature,
+	}
+}
+
+func blockHeaderFromJson(h *signedBeaconBlockHeaderJson) *ethpb.SignedBeaconBlockHeader {
+	if h == nil || h.Header == nil {
+		return nil
+	}
+	return &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          types.Slot(h.Header.Slot),
+			ProposerIndex: types.ValidatorIndex(h.Header.ProposerIndex),
+			ParentRoot:    h.Header.ParentRoot,
+			StateRoot:     h.Header.StateRoot,
+			BodyRoot:      h.Header.BodyRoot,
+		},
+		Signature: h.Signature,
+	}
+}
+
+func eth1DataToJson(d *ethpb.Eth1Data) *eth1DataJson {
+	if d == nil {
+		return nil
+	}
+	return &eth1DataJson{
+		DepositRoot:  d.DepositRoot,
+		DepositCount: uint64String(d.DepositCount),
+		BlockHash:    d.BlockHash,
+	}
+}
+
+func eth1DataFromJson(d *eth1DataJson) *ethpb.Eth1Data {
+	if d == nil {
+		return nil
+	}
+	return &ethpb.Eth1Data{
+		DepositRoot:  d.DepositRoot,
+		DepositCount: uint64(d.DepositCount),
+		BlockHash:    d.BlockHash,
+	}
+}
+
+func proposerSlashingsToJson(slashings []*ethpb.ProposerSlashing) []*proposerSlashingJson {
+	result := make([]*proposerSlashingJson, len(slashings))
+	for i, s := range slashings {
+		result[i] = &proposerSlashingJson{
+			Header_1: blockHeaderToJson(s.Header_1),
+			Header_2: blockHeaderToJson(s.Header_2),
+		}
+	}
+	return result
+}
+
+func proposerSlashingsFromJson(slashings []*proposerSlashingJson) []*ethpb.ProposerSlashing {
+	result := make([]*ethpb.ProposerSlashing, len(slashings))
+	for i, s := range slashings {
+		result[i] = &ethpb.ProposerSlashing{
+			Header_1: blockHeaderFromJson(s.Header_1),
+			Header_2: blockHeaderFromJson(s.Header_2),
+		}
+	}
+	return result
+}
+
+func attesterSlashingsToJson(slashings []*ethpb.AttesterSlashing) []*attesterSlashingJson {
+	result := make([]*attesterSlashingJson, len(slashings))
+	for i, s := range slashings {
+		result[i] = &attesterSlashingJson{
+			Attestation_1: indexedAttestationToJson(s.Attestation_1),
+			Attestation_2: indexedAttestationToJson(s.Attestation_2),
+		}
+	}
+	return result
+}
+
+func attesterSlashingsFromJson(slashings []*attesterSlashingJson) []*ethpb.AttesterSlashing {
+	result := make([]*ethpb.AttesterSlashing, len(slashings))
+	for i, s := range slashings {
+		result[i] = &ethpb.AttesterSlashing{
+			Attestation_1: indexedAttestationFromJson(s.Attestation_1),
+			Attestation_2: indexedAttestationFromJson(s.Attestation_2),
+		}
+	}
+	return result
+}
+
+func depositsToJson(deposits []*ethpb.Deposit) []*depositJson {
+	result := make([]*depositJson, len(deposits))
+	for i, d := range deposits {
+		proof := make([]hexBytes, len(d.Proof))
+		for j, p := range d.Proof {
+			proof[j] = p
+		}
+		result[i] = &depositJson{Proof: proof}
+		if d.Data != nil {
+			result[i].Data = &deposit_DataJson{
+				PublicKey:             d.Data.PublicKey,
+				WithdrawalCredentials: d.Data.WithdrawalCredentials,
+				Amount:                uint64String(d.Data.Amount),
+				Signature:             d.Data.Signature,
+			}
+		}
+	}
+	return result
+}
+
+func depositsFromJson(deposits []*depositJson) []*ethpb.Deposit {
+	result := make([]*ethpb.Deposit, len(deposits))
+	for i, d := range deposits {
+		proof := make([][]byte, len(d.Proof))
+		for j, p := range d.Proof {
+			proof[j] = p
+		}
+		result[i] = &ethpb.Deposit{Proof: proof}
+		if d.Data != nil {
+			result[i].Data = &ethpb.Deposit_Data{
+				PublicKey:             d.Data.PublicKey,
+				WithdrawalCredentials: d.Data.WithdrawalCredentials,
+				Amount:                uint64(d.Data.Amount),
+				Signature:             d.Data.Signature,
+			}
+		}
+	}
+	return result
+}
+
+func voluntaryExitToJson(e *ethpb.SignedVoluntaryExit) *signedVoluntaryExitJson {
+	if e == nil || e.Exit == nil {
+		return nil
+	}
+	return &signedVoluntaryExitJson{
+		Exit: &voluntaryExitJson{
+			Epoch:          uint64String(e.Exit.Epoch),
+			ValidatorIndex: uint64String(e.Exit.ValidatorIndex),
+		},
+		Signature: e.Signature,
+	}
+}
+
+func voluntaryExitsToJson(exits []*ethpb.SignedVoluntaryExit) []*signedVoluntaryExitJson {
+	result := make([]*signedVoluntaryExitJson, len(exits))
+	for i, e := range exits {
+		result[i] = voluntaryExitToJson(e)
+	}
+	return result
+}
+
+func voluntaryExitsFromJson(exits []*signedVoluntaryExitJson) []*ethpb.SignedVoluntaryExit {
+	result := make([]*ethpb.SignedVoluntaryExit, len(exits))
+	for i, e := range exits {
+		result[i] = &ethpb.SignedVoluntaryExit{Signature: e.Signature}
+		if e.Exit != nil {
+			result[i].Exit = &ethpb.VoluntaryExit{
+				Epoch:          types.Epoch(e.Exit.Epoch),
+				ValidatorIndex: types.ValidatorIndex(e.Exit.ValidatorIndex),
+			}
+		}
+	}
+	return result
+}
+
+func syncAggregateToJson(a *ethpb.SyncAggregate) *syncAggregateJson {
+	if a == nil {
+		return nil
+	}
+	return &syncAggregateJson{
+		SyncCommitteeBits:      hexBytes(a.SyncCommitteeBits),
+		SyncCommitteeSignature: a.SyncCommitteeSignature,
+	}
+}
+
+func syncAggregateFromJson(a *syncAggregateJson) *ethpb.SyncAggregate {
+	if a == nil {
+		return nil
+	}
+	return &ethpb.SyncAggregate{
+		SyncCommitteeBits:      bitfield.Bitvector512(a.SyncCommitteeBits),
+		SyncCommitteeSignature: a.SyncCommitteeSignature,
+	}
+}
+
+func blockToJson(b *ethpb.BeaconBlock) *beaconBlockJson {
+	if b == nil {
+		return nil
+	}
+	result := &beaconBlockJson{
+		Slot:          uint64String(b.Slot),
+		ProposerIndex: uint64String(b.ProposerIndex),
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+	}
+	if b.Body != nil {
+		result.Body = &beaconBlockBodyJson{
+			RandaoReveal:      b.Body.RandaoReveal,
+			Eth1Data:          eth1DataToJson(b.Body.Eth1Data),
+			Graffiti:          b.Body.Graffiti,
+			ProposerSlashings: proposerSlashingsToJson(b.Body.ProposerSlashings),
+			AttesterSlashings: attesterSlashingsToJson(b.Body.AttesterSlashings),
+			Attestations:      attestationsToJson(b.Body.Attestations),
+			Deposits:          depositsToJson(b.Body.Deposits),
+			VoluntaryExits:    voluntaryExitsToJson(b.Body.VoluntaryExits),
+		}
+	}
+	return result
+}
+
+func blockFromJson(b *beaconBlockJson) *ethpb.BeaconBlock {
+	if b == nil {
+		return nil
+	}
+	result := &ethpb.BeaconBlock{
+		Slot:          types.Slot(b.Slot),
+		ProposerIndex: types.ValidatorIndex(b.ProposerIndex),
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+	}
+	if b.Body != nil {
+		result.Body = &ethpb.BeaconBlockBody{
+			RandaoReveal:      b.Body.RandaoReveal,
+			Eth1Data:          eth1DataFromJson(b.Body.Eth1Data),
+			Graffiti:          b.Body.Graffiti,
+			ProposerSlashings: proposerSlashingsFromJson(b.Body.ProposerSlashings),
+			AttesterSlashings: attesterSlashingsFromJson(b.Body.AttesterSlashings),
+			Attestations:      attestationsFromJson(b.Body.Attestations),
+			Deposits:          depositsFromJson(b.Body.Deposits),
+			VoluntaryExits:    voluntaryExitsFromJson(b.Body.VoluntaryExits),
+		}
+	}
+	return result
+}
+
+func altairBlockToJson(b *ethpb.BeaconBlockAltair) *beaconBlockAltairJson {
+	if b == nil {
+		return nil
+	}
+	result := &beaconBlockAltairJson{
+		Slot:          uint64String(b.Slot),
+		ProposerIndex: uint64String(b.ProposerIndex),
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+	}
+	if b.Body != nil {
+		result.Body = &beaconBlockBodyAltairJson{
+			RandaoReveal:      b.Body.RandaoReveal,
+			Eth1Data:          eth1DataToJson(b.Body.Eth1Data),
+			Graffiti:          b.Body.Graffiti,
+			ProposerSlashings: proposerSlashingsToJson(b.Body.ProposerSlashings),
+			AttesterSlashings: attesterSlashingsToJson(b.Body.AttesterSlashings),
+			Attestations:      attestationsToJson(b.Body.Attestations),
+			Deposits:          depositsToJson(b.Body.Deposits),
+			VoluntaryExits:    voluntaryExitsToJson(b.Body.VoluntaryExits),
+			SyncAggregate:     syncAggregateToJson(b.Body.SyncAggregate),
+		}
+	}
+	return result
+}
+
+func altairBlockFromJson(b *beaconBlockAltairJson) *ethpb.BeaconBlockAltair {
+	if b == nil {
+		return nil
+	}
+	result := &ethpb.BeaconBlockAltair{
+		Slot:          types.Slot(b.Slot),
+		ProposerIndex: types.ValidatorIndex(b.ProposerIndex),
+		ParentRoot:    b.ParentRoot,
+		StateRoot:     b.StateRoot,
+	}
+	if b.Body != nil {
+		result.Body = &ethpb.BeaconBlockBodyAltair{
+			RandaoReveal:      b.Body.RandaoReveal,
+			Eth1Data:          eth1DataFromJson(b.Body.Eth1Data),
+			Graffiti:          b.Body.Graffiti,
+			ProposerSlashings: proposerSlashingsFromJson(b.Body.ProposerSlashings),
+			AttesterSlashings: attesterSlashingsFromJson(b.Body.AttesterSlashings),
+			Attestations:      attestationsFromJson(b.Body.Attestations),
+			Deposits:          depositsFromJson(b.Body.Deposits),
+			VoluntaryExits:    voluntaryExitsFromJson(b.Body.VoluntaryExits),
+			SyncAggregate:     syncAggregateFromJson(b.Body.SyncAggregate),
+		}
+	}
+	return result
+}
+
+func aggregateAndProofToJson(a *ethpb.SignedAggregateAttestationAndProof) *signedAggregateAttestationAndProofJson {
+	if a == nil || a.Message == nil {
+		return nil
+	}
+	return &signedAggregateAttestationAndProofJson{
+		Message: &aggregateAttestationAndProofJson{
+			AggregatorIndex: uint64String(a.Message.AggregatorIndex),
+			Aggregate:       attestationToJson(a.Message.Aggregate),
+			SelectionProof:  a.Message.SelectionProof,
+		},
+		Signature: a.Signature,
+	}
+}
+
+func syncCommitteeMessageToJson(m *ethpb.SyncCommitteeMessage) *syncCommitteeMessageJson {
+	if m == nil {
+		return nil
+	}
+	return &syncCommitteeMessageJson{
+		Slot:            uint64String(m.Slot),
+		BeaconBlockRoot: m.BlockRoot,
+		ValidatorIndex:  uint64String(m.ValidatorIndex),
+		Signature:       m.Signature,
+	}
+}
+
+func contributionToJson(c *ethpb.SyncCommitteeContribution) *syncCommitteeContributionJson {
+	if c == nil {
+		return nil
+	}
+	return &syncCommitteeContributionJson{
+		Slot:              uint64String(c.Slot),
+		BeaconBlockRoot:   c.BlockRoot,
+		SubcommitteeIndex: uint64String(c.SubcommitteeIndex),
+		AggregationBits:   hexBytes(c.AggregationBits),
+		Signature:         c.Signature,
+	}
+}
+
+func contributionFromJson(c *syncCommitteeContributionJson) *ethpb.SyncCommitteeContribution {
+	if c == nil {
+		return nil
+	}
+	return &ethpb.SyncCommitteeContribution{
+		Slot:              types.Slot(c.Slot),
+		BlockRoot:         c.BeaconBlockRoot,
+		SubcommitteeIndex: uint64(c.SubcommitteeIndex),
+		AggregationBits:   bitfield.Bitvector128(c.AggregationBits),
+		Signature:         c.Signature,
+	}
+}
+
+func contributionAndProofToJson(c *ethpb.SignedContributionAndProof) *signedContributionAndProofJson {
+	if c == nil || c.Message == nil {
+		return nil
+	}
+	return &signedContributionAndProofJson{
+		Message: &contributionAndProofJson{
+			AggregatorIndex: uint64String(c.Message.AggregatorIndex),
+			Contribution:    contributionToJson(c.Message.Contribution),
+			SelectionProof:  c.Message.SelectionProof,
+		},
+		Signature: c.Signature,
+	}
+}
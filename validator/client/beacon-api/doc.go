@@ -0,0 +1,18 @@
+/*
+Package beaconapi defines a client of the standard Beacon API, which the validator
+client can use instead of the Prysm gRPC API to perform its duties with any
+consensus client.
+
+The client implements the beacon node interfaces of the validator client by
+translating each call into requests to the REST endpoints of the Beacon API, such as:
+
+	/eth/v1/validator/duties/attester/{epoch}
+	/eth/v1/validator/duties/proposer/{epoch}
+	/eth/v2/validator/blocks/{slot}
+	/eth/v1/validator/attestation_data
+	/eth/v1/validator/aggregate_and_proofs
+
+Streams of the gRPC API are implemented by polling the beacon node, or in the case of
+blocks, by subscribing to the server-sent events of /eth/v1/events.
+*/
+package beaconapi
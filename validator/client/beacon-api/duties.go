@@ -0,0 +1,422 @@
+package beaconapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	headValidatorsPath         = "/eth/v1/beacon/states/head/validators"
+	headCommitteesPath         = "/eth/v1/beacon/states/head/committees"
+	attesterDutiesPath         = "/eth/v1/validator/duties/attester/"
+	proposerDutiesPath         = "/eth/v1/validator/duties/proposer/"
+	syncDutiesPath             = "/eth/v1/validator/duties/sync/"
+	committeeSubscriptionsPath = "/eth/v1/validator/beacon_committee_subscriptions"
+	syncSubscriptionsPath      = "/eth/v1/validator/sync_committee_subscriptions"
+)
+
+// validatorsBatchSize is the maximum number of validators requested at once, which
+// keeps the URLs of the requests within the limits of the beacon nodes.
+const validatorsBatchSize = 64
+
+// nonexistentIndex is the index of validators which are not known to the beacon node.
+const nonexistentIndex = types.ValidatorIndex(^uint64(0))
+
+// GetDuties returns the attester, proposer and sync committee duties of the validators
+// for the requested epoch, along with their attester and sync committee duties for the
+// next epoch.
+func (c *Client) GetDuties(ctx context.Context, in *ethpb.DutiesRequest, _ ...grpc.CallOption) (*ethpb.DutiesResponse, error) {
+	vals, err := c.validators(ctx, in.PublicKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.pruneDuties(in.Epoch)
+	currentDuties, err := c.epochDuties(ctx, in.Epoch, in.PublicKeys, vals, true /* withProposals */)
+	if err != nil {
+		return nil, err
+	}
+	nextDuties, err := c.epochDuties(ctx, in.Epoch+1, in.PublicKeys, vals, false /* withProposals */)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.subscribeToSyncCommittees(ctx, in.Epoch, currentDuties); err != nil {
+		log.WithError(err).Warn("Could not subscribe to sync committee subnets")
+	}
+	return &ethpb.DutiesResponse{
+		Duties:             currentDuties,
+		CurrentEpochDuties: currentDuties,
+		NextEpochDuties:    nextDuties,
+	}, nil
+}
+
+// epochDuties assembles the duties of the validators in an epoch from the attester,
+// proposer and sync committee duties endpoints.
+func (c *Client) epochDuties(
+	ctx context.Context,
+	epoch types.Epoch,
+	pubKeys [][]byte,
+	vals map[[48]byte]*validatorContainerJson,
+	withProposals bool,
+) ([]*ethpb.DutiesResponse_Duty, error) {
+	duties := make([]*ethpb.DutiesResponse_Duty, len(pubKeys))
+	dutiesByKey := make(map[[48]byte]*ethpb.DutiesResponse_Duty, len(pubKeys))
+	indices := make([]uint64String, 0, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		duties[i] = &ethpb.DutiesResponse_Duty{
+			PublicKey: pubKey,
+			Status:    ethpb.ValidatorStatus_UNKNOWN_STATUS,
+		}
+		val, ok := vals[bytesutil.ToBytes48(pubKey)]
+		if !ok {
+			continue
+		}
+		duties[i].ValidatorIndex = types.ValidatorIndex(val.Index)
+		duties[i].Status = validatorStatus(val.Status)
+		dutiesByKey[bytesutil.ToBytes48(pubKey)] = duties[i]
+		indices = append(indices, val.Index)
+	}
+	if len(indices) == 0 {
+		return duties, nil
+	}
+
+	attesterResp := &attesterDutiesResponseJson{}
+	if err := c.post(ctx, attesterDutiesPath+epochString(epoch), indices, attesterResp); err != nil {
+		return nil, err
+	}
+	for _, d := range attesterResp.Data {
+		duty, ok := dutiesByKey[bytesutil.ToBytes48(d.Pubkey)]
+		if !ok {
+			continue
+		}
+		key := committeeKey{slot: types.Slot(d.Slot), index: types.CommitteeIndex(d.CommitteeIndex)}
+		committee, err := c.committee(ctx, epoch, key)
+		if err != nil {
+			return nil, err
+		}
+		duty.AttesterSlot = key.slot
+		duty.CommitteeIndex = key.index
+		duty.CommitteesAtSlot = uint64(d.CommitteesAtSlot)
+		duty.Committee = committee
+	}
+
+	if withProposals {
+		proposerResp := &proposerDutiesResponseJson{}
+		if err := c.get(ctx, proposerDutiesPath+epochString(epoch), nil, proposerResp); err != nil {
+			return nil, err
+		}
+		for _, d := range proposerResp.Data {
+			duty, ok := dutiesByKey[bytesutil.ToBytes48(d.Pubkey)]
+			if !ok {
+				continue
+			}
+			duty.ProposerSlots = append(duty.ProposerSlots, types.Slot(d.Slot))
+		}
+	}
+
+	if epoch >= params.BeaconConfig().AltairForkEpoch {
+		syncDuties, err := c.syncDuties(ctx, epoch, indices)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range syncDuties {
+			if duty, ok := dutiesByKey[bytesutil.ToBytes48(d.Pubkey)]; ok {
+				duty.IsSyncCommittee = true
+			}
+		}
+	}
+	return duties, nil
+}
+
+// SubscribeCommitteeSubnets subscribes the beacon node to the attestation subnets of
+// the committees of the validators' duties.
+func (c *Client) SubscribeCommitteeSubnets(
+	ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, _ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	n := len(in.Slots)
+	if len(in.CommitteeIds) != n || len(in.IsAggregator) != n || len(in.ValidatorIndices) != n || len(in.CommitteesAtSlot) != n {
+		return nil, errors.New("request fields are not the same length")
+	}
+	if n == 0 {
+		return &emptypb.Empty{}, nil
+	}
+	subscriptions := make([]*beaconCommitteeSubscribeJson, n)
+	for i := range in.Slots {
+		subscriptions[i] = &beaconCommitteeSubscribeJson{
+			ValidatorIndex:   uint64String(in.ValidatorIndices[i]),
+			CommitteeIndex:   uint64String(in.CommitteeIds[i]),
+			CommitteesAtSlot: uint64String(in.CommitteesAtSlot[i]),
+			Slot:             uint64String(in.Slots[i]),
+			IsAggregator:     in.IsAggregator[i],
+		}
+	}
+	if err := c.post(ctx, committeeSubscriptionsPath, subscriptions, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetSyncSubcommitteeIndex returns the positions of a validator in the sync committee
+// of the epoch of the slot.
+func (c *Client) GetSyncSubcommitteeIndex(
+	ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest, _ ...grpc.CallOption,
+) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	index, err := c.validatorIndex(ctx, in.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	syncDuties, err := c.syncDuties(ctx, core.SlotToEpoch(in.Slot), []uint64String{uint64String(index)})
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.SyncSubcommitteeIndexResponse{}
+	for _, d := range syncDuties {
+		for _, i := range d.ValidatorSyncCommitteeIndices {
+			resp.Indices = append(resp.Indices, types.CommitteeIndex(i))
+		}
+	}
+	return resp, nil
+}
+
+// ValidatorIndex returns the index of a validator.
+func (c *Client) ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, _ ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error) {
+	index, err := c.validatorIndex(ctx, in.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.ValidatorIndexResponse{Index: index}, nil
+}
+
+// MultipleValidatorStatus returns the statuses of the validators with the requested public
+// keys, followed by the statuses of the validators with the requested indices.
+func (c *Client) MultipleValidatorStatus(
+	ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, _ ...grpc.CallOption,
+) (*ethpb.MultipleValidatorStatusResponse, error) {
+	indices := make([]types.ValidatorIndex, len(in.Indices))
+	for i, idx := range in.Indices {
+		if idx < 0 {
+			return nil, errors.Errorf("invalid validator index %d", idx)
+		}
+		indices[i] = types.ValidatorIndex(idx)
+	}
+	vals, err := c.validators(ctx, in.PublicKeys, indices)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ethpb.MultipleValidatorStatusResponse{}
+	for _, pubKey := range in.PublicKeys {
+		status, index := validatorStatusResponse(vals[bytesutil.ToBytes48(pubKey)])
+		resp.PublicKeys = append(resp.PublicKeys, pubKey)
+		resp.Statuses = append(resp.Statuses, status)
+		resp.Indices = append(resp.Indices, index)
+	}
+	for _, idx := range indices {
+		for _, val := range vals {
+			if types.ValidatorIndex(val.Index) != idx || val.Validator == nil {
+				continue
+			}
+			status, index := validatorStatusResponse(val)
+			resp.PublicKeys = append(resp.PublicKeys, val.Validator.PublicKey)
+			resp.Statuses = append(resp.Statuses, status)
+			resp.Indices = append(resp.Indices, index)
+			break
+		}
+	}
+	return resp, nil
+}
+
+// validators returns the validators of the head state with the given public keys or
+// indices, keyed by public key. Validators unknown to the beacon node are omitted.
+func (c *Client) validators(
+	ctx context.Context, pubKeys [][]byte, indices []types.ValidatorIndex,
+) (map[[48]byte]*validatorContainerJson, error) {
+	ids := make([]string, 0, len(pubKeys)+len(indices))
+	for _, pubKey := range pubKeys {
+		ids = append(ids, fmt.Sprintf("%#x", pubKey))
+	}
+	for _, idx := range indices {
+		ids = append(ids, strconv.FormatUint(uint64(idx), 10))
+	}
+	vals := make(map[[48]byte]*validatorContainerJson, len(ids))
+	for start := 0; start < len(ids); start += validatorsBatchSize {
+		end := start + validatorsBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		resp := &stateValidatorsResponseJson{}
+		query := url.Values{"id": []string{strings.Join(ids[start:end], ",")}}
+		if err := c.get(ctx, headValidatorsPath, query, resp); err != nil {
+			return nil, err
+		}
+		c.indicesLock.Lock()
+		for _, val := range resp.Data {
+			if val.Validator == nil {
+				continue
+			}
+			pubKey := bytesutil.ToBytes48(val.Validator.PublicKey)
+			vals[pubKey] = val
+			c.indices[pubKey] = types.ValidatorIndex(val.Index)
+		}
+		c.indicesLock.Unlock()
+	}
+	return vals, nil
+}
+
+// validatorIndex returns the index of a validator, which is only requested from the
+// beacon node until it is known.
+func (c *Client) validatorIndex(ctx context.Context, pubKey []byte) (types.ValidatorIndex, error) {
+	c.indicesLock.RLock()
+	index, ok := c.indices[bytesutil.ToBytes48(pubKey)]
+	c.indicesLock.RUnlock()
+	if ok {
+		return index, nil
+	}
+	vals, err := c.validators(ctx, [][]byte{pubKey}, nil)
+	if err != nil {
+		return 0, err
+	}
+	val, ok := vals[bytesutil.ToBytes48(pubKey)]
+	if !ok {
+		return 0, errors.Errorf("could not find validator index for public key %#x", bytesutil.Trunc(pubKey))
+	}
+	return types.ValidatorIndex(val.Index), nil
+}
+
+// committee returns the validator indices of a beacon committee, which are only
+// requested from the beacon node until they are known.
+func (c *Client) committee(ctx context.Context, epoch types.Epoch, key committeeKey) ([]types.ValidatorIndex, error) {
+	c.dutiesLock.RLock()
+	committee, ok := c.committees[key]
+	c.dutiesLock.RUnlock()
+	if ok {
+		return committee, nil
+	}
+	query := url.Values{
+		"epoch": []string{epochString(epoch)},
+		"slot":  []string{strconv.FormatUint(uint64(key.slot), 10)},
+		"index": []string{strconv.FormatUint(uint64(key.index), 10)},
+	}
+	resp := &stateCommitteesResponseJson{}
+	if err := c.get(ctx, headCommitteesPath, query, resp); err != nil {
+		return nil, err
+	}
+	for _, cmt := range resp.Data {
+		if types.Slot(cmt.Slot) != key.slot || types.CommitteeIndex(cmt.Index) != key.index {
+			continue
+		}
+		committee = make([]types.ValidatorIndex, len(cmt.Validators))
+		for i, idx := range cmt.Validators {
+			committee[i] = types.ValidatorIndex(idx)
+		}
+		c.dutiesLock.Lock()
+		c.committees[key] = committee
+		c.dutiesLock.Unlock()
+		return committee, nil
+	}
+	return nil, errors.Errorf("no committee %d at slot %d in response", key.index, key.slot)
+}
+
+// pruneDuties removes the committees of the epochs before the previous epoch, which
+// the validator no longer needs.
+func (c *Client) pruneDuties(epoch types.Epoch) {
+	if epoch == 0 {
+		return
+	}
+	minSlot, err := core.StartSlot(epoch - 1)
+	if err != nil {
+		return
+	}
+	c.dutiesLock.Lock()
+	defer c.dutiesLock.Unlock()
+	for key := range c.committees {
+		if key.slot < minSlot {
+			delete(c.committees, key)
+		}
+	}
+}
+
+func (c *Client) syncDuties(ctx context.Context, epoch types.Epoch, indices []uint64String) ([]*syncDutyJson, error) {
+	resp := &syncDutiesResponseJson{}
+	if err := c.post(ctx, syncDutiesPath+epochString(epoch), indices, resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// subscribeToSyncCommittees subscribes the beacon node to the sync committee subnets of
+// the validators in the sync committee, until the end of the sync committee period.
+func (c *Client) subscribeToSyncCommittees(ctx context.Context, epoch types.Epoch, duties []*ethpb.DutiesResponse_Duty) error {
+	if epoch < params.BeaconConfig().AltairForkEpoch {
+		return nil
+	}
+	indices := make([]uint64String, 0)
+	for _, duty := range duties {
+		if duty.IsSyncCommittee {
+			indices = append(indices, uint64String(duty.ValidatorIndex))
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	syncDuties, err := c.syncDuties(ctx, epoch, indices)
+	if err != nil {
+		return err
+	}
+	untilEpoch := types.Epoch(core.SyncCommitteePeriod(epoch)+1) * params.BeaconConfig().EpochsPerSyncCommitteePeriod
+	subscriptions := make([]*syncCommitteeSubscriptionJson, len(syncDuties))
+	for i, d := range syncDuties {
+		subscriptions[i] = &syncCommitteeSubscriptionJson{
+			ValidatorIndex:       d.ValidatorIndex,
+			SyncCommitteeIndices: d.ValidatorSyncCommitteeIndices,
+			UntilEpoch:           uint64String(untilEpoch),
+		}
+	}
+	return c.post(ctx, syncSubscriptionsPath, subscriptions, nil)
+}
+
+// validatorStatus converts a validator status of the Beacon API into its v1alpha1 equivalent.
+func validatorStatus(status string) ethpb.ValidatorStatus {
+	switch status {
+	case "pending_initialized":
+		return ethpb.ValidatorStatus_DEPOSITED
+	case "pending_queued":
+		return ethpb.ValidatorStatus_PENDING
+	case "active_ongoing":
+		return ethpb.ValidatorStatus_ACTIVE
+	case "active_exiting":
+		return ethpb.ValidatorStatus_EXITING
+	case "active_slashed":
+		return ethpb.ValidatorStatus_SLASHING
+	case "exited_unslashed", "exited_slashed", "withdrawal_possible", "withdrawal_done":
+		return ethpb.ValidatorStatus_EXITED
+	default:
+		return ethpb.ValidatorStatus_UNKNOWN_STATUS
+	}
+}
+
+// validatorStatusResponse returns the status and index of a validator, which may be
+// unknown to the beacon node.
+func validatorStatusResponse(val *validatorContainerJson) (*ethpb.ValidatorStatusResponse, types.ValidatorIndex) {
+	if val == nil || val.Validator == nil {
+		return &ethpb.ValidatorStatusResponse{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS}, nonexistentIndex
+	}
+	return &ethpb.ValidatorStatusResponse{
+		Status:          validatorStatus(val.Status),
+		ActivationEpoch: types.Epoch(val.Validator.ActivationEpoch),
+	}, types.ValidatorIndex(val.Index)
+}
+
+func epochString(epoch types.Epoch) string {
+	return strconv.FormatUint(uint64(epoch), 10)
+}
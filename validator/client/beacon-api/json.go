@@ -0,0 +1,450 @@
+package beaconapi
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// hexBytes is a byte slice encoded as a 0x prefixed hex string, as all byte
+// values of the Beacon API are.
+type hexBytes []byte
+
+// MarshalJSON encodes the bytes as a 0x prefixed hex string.
+func (b hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexutil.Encode(b))
+}
+
+// UnmarshalJSON decodes a 0x prefixed hex string.
+func (b *hexBytes) UnmarshalJSON(enc []byte) error {
+	var s string
+	if err := json.Unmarshal(enc, &s); err != nil {
+		return err
+	}
+	decoded, err := hexutil.Decode(s)
+	if err != nil {
+		return errors.Wrapf(err, "invalid hex value %s", s)
+	}
+	*b = decoded
+	return nil
+}
+
+// uint64String is an integer encoded as a decimal string, as all integer
+// values of the Beacon API are.
+type uint64String uint64
+
+// MarshalJSON encodes the integer as a decimal string.
+func (u uint64String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatUint(uint64(u), 10))
+}
+
+// UnmarshalJSON decodes a decimal string.
+func (u *uint64String) UnmarshalJSON(enc []byte) error {
+	var s string
+	if err := json.Unmarshal(enc, &s); err != nil {
+		return err
+	}
+	decoded, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "invalid integer value %s", s)
+	}
+	*u = uint64String(decoded)
+	return nil
+}
+
+// errorJson is the body of the error responses of the Beacon API.
+type errorJson struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// genesisResponseJson is used in /beacon/genesis API endpoint.
+type genesisResponseJson struct {
+	Data *genesisJson `json:"data"`
+}
+
+type genesisJson struct {
+	GenesisTime           uint64String `json:"genesis_time"`
+	GenesisValidatorsRoot hexBytes     `json:"genesis_validators_root"`
+	GenesisForkVersion    hexBytes     `json:"genesis_fork_version"`
+}
+
+// depositContractResponseJson is used in /config/deposit_contract API endpoint.
+type depositContractResponseJson struct {
+	Data *depositContractJson `json:"data"`
+}
+
+type depositContractJson struct {
+	ChainId uint64String `json:"chain_id"`
+	Address hexBytes     `json:"address"`
+}
+
+// syncingResponseJson is used in /node/syncing API endpoint.
+type syncingResponseJson struct {
+	Data *syncingJson `json:"data"`
+}
+
+type syncingJson struct {
+	HeadSlot     uint64String `json:"head_slot"`
+	SyncDistance uint64String `json:"sync_distance"`
+	IsSyncing    bool         `json:"is_syncing"`
+}
+
+// peersResponseJson is used in /node/peers API endpoint.
+type peersResponseJson struct {
+	Data []*peerJson `json:"data"`
+}
+
+type peerJson struct {
+	PeerId    string `json:"peer_id"`
+	Enr       string `json:"enr"`
+	Address   string `json:"last_seen_p2p_address"`
+	State     string `json:"state"`
+	Direction string `json:"direction"`
+}
+
+// blockHeaderResponseJson is used in /beacon/headers/{block_id} API endpoint.
+type blockHeaderResponseJson struct {
+	Data *blockHeaderContainerJson `json:"data"`
+}
+
+type blockHeaderContainerJson struct {
+	Root      hexBytes                     `json:"root"`
+	Canonical bool                         `json:"canonical"`
+	Header    *signedBeaconBlockHeaderJson `json:"header"`
+}
+
+// stateFinalityCheckpointResponseJson is used in /beacon/states/{state_id}/finality_checkpoints API endpoint.
+type stateFinalityCheckpointResponseJson struct {
+	Data *finalityCheckpointsJson `json:"data"`
+}
+
+type finalityCheckpointsJson struct {
+	PreviousJustified *checkpointJson `json:"previous_justified"`
+	CurrentJustified  *checkpointJson `json:"current_justified"`
+	Finalized         *checkpointJson `json:"finalized"`
+}
+
+// stateValidatorsResponseJson is used in /beacon/states/{state_id}/validators API endpoint.
+type stateValidatorsResponseJson struct {
+	Data []*validatorContainerJson `json:"data"`
+}
+
+type validatorContainerJson struct {
+	Index     uint64String   `json:"index"`
+	Balance   uint64String   `json:"balance"`
+	Status    string         `json:"status"`
+	Validator *validatorJson `json:"validator"`
+}
+
+type validatorJson struct {
+	PublicKey                  hexBytes     `json:"pubkey"`
+	WithdrawalCredentials      hexBytes     `json:"withdrawal_credentials"`
+	EffectiveBalance           uint64String `json:"effective_balance"`
+	Slashed                    bool         `json:"slashed"`
+	ActivationEligibilityEpoch uint64String `json:"activation_eligibility_epoch"`
+	ActivationEpoch            uint64String `json:"activation_epoch"`
+	ExitEpoch                  uint64String `json:"exit_epoch"`
+	WithdrawableEpoch          uint64String `json:"withdrawable_epoch"`
+}
+
+// validatorBalancesResponseJson is used in /beacon/states/{state_id}/validator_balances API endpoint.
+type validatorBalancesResponseJson struct {
+	Data []*validatorBalanceJson `json:"data"`
+}
+
+type validatorBalanceJson struct {
+	Index   uint64String `json:"index"`
+	Balance uint64String `json:"balance"`
+}
+
+// stateCommitteesResponseJson is used in /beacon/states/{state_id}/committees API endpoint.
+type stateCommitteesResponseJson struct {
+	Data []*committeeJson `json:"data"`
+}
+
+type committeeJson struct {
+	Index      uint64String   `json:"index"`
+	Slot       uint64String   `json:"slot"`
+	Validators []uint64String `json:"validators"`
+}
+
+// attesterDutiesResponseJson is used in /validator/duties/attester/{epoch} API endpoint.
+type attesterDutiesResponseJson struct {
+	DependentRoot hexBytes            `json:"dependent_root"`
+	Data          []*attesterDutyJson `json:"data"`
+}
+
+type attesterDutyJson struct {
+	Pubkey                  hexBytes     `json:"pubkey"`
+	ValidatorIndex          uint64String `json:"validator_index"`
+	CommitteeIndex          uint64String `json:"committee_index"`
+	CommitteeLength         uint64String `json:"committee_length"`
+	CommitteesAtSlot        uint64String `json:"committees_at_slot"`
+	ValidatorCommitteeIndex uint64String `json:"validator_committee_index"`
+	Slot                    uint64String `json:"slot"`
+}
+
+// proposerDutiesResponseJson is used in /validator/duties/proposer/{epoch} API endpoint.
+type proposerDutiesResponseJson struct {
+	DependentRoot hexBytes            `json:"dependent_root"`
+	Data          []*proposerDutyJson `json:"data"`
+}
+
+type proposerDutyJson struct {
+	Pubkey         hexBytes     `json:"pubkey"`
+	ValidatorIndex uint64String `json:"validator_index"`
+	Slot           uint64String `json:"slot"`
+}
+
+// syncDutiesResponseJson is used in /validator/duties/sync/{epoch} API endpoint.
+type syncDutiesResponseJson struct {
+	Data []*syncDutyJson `json:"data"`
+}
+
+type syncDutyJson struct {
+	Pubkey                        hexBytes       `json:"pubkey"`
+	ValidatorIndex                uint64String   `json:"validator_index"`
+	ValidatorSyncCommitteeIndices []uint64String `json:"validator_sync_committee_indices"`
+}
+
+// beaconCommitteeSubscribeJson is used in /validator/beacon_committee_subscriptions API endpoint.
+type beaconCommitteeSubscribeJson struct {
+	ValidatorIndex   uint64String `json:"validator_index"`
+	CommitteeIndex   uint64String `json:"committee_index"`
+	CommitteesAtSlot uint64String `json:"committees_at_slot"`
+	Slot             uint64String `json:"slot"`
+	IsAggregator     bool         `json:"is_aggregator"`
+}
+
+// syncCommitteeSubscriptionJson is used in /validator/sync_committee_subscriptions API endpoint.
+type syncCommitteeSubscriptionJson struct {
+	ValidatorIndex       uint64String   `json:"validator_index"`
+	SyncCommitteeIndices []uint64String `json:"sync_committee_indices"`
+	UntilEpoch           uint64String   `json:"until_epoch"`
+}
+
+// livenessResponseJson is used in /validator/liveness/{epoch} API endpoint.
+type livenessResponseJson struct {
+	Data []*validatorLivenessJson `json:"data"`
+}
+
+type validatorLivenessJson struct {
+	Index  uint64String `json:"index"`
+	IsLive bool         `json:"is_live"`
+}
+
+// produceBlockResponseJson is used in /validator/blocks/{slot} API endpoint.
+type produceBlockResponseJson struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// blockResponseJson is used in /beacon/blocks/{block_id} API endpoint.
+type blockResponseJson struct {
+	Version string          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// blockRootResponseJson is used in /beacon/blocks/{block_id}/root API endpoint.
+type blockRootResponseJson struct {
+	Data *blockRootContainerJson `json:"data"`
+}
+
+type blockRootContainerJson struct {
+	Root hexBytes `json:"root"`
+}
+
+// produceAttestationDataResponseJson is used in /validator/attestation_data API endpoint.
+type produceAttestationDataResponseJson struct {
+	Data *attestationDataJson `json:"data"`
+}
+
+// aggregateAttestationResponseJson is used in /validator/aggregate_attestation API endpoint.
+type aggregateAttestationResponseJson struct {
+	Data *attestationJson `json:"data"`
+}
+
+// produceSyncCommitteeContributionResponseJson is used in /validator/sync_committee_contribution API endpoint.
+type produceSyncCommitteeContributionResponseJson struct {
+	Data *syncCommitteeContributionJson `json:"data"`
+}
+
+// blockEventJson is the data of the block events of the /events API endpoint.
+type blockEventJson struct {
+	Slot  uint64String `json:"slot"`
+	Block hexBytes     `json:"block"`
+}
+
+//----------------
+// Reusable types.
+//----------------
+
+type checkpointJson struct {
+	Epoch uint64String `json:"epoch"`
+	Root  hexBytes     `json:"root"`
+}
+
+type signedBeaconBlockJson struct {
+	Message   *beaconBlockJson `json:"message"`
+	Signature hexBytes         `json:"signature"`
+}
+
+type beaconBlockJson struct {
+	Slot          uint64String         `json:"slot"`
+	ProposerIndex uint64String         `json:"proposer_index"`
+	ParentRoot    hexBytes             `json:"parent_root"`
+	StateRoot     hexBytes             `json:"state_root"`
+	Body          *beaconBlockBodyJson `json:"body"`
+}
+
+type beaconBlockBodyJson struct {
+	RandaoReveal      hexBytes                   `json:"randao_reveal"`
+	Eth1Data          *eth1DataJson              `json:"eth1_data"`
+	Graffiti          hexBytes                   `json:"graffiti"`
+	ProposerSlashings []*proposerSlashingJson    `json:"proposer_slashings"`
+	AttesterSlashings []*attesterSlashingJson    `json:"attester_slashings"`
+	Attestations      []*attestationJson         `json:"attestations"`
+	Deposits          []*depositJson             `json:"deposits"`
+	VoluntaryExits    []*signedVoluntaryExitJson `json:"voluntary_exits"`
+}
+
+type signedBeaconBlockAltairJson struct {
+	Message   *beaconBlockAltairJson `json:"message"`
+	Signature hexBytes               `json:"signature"`
+}
+
+type beaconBlockAltairJson struct {
+	Slot          uint64String               `json:"slot"`
+	ProposerIndex uint64String               `json:"proposer_index"`
+	ParentRoot    hexBytes                   `json:"parent_root"`
+	StateRoot     hexBytes                   `json:"state_root"`
+	Body          *beaconBlockBodyAltairJson `json:"body"`
+}
+
+type beaconBlockBodyAltairJson struct {
+	RandaoReveal      hexBytes                   `json:"randao_reveal"`
+	Eth1Data          *eth1DataJson              `json:"eth1_data"`
+	Graffiti          hexBytes                   `json:"graffiti"`
+	ProposerSlashings []*proposerSlashingJson    `json:"proposer_slashings"`
+	AttesterSlashings []*attesterSlashingJson    `json:"attester_slashings"`
+	Attestations      []*attestationJson         `json:"attestations"`
+	Deposits          []*depositJson             `json:"deposits"`
+	VoluntaryExits    []*signedVoluntaryExitJson `json:"voluntary_exits"`
+	SyncAggregate     *syncAggregateJson         `json:"sync_aggregate"`
+}
+
+type syncAggregateJson struct {
+	SyncCommitteeBits      hexBytes `json:"sync_committee_bits"`
+	SyncCommitteeSignature hexBytes `json:"sync_committee_signature"`
+}
+
+type signedBeaconBlockHeaderJson struct {
+	Header    *beaconBlockHeaderJson `json:"message"`
+	Signature hexBytes               `json:"signature"`
+}
+
+type beaconBlockHeaderJson struct {
+	Slot          uint64String `json:"slot"`
+	ProposerIndex uint64String `json:"proposer_index"`
+	ParentRoot    hexBytes     `json:"parent_root"`
+	StateRoot     hexBytes     `json:"state_root"`
+	BodyRoot      hexBytes     `json:"body_root"`
+}
+
+type eth1DataJson struct {
+	DepositRoot  hexBytes     `json:"deposit_root"`
+	DepositCount uint64String `json:"deposit_count"`
+	BlockHash    hexBytes     `json:"block_hash"`
+}
+
+type proposerSlashingJson struct {
+	Header_1 *signedBeaconBlockHeaderJson `json:"signed_header_1"`
+	Header_2 *signedBeaconBlockHeaderJson `json:"signed_header_2"`
+}
+
+type attesterSlashingJson struct {
+	Attestation_1 *indexedAttestationJson `json:"attestation_1"`
+	Attestation_2 *indexedAttestationJson `json:"attestation_2"`
+}
+
+type indexedAttestationJson struct {
+	AttestingIndices []uint64String       `json:"attesting_indices"`
+	Data             *attestationDataJson `json:"data"`
+	Signature        hexBytes             `json:"signature"`
+}
+
+type attestationJson struct {
+	AggregationBits hexBytes             `json:"aggregation_bits"`
+	Data            *attestationDataJson `json:"data"`
+	Signature       hexBytes             `json:"signature"`
+}
+
+type attestationDataJson struct {
+	Slot            uint64String    `json:"slot"`
+	CommitteeIndex  uint64String    `json:"index"`
+	BeaconBlockRoot hexBytes        `json:"beacon_block_root"`
+	Source          *checkpointJson `json:"source"`
+	Target          *checkpointJson `json:"target"`
+}
+
+type depositJson struct {
+	Proof []hexBytes        `json:"proof"`
+	Data  *deposit_DataJson `json:"data"`
+}
+
+type deposit_DataJson struct {
+	PublicKey             hexBytes     `json:"pubkey"`
+	WithdrawalCredentials hexBytes     `json:"withdrawal_credentials"`
+	Amount                uint64String `json:"amount"`
+	Signature             hexBytes     `json:"signature"`
+}
+
+type signedVoluntaryExitJson struct {
+	Exit      *voluntaryExitJson `json:"message"`
+	Signature hexBytes           `json:"signature"`
+}
+
+type voluntaryExitJson struct {
+	Epoch          uint64String `json:"epoch"`
+	ValidatorIndex uint64String `json:"validator_index"`
+}
+
+type signedAggregateAttestationAndProofJson struct {
+	Message   *aggregateAttestationAndProofJson `json:"message"`
+	Signature hexBytes                          `json:"signature"`
+}
+
+type aggregateAttestationAndProofJson struct {
+	AggregatorIndex uint64String     `json:"aggregator_index"`
+	Aggregate       *attestationJson `json:"aggregate"`
+	SelectionProof  hexBytes         `json:"selection_proof"`
+}
+
+type syncCommitteeMessageJson struct {
+	Slot            uint64String `json:"slot"`
+	BeaconBlockRoot hexBytes     `json:"beacon_block_root"`
+	ValidatorIndex  uint64String `json:"validator_index"`
+	Signature       hexBytes     `json:"signature"`
+}
+
+type signedContributionAndProofJson struct {
+	Message   *contributionAndProofJson `json:"message"`
+	Signature hexBytes                  `json:"signature"`
+}
+
+type contributionAndProofJson struct {
+	AggregatorIndex uint64String                   `json:"aggregator_index"`
+	Contribution    *syncCommitteeContributionJson `json:"contribution"`
+	SelectionProof  hexBytes                       `json:"selection_proof"`
+}
+
+type syncCommitteeContributionJson struct {
+	Slot              uint64String `json:"slot"`
+	BeaconBlockRoot   hexBytes     `json:"beacon_block_root"`
+	SubcommitteeIndex uint64String `json:"subcommittee_index"`
+	AggregationBits   hexBytes     `json:"aggregation_bits"`
+	Signature         hexBytes     `json:"signature"`
+}
@@ -0,0 +1,155 @@
+package beaconapi
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	genesisPath                 = "/eth/v1/beacon/genesis"
+	depositContractPath         = "/eth/v1/config/deposit_contract"
+	syncingPath                 = "/eth/v1/node/syncing"
+	peersPath                   = "/eth/v1/node/peers"
+	headHeaderPath              = "/eth/v1/beacon/headers/head"
+	headFinalityCheckpointsPath = "/eth/v1/beacon/states/head/finality_checkpoints"
+)
+
+// ErrNotSupported is returned for the calls of the validator client which have no
+// equivalent in the Beacon API.
+var ErrNotSupported = errors.New("not supported by the beacon API")
+
+// GetSyncStatus returns whether the beacon node is syncing.
+func (c *Client) GetSyncStatus(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	resp := &syncingResponseJson{}
+	if err := c.get(ctx, syncingPath, nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no sync status in response")
+	}
+	return &ethpb.SyncStatus{Syncing: resp.Data.IsSyncing}, nil
+}
+
+// GetGenesis returns the genesis time and validators root of the chain, along with
+// the address of the deposit contract.
+func (c *Client) GetGenesis(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.Genesis, error) {
+	genesis, err := c.genesisData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &depositContractResponseJson{}
+	if err := c.get(ctx, depositContractPath, nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no deposit contract in response")
+	}
+	return &ethpb.Genesis{
+		GenesisTime:            &timestamppb.Timestamp{Seconds: int64(genesis.GenesisTime)},
+		DepositContractAddress: resp.Data.Address,
+		GenesisValidatorsRoot:  genesis.GenesisValidatorsRoot,
+	}, nil
+}
+
+// ListPeers returns the peers the beacon node is connected to.
+func (c *Client) ListPeers(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.Peers, error) {
+	resp := &peersResponseJson{}
+	if err := c.get(ctx, peersPath, url.Values{"state": []string{"connected"}}, resp); err != nil {
+		return nil, err
+	}
+	peers := make([]*ethpb.Peer, len(resp.Data))
+	for i, p := range resp.Data {
+		peers[i] = &ethpb.Peer{
+			Address:         p.Address,
+			Direction:       ethpb.PeerDirection(ethpb.PeerDirection_value[strings.ToUpper(p.Direction)]),
+			ConnectionState: ethpb.ConnectionState(ethpb.ConnectionState_value[strings.ToUpper(p.State)]),
+			PeerId:          p.PeerId,
+			Enr:             p.Enr,
+		}
+	}
+	return &ethpb.Peers{Peers: peers}, nil
+}
+
+// GetChainHead returns the head block of the beacon node, along with its finality checkpoints.
+func (c *Client) GetChainHead(ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	headerResp := &blockHeaderResponseJson{}
+	if err := c.get(ctx, headHeaderPath, nil, headerResp); err != nil {
+		return nil, err
+	}
+	if headerResp.Data == nil || headerResp.Data.Header == nil || headerResp.Data.Header.Header == nil {
+		return nil, errors.New("no head block header in response")
+	}
+	checkpointsResp := &stateFinalityCheckpointResponseJson{}
+	if err := c.get(ctx, headFinalityCheckpointsPath, nil, checkpointsResp); err != nil {
+		return nil, err
+	}
+	checkpoints := checkpointsResp.Data
+	if checkpoints == nil || checkpoints.Finalized == nil || checkpoints.CurrentJustified == nil || checkpoints.PreviousJustified == nil {
+		return nil, errors.New("no finality checkpoints in response")
+	}
+	finalizedSlot, err := core.StartSlot(types.Epoch(checkpoints.Finalized.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	justifiedSlot, err := core.StartSlot(types.Epoch(checkpoints.CurrentJustified.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	prevJustifiedSlot, err := core.StartSlot(types.Epoch(checkpoints.PreviousJustified.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	headSlot := types.Slot(headerResp.Data.Header.Header.Slot)
+	return &ethpb.ChainHead{
+		HeadSlot:                   headSlot,
+		HeadEpoch:                  core.SlotToEpoch(headSlot),
+		HeadBlockRoot:              headerResp.Data.Root,
+		FinalizedSlot:              finalizedSlot,
+		FinalizedEpoch:             types.Epoch(checkpoints.Finalized.Epoch),
+		FinalizedBlockRoot:         checkpoints.Finalized.Root,
+		JustifiedSlot:              justifiedSlot,
+		JustifiedEpoch:             types.Epoch(checkpoints.CurrentJustified.Epoch),
+		JustifiedBlockRoot:         checkpoints.CurrentJustified.Root,
+		PreviousJustifiedSlot:      prevJustifiedSlot,
+		PreviousJustifiedEpoch:     types.Epoch(checkpoints.PreviousJustified.Epoch),
+		PreviousJustifiedBlockRoot: checkpoints.PreviousJustified.Root,
+	}, nil
+}
+
+// GetValidatorPerformance is not supported, as the Beacon API has no equivalent endpoint.
+func (c *Client) GetValidatorPerformance(
+	_ context.Context, _ *ethpb.ValidatorPerformanceRequest, _ ...grpc.CallOption,
+) (*ethpb.ValidatorPerformanceResponse, error) {
+	return nil, errors.Wrap(ErrNotSupported, "validator performance")
+}
+
+// genesisData returns the genesis of the chain, which is only requested from the
+// beacon node until it is known.
+func (c *Client) genesisData(ctx context.Context) (*genesisJson, error) {
+	c.genesisLock.RLock()
+	genesis := c.genesis
+	c.genesisLock.RUnlock()
+	if genesis != nil {
+		return genesis, nil
+	}
+	resp := &genesisResponseJson{}
+	if err := c.get(ctx, genesisPath, nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no genesis in response")
+	}
+	c.genesisLock.Lock()
+	c.genesis = resp.Data
+	c.genesisLock.Unlock()
+	return resp.Data, nil
+}
@@ -0,0 +1,255 @@
+package beaconapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	eventsPath    = "/eth/v1/events"
+	blocksV2Path  = "/eth/v2/beacon/blocks/"
+	blockTopic    = "block"
+	maxEventBytes = 1 << 20
+)
+
+// chainStartPollInterval is the interval at which the beacon node is polled for the
+// genesis of the chain until it starts.
+var chainStartPollInterval = 10 * time.Second
+
+// clientStream implements the methods of grpc.ClientStream for the streams of the
+// client, which are built on top of the Beacon API rather than gRPC streams.
+type clientStream struct {
+	ctx context.Context
+}
+
+// Header is not supported and returns no metadata.
+func (s *clientStream) Header() (metadata.MD, error) {
+	return nil, nil
+}
+
+// Trailer is not supported and returns no metadata.
+func (s *clientStream) Trailer() metadata.MD {
+	return nil
+}
+
+// CloseSend does nothing, as no messages are ever sent on the stream.
+func (s *clientStream) CloseSend() error {
+	return nil
+}
+
+// Context returns the context of the stream.
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg is not supported.
+func (s *clientStream) SendMsg(_ interface{}) error {
+	return errors.Wrap(ErrNotSupported, "sending messages on streams")
+}
+
+// RecvMsg is not supported, messages are received with the typed Recv methods instead.
+func (s *clientStream) RecvMsg(_ interface{}) error {
+	return errors.Wrap(ErrNotSupported, "receiving untyped messages on streams")
+}
+
+// WaitForChainStart returns a stream which receives the genesis of the chain once it
+// is known to the beacon node.
+func (c *Client) WaitForChainStart(
+	ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	return &chainStartStream{clientStream: clientStream{ctx: ctx}, client: c}, nil
+}
+
+type chainStartStream struct {
+	clientStream
+	client *Client
+}
+
+// Recv polls the beacon node until the chain has started.
+func (s *chainStartStream) Recv() (*ethpb.ChainStartResponse, error) {
+	for {
+		genesis, err := s.client.genesisData(s.ctx)
+		if err == nil {
+			return &ethpb.ChainStartResponse{
+				Started:               true,
+				GenesisTime:           uint64(genesis.GenesisTime),
+				GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+			}, nil
+		}
+		if !isNotFound(err) {
+			return nil, err
+		}
+		log.Debug("Chain has not started yet")
+		select {
+		case <-time.After(chainStartPollInterval):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+}
+
+// WaitForActivation returns a stream which receives the statuses of the validators
+// once per slot.
+func (c *Client) WaitForActivation(
+	ctx context.Context, in *ethpb.ValidatorActivationRequest, _ ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	return &activationStream{clientStream: clientStream{ctx: ctx}, client: c, pubKeys: in.PublicKeys}, nil
+}
+
+type activationStream struct {
+	clientStream
+	client  *Client
+	pubKeys [][]byte
+	polled  bool
+}
+
+// Recv returns the statuses of the validators, waiting for a slot between consecutive calls.
+func (s *activationStream) Recv() (*ethpb.ValidatorActivationResponse, error) {
+	if s.polled {
+		select {
+		case <-time.After(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second):
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+	}
+	s.polled = true
+	resp, err := s.client.MultipleValidatorStatus(s.ctx, &ethpb.MultipleValidatorStatusRequest{PublicKeys: s.pubKeys})
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*ethpb.ValidatorActivationResponse_Status, len(resp.Statuses))
+	for i, status := range resp.Statuses {
+		statuses[i] = &ethpb.ValidatorActivationResponse_Status{
+			PublicKey: resp.PublicKeys[i],
+			Status:    status,
+			Index:     resp.Indices[i],
+		}
+	}
+	return &ethpb.ValidatorActivationResponse{Statuses: statuses}, nil
+}
+
+// StreamBlocksAltair returns a stream which receives the blocks imported by the beacon node,
+// as announced by the block events of the Beacon API.
+func (c *Client) StreamBlocksAltair(
+	ctx context.Context, _ *ethpb.StreamBlocksRequest, _ ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+eventsPath+"?topics="+blockTopic, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not subscribe to block events")
+	}
+	if resp.StatusCode != http.StatusOK {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+		return nil, errors.Errorf("could not subscribe to block events: %s", resp.Status)
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventBytes)
+	return &blocksStream{clientStream: clientStream{ctx: ctx}, client: c, body: resp.Body, scanner: scanner}, nil
+}
+
+type blocksStream struct {
+	clientStream
+	client  *Client
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Recv waits for the next block event, and returns the announced block.
+func (s *blocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	for {
+		event, data, err := s.nextEvent()
+		if err != nil {
+			if closeErr := s.body.Close(); closeErr != nil {
+				log.WithError(closeErr).Error("Could not close response body")
+			}
+			return nil, err
+		}
+		if event != blockTopic {
+			continue
+		}
+		blockEvent := &blockEventJson{}
+		if err := json.Unmarshal(data, blockEvent); err != nil {
+			return nil, errors.Wrap(err, "could not decode block event")
+		}
+		return s.client.signedBlock(s.ctx, blockEvent.Block)
+	}
+}
+
+// nextEvent reads the next server-sent event of the stream, and returns its type and data.
+func (s *blocksStream) nextEvent() (string, []byte, error) {
+	var event string
+	var data bytes.Buffer
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				return event, data.Bytes(), nil
+			}
+			event = ""
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if err := s.scanner.Err(); err != nil {
+		return "", nil, errors.Wrap(err, "could not read events")
+	}
+	return "", nil, io.EOF
+}
+
+// signedBlock returns the signed block with the given root.
+func (c *Client) signedBlock(ctx context.Context, root []byte) (*ethpb.StreamBlocksResponse, error) {
+	resp := &blockResponseJson{}
+	if err := c.get(ctx, blocksV2Path+fmt.Sprintf("%#x", root), nil, resp); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(resp.Version) {
+	case phase0Version:
+		blk := &signedBeaconBlockJson{}
+		if err := json.Unmarshal(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase 0 block")
+		}
+		return &ethpb.StreamBlocksResponse{
+			Block: &ethpb.StreamBlocksResponse_Phase0Block{
+				Phase0Block: &ethpb.SignedBeaconBlock{Block: blockFromJson(blk.Message), Signature: blk.Signature},
+			},
+		}, nil
+	case altairVersion:
+		blk := &signedBeaconBlockAltairJson{}
+		if err := json.Unmarshal(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.StreamBlocksResponse{
+			Block: &ethpb.StreamBlocksResponse_AltairBlock{
+				AltairBlock: &ethpb.SignedBeaconBlockAltair{Block: altairBlockFromJson(blk.Message), Signature: blk.Signature},
+			},
+		}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
@@ -0,0 +1,427 @@
+package beaconapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/p2putils"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	produceBlockPath            = "/eth/v2/validator/blocks/"
+	blocksPath                  = "/eth/v1/beacon/blocks"
+	headBlockRootPath           = "/eth/v1/beacon/blocks/head/root"
+	attestationDataPath         = "/eth/v1/validator/attestation_data"
+	attestationsPoolPath        = "/eth/v1/beacon/pool/attestations"
+	aggregateAttestationPath    = "/eth/v1/validator/aggregate_attestation"
+	aggregateAndProofsPath      = "/eth/v1/validator/aggregate_and_proofs"
+	voluntaryExitsPoolPath      = "/eth/v1/beacon/pool/voluntary_exits"
+	syncCommitteesPoolPath      = "/eth/v1/beacon/pool/sync_committees"
+	syncContributionPath        = "/eth/v1/validator/sync_committee_contribution"
+	contributionAndProofsPath   = "/eth/v1/validator/contribution_and_proofs"
+	validatorBalancesPathFormat = "/eth/v1/beacon/states/%d/validator_balances"
+	livenessPath                = "/eth/v1/validator/liveness/"
+)
+
+// The versions of the blocks of the Beacon API.
+const (
+	phase0Version = "phase0"
+	altairVersion = "altair"
+)
+
+// DomainData returns the signature domain of an epoch, computed from the fork schedule
+// of the configuration and the genesis validators root of the beacon node.
+func (c *Client) DomainData(ctx context.Context, in *ethpb.DomainRequest, _ ...grpc.CallOption) (*ethpb.DomainResponse, error) {
+	genesis, err := c.genesisData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fork, err := p2putils.Fork(in.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := helpers.Domain(fork, in.Epoch, bytesutil.ToBytes4(in.Domain), genesis.GenesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.DomainResponse{SignatureDomain: domain}, nil
+}
+
+// GetBlock returns a phase 0 block to propose at the requested slot.
+func (c *Client) GetBlock(ctx context.Context, in *ethpb.BlockRequest, _ ...grpc.CallOption) (*ethpb.BeaconBlock, error) {
+	blk, err := c.GetBeaconBlock(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	phase0Blk := blk.GetPhase0()
+	if phase0Blk == nil {
+		return nil, errors.New("beacon node did not produce a phase 0 block")
+	}
+	return phase0Blk, nil
+}
+
+// GetBeaconBlock returns a block to propose at the requested slot, in the version of
+// the fork of the slot.
+func (c *Client) GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest, _ ...grpc.CallOption) (*ethpb.GenericBeaconBlock, error) {
+	query := url.Values{
+		"randao_reveal": []string{fmt.Sprintf("%#x", in.RandaoReveal)},
+		"graffiti":      []string{fmt.Sprintf("%#x", in.Graffiti)},
+	}
+	resp := &produceBlockResponseJson{}
+	if err := c.get(ctx, produceBlockPath+strconv.FormatUint(uint64(in.Slot), 10), query, resp); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(resp.Version) {
+	case phase0Version:
+		blk := &beaconBlockJson{}
+		if err := json.Unmarshal(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode phase 0 block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Phase0{Phase0: blockFromJson(blk)}}, nil
+	case altairVersion:
+		blk := &beaconBlockAltairJson{}
+		if err := json.Unmarshal(resp.Data, blk); err != nil {
+			return nil, errors.Wrap(err, "could not decode altair block")
+		}
+		return &ethpb.GenericBeaconBlock{Block: &ethpb.GenericBeaconBlock_Altair{Altair: altairBlockFromJson(blk)}}, nil
+	default:
+		return nil, errors.Errorf("unsupported block version %s", resp.Version)
+	}
+}
+
+// ProposeBlock publishes a signed phase 0 block.
+func (c *Client) ProposeBlock(ctx context.Context, in *ethpb.SignedBeaconBlock, _ ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	return c.ProposeBeaconBlock(ctx, &ethpb.GenericSignedBeaconBlock{
+		Block: &ethpb.GenericSignedBeaconBlock_Phase0{Phase0: in},
+	})
+}
+
+// ProposeBeaconBlock publishes a signed block.
+func (c *Client) ProposeBeaconBlock(
+	ctx context.Context, in *ethpb.GenericSignedBeaconBlock, _ ...grpc.CallOption,
+) (*ethpb.ProposeResponse, error) {
+	var req interface{}
+	var root [32]byte
+	var err error
+	switch b := in.Block.(type) {
+	case *ethpb.GenericSignedBeaconBlock_Phase0:
+		if b.Phase0 == nil || b.Phase0.Block == nil {
+			return nil, errors.New("nil block")
+		}
+		req = &signedBeaconBlockJson{Message: blockToJson(b.Phase0.Block), Signature: b.Phase0.Signature}
+		root, err = b.Phase0.Block.HashTreeRoot()
+	case *ethpb.GenericSignedBeaconBlock_Altair:
+		if b.Altair == nil || b.Altair.Block == nil {
+			return nil, errors.New("nil block")
+		}
+		req = &signedBeaconBlockAltairJson{Message: altairBlockToJson(b.Altair.Block), Signature: b.Altair.Signature}
+		root, err = b.Altair.Block.HashTreeRoot()
+	default:
+		return nil, errors.Errorf("unsupported block type %T", in.Block)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block root")
+	}
+	if err := c.post(ctx, blocksPath, req, nil); err != nil {
+		return nil, err
+	}
+	return &ethpb.ProposeResponse{BlockRoot: root[:]}, nil
+}
+
+// GetAttestationData returns the attestation data to sign for a committee at a slot.
+func (c *Client) GetAttestationData(
+	ctx context.Context, in *ethpb.AttestationDataRequest, _ ...grpc.CallOption,
+) (*ethpb.AttestationData, error) {
+	query := url.Values{
+		"slot":            []string{strconv.FormatUint(uint64(in.Slot), 10)},
+		"committee_index": []string{strconv.FormatUint(uint64(in.CommitteeIndex), 10)},
+	}
+	resp := &produceAttestationDataResponseJson{}
+	if err := c.get(ctx, attestationDataPath, query, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no attestation data in response")
+	}
+	return attestationDataFromJson(resp.Data), nil
+}
+
+// ProposeAttestation publishes a signed attestation.
+func (c *Client) ProposeAttestation(ctx context.Context, in *ethpb.Attestation, _ ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	if in.Data == nil {
+		return nil, errors.New("nil attestation data")
+	}
+	root, err := in.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	if err := c.post(ctx, attestationsPoolPath, []*attestationJson{attestationToJson(in)}, nil); err != nil {
+		return nil, err
+	}
+	return &ethpb.AttestResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// SubmitAggregateSelectionProof returns the aggregate attestation of a committee at a slot,
+// for the aggregator to sign.
+func (c *Client) SubmitAggregateSelectionProof(
+	ctx context.Context, in *ethpb.AggregateSelectionRequest, _ ...grpc.CallOption,
+) (*ethpb.AggregateSelectionResponse, error) {
+	index, err := c.validatorIndex(ctx, in.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.GetAttestationData(ctx, &ethpb.AttestationDataRequest{Slot: in.Slot, CommitteeIndex: in.CommitteeIndex})
+	if err != nil {
+		return nil, err
+	}
+	dataRoot, err := data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	query := url.Values{
+		"attestation_data_root": []string{fmt.Sprintf("%#x", dataRoot)},
+		"slot":                  []string{strconv.FormatUint(uint64(in.Slot), 10)},
+	}
+	resp := &aggregateAttestationResponseJson{}
+	if err := c.get(ctx, aggregateAttestationPath, query, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no aggregate attestation in response")
+	}
+	return &ethpb.AggregateSelectionResponse{
+		AggregateAndProof: &ethpb.AggregateAttestationAndProof{
+			AggregatorIndex: index,
+			Aggregate:       attestationFromJson(resp.Data),
+			SelectionProof:  in.SlotSignature,
+		},
+	}, nil
+}
+
+// SubmitSignedAggregateSelectionProof publishes a signed aggregate and proof.
+func (c *Client) SubmitSignedAggregateSelectionProof(
+	ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, _ ...grpc.CallOption,
+) (*ethpb.SignedAggregateSubmitResponse, error) {
+	agg := in.SignedAggregateAndProof
+	if agg == nil || agg.Message == nil || agg.Message.Aggregate == nil || agg.Message.Aggregate.Data == nil {
+		return nil, errors.New("nil aggregate and proof")
+	}
+	root, err := agg.Message.Aggregate.Data.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute attestation data root")
+	}
+	req := []*signedAggregateAttestationAndProofJson{aggregateAndProofToJson(agg)}
+	if err := c.post(ctx, aggregateAndProofsPath, req, nil); err != nil {
+		return nil, err
+	}
+	return &ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// ProposeExit publishes a signed voluntary exit.
+func (c *Client) ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, _ ...grpc.CallOption) (*ethpb.ProposeExitResponse, error) {
+	if in.Exit == nil {
+		return nil, errors.New("nil voluntary exit")
+	}
+	root, err := in.Exit.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute voluntary exit root")
+	}
+	if err := c.post(ctx, voluntaryExitsPoolPath, voluntaryExitToJson(in), nil); err != nil {
+		return nil, err
+	}
+	return &ethpb.ProposeExitResponse{ExitRoot: root[:]}, nil
+}
+
+// CheckDoppelGanger reports the validators whose balance increased between the starts of
+// the two epochs preceding the current epoch, meaning that another instance of them is
+// attesting, as long as their latest attestation is older than these epochs.
+func (c *Client) CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest, _ ...grpc.CallOption) (*ethpb.DoppelGangerResponse, error) {
+	resp := &ethpb.DoppelGangerResponse{Responses: []*ethpb.DoppelGangerResponse_ValidatorResponse{}}
+	if len(in.ValidatorRequests) == 0 {
+		return resp, nil
+	}
+	head, err := c.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	currEpoch := head.HeadEpoch
+	previousEpoch, err := currEpoch.SafeSub(1)
+	if err != nil {
+		previousEpoch = currEpoch
+	}
+	olderEpoch, err := previousEpoch.SafeSub(1)
+	if err != nil {
+		olderEpoch = previousEpoch
+	}
+
+	pubKeys := make([][]byte, 0, len(in.ValidatorRequests))
+	for _, req := range in.ValidatorRequests {
+		// As attestations may be included up to the next epoch, duplicates can only be
+		// detected from the balance changes of the epochs after the latest attestation.
+		if req.Epoch+2 >= currEpoch {
+			resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
+				PublicKey:       req.PublicKey,
+				DuplicateExists: false,
+			})
+			continue
+		}
+		pubKeys = append(pubKeys, req.PublicKey)
+	}
+	if len(pubKeys) == 0 {
+		return resp, nil
+	}
+	vals, err := c.validators(ctx, pubKeys, nil)
+	if err != nil {
+		return nil, err
+	}
+	indices := make([]types.ValidatorIndex, 0, len(vals))
+	for _, val := range vals {
+		indices = append(indices, types.ValidatorIndex(val.Index))
+	}
+	baseBalances, err := c.balancesAtEpoch(ctx, olderEpoch, indices)
+	if err != nil {
+		return nil, err
+	}
+	nextBalances, err := c.balancesAtEpoch(ctx, previousEpoch, indices)
+	if err != nil {
+		return nil, err
+	}
+	for _, pubKey := range pubKeys {
+		val, ok := vals[bytesutil.ToBytes48(pubKey)]
+		if !ok {
+			// Ignore validators unknown to the beacon node.
+			continue
+		}
+		index := types.ValidatorIndex(val.Index)
+		resp.Responses = append(resp.Responses, &ethpb.DoppelGangerResponse_ValidatorResponse{
+			PublicKey:       pubKey,
+			DuplicateExists: nextBalances[index] > baseBalances[index],
+		})
+	}
+	return resp, nil
+}
+
+// GetLiveness returns whether the beacon node saw attestations or blocks of the validators
+// in the requested epoch.
+func (c *Client) GetLiveness(ctx context.Context, in *ethpb.LivenessRequest, _ ...grpc.CallOption) (*ethpb.LivenessResponse, error) {
+	indices := make([]uint64String, len(in.Indices))
+	for i, index := range in.Indices {
+		indices[i] = uint64String(index)
+	}
+	resp := &livenessResponseJson{}
+	if err := c.post(ctx, livenessPath+epochString(in.Epoch), indices, resp); err != nil {
+		return nil, err
+	}
+	statuses := make([]*ethpb.ValidatorLiveness, len(resp.Data))
+	for i, l := range resp.Data {
+		statuses[i] = &ethpb.ValidatorLiveness{Index: types.ValidatorIndex(l.Index), IsLive: l.IsLive}
+	}
+	return &ethpb.LivenessResponse{Statuses: statuses}, nil
+}
+
+// GetSyncMessageBlockRoot returns the head block root for sync committee messages.
+func (c *Client) GetSyncMessageBlockRoot(
+	ctx context.Context, _ *emptypb.Empty, _ ...grpc.CallOption,
+) (*ethpb.SyncMessageBlockRootResponse, error) {
+	root, err := c.headBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ethpb.SyncMessageBlockRootResponse{Root: root}, nil
+}
+
+// SubmitSyncMessage publishes a signed sync committee message.
+func (c *Client) SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if err := c.post(ctx, syncCommitteesPoolPath, []*syncCommitteeMessageJson{syncCommitteeMessageToJson(in)}, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetSyncCommitteeContribution returns the sync committee contribution of a subcommittee
+// for the head block at a slot, for the aggregator to sign.
+func (c *Client) GetSyncCommitteeContribution(
+	ctx context.Context, in *ethpb.SyncCommitteeContributionRequest, _ ...grpc.CallOption,
+) (*ethpb.SyncCommitteeContribution, error) {
+	root, err := c.headBlockRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{
+		"slot":               []string{strconv.FormatUint(uint64(in.Slot), 10)},
+		"subcommittee_index": []string{strconv.FormatUint(in.SubnetId, 10)},
+		"beacon_block_root":  []string{fmt.Sprintf("%#x", root)},
+	}
+	resp := &produceSyncCommitteeContributionResponseJson{}
+	if err := c.get(ctx, syncContributionPath, query, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no sync committee contribution in response")
+	}
+	return contributionFromJson(resp.Data), nil
+}
+
+// SubmitSignedContributionAndProof publishes a signed sync committee contribution and proof.
+func (c *Client) SubmitSignedContributionAndProof(
+	ctx context.Context, in *ethpb.SignedContributionAndProof, _ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	req := []*signedContributionAndProofJson{contributionAndProofToJson(in)}
+	if err := c.post(ctx, contributionAndProofsPath, req, nil); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (c *Client) headBlockRoot(ctx context.Context) ([]byte, error) {
+	resp := &blockRootResponseJson{}
+	if err := c.get(ctx, headBlockRootPath, nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no block root in response")
+	}
+	return resp.Data.Root, nil
+}
+
+// balancesAtEpoch returns the balances of validators in the state at the start of an epoch.
+func (c *Client) balancesAtEpoch(
+	ctx context.Context, epoch types.Epoch, indices []types.ValidatorIndex,
+) (map[types.ValidatorIndex]uint64, error) {
+	slot, err := core.StartSlot(epoch)
+	if err != nil {
+		return nil, err
+	}
+	balances := make(map[types.ValidatorIndex]uint64, len(indices))
+	for start := 0; start < len(indices); start += validatorsBatchSize {
+		end := start + validatorsBatchSize
+		if end > len(indices) {
+			end = len(indices)
+		}
+		ids := make([]string, 0, end-start)
+		for _, idx := range indices[start:end] {
+			ids = append(ids, strconv.FormatUint(uint64(idx), 10))
+		}
+		resp := &validatorBalancesResponseJson{}
+		query := url.Values{"id": []string{strings.Join(ids, ",")}}
+		if err := c.get(ctx, fmt.Sprintf(validatorBalancesPathFormat, slot), query, resp); err != nil {
+			return nil, err
+		}
+		for _, b := range resp.Data {
+			balances[types.ValidatorIndex(b.Index)] = uint64(b.Balance)
+		}
+	}
+	return balances, nil
+}
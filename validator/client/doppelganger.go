@@ -0,0 +1,219 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// doppelgangerStatus tracks the liveness checks of a validator key which is held back from
+// signing by doppelganger protection.
+type doppelgangerStatus struct {
+	// started is set once the first epoch to check the key in is known.
+	started bool
+	// nextEpoch is the earliest epoch whose liveness of the key has not been checked yet.
+	nextEpoch types.Epoch
+	// remaining is the number of epochs the key still has to be found not live in.
+	remaining uint64
+}
+
+// doppelgangerEnabled returns true if newly loaded keys are held back from signing until
+// they are found not live in the network.
+func (v *validator) doppelgangerEnabled() bool {
+	return featureconfig.Get().EnableDoppelGanger && v.doppelgangerEpochs > 0
+}
+
+// updateDoppelgangerKeys starts tracking the keys which were not loaded before, and stops
+// tracking the keys which are no longer loaded.
+func (v *validator) updateDoppelgangerKeys(pubKeys [][48]byte) {
+	if !v.doppelgangerEnabled() {
+		return
+	}
+	v.doppelgangerLock.Lock()
+	defer v.doppelgangerLock.Unlock()
+
+	loaded := make(map[[48]byte]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		loaded[pubKey] = true
+		if _, ok := v.doppelgangerKeys[pubKey]; ok {
+			continue
+		}
+		v.doppelgangerKeys[pubKey] = &doppelgangerStatus{remaining: v.doppelgangerEpochs}
+		log.WithField(
+			"publicKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+		).Info("Holding back validator key from signing until doppelganger protection completes")
+	}
+	for pubKey := range v.doppelgangerKeys {
+		if !loaded[pubKey] {
+			delete(v.doppelgangerKeys, pubKey)
+		}
+	}
+}
+
+// heldByDoppelganger returns true if the key must not sign anything, as it has not been
+// found not live in the network for enough epochs yet.
+func (v *validator) heldByDoppelganger(pubKey [48]byte) bool {
+	if !v.doppelgangerEnabled() {
+		return false
+	}
+	v.doppelgangerLock.RLock()
+	defer v.doppelgangerLock.RUnlock()
+
+	s, ok := v.doppelgangerKeys[pubKey]
+	return !ok || s.remaining > 0
+}
+
+// CheckDoppelGangerLiveness asks the beacon node whether the validator keys held back by
+// doppelganger protection were live in the previous epoch. Keys are checked starting from
+// the epoch after the one in which they were loaded, as a previous run of this validator
+// client may have signed with them in that epoch. The liveness of an epoch is only checked in
+// the last slot of the next epoch, as the attestations of an epoch may be included in blocks
+// until then. A key is released once it was found not live for the configured number of
+// epochs, and iface.ErrDoppelgangerDetected is returned as soon as any key is found live.
+func (v *validator) CheckDoppelGangerLiveness(ctx context.Context, slot types.Slot) error {
+	if !v.doppelgangerEnabled() {
+		return nil
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.CheckDoppelGangerLiveness")
+	defer span.End()
+
+	pubKeys, err := v.keyManager.FetchValidatingPublicKeys(ctx)
+	if err != nil {
+		return err
+	}
+	v.updateDoppelgangerKeys(pubKeys)
+	if v.duties == nil {
+		return nil
+	}
+
+	epoch := core.SlotToEpoch(slot)
+	indices := make(map[[48]byte]types.ValidatorIndex)
+	for _, duty := range v.duties.CurrentEpochDuties {
+		if duty == nil || duty.Status == ethpb.ValidatorStatus_UNKNOWN_STATUS || duty.Status == ethpb.ValidatorStatus_DEPOSITED {
+			continue
+		}
+		indices[bytesutil.ToBytes48(duty.PublicKey)] = duty.ValidatorIndex
+	}
+
+	// Collect the keys due for a check of the previous epoch.
+	var due [][48]byte
+	var dueIndices []types.ValidatorIndex
+	var unindexed [][]byte
+	v.doppelgangerLock.Lock()
+	for pubKey, s := range v.doppelgangerKeys {
+		if s.remaining == 0 {
+			continue
+		}
+		if !s.started {
+			s.started = true
+			s.nextEpoch = epoch + 1
+			continue
+		}
+		if !core.IsEpochEnd(slot) || epoch == 0 || s.nextEpoch > epoch-1 {
+			continue
+		}
+		due = append(due, pubKey)
+		if index, ok := indices[pubKey]; ok {
+			dueIndices = append(dueIndices, index)
+		} else {
+			copiedKey := pubKey
+			unindexed = append(unindexed, copiedKey[:])
+		}
+	}
+	v.doppelgangerLock.Unlock()
+	if len(due) == 0 {
+		return nil
+	}
+
+	// The duties may not cover every loaded key, so the indices of the remaining keys are
+	// resolved from the beacon state. Only keys confirmed to not be in the state yet are known
+	// to not be live without a liveness check.
+	notInState := make(map[[48]byte]bool, len(unindexed))
+	if len(unindexed) > 0 {
+		resp, err := v.validatorClient.MultipleValidatorStatus(ctx, &ethpb.MultipleValidatorStatusRequest{
+			PublicKeys: unindexed,
+		})
+		if err != nil {
+			return errors.Wrap(err, "could not get statuses of validators")
+		}
+		for i, s := range resp.Statuses {
+			if i >= len(resp.PublicKeys) || i >= len(resp.Indices) || s == nil {
+				break
+			}
+			pubKey := bytesutil.ToBytes48(resp.PublicKeys[i])
+			if s.Status == ethpb.ValidatorStatus_UNKNOWN_STATUS || s.Status == ethpb.ValidatorStatus_DEPOSITED {
+				notInState[pubKey] = true
+				continue
+			}
+			indices[pubKey] = resp.Indices[i]
+			dueIndices = append(dueIndices, resp.Indices[i])
+		}
+	}
+	req := &ethpb.LivenessRequest{Epoch: epoch - 1, Indices: dueIndices}
+
+	live := make(map[types.ValidatorIndex]bool, len(req.Indices))
+	checked := make(map[types.ValidatorIndex]bool, len(req.Indices))
+	if len(req.Indices) > 0 {
+		resp, err := v.validatorClient.GetLiveness(ctx, req)
+		if err != nil {
+			return errors.Wrap(err, "could not get liveness of validators")
+		}
+		for _, l := range resp.Statuses {
+			checked[l.Index] = true
+			live[l.Index] = l.IsLive
+		}
+	}
+
+	var duplicates [][]byte
+	for _, pubKey := range due {
+		if index, ok := indices[pubKey]; ok && live[index] {
+			ValidatorDoppelgangersDetectedVec.WithLabelValues(fmt.Sprintf("%#x", pubKey[:])).Inc()
+			copiedKey := pubKey
+			duplicates = append(duplicates, copiedKey[:])
+		}
+	}
+	if len(duplicates) > 0 {
+		return errors.Wrapf(
+			iface.ErrDoppelgangerDetected,
+			"validator keys %#x were live in epoch %d while held back from signing",
+			duplicates,
+			req.Epoch,
+		)
+	}
+
+	v.doppelgangerLock.Lock()
+	defer v.doppelgangerLock.Unlock()
+	for _, pubKey := range due {
+		s, ok := v.doppelgangerKeys[pubKey]
+		if !ok {
+			continue
+		}
+		// Keys which are not in the beacon state cannot be live, while the liveness of all
+		// other keys must have been checked.
+		if index, ok := indices[pubKey]; ok {
+			if !checked[index] {
+				continue
+			}
+		} else if !notInState[pubKey] {
+			continue
+		}
+		s.nextEpoch = epoch
+		s.remaining--
+		if s.remaining == 0 {
+			log.WithFields(logrus.Fields{
+				"publicKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+				"epoch":     req.Epoch,
+			}).Info("Doppelganger protection completed, validator key may now sign")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+)
+
+func setupDoppelgangerValidator(t *testing.T, client *mock.MockBeaconNodeValidatorClient) (*validator, [][48]byte) {
+	flgs := featureconfig.Get()
+	flgs.EnableDoppelGanger = true
+	reset := featureconfig.InitWithReset(flgs)
+	t.Cleanup(reset)
+
+	km := &mockKeymanager{keysMap: make(map[[48]byte]bls.SecretKey)}
+	duties := make([]*ethpb.DutiesResponse_Duty, 0, 2)
+	pubKeys := make([][48]byte, 0, 2)
+	for i := 0; i < 2; i++ {
+		privKey, err := bls.RandKey()
+		require.NoError(t, err)
+		pubKey := [48]byte{}
+		copy(pubKey[:], privKey.PublicKey().Marshal())
+		km.keysMap[pubKey] = privKey
+		pubKeys = append(pubKeys, pubKey)
+		duties = append(duties, &ethpb.DutiesResponse_Duty{
+			PublicKey:      pubKey[:],
+			ValidatorIndex: types.ValidatorIndex(i + 1),
+			Status:         ethpb.ValidatorStatus_ACTIVE,
+			AttesterSlot:   1,
+		})
+	}
+	v := &validator{
+		validatorClient:    client,
+		keyManager:         km,
+		duties:             &ethpb.DutiesResponse{Duties: duties, CurrentEpochDuties: duties},
+		doppelgangerEpochs: 2,
+		doppelgangerKeys:   make(map[[48]byte]*doppelgangerStatus),
+	}
+	return v, pubKeys
+}
+
+func epochSlot(epoch types.Epoch) types.Slot {
+	return params.BeaconConfig().SlotsPerEpoch.Mul(uint64(epoch))
+}
+
+func epochEndSlot(epoch types.Epoch) types.Slot {
+	return epochSlot(epoch+1) - 1
+}
+
+func TestCheckDoppelGangerLiveness_ReleasesKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hook := logTest.NewGlobal()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	ctx := context.Background()
+
+	// Keys are held back before and after being loaded, and the epoch in which they were
+	// loaded is not checked.
+	assert.Equal(t, true, v.heldByDoppelganger(pubKeys[0]))
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(10)))
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(10)))
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(11)))
+	roles, err := v.RolesAt(ctx, epochSlot(11)+2)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(roles))
+
+	for _, epoch := range []types.Epoch{11, 12} {
+		e := epoch
+		client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *ethpb.LivenessRequest, _ ...grpc.CallOption) (*ethpb.LivenessResponse, error) {
+				assert.Equal(t, e, req.Epoch)
+				assert.Equal(t, 2, len(req.Indices))
+				return &ethpb.LivenessResponse{Statuses: []*ethpb.ValidatorLiveness{{Index: 1}, {Index: 2}}}, nil
+			})
+		// Checks are only made in the last slot of the next epoch, and only once.
+		require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(e+1)))
+		require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(e+1)))
+		require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(e+1)))
+	}
+	assert.LogsContain(t, hook, "Doppelganger protection completed")
+	assert.Equal(t, false, v.heldByDoppelganger(pubKeys[0]))
+	assert.Equal(t, false, v.heldByDoppelganger(pubKeys[1]))
+	roles, err = v.RolesAt(ctx, epochSlot(13)+2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(roles))
+}
+
+func TestCheckDoppelGangerLiveness_KeyReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	v.doppelgangerKeys[pubKeys[0]] = &doppelgangerStatus{started: true}
+
+	client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{}, nil).Times(3)
+	_, err := v.HandleKeyReload(context.Background(), pubKeys)
+	require.NoError(t, err)
+	assert.Equal(t, false, v.heldByDoppelganger(pubKeys[0]))
+	assert.Equal(t, true, v.heldByDoppelganger(pubKeys[1]))
+
+	// Removed keys are checked again once loaded back.
+	_, err = v.HandleKeyReload(context.Background(), pubKeys[1:])
+	require.NoError(t, err)
+	_, err = v.HandleKeyReload(context.Background(), pubKeys)
+	require.NoError(t, err)
+	assert.Equal(t, true, v.heldByDoppelganger(pubKeys[0]))
+}
+
+func TestCheckDoppelGangerLiveness_Detected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	ctx := context.Background()
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(10)))
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(&ethpb.LivenessResponse{
+		Statuses: []*ethpb.ValidatorLiveness{{Index: 1}, {Index: 2, IsLive: true}},
+	}, nil)
+	err := v.CheckDoppelGangerLiveness(ctx, epochEndSlot(12))
+	require.ErrorContains(t, "were live in epoch 11", err)
+	assert.Equal(t, true, errors.Is(err, iface.ErrDoppelgangerDetected))
+	assert.Equal(t, true, v.heldByDoppelganger(pubKeys[1]))
+}
+
+func TestCheckDoppelGangerLiveness_RetriesOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	ctx := context.Background()
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(10)))
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(nil, errors.New("bad"))
+	err := v.CheckDoppelGangerLiveness(ctx, epochEndSlot(12))
+	require.ErrorContains(t, "could not get liveness of validators", err)
+	assert.Equal(t, false, errors.Is(err, iface.ErrDoppelgangerDetected))
+
+	// The keys are checked again on the next epoch.
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpb.LivenessRequest, _ ...grpc.CallOption) (*ethpb.LivenessResponse, error) {
+			assert.Equal(t, types.Epoch(12), req.Epoch)
+			return &ethpb.LivenessResponse{Statuses: []*ethpb.ValidatorLiveness{{Index: 1}, {Index: 2}}}, nil
+		})
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(13)))
+	assert.Equal(t, uint64(1), v.doppelgangerKeys[pubKeys[0]].remaining)
+}
+
+func TestCheckDoppelGangerLiveness_LateInclusion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	ctx := context.Background()
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(10)))
+	// The attestation of a doppelganger in epoch 11 is only included after the first slot of
+	// epoch 12, when the liveness of epoch 11 is not requested yet.
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(12)))
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(12)+1))
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(&ethpb.LivenessResponse{
+		Statuses: []*ethpb.ValidatorLiveness{{Index: 1, IsLive: true}, {Index: 2}},
+	}, nil)
+	err := v.CheckDoppelGangerLiveness(ctx, epochEndSlot(12))
+	require.ErrorContains(t, "were live in epoch 11", err)
+	assert.Equal(t, true, errors.Is(err, iface.ErrDoppelgangerDetected))
+	assert.Equal(t, true, v.heldByDoppelganger(pubKeys[0]))
+}
+
+func TestCheckDoppelGangerLiveness_KeyWithoutDuties(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconNodeValidatorClient(ctrl)
+	v, pubKeys := setupDoppelgangerValidator(t, client)
+	v.duties.CurrentEpochDuties = v.duties.CurrentEpochDuties[:1]
+	ctx := context.Background()
+
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochSlot(10)))
+
+	// A key whose status is not returned is not counted as not live.
+	client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{}, nil)
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(&ethpb.LivenessResponse{
+		Statuses: []*ethpb.ValidatorLiveness{{Index: 1}},
+	}, nil)
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(12)))
+	assert.Equal(t, uint64(1), v.doppelgangerKeys[pubKeys[0]].remaining)
+	assert.Equal(t, uint64(2), v.doppelgangerKeys[pubKeys[1]].remaining)
+
+	// The index of a key without duties is resolved from its status and checked for liveness.
+	client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpb.MultipleValidatorStatusRequest, _ ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error) {
+			require.Equal(t, 1, len(req.PublicKeys))
+			assert.DeepEqual(t, pubKeys[1][:], req.PublicKeys[0])
+			return &ethpb.MultipleValidatorStatusResponse{
+				PublicKeys: req.PublicKeys,
+				Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_ACTIVE}},
+				Indices:    []types.ValidatorIndex{2},
+			}, nil
+		})
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).Return(&ethpb.LivenessResponse{
+		Statuses: []*ethpb.ValidatorLiveness{{Index: 1}, {Index: 2, IsLive: true}},
+	}, nil)
+	err := v.CheckDoppelGangerLiveness(ctx, epochEndSlot(13))
+	assert.Equal(t, true, errors.Is(err, iface.ErrDoppelgangerDetected))
+
+	// A key which is not in the beacon state cannot be live.
+	client.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{pubKeys[1][:]},
+		Statuses:   []*ethpb.ValidatorStatusResponse{{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS}},
+		Indices:    []types.ValidatorIndex{0},
+	}, nil)
+	client.EXPECT().GetLiveness(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, req *ethpb.LivenessRequest, _ ...grpc.CallOption) (*ethpb.LivenessResponse, error) {
+			assert.DeepEqual(t, []types.ValidatorIndex{1}, req.Indices)
+			return &ethpb.LivenessResponse{Statuses: []*ethpb.ValidatorLiveness{{Index: 1}}}, nil
+		})
+	require.NoError(t, v.CheckDoppelGangerLiveness(ctx, epochEndSlot(14)))
+	assert.Equal(t, false, v.heldByDoppelganger(pubKeys[0]))
+	assert.Equal(t, uint64(1), v.doppelgangerKeys[pubKeys[1]].remaining)
+}
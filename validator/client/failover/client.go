@@ -0,0 +1,191 @@
+package failover
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	beaconapi "github.com/prysmaticlabs/prysm/validator/client/beacon-api"
+	"github.com/prysmaticlabs/prysm/validator/client/iface"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ = iface.ValidatorClient(&Client{})
+	_ = iface.NodeClient(&Client{})
+	_ = iface.BeaconChainClient(&Client{})
+)
+
+// Node is a beacon node the validator client can send its requests to.
+type Node struct {
+	Endpoint        string
+	ValidatorClient iface.ValidatorClient
+	NodeClient      iface.NodeClient
+	BeaconClient    iface.BeaconChainClient
+}
+
+// Client of several beacon nodes. It implements the beacon node interfaces of the
+// validator client by sending each request to the best of its nodes.
+type Client struct {
+	nodes     []*Node
+	broadcast bool
+
+	lock   sync.RWMutex
+	health []NodeHealth
+	active int
+	// switched is closed, and replaced, when requests switch to another node.
+	switched chan struct{}
+}
+
+// NewClient creates a client of the given beacon nodes, listed by order of preference.
+// If broadcast is set, signed attestations, aggregates and blocks are also sent to the
+// healthy nodes other than the one in use.
+func NewClient(nodes []*Node, broadcast bool) (*Client, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("no beacon node to connect to")
+	}
+	health := make([]NodeHealth, len(nodes))
+	for i, n := range nodes {
+		health[i].Endpoint = n.Endpoint
+	}
+	health[0].Active = true
+	return &Client{
+		nodes:     nodes,
+		broadcast: broadcast,
+		health:    health,
+		switched:  make(chan struct{}),
+	}, nil
+}
+
+// Start checks the health of the beacon nodes until the context is canceled.
+func (c *Client) Start(ctx context.Context) {
+	go func() {
+		c.checkHealth(ctx)
+		ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.checkHealth(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// activeNode returns the index of the node requests are sent to.
+func (c *Client) activeNode() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.active
+}
+
+// activeNodeUntilSwitch returns the index of the node requests are sent to, and a channel
+// which is closed once requests switch to another node.
+func (c *Client) activeNodeUntilSwitch() (int, <-chan struct{}) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.active, c.switched
+}
+
+// call sends a request to the active node. If the request fails because of the node,
+// the node is considered unhealthy until its next health check and the request is
+// retried once on the next best node.
+func (c *Client) call(ctx context.Context, f func(n *Node) (interface{}, error)) (interface{}, error) {
+	i := c.activeNode()
+	resp, err := f(c.nodes[i])
+	if !isNodeFailure(ctx, err) {
+		c.recordResult(i, false)
+		return resp, err
+	}
+	next := c.nodeFailed(i, err)
+	if next == i {
+		return resp, err
+	}
+	resp, err = f(c.nodes[next])
+	if isNodeFailure(ctx, err) {
+		c.nodeFailed(next, err)
+	} else {
+		c.recordResult(next, false)
+	}
+	return resp, err
+}
+
+// broadcastCall sends a request to the active node and, if broadcasting is enabled,
+// to the other healthy nodes in the background. The response of the active node is
+// returned.
+func (c *Client) broadcastCall(ctx context.Context, f func(n *Node) (interface{}, error)) (interface{}, error) {
+	resp, err := c.call(ctx, f)
+	if !c.broadcast {
+		return resp, err
+	}
+	c.lock.RLock()
+	var others []int
+	for i, h := range c.health {
+		if i != c.active && h.Healthy {
+			others = append(others, i)
+		}
+	}
+	c.lock.RUnlock()
+	for _, i := range others {
+		go func(i int) {
+			if _, err := f(c.nodes[i]); err != nil {
+				log.WithError(err).WithField("endpoint", c.nodes[i].Endpoint).Debug("Could not broadcast to beacon node")
+				if isNodeFailure(ctx, err) {
+					c.recordResult(i, true)
+				}
+				return
+			}
+			c.recordResult(i, false)
+		}(i)
+	}
+	return resp, err
+}
+
+// nodeFailed marks a node as unhealthy after a failed request and returns the index of
+// the node requests are now sent to.
+func (c *Client) nodeFailed(i int, err error) int {
+	c.lock.Lock()
+	c.recordResultLocked(i, true)
+	c.health[i].Healthy = false
+	c.selectNodeLocked()
+	active := c.active
+	health := c.healthLocked()
+	c.lock.Unlock()
+
+	log.WithError(err).WithField("endpoint", c.nodes[i].Endpoint).Debug("Request to beacon node failed")
+	updateMetrics(health)
+	return active
+}
+
+// isNodeFailure returns true if a request failed because of the beacon node, rather
+// than because of the request itself.
+func isNodeFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, beaconapi.ErrNotSupported) {
+		return false
+	}
+	var s interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &s) {
+		// Errors without a status come from the transport or from invalid responses.
+		return true
+	}
+	switch s.GRPCStatus().Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// logSwitch logs the node requests are now sent to.
+func (c *Client) logSwitch(from, to int) {
+	log.WithFields(logrus.Fields{
+		"from": c.nodes[from].Endpoint,
+		"to":   c.nodes[to].Endpoint,
+	}).Warn("Switching to another beacon node")
+}
@@ -0,0 +1,376 @@
+package failover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	beaconapi "github.com/prysmaticlabs/prysm/validator/client/beacon-api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockNode struct {
+	node            *Node
+	validatorClient *mock.MockBeaconNodeValidatorClient
+	nodeClient      *mock.MockNodeClient
+	beaconClient    *mock.MockBeaconChainClient
+}
+
+func newMockNode(ctrl *gomock.Controller, endpoint string) *mockNode {
+	m := &mockNode{
+		validatorClient: mock.NewMockBeaconNodeValidatorClient(ctrl),
+		nodeClient:      mock.NewMockNodeClient(ctrl),
+		beaconClient:    mock.NewMockBeaconChainClient(ctrl),
+	}
+	m.node = &Node{
+		Endpoint:        endpoint,
+		ValidatorClient: m.validatorClient,
+		NodeClient:      m.nodeClient,
+		BeaconClient:    m.beaconClient,
+	}
+	return m
+}
+
+var testGenesisRoot = []byte("genesis")
+
+// expectHealthCheck sets the responses of the node to a health check.
+func (m *mockNode) expectHealthCheck(syncing bool, headSlot types.Slot, peers int) {
+	m.expectChainHealthCheck(syncing, testGenesisRoot, &ethpb.ChainHead{HeadSlot: headSlot}, peers)
+}
+
+// expectChainHealthCheck sets the responses of the node to a health check, with the
+// network and checkpoints of its chain.
+func (m *mockNode) expectChainHealthCheck(syncing bool, genesisRoot []byte, head *ethpb.ChainHead, peers int) {
+	m.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(&ethpb.SyncStatus{Syncing: syncing}, nil)
+	m.nodeClient.EXPECT().GetGenesis(gomock.Any(), gomock.Any()).Return(&ethpb.Genesis{GenesisValidatorsRoot: genesisRoot}, nil)
+	m.beaconClient.EXPECT().GetChainHead(gomock.Any(), gomock.Any()).Return(head, nil)
+	m.nodeClient.EXPECT().ListPeers(gomock.Any(), gomock.Any()).Return(&ethpb.Peers{Peers: make([]*ethpb.Peer, peers)}, nil)
+}
+
+func newTestClient(t *testing.T, broadcast bool, nodes ...*mockNode) *Client {
+	n := make([]*Node, len(nodes))
+	for i, m := range nodes {
+		n[i] = m.node
+	}
+	c, err := NewClient(n, broadcast)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewClient_NoNodes(t *testing.T) {
+	_, err := NewClient(nil, false)
+	assert.ErrorContains(t, "no beacon node", err)
+}
+
+func TestClient_CheckHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	syncing := newMockNode(ctrl, "syncing")
+	lagging := newMockNode(ctrl, "lagging")
+	noPeers := newMockNode(ctrl, "no-peers")
+	unreachable := newMockNode(ctrl, "unreachable")
+	healthy := newMockNode(ctrl, "healthy")
+	c := newTestClient(t, false, syncing, lagging, noPeers, unreachable, healthy)
+
+	syncing.expectHealthCheck(true, 100, 10)
+	lagging.expectHealthCheck(false, 100-maxHeadSlotLag-1, 10)
+	noPeers.expectHealthCheck(false, 100, 0)
+	unreachable.nodeClient.EXPECT().GetSyncStatus(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "down"))
+	healthy.expectHealthCheck(false, 100-maxHeadSlotLag, 10)
+	c.checkHealth(context.Background())
+
+	health := c.Health()
+	require.Equal(t, 5, len(health))
+	for i, h := range health[:4] {
+		assert.Equal(t, false, h.Healthy, "Node %d is healthy", i)
+		assert.Equal(t, false, h.Active, "Node %d is active", i)
+	}
+	assert.Equal(t, true, health[0].Syncing)
+	assert.Equal(t, types.Slot(100), health[0].HeadSlot)
+	assert.Equal(t, uint64(10), health[0].Peers)
+	assert.Equal(t, false, health[3].Connected)
+	assert.Equal(t, true, health[3].ErrorRate > 0)
+	assert.Equal(t, "healthy", health[4].Endpoint)
+	assert.Equal(t, true, health[4].Healthy)
+	assert.Equal(t, true, health[4].Active)
+}
+
+func TestClient_CheckHealth_MajorityChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	otherNetwork := newMockNode(ctrl, "other-network")
+	otherFork := newMockNode(ctrl, "other-fork")
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	lateFinality := newMockNode(ctrl, "late-finality")
+	c := newTestClient(t, false, otherNetwork, otherFork, first, second, lateFinality)
+
+	chainHead := func(headSlot types.Slot, finalizedEpoch types.Epoch, finalizedRoot string) *ethpb.ChainHead {
+		return &ethpb.ChainHead{
+			HeadSlot:           headSlot,
+			FinalizedEpoch:     finalizedEpoch,
+			FinalizedBlockRoot: []byte(finalizedRoot),
+			JustifiedEpoch:     finalizedEpoch + 1,
+			JustifiedBlockRoot: []byte(finalizedRoot + "-justified"),
+		}
+	}
+	// The nodes on another network or fork have a higher head than the other nodes.
+	otherNetwork.expectChainHealthCheck(false, []byte("other"), chainHead(200, 3, "a"), 10)
+	otherFork.expectChainHealthCheck(false, testGenesisRoot, chainHead(200, 3, "b"), 10)
+	first.expectChainHealthCheck(false, testGenesisRoot, chainHead(100, 3, "a"), 10)
+	second.expectChainHealthCheck(false, testGenesisRoot, chainHead(100, 3, "a"), 10)
+	// A node which has not finalized the latest epoch yet is not compared with the others.
+	lateFinality.expectChainHealthCheck(false, testGenesisRoot, chainHead(100, 2, "c"), 10)
+	c.checkHealth(context.Background())
+
+	health := c.Health()
+	require.Equal(t, 5, len(health))
+	assert.Equal(t, false, health[0].Healthy, "Node on another network is healthy")
+	assert.Equal(t, true, health[0].Connected)
+	assert.Equal(t, false, health[1].Healthy, "Node on another fork is healthy")
+	assert.Equal(t, true, health[2].Healthy)
+	assert.Equal(t, true, health[2].Active)
+	assert.Equal(t, true, health[3].Healthy)
+	assert.Equal(t, true, health[4].Healthy)
+}
+
+func TestOnMajorityChain(t *testing.T) {
+	chain := func(genesisRoot byte, finalizedEpoch types.Epoch, finalizedRoot byte) *nodeChain {
+		return &nodeChain{
+			genesisValidatorsRoot: [32]byte{genesisRoot},
+			checkpoints:           [checkpointCount]checkpoint{{epoch: finalizedEpoch, root: [32]byte{finalizedRoot}}},
+		}
+	}
+	tests := []struct {
+		name   string
+		chains []*nodeChain
+		want   []bool
+	}{
+		{
+			name:   "single node",
+			chains: []*nodeChain{chain('g', 3, 'a')},
+			want:   []bool{true},
+		},
+		{
+			name:   "unreachable node",
+			chains: []*nodeChain{chain('g', 3, 'a'), nil},
+			want:   []bool{true, false},
+		},
+		{
+			name:   "no majority",
+			chains: []*nodeChain{chain('g', 3, 'a'), chain('h', 3, 'b')},
+			want:   []bool{true, true},
+		},
+		{
+			name:   "other network",
+			chains: []*nodeChain{chain('h', 3, 'a'), chain('g', 3, 'a'), chain('g', 3, 'a')},
+			want:   []bool{false, true, true},
+		},
+		{
+			name:   "other finalized root",
+			chains: []*nodeChain{chain('g', 3, 'b'), chain('g', 3, 'a'), chain('g', 3, 'a')},
+			want:   []bool{false, true, true},
+		},
+		{
+			name:   "other finalized epoch",
+			chains: []*nodeChain{chain('g', 2, 'b'), chain('g', 3, 'a'), chain('g', 3, 'a')},
+			want:   []bool{true, true, true},
+		},
+		{
+			name:   "genesis epoch",
+			chains: []*nodeChain{chain('g', 0, 0), chain('g', 0, 'a'), chain('g', 0, 'a')},
+			want:   []bool{true, true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.DeepEqual(t, tt.want, onMajorityChain(tt.chains))
+		})
+	}
+}
+
+func TestClient_Call_FailsOver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	c := newTestClient(t, false, first, second)
+	ctx := context.Background()
+
+	first.expectHealthCheck(false, 10, 1)
+	second.expectHealthCheck(false, 10, 1)
+	c.checkHealth(ctx)
+	assert.Equal(t, 0, c.activeNode())
+
+	want := &ethpb.DutiesResponse{}
+	first.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "down"))
+	second.validatorClient.EXPECT().GetDuties(gomock.Any(), gomock.Any()).Return(want, nil)
+	resp, err := c.GetDuties(ctx, &ethpb.DutiesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, want, resp)
+	assert.Equal(t, 1, c.activeNode())
+
+	// The first node is preferred again once it passes a health check.
+	first.expectHealthCheck(false, 11, 1)
+	second.expectHealthCheck(false, 11, 1)
+	c.checkHealth(ctx)
+	assert.Equal(t, 0, c.activeNode())
+}
+
+func TestClient_Call_RequestError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	c := newTestClient(t, false, first, second)
+
+	first.validatorClient.EXPECT().DomainData(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "bad"))
+	_, err := c.DomainData(context.Background(), &ethpb.DomainRequest{})
+	assert.ErrorContains(t, "bad", err)
+	assert.Equal(t, 0, c.activeNode())
+	assert.Equal(t, float64(0), c.Health()[0].ErrorRate)
+}
+
+func TestClient_Broadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	syncing := newMockNode(ctrl, "syncing")
+	c := newTestClient(t, true, first, second, syncing)
+	ctx := context.Background()
+
+	first.expectHealthCheck(false, 10, 1)
+	second.expectHealthCheck(false, 10, 1)
+	syncing.expectHealthCheck(true, 10, 1)
+	c.checkHealth(ctx)
+
+	att := &ethpb.Attestation{}
+	want := &ethpb.AttestResponse{AttestationDataRoot: []byte("first")}
+	first.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), att).Return(want, nil)
+	done := make(chan struct{})
+	second.validatorClient.EXPECT().ProposeAttestation(gomock.Any(), att).DoAndReturn(
+		func(_ context.Context, _ *ethpb.Attestation, _ ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+			close(done)
+			return &ethpb.AttestResponse{AttestationDataRoot: []byte("second")}, nil
+		})
+	resp, err := c.ProposeAttestation(ctx, att)
+	require.NoError(t, err)
+	assert.DeepEqual(t, want, resp)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Attestation was not broadcast")
+	}
+}
+
+// expectBlocksStream makes the node open a stream which sends the given block, and then
+// waits for the stream to be closed. The returned channel is closed with the stream.
+func (m *mockNode) expectBlocksStream(ctrl *gomock.Controller, slot types.Slot) <-chan struct{} {
+	stream := mock.NewMockBeaconNodeValidatorAltair_StreamBlocksClient(ctrl)
+	closed := make(chan struct{})
+	m.validatorClient.EXPECT().StreamBlocksAltair(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *ethpb.StreamBlocksRequest, _ ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+			sent := false
+			stream.EXPECT().Recv().DoAndReturn(func() (*ethpb.StreamBlocksResponse, error) {
+				if !sent {
+					sent = true
+					return &ethpb.StreamBlocksResponse{Block: &ethpb.StreamBlocksResponse_Phase0Block{
+						Phase0Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot}},
+					}}, nil
+				}
+				<-ctx.Done()
+				close(closed)
+				return nil, ctx.Err()
+			}).MinTimes(1).MaxTimes(2)
+			return stream, nil
+		})
+	return closed
+}
+
+func TestClient_StreamBlocksAltair_ReopensOnSwitch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	c := newTestClient(t, false, first, second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	firstClosed := first.expectBlocksStream(ctrl, 1)
+	stream, err := c.StreamBlocksAltair(ctx, &ethpb.StreamBlocksRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(1), resp.GetPhase0Block().Block.Slot)
+
+	// The stream is moved to the second node once requests switch to it.
+	first.expectHealthCheck(true, 10, 1)
+	second.expectHealthCheck(false, 10, 1)
+	secondClosed := second.expectBlocksStream(ctrl, 2)
+	go c.checkHealth(ctx)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2), resp.GetPhase0Block().Block.Slot)
+	assert.Equal(t, 1, c.activeNode())
+	select {
+	case <-firstClosed:
+	case <-time.After(time.Second):
+		t.Fatal("Stream of the first node was not closed")
+	}
+	cancel()
+	<-secondClosed
+}
+
+func TestClient_StreamBlocksAltair_FailsOver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	first := newMockNode(ctrl, "first")
+	second := newMockNode(ctrl, "second")
+	c := newTestClient(t, false, first, second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first.expectHealthCheck(false, 10, 1)
+	second.expectHealthCheck(false, 10, 1)
+	c.checkHealth(ctx)
+
+	firstStream := mock.NewMockBeaconNodeValidatorAltair_StreamBlocksClient(ctrl)
+	first.validatorClient.EXPECT().StreamBlocksAltair(gomock.Any(), gomock.Any()).Return(firstStream, nil)
+	firstStream.EXPECT().Recv().Return(nil, status.Error(codes.Unavailable, "down"))
+	stream, err := c.StreamBlocksAltair(ctx, &ethpb.StreamBlocksRequest{})
+	require.NoError(t, err)
+
+	// A stream failing because of its node is re-opened on the next node.
+	secondClosed := second.expectBlocksStream(ctrl, 2)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, types.Slot(2), resp.GetPhase0Block().Block.Slot)
+	assert.Equal(t, 1, c.activeNode())
+	cancel()
+	<-secondClosed
+}
+
+func TestIsNodeFailure(t *testing.T) {
+	ctx := context.Background()
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	assert.Equal(t, false, isNodeFailure(ctx, nil))
+	assert.Equal(t, true, isNodeFailure(ctx, errors.New("connection refused")))
+	assert.Equal(t, false, isNodeFailure(canceled, errors.New("connection refused")))
+	assert.Equal(t, true, isNodeFailure(ctx, status.Error(codes.Unavailable, "down")))
+	assert.Equal(t, true, isNodeFailure(ctx, errors.Wrap(status.Error(codes.DeadlineExceeded, "slow"), "wrapped")))
+	assert.Equal(t, false, isNodeFailure(ctx, status.Error(codes.NotFound, "not found")))
+	assert.Equal(t, false, isNodeFailure(ctx, errors.Wrap(beaconapi.ErrNotSupported, "validator performance")))
+}
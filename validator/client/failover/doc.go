@@ -0,0 +1,18 @@
+/*
+Package failover defines a client of several beacon nodes, which the validator client
+uses to keep performing its duties when the beacon node it relies on degrades.
+
+The client periodically checks the sync status, network, checkpoints, head slot, peer
+count and error rate of each configured beacon node, and sends the requests of the
+validator client to the best node. Healthy nodes, which are on the network and fork of
+the majority of the nodes, synced, close to the highest head of that fork, connected to
+peers and rarely fail requests, are preferred over the nodes which only responded to
+the last health check, which are preferred over unreachable nodes.
+
+Ties are broken by the order in which the nodes were configured, so the first node is
+used as long as it is healthy. A request which fails because of its node is retried
+once on the next best node, and the blocks stream is re-opened on the node requests
+switch to. Signed attestations, aggregates and blocks can optionally be broadcast to
+all the healthy nodes.
+*/
+package failover
@@ -0,0 +1,259 @@
+package failover
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	types "github.com/prysmaticlabs/eth2-types"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// healthCheckTimeout is the time a node has to respond to the requests of a health check.
+	healthCheckTimeout = 3 * time.Second
+	// maxHeadSlotLag is the number of slots a healthy node may lag behind the highest head
+	// among the nodes.
+	maxHeadSlotLag = 4
+	// maxErrorRate is the rate of failed requests above which a node is not healthy.
+	maxErrorRate = 0.3
+	// errorRateWeight is the weight of the latest request in the moving average of the
+	// error rate of a node.
+	errorRateWeight = 0.2
+	// checkpointCount is the number of checkpoints compared among the nodes, the
+	// finalized and the justified checkpoints.
+	checkpointCount = 2
+)
+
+// NodeHealth is the health of a beacon node as of its last health check.
+type NodeHealth struct {
+	Endpoint  string
+	Connected bool
+	Syncing   bool
+	HeadSlot  types.Slot
+	Peers     uint64
+	ErrorRate float64
+	Healthy   bool
+	Active    bool
+}
+
+// checkpoint is a finalized or justified checkpoint of a node.
+type checkpoint struct {
+	epoch types.Epoch
+	root  [32]byte
+}
+
+// nodeChain identifies the chain a node follows by its network and its finalized and
+// justified checkpoints.
+type nodeChain struct {
+	genesisValidatorsRoot [32]byte
+	checkpoints           [checkpointCount]checkpoint
+}
+
+// Health returns the health of each node, in the order in which the nodes were
+// configured.
+func (c *Client) Health() []NodeHealth {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.healthLocked()
+}
+
+func (c *Client) healthLocked() []NodeHealth {
+	health := make([]NodeHealth, len(c.health))
+	copy(health, c.health)
+	return health
+}
+
+// checkHealth checks the health of all the nodes in parallel, then selects the node to
+// send requests to.
+func (c *Client) checkHealth(ctx context.Context) {
+	results := make([]NodeHealth, len(c.nodes))
+	chains := make([]*nodeChain, len(c.nodes))
+	var wg sync.WaitGroup
+	for i, n := range c.nodes {
+		wg.Add(1)
+		go func(i int, n *Node) {
+			defer wg.Done()
+			results[i], chains[i] = checkNode(ctx, n)
+		}(i, n)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Nodes on another network or fork are not healthy, and their head is not compared
+	// with the head of the other nodes.
+	onChain := onMajorityChain(chains)
+	var maxHead types.Slot
+	for i, r := range results {
+		if onChain[i] && r.HeadSlot > maxHead {
+			maxHead = r.HeadSlot
+		}
+	}
+
+	c.lock.Lock()
+	for i, r := range results {
+		r.ErrorRate = c.health[i].ErrorRate
+		r.Active = c.health[i].Active
+		c.health[i] = r
+		c.recordResultLocked(i, !r.Connected)
+		h := &c.health[i]
+		h.Healthy = h.Connected &&
+			onChain[i] &&
+			!h.Syncing &&
+			h.HeadSlot+maxHeadSlotLag >= maxHead &&
+			h.Peers > 0 &&
+			h.ErrorRate < maxErrorRate
+	}
+	c.selectNodeLocked()
+	health := c.healthLocked()
+	c.lock.Unlock()
+
+	updateMetrics(health)
+}
+
+// checkNode requests the sync status, network, head and peers of a node. The chain of
+// the node is nil if it could not be reached.
+func checkNode(ctx context.Context, n *Node) (NodeHealth, *nodeChain) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	h := NodeHealth{Endpoint: n.Endpoint}
+	syncStatus, err := n.NodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.Endpoint).Debug("Could not get sync status of beacon node")
+		return h, nil
+	}
+	genesis, err := n.NodeClient.GetGenesis(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.Endpoint).Debug("Could not get genesis of beacon node")
+		return h, nil
+	}
+	head, err := n.BeaconClient.GetChainHead(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.Endpoint).Debug("Could not get chain head of beacon node")
+		return h, nil
+	}
+	peers, err := n.NodeClient.ListPeers(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.WithError(err).WithField("endpoint", n.Endpoint).Debug("Could not get peers of beacon node")
+		return h, nil
+	}
+	h.Connected = true
+	h.Syncing = syncStatus.Syncing
+	h.HeadSlot = head.HeadSlot
+	h.Peers = uint64(len(peers.Peers))
+	return h, &nodeChain{
+		genesisValidatorsRoot: bytesutil.ToBytes32(genesis.GenesisValidatorsRoot),
+		checkpoints: [checkpointCount]checkpoint{
+			{epoch: head.FinalizedEpoch, root: bytesutil.ToBytes32(head.FinalizedBlockRoot)},
+			{epoch: head.JustifiedEpoch, root: bytesutil.ToBytes32(head.JustifiedBlockRoot)},
+		},
+	}
+}
+
+// onMajorityChain returns whether each node has the genesis validators root of the
+// majority of the nodes, and the finalized and justified roots of the majority of the
+// nodes at the same epochs. Nodes finalize and justify epochs at slightly different
+// times, so only the roots of the same epochs are compared. The roots of the genesis
+// epoch are not compared either, as nodes report them as zero or as the genesis block
+// root. Without a majority, no node is ruled out.
+func onMajorityChain(chains []*nodeChain) []bool {
+	onChain := make([]bool, len(chains))
+	var genesisRoots [][32]byte
+	for _, ch := range chains {
+		if ch != nil {
+			genesisRoots = append(genesisRoots, ch.genesisValidatorsRoot)
+		}
+	}
+	genesisRoot, ok := majorityRoot(genesisRoots)
+	for i, ch := range chains {
+		onChain[i] = ch != nil && (!ok || ch.genesisValidatorsRoot == genesisRoot)
+	}
+
+	for k := 0; k < checkpointCount; k++ {
+		roots := make(map[types.Epoch][][32]byte)
+		for i, ch := range chains {
+			if onChain[i] {
+				cp := ch.checkpoints[k]
+				roots[cp.epoch] = append(roots[cp.epoch], cp.root)
+			}
+		}
+		for i, ch := range chains {
+			if !onChain[i] {
+				continue
+			}
+			cp := ch.checkpoints[k]
+			if cp.epoch == 0 {
+				continue
+			}
+			if root, ok := majorityRoot(roots[cp.epoch]); ok && root != cp.root {
+				onChain[i] = false
+			}
+		}
+	}
+	return onChain
+}
+
+// majorityRoot returns the root shared by more than half of the roots, if any.
+func majorityRoot(roots [][32]byte) ([32]byte, bool) {
+	counts := make(map[[32]byte]int, len(roots))
+	for _, r := range roots {
+		counts[r]++
+	}
+	for r, n := range counts {
+		if 2*n > len(roots) {
+			return r, true
+		}
+	}
+	return [32]byte{}, false
+}
+
+// recordResult updates the error rate of a node with the result of a request.
+func (c *Client) recordResult(i int, failed bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.recordResultLocked(i, failed)
+}
+
+func (c *Client) recordResultLocked(i int, failed bool) {
+	var result float64
+	if failed {
+		result = 1
+	}
+	c.health[i].ErrorRate = (1-errorRateWeight)*c.health[i].ErrorRate + errorRateWeight*result
+}
+
+// tierLocked ranks a node, lower being better.
+func (c *Client) tierLocked(i int) int {
+	switch {
+	case c.health[i].Healthy:
+		return 0
+	case c.health[i].Connected:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// selectNodeLocked sends requests to the best ranked node, preferring the nodes
+// configured first.
+func (c *Client) selectNodeLocked() {
+	best := 0
+	for i := range c.health {
+		if c.tierLocked(i) < c.tierLocked(best) {
+			best = i
+		}
+	}
+	if best == c.active {
+		return
+	}
+	c.logSwitch(c.active, best)
+	c.health[c.active].Active = false
+	c.health[best].Active = true
+	c.active = best
+	close(c.switched)
+	c.switched = make(chan struct{})
+}
@@ -0,0 +1,76 @@
+package failover
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	beaconNodeHealthyGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "1 if the beacon node passed the last health check, 0 otherwise.",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeActiveGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_active",
+			Help:      "1 if the validator client sends its requests to the beacon node, 0 otherwise.",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeSyncingGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_syncing",
+			Help:      "1 if the beacon node is synchronizing to chain head, 0 otherwise.",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeHeadSlotGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_head_slot",
+			Help:      "Head slot of the beacon node.",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodePeersGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_peers",
+			Help:      "Number of peers the beacon node is connected to.",
+		},
+		[]string{"endpoint"},
+	)
+	beaconNodeErrorRateGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_error_rate",
+			Help:      "Moving average of the rate of failed requests to the beacon node.",
+		},
+		[]string{"endpoint"},
+	)
+)
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// updateMetrics exports the health of the nodes.
+func updateMetrics(health []NodeHealth) {
+	for _, h := range health {
+		beaconNodeHealthyGaugeVec.WithLabelValues(h.Endpoint).Set(boolToFloat(h.Healthy))
+		beaconNodeActiveGaugeVec.WithLabelValues(h.Endpoint).Set(boolToFloat(h.Active))
+		beaconNodeSyncingGaugeVec.WithLabelValues(h.Endpoint).Set(boolToFloat(h.Syncing))
+		beaconNodeHeadSlotGaugeVec.WithLabelValues(h.Endpoint).Set(float64(h.HeadSlot))
+		beaconNodePeersGaugeVec.WithLabelValues(h.Endpoint).Set(float64(h.Peers))
+		beaconNodeErrorRateGaugeVec.WithLabelValues(h.Endpoint).Set(h.ErrorRate)
+	}
+}
@@ -0,0 +1,53 @@
+package failover
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetSyncStatus sends the request to the active node.
+func (c *Client) GetSyncStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.SyncStatus, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.NodeClient.GetSyncStatus(ctx, in, opts...)
+	})
+	return resp.(*ethpb.SyncStatus), err
+}
+
+// GetGenesis sends the request to the active node.
+func (c *Client) GetGenesis(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.Genesis, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.NodeClient.GetGenesis(ctx, in, opts...)
+	})
+	return resp.(*ethpb.Genesis), err
+}
+
+// ListPeers sends the request to the active node.
+func (c *Client) ListPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.Peers, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.NodeClient.ListPeers(ctx, in, opts...)
+	})
+	return resp.(*ethpb.Peers), err
+}
+
+// GetChainHead sends the request to the active node.
+func (c *Client) GetChainHead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.BeaconClient.GetChainHead(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ChainHead), err
+}
+
+// GetValidatorPerformance sends the request to the active node.
+func (c *Client) GetValidatorPerformance(
+	ctx context.Context,
+	in *ethpb.ValidatorPerformanceRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.ValidatorPerformanceResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.BeaconClient.GetValidatorPerformance(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ValidatorPerformanceResponse), err
+}
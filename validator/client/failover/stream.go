@@ -0,0 +1,127 @@
+package failover
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+type blocksResult struct {
+	resp *ethpb.StreamBlocksResponse
+	err  error
+}
+
+// blocksStream receives the blocks streamed by the active node. When requests switch to
+// another node, or the stream fails because of its node, the stream is re-opened on the
+// node requests are now sent to. Like gRPC streams, it must not be received from by
+// several goroutines at once.
+type blocksStream struct {
+	grpc.ClientStream
+
+	c    *Client
+	ctx  context.Context
+	in   *ethpb.StreamBlocksRequest
+	opts []grpc.CallOption
+
+	node     int
+	switched <-chan struct{}
+	cancel   context.CancelFunc
+	results  chan blocksResult
+}
+
+// open opens the stream on the active node, closing the stream previously opened. If the
+// stream cannot be opened because of the node, it is opened once on the next best node.
+func (s *blocksStream) open() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	i, switched := s.c.activeNodeUntilSwitch()
+	stream, ctx, cancel, err := s.openOn(i)
+	if isNodeFailure(s.ctx, err) {
+		if next := s.c.nodeFailed(i, err); next != i {
+			i, switched = s.c.activeNodeUntilSwitch()
+			stream, ctx, cancel, err = s.openOn(i)
+			if isNodeFailure(s.ctx, err) {
+				s.c.nodeFailed(i, err)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	s.c.recordResult(i, false)
+
+	results := make(chan blocksResult)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			select {
+			case results <- blocksResult{resp: resp, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	s.ClientStream = stream
+	s.node = i
+	s.switched = switched
+	s.cancel = cancel
+	s.results = results
+	return nil
+}
+
+// openOn opens the stream on the given node, with a context canceled once the stream is
+// no longer used.
+func (s *blocksStream) openOn(i int) (
+	ethpb.BeaconNodeValidator_StreamBlocksAltairClient, context.Context, context.CancelFunc, error,
+) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	stream, err := s.c.nodes[i].ValidatorClient.StreamBlocksAltair(ctx, s.in, s.opts...)
+	if err != nil {
+		cancel()
+		return nil, nil, nil, err
+	}
+	return stream, ctx, cancel, nil
+}
+
+// Recv receives the next block from the node in use.
+func (s *blocksStream) Recv() (*ethpb.StreamBlocksResponse, error) {
+	for {
+		select {
+		case r := <-s.results:
+			if r.err == nil {
+				return r.resp, nil
+			}
+			if !isNodeFailure(s.ctx, r.err) {
+				return nil, r.err
+			}
+			s.c.nodeFailed(s.node, r.err)
+			if i, _ := s.c.activeNodeUntilSwitch(); i == s.node {
+				return nil, r.err
+			}
+		case <-s.switched:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
+		}
+		log.WithField("endpoint", s.c.nodes[s.c.activeNode()].Endpoint).Debug("Re-opening blocks stream")
+		if err := s.open(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Context returns the context the stream was opened with.
+func (s *blocksStream) Context() context.Context {
+	return s.ctx
+}
+
+// CloseSend closes the stream on the node in use.
+func (s *blocksStream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	s.cancel()
+	return err
+}
@@ -0,0 +1,297 @@
+package failover
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetDuties sends the request to the active node.
+func (c *Client) GetDuties(
+	ctx context.Context,
+	in *ethpb.DutiesRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.DutiesResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetDuties(ctx, in, opts...)
+	})
+	return resp.(*ethpb.DutiesResponse), err
+}
+
+// DomainData sends the request to the active node.
+func (c *Client) DomainData(
+	ctx context.Context,
+	in *ethpb.DomainRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.DomainResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.DomainData(ctx, in, opts...)
+	})
+	return resp.(*ethpb.DomainResponse), err
+}
+
+// WaitForChainStart opens the stream on the active node.
+func (c *Client) WaitForChainStart(
+	ctx context.Context,
+	in *emptypb.Empty,
+	opts ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.WaitForChainStart(ctx, in, opts...)
+	})
+	stream, _ := resp.(ethpb.BeaconNodeValidator_WaitForChainStartClient)
+	return stream, err
+}
+
+// WaitForActivation opens the stream on the active node.
+func (c *Client) WaitForActivation(
+	ctx context.Context,
+	in *ethpb.ValidatorActivationRequest,
+	opts ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_WaitForActivationClient, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.WaitForActivation(ctx, in, opts...)
+	})
+	stream, _ := resp.(ethpb.BeaconNodeValidator_WaitForActivationClient)
+	return stream, err
+}
+
+// ValidatorIndex sends the request to the active node.
+func (c *Client) ValidatorIndex(
+	ctx context.Context,
+	in *ethpb.ValidatorIndexRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.ValidatorIndexResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.ValidatorIndex(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ValidatorIndexResponse), err
+}
+
+// MultipleValidatorStatus sends the request to the active node.
+func (c *Client) MultipleValidatorStatus(
+	ctx context.Context,
+	in *ethpb.MultipleValidatorStatusRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.MultipleValidatorStatusResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.MultipleValidatorStatus(ctx, in, opts...)
+	})
+	return resp.(*ethpb.MultipleValidatorStatusResponse), err
+}
+
+// GetBlock sends the request to the active node.
+func (c *Client) GetBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.BeaconBlock, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetBlock(ctx, in, opts...)
+	})
+	return resp.(*ethpb.BeaconBlock), err
+}
+
+// ProposeBlock is broadcast to the healthy nodes if enabled.
+func (c *Client) ProposeBlock(
+	ctx context.Context,
+	in *ethpb.SignedBeaconBlock,
+	opts ...grpc.CallOption,
+) (*ethpb.ProposeResponse, error) {
+	resp, err := c.broadcastCall(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.ProposeBlock(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ProposeResponse), err
+}
+
+// GetBeaconBlock sends the request to the active node.
+func (c *Client) GetBeaconBlock(
+	ctx context.Context,
+	in *ethpb.BlockRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.GenericBeaconBlock, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetBeaconBlock(ctx, in, opts...)
+	})
+	return resp.(*ethpb.GenericBeaconBlock), err
+}
+
+// ProposeBeaconBlock is broadcast to the healthy nodes if enabled.
+func (c *Client) ProposeBeaconBlock(
+	ctx context.Context,
+	in *ethpb.GenericSignedBeaconBlock,
+	opts ...grpc.CallOption,
+) (*ethpb.ProposeResponse, error) {
+	resp, err := c.broadcastCall(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.ProposeBeaconBlock(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ProposeResponse), err
+}
+
+// GetAttestationData sends the request to the active node.
+func (c *Client) GetAttestationData(
+	ctx context.Context,
+	in *ethpb.AttestationDataRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.AttestationData, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetAttestationData(ctx, in, opts...)
+	})
+	return resp.(*ethpb.AttestationData), err
+}
+
+// ProposeAttestation is broadcast to the healthy nodes if enabled.
+func (c *Client) ProposeAttestation(
+	ctx context.Context,
+	in *ethpb.Attestation,
+	opts ...grpc.CallOption,
+) (*ethpb.AttestResponse, error) {
+	resp, err := c.broadcastCall(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.ProposeAttestation(ctx, in, opts...)
+	})
+	return resp.(*ethpb.AttestResponse), err
+}
+
+// SubmitAggregateSelectionProof sends the request to the active node.
+func (c *Client) SubmitAggregateSelectionProof(
+	ctx context.Context,
+	in *ethpb.AggregateSelectionRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.AggregateSelectionResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.SubmitAggregateSelectionProof(ctx, in, opts...)
+	})
+	return resp.(*ethpb.AggregateSelectionResponse), err
+}
+
+// SubmitSignedAggregateSelectionProof is broadcast to the healthy nodes if enabled.
+func (c *Client) SubmitSignedAggregateSelectionProof(
+	ctx context.Context,
+	in *ethpb.SignedAggregateSubmitRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.SignedAggregateSubmitResponse, error) {
+	resp, err := c.broadcastCall(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.SubmitSignedAggregateSelectionProof(ctx, in, opts...)
+	})
+	return resp.(*ethpb.SignedAggregateSubmitResponse), err
+}
+
+// ProposeExit sends the request to the active node.
+func (c *Client) ProposeExit(
+	ctx context.Context,
+	in *ethpb.SignedVoluntaryExit,
+	opts ...grpc.CallOption,
+) (*ethpb.ProposeExitResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.ProposeExit(ctx, in, opts...)
+	})
+	return resp.(*ethpb.ProposeExitResponse), err
+}
+
+// SubscribeCommitteeSubnets sends the request to the active node.
+func (c *Client) SubscribeCommitteeSubnets(
+	ctx context.Context,
+	in *ethpb.CommitteeSubnetsSubscribeRequest,
+	opts ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.SubscribeCommitteeSubnets(ctx, in, opts...)
+	})
+	return resp.(*emptypb.Empty), err
+}
+
+// CheckDoppelGanger sends the request to the active node.
+func (c *Client) CheckDoppelGanger(
+	ctx context.Context,
+	in *ethpb.DoppelGangerRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.DoppelGangerResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.CheckDoppelGanger(ctx, in, opts...)
+	})
+	return resp.(*ethpb.DoppelGangerResponse), err
+}
+
+// GetSyncMessageBlockRoot sends the request to the active node.
+func (c *Client) GetSyncMessageBlockRoot(
+	ctx context.Context,
+	in *emptypb.Empty,
+	opts ...grpc.CallOption,
+) (*ethpb.SyncMessageBlockRootResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetSyncMessageBlockRoot(ctx, in, opts...)
+	})
+	return resp.(*ethpb.SyncMessageBlockRootResponse), err
+}
+
+// SubmitSyncMessage sends the request to the active node.
+func (c *Client) SubmitSyncMessage(
+	ctx context.Context,
+	in *ethpb.SyncCommitteeMessage,
+	opts ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.SubmitSyncMessage(ctx, in, opts...)
+	})
+	return resp.(*emptypb.Empty), err
+}
+
+// GetSyncSubcommitteeIndex sends the request to the active node.
+func (c *Client) GetSyncSubcommitteeIndex(
+	ctx context.Context,
+	in *ethpb.SyncSubcommitteeIndexRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.SyncSubcommitteeIndexResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetSyncSubcommitteeIndex(ctx, in, opts...)
+	})
+	return resp.(*ethpb.SyncSubcommitteeIndexResponse), err
+}
+
+// GetSyncCommitteeContribution sends the request to the active node.
+func (c *Client) GetSyncCommitteeContribution(
+	ctx context.Context,
+	in *ethpb.SyncCommitteeContributionRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.SyncCommitteeContribution, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetSyncCommitteeContribution(ctx, in, opts...)
+	})
+	return resp.(*ethpb.SyncCommitteeContribution), err
+}
+
+// SubmitSignedContributionAndProof sends the request to the active node.
+func (c *Client) SubmitSignedContributionAndProof(
+	ctx context.Context,
+	in *ethpb.SignedContributionAndProof,
+	opts ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.SubmitSignedContributionAndProof(ctx, in, opts...)
+	})
+	return resp.(*emptypb.Empty), err
+}
+
+// StreamBlocksAltair opens the stream on the active node. The stream is re-opened on the
+// new active node whenever requests switch to another node.
+func (c *Client) StreamBlocksAltair(
+	ctx context.Context,
+	in *ethpb.StreamBlocksRequest,
+	opts ...grpc.CallOption,
+) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error) {
+	s := &blocksStream{c: c, ctx: ctx, in: in, opts: opts}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetLiveness sends the request to the active node.
+func (c *Client) GetLiveness(
+	ctx context.Context,
+	in *ethpb.LivenessRequest,
+	opts ...grpc.CallOption,
+) (*ethpb.LivenessResponse, error) {
+	resp, err := c.call(ctx, func(n *Node) (interface{}, error) {
+		return n.ValidatorClient.GetLiveness(ctx, in, opts...)
+	})
+	return resp.(*ethpb.LivenessResponse), err
+}
@@ -12,6 +12,9 @@ import (
 // ErrConnectionIssue represents a connection problem.
 var ErrConnectionIssue = errors.New("could not connect")
 
+// ErrDoppelgangerDetected is returned when another instance of a validator key is live in the network.
+var ErrDoppelgangerDetected = errors.New("doppelganger detected")
+
 // ValidatorRole defines the validator role.
 type ValidatorRole int8
 
@@ -57,4 +60,5 @@ type Validator interface {
 	ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error)
 	HandleKeyReload(ctx context.Context, newKeys [][48]byte) (bool, error)
 	CheckDoppelGanger(ctx context.Context) error
+	CheckDoppelGangerLiveness(ctx context.Context, slot types.Slot) error
 }
@@ -0,0 +1,54 @@
+package iface
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ValidatorClient defines the beacon node API the validator client depends on to perform
+// its duties. It is implemented by the gRPC client of a Prysm beacon node, as well as by
+// clients of the standard Beacon API which can connect to any beacon node.
+type ValidatorClient interface {
+	GetDuties(ctx context.Context, in *ethpb.DutiesRequest, opts ...grpc.CallOption) (*ethpb.DutiesResponse, error)
+	DomainData(ctx context.Context, in *ethpb.DomainRequest, opts ...grpc.CallOption) (*ethpb.DomainResponse, error)
+	WaitForChainStart(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForChainStartClient, error)
+	WaitForActivation(ctx context.Context, in *ethpb.ValidatorActivationRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_WaitForActivationClient, error)
+	ValidatorIndex(ctx context.Context, in *ethpb.ValidatorIndexRequest, opts ...grpc.CallOption) (*ethpb.ValidatorIndexResponse, error)
+	MultipleValidatorStatus(ctx context.Context, in *ethpb.MultipleValidatorStatusRequest, opts ...grpc.CallOption) (*ethpb.MultipleValidatorStatusResponse, error)
+	GetBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.BeaconBlock, error)
+	ProposeBlock(ctx context.Context, in *ethpb.SignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error)
+	GetBeaconBlock(ctx context.Context, in *ethpb.BlockRequest, opts ...grpc.CallOption) (*ethpb.GenericBeaconBlock, error)
+	ProposeBeaconBlock(ctx context.Context, in *ethpb.GenericSignedBeaconBlock, opts ...grpc.CallOption) (*ethpb.ProposeResponse, error)
+	GetAttestationData(ctx context.Context, in *ethpb.AttestationDataRequest, opts ...grpc.CallOption) (*ethpb.AttestationData, error)
+	ProposeAttestation(ctx context.Context, in *ethpb.Attestation, opts ...grpc.CallOption) (*ethpb.AttestResponse, error)
+	SubmitAggregateSelectionProof(ctx context.Context, in *ethpb.AggregateSelectionRequest, opts ...grpc.CallOption) (*ethpb.AggregateSelectionResponse, error)
+	SubmitSignedAggregateSelectionProof(ctx context.Context, in *ethpb.SignedAggregateSubmitRequest, opts ...grpc.CallOption) (*ethpb.SignedAggregateSubmitResponse, error)
+	ProposeExit(ctx context.Context, in *ethpb.SignedVoluntaryExit, opts ...grpc.CallOption) (*ethpb.ProposeExitResponse, error)
+	SubscribeCommitteeSubnets(ctx context.Context, in *ethpb.CommitteeSubnetsSubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CheckDoppelGanger(ctx context.Context, in *ethpb.DoppelGangerRequest, opts ...grpc.CallOption) (*ethpb.DoppelGangerResponse, error)
+	GetSyncMessageBlockRoot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.SyncMessageBlockRootResponse, error)
+	SubmitSyncMessage(ctx context.Context, in *ethpb.SyncCommitteeMessage, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetSyncSubcommitteeIndex(ctx context.Context, in *ethpb.SyncSubcommitteeIndexRequest, opts ...grpc.CallOption) (*ethpb.SyncSubcommitteeIndexResponse, error)
+	GetSyncCommitteeContribution(ctx context.Context, in *ethpb.SyncCommitteeContributionRequest, opts ...grpc.CallOption) (*ethpb.SyncCommitteeContribution, error)
+	SubmitSignedContributionAndProof(ctx context.Context, in *ethpb.SignedContributionAndProof, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	StreamBlocksAltair(ctx context.Context, in *ethpb.StreamBlocksRequest, opts ...grpc.CallOption) (ethpb.BeaconNodeValidator_StreamBlocksAltairClient, error)
+	GetLiveness(ctx context.Context, in *ethpb.LivenessRequest, opts ...grpc.CallOption) (*ethpb.LivenessResponse, error)
+}
+
+// NodeClient defines the beacon node API the validator client depends on to follow the
+// status and the health of the beacon node.
+type NodeClient interface {
+	GetSyncStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.SyncStatus, error)
+	GetGenesis(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.Genesis, error)
+	ListPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.Peers, error)
+}
+
+// BeaconChainClient defines the beacon node API the validator client depends on to follow
+// the beacon chain and the performance of its validators.
+type BeaconChainClient interface {
+	GetChainHead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ethpb.ChainHead, error)
+	GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest, opts ...grpc.CallOption) (*ethpb.ValidatorPerformanceResponse, error)
+}
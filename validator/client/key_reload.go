@@ -13,6 +13,9 @@ func (v *validator) HandleKeyReload(ctx context.Context, newKeys [][48]byte) (an
 	ctx, span := trace.StartSpan(ctx, "validator.HandleKeyReload")
 	defer span.End()
 
+	// New keys are held back from signing until they went through the doppelganger checks.
+	v.updateDoppelgangerKeys(newKeys)
+
 	statusRequestKeys := make([][]byte, len(newKeys))
 	for i := range newKeys {
 		statusRequestKeys[i] = newKeys[i][:]
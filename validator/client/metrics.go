@@ -194,6 +194,18 @@ var (
 			"pubkey",
 		},
 	)
+	// ValidatorDoppelgangersDetectedVec used to count the validator keys found live in the network
+	// while held back from signing by doppelganger protection.
+	ValidatorDoppelgangersDetectedVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "doppelgangers_detected",
+			Help:      "validator keys found live in the network while held back by doppelganger protection",
+		},
+		[]string{
+			"pubkey",
+		},
+	)
 )
 
 // LogValidatorGainsAndLosses logs important metrics related to this validator client's
@@ -317,8 +317,8 @@ func (v *validator) proposeBlockAltair(ctx context.Context, slot types.Slot, pub
 // The exit is signed by the validator before being sent to the beacon node for broadcasting.
 func ProposeExit(
 	ctx context.Context,
-	validatorClient ethpb.BeaconNodeValidatorClient,
-	nodeClient ethpb.NodeClient,
+	validatorClient iface.ValidatorClient,
+	nodeClient iface.NodeClient,
 	signer signingFunc,
 	pubKey []byte,
 ) error {
@@ -371,11 +371,16 @@ func (v *validator) signRandaoReveal(ctx context.Context, pubKey [48]byte, epoch
 	if err != nil {
 		return nil, err
 	}
+	slot, err := core.StartSlot(epoch)
+	if err != nil {
+		return nil, err
+	}
 	randaoReveal, err = v.keyManager.Sign(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey[:],
 		SigningRoot:     root[:],
 		SignatureDomain: domain.SignatureDomain,
 		Object:          &validatorpb.SignRequest_Epoch{Epoch: epoch},
+		SigningSlot:     slot,
 	})
 	if err != nil {
 		return nil, err
@@ -409,6 +414,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch types.
 			SigningRoot:     blockRoot[:],
 			SignatureDomain: domain.SignatureDomain,
 			Object:          &validatorpb.SignRequest_BlockV2{BlockV2: block},
+			SigningSlot:     block.Slot,
 		})
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "could not sign block proposal")
@@ -428,6 +434,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch types.
 			SigningRoot:     blockRoot[:],
 			SignatureDomain: domain.SignatureDomain,
 			Object:          &validatorpb.SignRequest_Block{Block: block},
+			SigningSlot:     block.Slot,
 		})
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "could not sign block proposal")
@@ -441,7 +448,7 @@ func (v *validator) signBlock(ctx context.Context, pubKey [48]byte, epoch types.
 // Sign voluntary exit with proposer domain and private key.
 func signVoluntaryExit(
 	ctx context.Context,
-	validatorClient ethpb.BeaconNodeValidatorClient,
+	validatorClient iface.ValidatorClient,
 	signer signingFunc,
 	pubKey []byte,
 	exit *ethpb.VoluntaryExit,
@@ -463,12 +470,17 @@ func signVoluntaryExit(
 	if err != nil {
 		return nil, errors.Wrap(err, signingRootErr)
 	}
+	slot, err := core.StartSlot(exit.Epoch)
+	if err != nil {
+		return nil, err
+	}
 
 	sig, err := signer(ctx, &validatorpb.SignRequest{
 		PublicKey:       pubKey,
 		SigningRoot:     exitRoot[:],
 		SignatureDomain: domain.SignatureDomain,
 		Object:          &validatorpb.SignRequest_Exit{Exit: exit},
+		SigningSlot:     slot,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, signExitErr)
@@ -175,6 +175,16 @@ func run(ctx context.Context, v iface.Validator) {
 				continue
 			}
 
+			// Release or stop on the keys held back by doppelganger protection.
+			if err := v.CheckDoppelGangerLiveness(ctx, slot); err != nil {
+				if errors.Is(err, iface.ErrDoppelgangerDetected) {
+					cancel()
+					span.End()
+					log.Fatalf("Stopping validator: %v", err)
+				}
+				log.WithError(err).Warn("Could not check liveness of validator keys held back by doppelganger protection")
+			}
+
 			// Start fetching domain data for the next epoch.
 			if core.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
@@ -92,6 +92,23 @@ func TestUpdateDuties_NextSlot(t *testing.T) {
 	assert.Equal(t, uint64(slot), v.UpdateDutiesArg1, "UpdateAssignments was called with wrong argument")
 }
 
+func TestCheckDoppelGangerLiveness_NextSlot(t *testing.T) {
+	v := &testutil.FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ticker := make(chan types.Slot)
+	v.NextSlotRet = ticker
+	go func() {
+		ticker <- 55
+
+		cancel()
+	}()
+
+	run(ctx, v)
+
+	require.Equal(t, true, v.CheckDoppelGangerLivenessCalled, "Expected CheckDoppelGangerLiveness to be called")
+}
+
 func TestUpdateDuties_HandlesError(t *testing.T) {
 	hook := logTest.NewGlobal()
 	v := &testutil.FakeValidator{Keymanager: &mockKeymanager{accountsChangedFeed: &event.Feed{}}}
@@ -22,6 +22,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	accountsiface "github.com/prysmaticlabs/prysm/validator/accounts/iface"
 	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	beaconapi "github.com/prysmaticlabs/prysm/validator/client/beacon-api"
+	"github.com/prysmaticlabs/prysm/validator/client/failover"
 	"github.com/prysmaticlabs/prysm/validator/client/iface"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/graffiti"
@@ -53,7 +55,13 @@ type ValidatorService struct {
 	emitAccountMetrics    bool
 	logValidatorBalances  bool
 	logDutyCountDown      bool
-	conn                  *grpc.ClientConn
+	conns                 []*grpc.ClientConn
+	nodeClient            iface.NodeClient
+	beaconNodes           *failover.Client
+	beaconNodeBroadcast   bool
+	beaconApiEndpoint     string
+	beaconApiTimeout      time.Duration
+	doppelgangerEpochs    uint64
 	grpcRetryDelay        time.Duration
 	grpcRetries           uint
 	maxCallRecvMsgSize    int
@@ -92,6 +100,10 @@ type Config struct {
 	DataDir                    string
 	GrpcHeadersFlag            string
 	GraffitiStruct             *graffiti.Graffiti
+	BeaconApiEndpoint          string
+	BeaconApiTimeout           time.Duration
+	DoppelgangerEpochs         uint64
+	BeaconNodeBroadcast        bool
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -119,34 +131,84 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		useWeb:                cfg.UseWeb,
 		graffitiStruct:        cfg.GraffitiStruct,
 		logDutyCountDown:      cfg.LogDutyCountDown,
+		beaconApiEndpoint:     cfg.BeaconApiEndpoint,
+		beaconApiTimeout:      cfg.BeaconApiTimeout,
+		doppelgangerEpochs:    cfg.DoppelgangerEpochs,
+		beaconNodeBroadcast:   cfg.BeaconNodeBroadcast,
 	}, nil
 }
 
 // Start the validator service. Launches the main go routine for the validator
 // client.
 func (v *ValidatorService) Start() {
-	dialOpts := ConstructDialOptions(
-		v.maxCallRecvMsgSize,
-		v.withCert,
-		v.grpcRetries,
-		v.grpcRetryDelay,
-	)
-	if dialOpts == nil {
-		return
-	}
+	var nodes []*failover.Node
+	logValidatorBalances := v.logValidatorBalances
+	if v.beaconApiEndpoint != "" {
+		for _, endpoint := range strings.Split(v.beaconApiEndpoint, ",") {
+			apiClient, err := beaconapi.NewClient(strings.TrimSpace(endpoint), v.beaconApiTimeout)
+			if err != nil {
+				log.Errorf("Could not create beacon API client: %v", err)
+				return
+			}
+			log.WithField("endpoint", apiClient.BaseEndpoint()).Info("Using the beacon API of the beacon node")
+			nodes = append(nodes, &failover.Node{
+				Endpoint:        apiClient.BaseEndpoint(),
+				ValidatorClient: apiClient,
+				NodeClient:      apiClient,
+				BeaconClient:    apiClient,
+			})
+		}
+		if logValidatorBalances {
+			log.Warn("Validator balances and rewards are not logged when using the beacon API")
+			logValidatorBalances = false
+		}
+	} else {
+		endpoints := strings.Split(v.endpoint, ",")
+		// With several beacon nodes, a failed request is retried on the next node by the
+		// failover client rather than retried against the same failing node.
+		grpcRetries := v.grpcRetries
+		if len(endpoints) > 1 {
+			grpcRetries = 0
+		}
+		dialOpts := ConstructDialOptions(
+			v.maxCallRecvMsgSize,
+			v.withCert,
+			grpcRetries,
+			v.grpcRetryDelay,
+		)
+		if dialOpts == nil {
+			return
+		}
 
-	v.ctx = grpcutils.AppendHeaders(v.ctx, v.grpcHeaders)
+		v.ctx = grpcutils.AppendHeaders(v.ctx, v.grpcHeaders)
 
-	conn, err := grpc.DialContext(v.ctx, v.endpoint, dialOpts...)
+		for _, endpoint := range endpoints {
+			endpoint = strings.TrimSpace(endpoint)
+			conn, err := grpc.DialContext(v.ctx, endpoint, dialOpts...)
+			if err != nil {
+				log.Errorf("Could not dial endpoint: %s, %v", endpoint, err)
+				return
+			}
+			v.conns = append(v.conns, conn)
+			nodes = append(nodes, &failover.Node{
+				Endpoint:        endpoint,
+				ValidatorClient: ethpb.NewBeaconNodeValidatorClient(conn),
+				NodeClient:      ethpb.NewNodeClient(conn),
+				BeaconClient:    ethpb.NewBeaconChainClient(conn),
+			})
+		}
+		if v.withCert != "" {
+			log.Info("Established secure gRPC connection")
+		}
+	}
+	beaconNodes, err := failover.NewClient(nodes, v.beaconNodeBroadcast)
 	if err != nil {
-		log.Errorf("Could not dial endpoint: %s, %v", v.endpoint, err)
+		log.Errorf("Could not create beacon node client: %v", err)
 		return
 	}
-	if v.withCert != "" {
-		log.Info("Established secure gRPC connection")
-	}
-
-	v.conn = conn
+	beaconNodes.Start(v.ctx)
+	v.beaconNodes = beaconNodes
+	v.nodeClient = beaconNodes
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1920, // number of keys to track.
 		MaxCost:     192,  // maximum cost of cache, 1 item = 1 cost.
@@ -176,12 +238,12 @@ func (v *ValidatorService) Start() {
 
 	valStruct := &validator{
 		db:                             v.db,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
-		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
-		node:                           ethpb.NewNodeClient(v.conn),
+		validatorClient:                beaconNodes,
+		beaconClient:                   beaconNodes,
+		node:                           v.nodeClient,
 		keyManager:                     v.keyManager,
 		graffiti:                       v.graffiti,
-		logValidatorBalances:           v.logValidatorBalances,
+		logValidatorBalances:           logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		startBalances:                  make(map[[48]byte]uint64),
 		prevBalance:                    make(map[[48]byte]uint64),
@@ -197,6 +259,8 @@ func (v *ValidatorService) Start() {
 		graffitiOrderedIndex:           graffitiOrderedIndex,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		logDutyCountDown:               v.logDutyCountDown,
+		doppelgangerEpochs:             v.doppelgangerEpochs,
+		doppelgangerKeys:               make(map[[48]byte]*doppelgangerStatus),
 	}
 	// To resolve a race condition at startup due to the interface
 	// nature of the abstracted block type. We initialize
@@ -217,15 +281,26 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
-	if v.conn != nil {
-		return v.conn.Close()
+	for _, conn := range v.conns {
+		if err := conn.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// BeaconNodesHealth returns the health of each beacon node the validator client may
+// send its requests to.
+func (v *ValidatorService) BeaconNodesHealth() []failover.NodeHealth {
+	if v.beaconNodes == nil {
+		return nil
+	}
+	return v.beaconNodes.Health()
+}
+
 // Status of the validator service.
 func (v *ValidatorService) Status() error {
-	if v.conn == nil {
+	if v.nodeClient == nil {
 		return errors.New("no connection to beacon RPC")
 	}
 	return nil
@@ -319,8 +394,7 @@ func ConstructDialOptions(
 
 // Syncing returns whether or not the beacon node is currently synchronizing the chain.
 func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	resp, err := nc.GetSyncStatus(ctx, &emptypb.Empty{})
+	resp, err := v.nodeClient.GetSyncStatus(ctx, &emptypb.Empty{})
 	if err != nil {
 		return false, err
 	}
@@ -330,8 +404,7 @@ func (v *ValidatorService) Syncing(ctx context.Context) (bool, error) {
 // GenesisInfo queries the beacon node for the chain genesis info containing
 // the genesis time along with the validator deposit contract address.
 func (v *ValidatorService) GenesisInfo(ctx context.Context) (*ethpb.Genesis, error) {
-	nc := ethpb.NewNodeClient(v.conn)
-	return nc.GetGenesis(ctx, &emptypb.Empty{})
+	return v.nodeClient.GetGenesis(ctx, &emptypb.Empty{})
 }
 
 // to accounts changes in the keymanager, then updates those keys'
@@ -56,6 +56,8 @@ func (v *validator) SubmitSyncCommitteeMessage(ctx context.Context, slot types.S
 		PublicKey:       pubKey[:],
 		SigningRoot:     r[:],
 		SignatureDomain: d.SignatureDomain,
+		Object:          &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: res.Root},
+		SigningSlot:     slot,
 	})
 	if err != nil {
 		log.WithError(err).Error("Could not sign sync committee message")
@@ -199,7 +201,12 @@ func (v *validator) signSyncSelectionData(ctx context.Context, pubKey [48]byte,
 		Slot:              slot,
 		SubcommitteeIndex: index,
 	}
-	sig, err := v.computeAndSign(ctx, data, pubKey, domain.SignatureDomain)
+	sig, err := v.computeAndSign(ctx, data, &validatorpb.SignRequest{
+		PublicKey:       pubKey[:],
+		SignatureDomain: domain.SignatureDomain,
+		Object:          &validatorpb.SignRequest_SyncAggregatorSelectionData{SyncAggregatorSelectionData: data},
+		SigningSlot:     slot,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -212,22 +219,24 @@ func (v *validator) signContributionAndProof(ctx context.Context, pubKey [48]byt
 	if err != nil {
 		return nil, err
 	}
-	sig, err := v.computeAndSign(ctx, c, pubKey, d.SignatureDomain)
+	sig, err := v.computeAndSign(ctx, c, &validatorpb.SignRequest{
+		PublicKey:       pubKey[:],
+		SignatureDomain: d.SignatureDomain,
+		Object:          &validatorpb.SignRequest_ContributionAndProof{ContributionAndProof: c},
+		SigningSlot:     c.Contribution.Slot,
+	})
 	if err != nil {
 		return nil, err
 	}
 	return sig.Marshal(), nil
 }
 
-// This computes the signing root of hash tree root capable object `obj` and signs it using public key `pubKey` along with the signature domain `sigDomain`.
-func (v *validator) computeAndSign(ctx context.Context, obj fssz.HashRoot, pubKey [48]byte, sigDomain []byte) (bls.Signature, error) {
-	root, err := helpers.ComputeSigningRoot(obj, sigDomain)
+// This computes the signing root of hash tree root capable object `obj` with the signature domain of the sign request `req`, and signs it using the public key of the request.
+func (v *validator) computeAndSign(ctx context.Context, obj fssz.HashRoot, req *validatorpb.SignRequest) (bls.Signature, error) {
+	root, err := helpers.ComputeSigningRoot(obj, req.SignatureDomain)
 	if err != nil {
 		return nil, err
 	}
-	return v.keyManager.Sign(ctx, &validatorpb.SignRequest{
-		PublicKey:       pubKey[:],
-		SigningRoot:     root[:],
-		SignatureDomain: sigDomain,
-	})
+	req.SigningRoot = root[:]
+	return v.keyManager.Sign(ctx, req)
 }
@@ -30,6 +30,7 @@ type FakeValidator struct {
 	DeleteProtectionCalled            bool
 	SlotDeadlineCalled                bool
 	HandleKeyReloadCalled             bool
+	CheckDoppelGangerLivenessCalled   bool
 	WaitForChainStartCalled           int
 	WaitForSyncCalled                 int
 	WaitForActivationCalled           int
@@ -221,6 +222,12 @@ func (fv *FakeValidator) CheckDoppelGanger(ctx context.Context) error {
 	return nil
 }
 
+// CheckDoppelGangerLiveness for mocking
+func (fv *FakeValidator) CheckDoppelGangerLiveness(_ context.Context, _ types.Slot) error {
+	fv.CheckDoppelGangerLivenessCalled = true
+	return nil
+}
+
 // ReceiveBlocks for mocking
 func (fv *FakeValidator) ReceiveBlocks(ctx context.Context, connectionErrorChannel chan<- error) {
 	fv.ReceiveBlocksCalled++
@@ -66,6 +66,7 @@ type validator struct {
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
 	prevBalanceLock                    sync.RWMutex
 	slashableKeysLock                  sync.RWMutex
+	doppelgangerLock                   sync.RWMutex
 	walletInitializedFeed              *event.Feed
 	blockFeed                          *event.Feed
 	genesisTime                        uint64
@@ -77,10 +78,10 @@ type validator struct {
 	duties                             *ethpb.DutiesResponse
 	startBalances                      map[[48]byte]uint64
 	attLogs                            map[[32]byte]*attSubmitted
-	node                               ethpb.NodeClient
+	node                               iface.NodeClient
 	keyManager                         keymanager.IKeymanager
-	beaconClient                       ethpb.BeaconChainClient
-	validatorClient                    ethpb.BeaconNodeValidatorClient
+	beaconClient                       iface.BeaconChainClient
+	validatorClient                    iface.ValidatorClient
 	protector                          slashingiface.Protector
 	db                                 vdb.Database
 	graffiti                           []byte
@@ -88,6 +89,8 @@ type validator struct {
 	graffitiStruct                     *graffiti.Graffiti
 	graffitiOrderedIndex               uint64
 	eipImportBlacklistedPublicKeys     map[[48]byte]bool
+	doppelgangerEpochs                 uint64
+	doppelgangerKeys                   map[[48]byte]*doppelgangerStatus
 }
 
 type validatorStatus struct {
@@ -553,6 +556,8 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 	subscribeSlots := make([]types.Slot, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	subscribeCommitteeIndices := make([]types.CommitteeIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	subscribeIsAggregator := make([]bool, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
+	subscribeValidatorIndices := make([]types.ValidatorIndex, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
+	subscribeCommitteesAtSlot := make([]uint64, 0, len(res.CurrentEpochDuties)+len(res.NextEpochDuties))
 	alreadySubscribed := make(map[[64]byte]bool)
 
 	for _, duty := range res.CurrentEpochDuties {
@@ -577,6 +582,8 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 			subscribeSlots = append(subscribeSlots, attesterSlot)
 			subscribeCommitteeIndices = append(subscribeCommitteeIndices, committeeIndex)
 			subscribeIsAggregator = append(subscribeIsAggregator, aggregator)
+			subscribeValidatorIndices = append(subscribeValidatorIndices, duty.ValidatorIndex)
+			subscribeCommitteesAtSlot = append(subscribeCommitteesAtSlot, duty.CommitteesAtSlot)
 		}
 	}
 
@@ -601,13 +608,17 @@ func (v *validator) subscribeToSubnets(ctx context.Context, res *ethpb.DutiesRes
 			subscribeSlots = append(subscribeSlots, attesterSlot)
 			subscribeCommitteeIndices = append(subscribeCommitteeIndices, committeeIndex)
 			subscribeIsAggregator = append(subscribeIsAggregator, aggregator)
+			subscribeValidatorIndices = append(subscribeValidatorIndices, duty.ValidatorIndex)
+			subscribeCommitteesAtSlot = append(subscribeCommitteesAtSlot, duty.CommitteesAtSlot)
 		}
 	}
 
 	_, err := v.validatorClient.SubscribeCommitteeSubnets(ctx, &ethpb.CommitteeSubnetsSubscribeRequest{
-		Slots:        subscribeSlots,
-		CommitteeIds: subscribeCommitteeIndices,
-		IsAggregator: subscribeIsAggregator,
+		Slots:            subscribeSlots,
+		CommitteeIds:     subscribeCommitteeIndices,
+		IsAggregator:     subscribeIsAggregator,
+		ValidatorIndices: subscribeValidatorIndices,
+		CommitteesAtSlot: subscribeCommitteesAtSlot,
 	})
 
 	return err
@@ -624,6 +635,10 @@ func (v *validator) RolesAt(ctx context.Context, slot types.Slot) (map[[48]byte]
 		if duty == nil {
 			continue
 		}
+		// Keys held back by doppelganger protection must not sign anything.
+		if v.heldByDoppelganger(bytesutil.ToBytes48(duty.PublicKey)) {
+			continue
+		}
 		if len(duty.ProposerSlots) > 0 {
 			for _, proposerSlot := range duty.ProposerSlots {
 				if proposerSlot != 0 && proposerSlot == slot {
@@ -10,6 +10,7 @@ import (
 	"github.com/k0kubun/go-ansi"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/schollz/progressbar/v3"
@@ -49,8 +50,11 @@ func (km *Keymanager) ImportKeystores(
 		privKeys = append(privKeys, []byte(privKey))
 	}
 
+	// The lock is only taken once the keystores are decrypted, as decrypting may prompt for passwords.
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
 	// Write the accounts to disk into a single keystore.
-	accountsKeystore, err := km.CreateAccountsKeystore(ctx, privKeys, pubKeys)
+	accountsKeystore, err := km.createAccountsKeystore(privKeys, pubKeys)
 	if err != nil {
 		return err
 	}
@@ -61,10 +65,85 @@ func (km *Keymanager) ImportKeystores(
 	return km.wallet.WriteFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName, encodedAccounts)
 }
 
+// ImportKeystoresWithPasswords imports keystores into the imported keymanager, each decrypted
+// with its own password, and reloads the accounts of the keymanager so that the validator starts
+// performing duties for the new keys without a restart. Unlike ImportKeystores, it never prompts
+// for a password: the outcome of the import of each keystore is returned in its status instead.
+func (km *Keymanager) ImportKeystoresWithPasswords(
+	ctx context.Context,
+	keystores []*keymanager.Keystore,
+	passwords []string,
+) ([]*keymanager.KeyStatus, error) {
+	if len(keystores) != len(passwords) {
+		return nil, fmt.Errorf(
+			"number of keystores and passwords is not equal: %d != %d", len(keystores), len(passwords),
+		)
+	}
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
+	current := km.accountsStore
+	if current == nil {
+		current = &accountStore{}
+	}
+	importedPubKeys := make(map[[48]byte]bool, len(current.PublicKeys)+len(keystores))
+	for _, pubKey := range current.PublicKeys {
+		importedPubKeys[bytesutil.ToBytes48(pubKey)] = true
+	}
+	decryptor := keystorev4.New()
+	statuses := make([]*keymanager.KeyStatus, len(keystores))
+	privKeys := make([][]byte, 0, len(keystores))
+	pubKeys := make([][]byte, 0, len(keystores))
+	for i, keystore := range keystores {
+		privKeyBytes, err := decryptor.Decrypt(keystore.Crypto, passwords[i])
+		if err != nil {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusError,
+				Message: fmt.Sprintf("could not decrypt keystore: %v", err),
+			}
+			continue
+		}
+		privKey, err := bls.SecretKeyFromBytes(privKeyBytes)
+		if err != nil {
+			statuses[i] = &keymanager.KeyStatus{
+				Status:  keymanager.StatusError,
+				Message: fmt.Sprintf("could not initialize private key: %v", err),
+			}
+			continue
+		}
+		pubKeyBytes := privKey.PublicKey().Marshal()
+		if importedPubKeys[bytesutil.ToBytes48(pubKeyBytes)] {
+			statuses[i] = &keymanager.KeyStatus{Status: keymanager.StatusDuplicate}
+			continue
+		}
+		importedPubKeys[bytesutil.ToBytes48(pubKeyBytes)] = true
+		privKeys = append(privKeys, privKeyBytes)
+		pubKeys = append(pubKeys, pubKeyBytes)
+		statuses[i] = &keymanager.KeyStatus{Status: keymanager.StatusImported}
+	}
+	if len(pubKeys) == 0 {
+		return statuses, nil
+	}
+	// The accounts store of the keymanager is only replaced once the new keystore is saved.
+	updated := &accountStore{
+		PrivateKeys: append(append(make([][]byte, 0, len(current.PrivateKeys)+len(privKeys)), current.PrivateKeys...), privKeys...),
+		PublicKeys:  append(append(make([][]byte, 0, len(current.PublicKeys)+len(pubKeys)), current.PublicKeys...), pubKeys...),
+	}
+	accountsKeystore, err := km.encryptAccounts(updated)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not import keystores")
+	}
+	if err := km.saveAndReloadAccounts(ctx, accountsKeystore); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
 // ImportKeypairs directly into the keymanager.
 func (km *Keymanager) ImportKeypairs(ctx context.Context, privKeys, pubKeys [][]byte) error {
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
 	// Write the accounts to disk into a single keystore.
-	accountsKeystore, err := km.CreateAccountsKeystore(ctx, privKeys, pubKeys)
+	accountsKeystore, err := km.createAccountsKeystore(privKeys, pubKeys)
 	if err != nil {
 		return errors.Wrap(err, "could not import account keypairs")
 	}
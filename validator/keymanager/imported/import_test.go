@@ -2,6 +2,7 @@ package imported
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
@@ -152,3 +155,49 @@ func TestImportedKeymanager_ImportKeystores(t *testing.T) {
 	assert.Equal(t, numAccounts, len(store.PublicKeys))
 	assert.Equal(t, numAccounts, len(store.PrivateKeys))
 }
+
+func TestImportedKeymanager_ImportKeystoresWithPasswords(t *testing.T) {
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	ResetCaches()
+	ctx := context.Background()
+	keystore := createRandomKeystore(t, password)
+	keystores := []*keymanager.Keystore{keystore, createRandomKeystore(t, password), keystore}
+	passwords := []string{password, "wrongPassw0rd", password}
+
+	_, err := dr.ImportKeystoresWithPasswords(ctx, keystores, passwords[:2])
+	require.ErrorContains(t, "number of keystores and passwords is not equal", err)
+
+	accountsChanged := make(chan [][48]byte, 1)
+	sub := dr.SubscribeAccountChanges(accountsChanged)
+	defer sub.Unsubscribe()
+	statuses, err := dr.ImportKeystoresWithPasswords(ctx, keystores, passwords)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(statuses))
+	assert.Equal(t, keymanager.StatusImported, statuses[0].Status)
+	assert.Equal(t, keymanager.StatusError, statuses[1].Status)
+	assert.Equal(t, true, strings.Contains(statuses[1].Message, "could not decrypt keystore"))
+	assert.Equal(t, keymanager.StatusDuplicate, statuses[2].Status)
+
+	// The accounts are reloaded with the imported key.
+	pubKey, err := hex.DecodeString(keystore.Pubkey)
+	require.NoError(t, err)
+	reloadedKeys := <-accountsChanged
+	require.Equal(t, 1, len(reloadedKeys))
+	assert.Equal(t, bytesutil.ToBytes48(pubKey), reloadedKeys[0])
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, reloadedKeys, keys)
+
+	// Importing the key again reports it as a duplicate.
+	statuses, err = dr.ImportKeystoresWithPasswords(ctx, keystores[:1], passwords[:1])
+	require.NoError(t, err)
+	assert.Equal(t, keymanager.StatusDuplicate, statuses[0].Status)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/interop"
 	"github.com/prysmaticlabs/prysm/shared/petnames"
 	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"go.opencensus.io/trace"
@@ -42,6 +43,9 @@ type Keymanager struct {
 	wallet              iface.Wallet
 	accountsStore       *accountStore
 	accountsChangedFeed *event.Feed
+	// accountsLock serializes the imports and deletions of accounts, so that concurrent
+	// changes do not overwrite each other in the accounts keystore.
+	accountsLock sync.Mutex
 }
 
 // SetupConfig includes configuration values for initializing
@@ -159,6 +163,8 @@ func (km *Keymanager) initializeKeysCachesFromKeystore() error {
 
 // DeleteAccounts takes in public keys and removes the accounts entirely. This includes their disk keystore and cached keystore.
 func (km *Keymanager) DeleteAccounts(ctx context.Context, publicKeys [][]byte) error {
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
 	for _, publicKey := range publicKeys {
 		var index int
 		var found bool
@@ -177,7 +183,7 @@ func (km *Keymanager) DeleteAccounts(ctx context.Context, publicKeys [][]byte) e
 		km.accountsStore.PrivateKeys = append(km.accountsStore.PrivateKeys[:index], km.accountsStore.PrivateKeys[index+1:]...)
 		km.accountsStore.PublicKeys = append(km.accountsStore.PublicKeys[:index], km.accountsStore.PublicKeys[index+1:]...)
 
-		newStore, err := km.CreateAccountsKeystore(ctx, km.accountsStore.PrivateKeys, km.accountsStore.PublicKeys)
+		newStore, err := km.createAccountsKeystore(km.accountsStore.PrivateKeys, km.accountsStore.PublicKeys)
 		if err != nil {
 			return errors.Wrap(err, "could not rewrite accounts keystore")
 		}
@@ -203,6 +209,60 @@ func (km *Keymanager) DeleteAccounts(ctx context.Context, publicKeys [][]byte) e
 	return nil
 }
 
+// DeleteKeystores removes the accounts of the given public keys, and reloads the accounts of the
+// keymanager so that the validator stops performing duties for them without a restart. The
+// outcome of the deletion of each key is returned in its status.
+func (km *Keymanager) DeleteKeystores(ctx context.Context, publicKeys [][]byte) ([]*keymanager.KeyStatus, error) {
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
+	current := km.accountsStore
+	if current == nil {
+		current = &accountStore{}
+	}
+	deleted := make(map[string]bool, len(publicKeys))
+	statuses := make([]*keymanager.KeyStatus, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		statuses[i] = &keymanager.KeyStatus{Status: keymanager.StatusNotFound}
+		for _, pubKey := range current.PublicKeys {
+			if bytes.Equal(pubKey, publicKey) {
+				deleted[string(pubKey)] = true
+				statuses[i].Status = keymanager.StatusDeleted
+				break
+			}
+		}
+	}
+	if len(deleted) == 0 {
+		return statuses, nil
+	}
+
+	// The accounts store of the keymanager is only replaced once the new keystore is saved.
+	remaining := &accountStore{
+		PrivateKeys: make([][]byte, 0, len(current.PrivateKeys)),
+		PublicKeys:  make([][]byte, 0, len(current.PublicKeys)),
+	}
+	for i, pubKey := range current.PublicKeys {
+		if deleted[string(pubKey)] {
+			continue
+		}
+		remaining.PrivateKeys = append(remaining.PrivateKeys, current.PrivateKeys[i])
+		remaining.PublicKeys = append(remaining.PublicKeys, pubKey)
+	}
+	newStore, err := km.encryptAccounts(remaining)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not rewrite accounts keystore")
+	}
+	if err := km.saveAndReloadAccounts(ctx, newStore); err != nil {
+		return nil, err
+	}
+	for pubKey := range deleted {
+		log.WithFields(logrus.Fields{
+			"name":      petnames.DeterministicName([]byte(pubKey), "-"),
+			"publicKey": fmt.Sprintf("%#x", bytesutil.Trunc([]byte(pubKey))),
+		}).Info("Successfully deleted validator account")
+	}
+	return statuses, nil
+}
+
 // FetchValidatingPublicKeys fetches the list of active public keys from the imported account keystores.
 func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "keymanager.FetchValidatingPublicKeys")
@@ -300,11 +360,14 @@ func (km *Keymanager) CreateAccountsKeystore(
 	_ context.Context,
 	privateKeys, publicKeys [][]byte,
 ) (*AccountsKeystoreRepresentation, error) {
-	encryptor := keystorev4.New()
-	id, err := uuid.NewRandom()
-	if err != nil {
-		return nil, err
-	}
+	km.accountsLock.Lock()
+	defer km.accountsLock.Unlock()
+	return km.createAccountsKeystore(privateKeys, publicKeys)
+}
+
+// Adds the provided keys to the accounts of the keymanager, and creates a new keystore holding
+// them. The caller must hold the accounts lock.
+func (km *Keymanager) createAccountsKeystore(privateKeys, publicKeys [][]byte) (*AccountsKeystoreRepresentation, error) {
 	if len(privateKeys) != len(publicKeys) {
 		return nil, fmt.Errorf(
 			"number of private keys and public keys is not equal: %d != %d", len(privateKeys), len(publicKeys),
@@ -336,11 +399,21 @@ func (km *Keymanager) CreateAccountsKeystore(
 			km.accountsStore.PrivateKeys = append(km.accountsStore.PrivateKeys, sk)
 		}
 	}
-	err = km.initializeKeysCachesFromKeystore()
-	if err != nil {
+	if err := km.initializeKeysCachesFromKeystore(); err != nil {
 		return nil, errors.Wrap(err, "failed to initialize keys caches")
 	}
-	encodedStore, err := json.MarshalIndent(km.accountsStore, "", "\t")
+	return km.encryptAccounts(km.accountsStore)
+}
+
+// encryptAccounts creates a new keystore holding the keys of the given accounts store, without
+// changing the accounts of the keymanager.
+func (km *Keymanager) encryptAccounts(store *accountStore) (*AccountsKeystoreRepresentation, error) {
+	encryptor := keystorev4.New()
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	encodedStore, err := json.MarshalIndent(store, "", "\t")
 	if err != nil {
 		return nil, err
 	}
@@ -2,14 +2,18 @@ package imported
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 
 	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
 	"github.com/prysmaticlabs/prysm/shared/testutil/require"
 	mock "github.com/prysmaticlabs/prysm/validator/accounts/testing"
@@ -68,6 +72,175 @@ func TestImportedKeymanager_RemoveAccounts(t *testing.T) {
 	require.LogsContain(t, hook, "Successfully deleted validator account")
 }
 
+func TestImportedKeymanager_DeleteKeystores(t *testing.T) {
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	numAccounts := 3
+	ctx := context.Background()
+	keystores := make([]*keymanager.Keystore, numAccounts)
+	for i := 0; i < numAccounts; i++ {
+		keystores[i] = createRandomKeystore(t, password)
+	}
+	require.NoError(t, dr.ImportKeystores(ctx, keystores, password))
+	accounts, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, numAccounts, len(accounts))
+
+	unknownKey, err := bls.RandKey()
+	require.NoError(t, err)
+	accountsChanged := make(chan [][48]byte, 1)
+	sub := dr.SubscribeAccountChanges(accountsChanged)
+	defer sub.Unsubscribe()
+	statuses, err := dr.DeleteKeystores(ctx, [][]byte{accounts[1][:], unknownKey.PublicKey().Marshal()})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(statuses))
+	assert.Equal(t, keymanager.StatusDeleted, statuses[0].Status)
+	assert.Equal(t, keymanager.StatusNotFound, statuses[1].Status)
+
+	// The accounts are reloaded without the deleted key.
+	reloadedKeys := <-accountsChanged
+	assert.DeepEqual(t, [][48]byte{accounts[0], accounts[2]}, reloadedKeys)
+	_, err = dr.Sign(ctx, &validatorpb.SignRequest{PublicKey: accounts[1][:], SigningRoot: make([]byte, 32)})
+	require.ErrorContains(t, "no signing key found in keys cache", err)
+}
+
+func TestImportedKeymanager_DeleteKeystores_WriteFailure(t *testing.T) {
+	wallet := &failingWriteWallet{Wallet: &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}}
+	dr := &Keymanager{
+		wallet:              wallet.Wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	ResetCaches()
+	ctx := context.Background()
+	keystores := []*keymanager.Keystore{createRandomKeystore(t, password), createRandomKeystore(t, password)}
+	require.NoError(t, dr.ImportKeystores(ctx, keystores, password))
+	accounts, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+
+	// The accounts are left untouched when the new keystore cannot be written.
+	dr.wallet = wallet
+	_, err = dr.DeleteKeystores(ctx, [][]byte{accounts[0][:]})
+	require.ErrorContains(t, "could not write keystore file", err)
+	assert.Equal(t, 2, len(dr.accountsStore.PublicKeys))
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, accounts, keys)
+}
+
+func TestImportedKeymanager_ImportAndDeleteKeystores_Concurrently(t *testing.T) {
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	ResetCaches()
+	ctx := context.Background()
+	keystores := []*keymanager.Keystore{createRandomKeystore(t, password), createRandomKeystore(t, password)}
+	require.NoError(t, dr.ImportKeystores(ctx, keystores, password))
+	accounts, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+
+	newKeystore := createRandomKeystore(t, password)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, err := dr.DeleteKeystores(ctx, [][]byte{accounts[0][:]})
+		assert.NoError(t, err)
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := dr.ImportKeystoresWithPasswords(ctx, []*keymanager.Keystore{newKeystore}, []string{password})
+		assert.NoError(t, err)
+	}()
+	wg.Wait()
+
+	// Neither change is lost, in memory or on disk.
+	keys, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(keys))
+	newPubKey, err := hex.DecodeString(newKeystore.Pubkey)
+	require.NoError(t, err)
+	assert.Equal(t, true, keys[0] == accounts[1] || keys[1] == accounts[1])
+	assert.Equal(t, true, keys[0] == bytesutil.ToBytes48(newPubKey) || keys[1] == bytesutil.ToBytes48(newPubKey))
+	reloaded := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	require.NoError(t, reloaded.initializeAccountKeystore(ctx))
+	assert.Equal(t, 2, len(reloaded.accountsStore.PublicKeys))
+}
+
+func TestImportedKeymanager_ImportKeystoresAndDeleteAccounts_Concurrently(t *testing.T) {
+	wallet := &mock.Wallet{
+		Files:          make(map[string]map[string][]byte),
+		WalletPassword: password,
+	}
+	dr := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	ResetCaches()
+	ctx := context.Background()
+	keystores := []*keymanager.Keystore{createRandomKeystore(t, password), createRandomKeystore(t, password)}
+	require.NoError(t, dr.ImportKeystores(ctx, keystores, password))
+	accounts, err := dr.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+
+	newKeystores := []*keymanager.Keystore{createRandomKeystore(t, password), createRandomKeystore(t, password)}
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, dr.DeleteAccounts(ctx, [][]byte{accounts[0][:]}))
+	}()
+	for _, keystore := range newKeystores {
+		go func(keystore *keymanager.Keystore) {
+			defer wg.Done()
+			assert.NoError(t, dr.ImportKeystores(ctx, []*keymanager.Keystore{keystore}, password))
+		}(keystore)
+	}
+	wg.Wait()
+
+	// None of the changes is lost on disk.
+	reloaded := &Keymanager{
+		wallet:              wallet,
+		accountsStore:       &accountStore{},
+		accountsChangedFeed: new(event.Feed),
+	}
+	require.NoError(t, reloaded.initializeAccountKeystore(ctx))
+	require.Equal(t, 3, len(reloaded.accountsStore.PublicKeys))
+	for _, pubKey := range reloaded.accountsStore.PublicKeys {
+		assert.Equal(t, false, bytesutil.ToBytes48(pubKey) == accounts[0], "Deleted account was restored")
+	}
+}
+
+// failingWriteWallet is a wallet to which no file can be written.
+type failingWriteWallet struct {
+	*mock.Wallet
+}
+
+func (w *failingWriteWallet) WriteFileAtPath(_ context.Context, _, _ string, _ []byte) error {
+	return errors.New("disk full")
+}
+
 func TestImportedKeymanager_FetchValidatingPublicKeys(t *testing.T) {
 	wallet := &mock.Wallet{
 		Files:          make(map[string]map[string][]byte),
@@ -91,6 +91,19 @@ func (km *Keymanager) listenForAccountChanges(ctx context.Context) {
 	}
 }
 
+// Writes the accounts keystore to the wallet, then reloads the accounts of the keymanager
+// from it, notifying the subscribers to account changes of the new set of keys.
+func (km *Keymanager) saveAndReloadAccounts(ctx context.Context, keystore *AccountsKeystoreRepresentation) error {
+	encoded, err := json.MarshalIndent(keystore, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal accounts keystore into JSON")
+	}
+	if err := km.wallet.WriteFileAtPath(ctx, AccountsPath, AccountsKeystoreFileName, encoded); err != nil {
+		return errors.Wrap(err, "could not write keystore file for accounts")
+	}
+	return km.reloadAccountsFromKeystore(keystore)
+}
+
 // Replaces the accounts store struct in the imported keymanager with
 // the contents of a keystore file by decrypting it with the accounts password.
 func (km *Keymanager) reloadAccountsFromKeystore(keystore *AccountsKeystoreRepresentation) error {
@@ -28,8 +28,8 @@ type Keystore struct {
 	Name    string                 `json:"name"`
 }
 
-// Kind defines an enum for either imported, derived, or remote-signing
-// keystores for Prysm wallets.
+// Kind defines an enum for either imported, derived, remote-signing, or
+// HTTP remote-signing keystores for Prysm wallets.
 type Kind int
 
 const (
@@ -39,6 +39,8 @@ const (
 	Derived
 	// Remote keymanager capable of remote-signing data.
 	Remote
+	// Web3Signer keymanager capable of remote-signing data via the HTTP remote signing API.
+	Web3Signer
 )
 
 // String marshals a keymanager kind to a string value.
@@ -50,6 +52,8 @@ func (k Kind) String() string {
 		return "direct"
 	case Remote:
 		return "remote"
+	case Web3Signer:
+		return "web3signer"
 	default:
 		return fmt.Sprintf("%d", int(k))
 	}
@@ -64,7 +68,36 @@ func ParseKind(k string) (Kind, error) {
 		return Imported, nil
 	case "remote":
 		return Remote, nil
+	case "web3signer":
+		return Web3Signer, nil
 	default:
 		return 0, fmt.Errorf("%s is not an allowed keymanager", k)
 	}
 }
+
+// KeyStatusKind defines the outcome of importing or deleting a single key, as reported
+// by the standard keymanager API.
+type KeyStatusKind string
+
+const (
+	// StatusImported means the key was imported.
+	StatusImported KeyStatusKind = "imported"
+	// StatusDuplicate means the key was already known to the keymanager, and was not imported again.
+	StatusDuplicate KeyStatusKind = "duplicate"
+	// StatusDeleted means the key was deleted.
+	StatusDeleted KeyStatusKind = "deleted"
+	// StatusNotActive means the key is not known to the keymanager, but slashing protection
+	// data was found for it.
+	StatusNotActive KeyStatusKind = "not_active"
+	// StatusNotFound means the key is not known to the keymanager.
+	StatusNotFound KeyStatusKind = "not_found"
+	// StatusError means the key could not be imported or deleted.
+	StatusError KeyStatusKind = "error"
+)
+
+// KeyStatus is the outcome of importing or deleting a single key, with a message
+// describing the error if there was one.
+type KeyStatus struct {
+	Status  KeyStatusKind `json:"status"`
+	Message string        `json:"message"`
+}
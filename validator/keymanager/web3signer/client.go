@@ -0,0 +1,184 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+const (
+	publicKeysPath = "/api/v1/eth2/publicKeys"
+	signPath       = "/api/v1/eth2/sign/"
+)
+
+var (
+	// ErrSigningFailed defines a failure from the remote signer
+	// when performing a signing operation.
+	ErrSigningFailed = errors.New("signing failed in the remote signer")
+	// ErrSigningDenied defines a signing operation denied by the slashing
+	// protection of the remote signer.
+	ErrSigningDenied = errors.New("signing request was denied by remote signer")
+	// ErrUnknownKey defines a signing operation for a key which is not known
+	// to the remote signer.
+	ErrUnknownKey = errors.New("public key not found in the remote signer")
+)
+
+// apiClient sends the requests of the HTTP remote signing API to a remote signer.
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+func newAPIClient(baseEndpoint string, tlsCfg *TLSConfig, timeout time.Duration) (*apiClient, error) {
+	if _, err := url.ParseRequestURI(baseEndpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid remote signer URL %s", baseEndpoint)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		cfg, err := tlsCfg.clientConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = cfg
+	}
+	return &apiClient{
+		baseURL:    strings.TrimSuffix(baseEndpoint, "/"),
+		httpClient: &http.Client{Transport: transport},
+		timeout:    timeout,
+	}, nil
+}
+
+// clientConfig loads the certificates of the TLS configuration.
+func (c *TLSConfig) clientConfig() (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(c.CACertPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read remote signer CA certificate")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("could not add remote signer CA certificate to pool")
+		}
+		cfg.RootCAs = pool
+	}
+	if c.ClientCertPath != "" || c.ClientKeyPath != "" {
+		if c.ClientCertPath == "" || c.ClientKeyPath == "" {
+			return nil, errors.New("both a client certificate and a client key are required")
+		}
+		clientPair, err := tls.LoadX509KeyPair(c.ClientCertPath, c.ClientKeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not load client certificate and key")
+		}
+		cfg.Certificates = []tls.Certificate{clientPair}
+	}
+	return cfg, nil
+}
+
+// publicKeys lists the public keys the remote signer can sign with.
+func (c *apiClient) publicKeys(ctx context.Context) ([][48]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, publicKeysPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.statusCode != http.StatusOK {
+		return nil, errors.Errorf("could not list public keys of remote signer: %s", resp.status)
+	}
+	var encoded []string
+	if err := json.Unmarshal(resp.body, &encoded); err != nil {
+		return nil, errors.Wrap(err, "could not decode public keys of remote signer")
+	}
+	pubKeys := make([][48]byte, len(encoded))
+	for i, k := range encoded {
+		pubKey, err := hexutil.Decode(k)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid public key %s", k)
+		}
+		if len(pubKey) != 48 {
+			return nil, errors.Errorf("invalid public key length of %s", k)
+		}
+		pubKeys[i] = bytesutil.ToBytes48(pubKey)
+	}
+	return pubKeys, nil
+}
+
+// sign sends a typed signing request for a public key to the remote signer.
+func (c *apiClient) sign(ctx context.Context, pubKey []byte, req *signRequestJSON) (bls.Signature, error) {
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode sign request")
+	}
+	resp, err := c.do(ctx, http.MethodPost, signPath+hexString(pubKey), encoded)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.statusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrUnknownKey
+	case http.StatusPreconditionFailed:
+		return nil, ErrSigningDenied
+	default:
+		return nil, errors.Wrapf(ErrSigningFailed, "%s: %s", resp.status, bytes.TrimSpace(resp.body))
+	}
+	signResp := &signResponseJSON{}
+	if err := json.Unmarshal(resp.body, signResp); err != nil {
+		return nil, errors.Wrap(err, "could not decode sign response")
+	}
+	sig, err := hexutil.Decode(signResp.Signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode signature")
+	}
+	return bls.SignatureFromBytes(sig)
+}
+
+type apiResponse struct {
+	statusCode int
+	status     string
+	body       []byte
+}
+
+// do sends a request to the remote signer, which is cancelled if no response is
+// received within the request timeout of the client.
+func (c *apiClient) do(ctx context.Context, method, path string, body []byte) (*apiResponse, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send request to remote signer")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response of remote signer")
+	}
+	return &apiResponse{statusCode: resp.StatusCode, status: resp.Status, body: respBody}, nil
+}
@@ -0,0 +1,33 @@
+/*
+Package web3signer defines a keymanager implementation which signs with the keys of a
+remote signer over the HTTP remote signing API, as implemented by Web3Signer. The public
+keys available for signing are listed from the /api/v1/eth2/publicKeys endpoint of the
+signer, and are refreshed periodically so that keys added to or removed from the signer
+are picked up by the validator client at runtime.
+
+Every sign request is sent to the /api/v1/eth2/sign/{pubkey} endpoint as a typed signing
+request, which includes the object being signed alongside its signing root and the fork
+information of the signature domain, so that the remote signer is able to verify the
+signing root and to apply its own slashing protection:
+
+ {
+   "type": "ATTESTATION",
+   "fork_info": {
+     "fork": {
+       "previous_version": "0x00000000",
+       "current_version": "0x01000000",
+       "epoch": "74240"
+     },
+     "genesis_validators_root": "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+   },
+   "signingRoot": "0x...",
+   "attestation": {...}
+ }
+
+Blocks are sent as BLOCK_V2 requests carrying the header of the block, from which the
+signing root of the block can be verified without sending its whole body.
+
+The connection to the remote signer can be secured with TLS, by trusting the certificate
+authority of the signer and by optionally authenticating with a client certificate.
+*/
+package web3signer
@@ -0,0 +1,166 @@
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+// TLSConfig defines the certificate authority cert, client cert, and client key
+// used to connect to a remote signer via TLS. All of them are optional.
+type TLSConfig struct {
+	CACertPath     string
+	ClientCertPath string
+	ClientKeyPath  string
+}
+
+// SetupConfig includes configuration values for initializing a keymanager
+// which signs with the keys of a remote signer.
+type SetupConfig struct {
+	// BaseEndpoint is the URL of the remote signer, such as http://localhost:9000.
+	BaseEndpoint string
+	// GenesisValidatorsRoot of the network, which is part of the fork information
+	// sent with every sign request.
+	GenesisValidatorsRoot []byte
+	// TLS configures the certificates of HTTPS connections to the remote signer, if any.
+	TLS *TLSConfig
+	// RequestTimeout is the duration after which a request to the remote signer is cancelled.
+	RequestTimeout time.Duration
+	// RefreshInterval is the interval at which the public keys are reloaded from
+	// the remote signer. Public keys are never reloaded if it is zero.
+	RefreshInterval time.Duration
+}
+
+// Keymanager implementation signing with the keys of a remote signer via the
+// HTTP remote signing API.
+type Keymanager struct {
+	client                *apiClient
+	genesisValidatorsRoot []byte
+	refreshInterval       time.Duration
+	keysLock              sync.RWMutex
+	keysLoaded            bool
+	orderedPubKeys        [][48]byte
+	accountsChangedFeed   *event.Feed
+}
+
+// NewKeymanager instantiates a new web3signer keymanager from configuration options. Its
+// public keys are reloaded from the remote signer periodically until the context is done.
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
+	if cfg.BaseEndpoint == "" {
+		return nil, errors.New("remote signer URL is required")
+	}
+	if len(cfg.GenesisValidatorsRoot) != 32 {
+		return nil, errors.New("a 32 byte genesis validators root is required")
+	}
+	client, err := newAPIClient(cfg.BaseEndpoint, cfg.TLS, cfg.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+	km := &Keymanager{
+		client:                client,
+		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
+		refreshInterval:       cfg.RefreshInterval,
+		orderedPubKeys:        make([][48]byte, 0),
+		accountsChangedFeed:   new(event.Feed),
+	}
+	if km.refreshInterval > 0 {
+		go km.refreshPublicKeys(ctx)
+	}
+	return km, nil
+}
+
+// BaseEndpoint returns the URL of the remote signer.
+func (km *Keymanager) BaseEndpoint() string {
+	return km.client.baseURL
+}
+
+// FetchValidatingPublicKeys fetches the list of public keys that should be used to validate with.
+// The keys are only requested from the remote signer the first time, and are kept up to date
+// by the periodic refresh afterwards.
+func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][48]byte, error) {
+	km.keysLock.RLock()
+	if km.keysLoaded {
+		pubKeys := make([][48]byte, len(km.orderedPubKeys))
+		copy(pubKeys, km.orderedPubKeys)
+		km.keysLock.RUnlock()
+		return pubKeys, nil
+	}
+	km.keysLock.RUnlock()
+	return km.reloadPublicKeys(ctx)
+}
+
+// Sign signs a message for a validator key via a typed signing request to the remote signer.
+func (km *Keymanager) Sign(ctx context.Context, req *validatorpb.SignRequest) (bls.Signature, error) {
+	signReq, err := newSignRequest(req, km.genesisValidatorsRoot)
+	if err != nil {
+		return nil, err
+	}
+	return km.client.sign(ctx, req.PublicKey, signReq)
+}
+
+// SubscribeAccountChanges creates an event subscription for a channel
+// to listen for public key changes at runtime, such as when keys are added
+// to or removed from the remote signer while the validator process is running.
+func (km *Keymanager) SubscribeAccountChanges(pubKeysChan chan [][48]byte) event.Subscription {
+	return km.accountsChangedFeed.Subscribe(pubKeysChan)
+}
+
+// refreshPublicKeys reloads the public keys from the remote signer at every refresh interval.
+func (km *Keymanager) refreshPublicKeys(ctx context.Context) {
+	ticker := time.NewTicker(km.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := km.reloadPublicKeys(ctx); err != nil {
+				log.WithError(err).Error("Could not refresh public keys from remote signer")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reloadPublicKeys fetches the public keys of the remote signer, and notifies the
+// subscribers of account changes if they differ from the previously loaded keys.
+func (km *Keymanager) reloadPublicKeys(ctx context.Context) ([][48]byte, error) {
+	pubKeys, err := km.client.publicKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not reload public keys")
+	}
+	sort.Slice(pubKeys, func(i, j int) bool { return bytes.Compare(pubKeys[i][:], pubKeys[j][:]) == -1 })
+
+	km.keysLock.Lock()
+	changed := km.keysLoaded && !equalKeys(km.orderedPubKeys, pubKeys)
+	km.orderedPubKeys = pubKeys
+	km.keysLoaded = true
+	km.keysLock.Unlock()
+
+	if changed {
+		log.WithField("numKeys", len(pubKeys)).Info(keymanager.KeysReloaded)
+		km.accountsChangedFeed.Send(pubKeys)
+	}
+	result := make([][48]byte, len(pubKeys))
+	copy(result, pubKeys)
+	return result, nil
+}
+
+func equalKeys(a, b [][48]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
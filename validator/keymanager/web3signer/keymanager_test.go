@@ -0,0 +1,435 @@
+package web3signer
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	types "github.com/prysmaticlabs/eth2-types"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+)
+
+var genesisValidatorsRoot = bytesutil.PadTo([]byte("genesis"), 32)
+
+// stubSigner is an in-process remote signer implementing the HTTP remote signing API.
+type stubSigner struct {
+	lock     sync.Mutex
+	keys     map[string]bls.SecretKey
+	requests []*signRequestJSON
+	status   int
+	delay    time.Duration
+}
+
+func newStubSigner(t *testing.T, numKeys int) *stubSigner {
+	s := &stubSigner{keys: make(map[string]bls.SecretKey), status: http.StatusOK}
+	for i := 0; i < numKeys; i++ {
+		s.addKey(t)
+	}
+	return s
+}
+
+func (s *stubSigner) addKey(t *testing.T) bls.SecretKey {
+	key, err := bls.RandKey()
+	require.NoError(t, err)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys[hexString(key.PublicKey().Marshal())] = key
+	return key
+}
+
+func (s *stubSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	delay := s.delay
+	s.lock.Unlock()
+	time.Sleep(delay)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == publicKeysPath:
+		pubKeys := make([]string, 0, len(s.keys))
+		for k := range s.keys {
+			pubKeys = append(pubKeys, k)
+		}
+		if err := json.NewEncoder(w).Encode(pubKeys); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, signPath):
+		key, ok := s.keys[strings.TrimPrefix(r.URL.Path, signPath)]
+		if !ok {
+			http.Error(w, "key not found", http.StatusNotFound)
+			return
+		}
+		req := &signRequestJSON{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.requests = append(s.requests, req)
+		if s.status != http.StatusOK {
+			http.Error(w, "signing failed", s.status)
+			return
+		}
+		root, err := hexutil.Decode(req.SigningRoot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := &signResponseJSON{Signature: hexString(key.Sign(root).Marshal())}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *stubSigner) lastRequest() *signRequestJSON {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[len(s.requests)-1]
+}
+
+func TestNewKeymanager_InvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewKeymanager(ctx, &SetupConfig{GenesisValidatorsRoot: genesisValidatorsRoot})
+	assert.ErrorContains(t, "remote signer URL is required", err)
+	_, err = NewKeymanager(ctx, &SetupConfig{BaseEndpoint: "http://localhost:9000"})
+	assert.ErrorContains(t, "genesis validators root is required", err)
+	_, err = NewKeymanager(ctx, &SetupConfig{BaseEndpoint: "localhost", GenesisValidatorsRoot: genesisValidatorsRoot})
+	assert.ErrorContains(t, "invalid remote signer URL", err)
+}
+
+func TestKeymanager_FetchValidatingPublicKeys_Refresh(t *testing.T) {
+	signer := newStubSigner(t, 2)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		RequestTimeout:        time.Second,
+		RefreshInterval:       10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	accountsChanged := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(accountsChanged)
+	defer sub.Unsubscribe()
+
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(keys))
+
+	added := signer.addKey(t)
+	select {
+	case changed := <-accountsChanged:
+		require.Equal(t, 3, len(changed))
+		found := false
+		for _, k := range changed {
+			found = found || k == bytesutil.ToBytes48(added.PublicKey().Marshal())
+		}
+		assert.Equal(t, true, found, "Added key was not reloaded")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Public keys were not refreshed")
+	}
+	keys, err = km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(keys))
+}
+
+func TestKeymanager_Sign(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 10
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	signer := newStubSigner(t, 1)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+	ctx := context.Background()
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keys))
+	pubKey := keys[0]
+
+	altairSlot := types.Slot(12) * params.BeaconConfig().SlotsPerEpoch
+	contribution := &ethpb.ContributionAndProof{
+		AggregatorIndex: 3,
+		Contribution: &ethpb.SyncCommitteeContribution{
+			Slot:              altairSlot,
+			BlockRoot:         bytesutil.PadTo([]byte("root"), 32),
+			SubcommitteeIndex: 2,
+			AggregationBits:   make([]byte, 16),
+			Signature:         make([]byte, 96),
+		},
+		SelectionProof: make([]byte, 96),
+	}
+	tests := []struct {
+		name  string
+		req   *validatorpb.SignRequest
+		check func(t *testing.T, req *signRequestJSON)
+	}{
+		{
+			name: "block",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_Block{Block: testBlock()},
+				SigningSlot: 5,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, blockV2Type, req.Type)
+				assert.Equal(t, phase0Version, req.BeaconBlock.Version)
+				require.NotNil(t, req.BeaconBlock.Block)
+				assert.Equal(t, "5", req.BeaconBlock.Block.Slot)
+				require.NotNil(t, req.BeaconBlock.Block.Body)
+				assert.Equal(t, 1, len(req.BeaconBlock.Block.Body.Attestations))
+				assert.Equal(t, (*syncAggregateJSON)(nil), req.BeaconBlock.Block.Body.SyncAggregate)
+				assert.Equal(t, "0", req.ForkInfo.Fork.Epoch)
+			},
+		},
+		{
+			name: "altair block",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_BlockV2{BlockV2: testBlockAltair(altairSlot)},
+				SigningSlot: altairSlot,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, blockV2Type, req.Type)
+				assert.Equal(t, altairVersion, req.BeaconBlock.Version)
+				require.NotNil(t, req.BeaconBlock.Block)
+				assert.Equal(t, fmt.Sprintf("%d", altairSlot), req.BeaconBlock.Block.Slot)
+				require.NotNil(t, req.BeaconBlock.Block.Body)
+				require.NotNil(t, req.BeaconBlock.Block.Body.SyncAggregate)
+				assert.Equal(t, hexutil.Encode(make([]byte, 96)), req.BeaconBlock.Block.Body.SyncAggregate.SyncCommitteeSignature)
+			},
+		},
+		{
+			name: "attestation",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_AttestationData{AttestationData: testAttestationData(altairSlot)},
+				SigningSlot: altairSlot,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, attestationType, req.Type)
+				assert.Equal(t, fmt.Sprintf("%d", altairSlot), req.Attestation.Slot)
+				assert.Equal(t, "2", req.Attestation.Target.Epoch)
+				assert.Equal(t, hexString(params.BeaconConfig().GenesisForkVersion), req.ForkInfo.Fork.PreviousVersion)
+				assert.Equal(t, hexString(params.BeaconConfig().AltairForkVersion), req.ForkInfo.Fork.CurrentVersion)
+				assert.Equal(t, "10", req.ForkInfo.Fork.Epoch)
+			},
+		},
+		{
+			name: "randao reveal",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_Epoch{Epoch: 12},
+				SigningSlot: altairSlot,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, randaoRevealType, req.Type)
+				assert.Equal(t, "12", req.RandaoReveal.Epoch)
+			},
+		},
+		{
+			name: "sync committee message",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_SyncMessageBlockRoot{SyncMessageBlockRoot: bytesutil.PadTo([]byte("root"), 32)},
+				SigningSlot: altairSlot,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, syncCommitteeMessageType, req.Type)
+				assert.Equal(t, fmt.Sprintf("%d", altairSlot), req.SyncCommitteeMessage.Slot)
+				assert.Equal(t, hexString(bytesutil.PadTo([]byte("root"), 32)), req.SyncCommitteeMessage.BeaconBlockRoot)
+			},
+		},
+		{
+			name: "sync committee contribution",
+			req: &validatorpb.SignRequest{
+				Object:      &validatorpb.SignRequest_ContributionAndProof{ContributionAndProof: contribution},
+				SigningSlot: altairSlot,
+			},
+			check: func(t *testing.T, req *signRequestJSON) {
+				assert.Equal(t, syncCommitteeContributionAndProofType, req.Type)
+				assert.Equal(t, "3", req.ContributionAndProof.AggregatorIndex)
+				assert.Equal(t, "2", req.ContributionAndProof.Contribution.SubcommitteeIndex)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			req.PublicKey = pubKey[:]
+			req.SigningRoot = bytesutil.PadTo([]byte(tt.name), 32)
+			sig, err := km.Sign(ctx, req)
+			require.NoError(t, err)
+			blsPubKey, err := bls.PublicKeyFromBytes(pubKey[:])
+			require.NoError(t, err)
+			assert.Equal(t, true, sig.Verify(blsPubKey, req.SigningRoot))
+
+			signReq := signer.lastRequest()
+			assert.Equal(t, hexString(req.SigningRoot), signReq.SigningRoot)
+			assert.Equal(t, hexString(genesisValidatorsRoot), signReq.ForkInfo.GenesisValidatorsRoot)
+			tt.check(t, signReq)
+		})
+	}
+}
+
+func TestKeymanager_Sign_Errors(t *testing.T) {
+	signer := newStubSigner(t, 1)
+	srv := httptest.NewServer(signer)
+	defer srv.Close()
+	ctx := context.Background()
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		RequestTimeout:        100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	req := &validatorpb.SignRequest{
+		PublicKey:   keys[0][:],
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Slot{Slot: 1},
+		SigningSlot: 1,
+	}
+
+	unknownKey, err := bls.RandKey()
+	require.NoError(t, err)
+	_, err = km.Sign(ctx, &validatorpb.SignRequest{
+		PublicKey:   unknownKey.PublicKey().Marshal(),
+		SigningRoot: make([]byte, 32),
+		Object:      &validatorpb.SignRequest_Slot{Slot: 1},
+	})
+	assert.ErrorContains(t, ErrUnknownKey.Error(), err)
+
+	_, err = km.Sign(ctx, &validatorpb.SignRequest{PublicKey: keys[0][:], SigningRoot: make([]byte, 32)})
+	assert.ErrorContains(t, "unsupported sign request object", err)
+
+	signer.lock.Lock()
+	signer.status = http.StatusPreconditionFailed
+	signer.lock.Unlock()
+	_, err = km.Sign(ctx, req)
+	assert.ErrorContains(t, ErrSigningDenied.Error(), err)
+	assert.Equal(t, aggregationSlotType, signer.lastRequest().Type)
+
+	signer.lock.Lock()
+	signer.status = http.StatusInternalServerError
+	signer.lock.Unlock()
+	_, err = km.Sign(ctx, req)
+	assert.ErrorContains(t, ErrSigningFailed.Error(), err)
+
+	signer.lock.Lock()
+	signer.status = http.StatusOK
+	signer.delay = time.Second
+	signer.lock.Unlock()
+	_, err = km.Sign(ctx, req)
+	assert.ErrorContains(t, "could not send request to remote signer", err)
+}
+
+func TestKeymanager_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(newStubSigner(t, 1))
+	defer srv.Close()
+	ctx := context.Background()
+
+	// The certificate of the signer is not trusted without its CA certificate.
+	km, err := NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		TLS:                   &TLSConfig{},
+	})
+	require.NoError(t, err)
+	_, err = km.FetchValidatingPublicKeys(ctx)
+	assert.ErrorContains(t, "could not send request to remote signer", err)
+
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, ioutil.WriteFile(caPath, caCert, 0600))
+	km, err = NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		TLS:                   &TLSConfig{CACertPath: caPath},
+	})
+	require.NoError(t, err)
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(keys))
+
+	_, err = NewKeymanager(ctx, &SetupConfig{
+		BaseEndpoint:          srv.URL,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		TLS:                   &TLSConfig{ClientCertPath: caPath},
+	})
+	assert.ErrorContains(t, "both a client certificate and a client key are required", err)
+}
+
+func testBlock() *ethpb.BeaconBlock {
+	return &ethpb.BeaconBlock{
+		Slot:       5,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		Body: &ethpb.BeaconBlockBody{
+			RandaoReveal: make([]byte, 96),
+			Eth1Data: &ethpb.Eth1Data{
+				DepositRoot: make([]byte, 32),
+				BlockHash:   make([]byte, 32),
+			},
+			Graffiti: make([]byte, 32),
+			Attestations: []*ethpb.Attestation{{
+				AggregationBits: []byte{0b11},
+				Data:            testAttestationData(5),
+				Signature:       make([]byte, 96),
+			}},
+		},
+	}
+}
+
+func testBlockAltair(slot types.Slot) *ethpb.BeaconBlockAltair {
+	return &ethpb.BeaconBlockAltair{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		Body: &ethpb.BeaconBlockBodyAltair{
+			RandaoReveal: make([]byte, 96),
+			Eth1Data: &ethpb.Eth1Data{
+				DepositRoot: make([]byte, 32),
+				BlockHash:   make([]byte, 32),
+			},
+			Graffiti: make([]byte, 32),
+			SyncAggregate: &ethpb.SyncAggregate{
+				SyncCommitteeBits:      make([]byte, 64),
+				SyncCommitteeSignature: make([]byte, 96),
+			},
+		},
+	}
+}
+
+func testAttestationData(slot types.Slot) *ethpb.AttestationData {
+	return &ethpb.AttestationData{
+		Slot:            slot,
+		BeaconBlockRoot: make([]byte, 32),
+		Source:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+	}
+}
@@ -0,0 +1,5 @@
+package web3signer
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "web3signer-keymanager")
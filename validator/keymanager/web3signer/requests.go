@@ -0,0 +1,446 @@
+package web3signer
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core"
+	ethpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1"
+	validatorpb "github.com/prysmaticlabs/prysm/proto/prysm/v1alpha1/validator-client"
+	"github.com/prysmaticlabs/prysm/shared/p2putils"
+)
+
+// The types of the typed signing requests of the remote signing API.
+const (
+	blockV2Type                           = "BLOCK_V2"
+	attestationType                       = "ATTESTATION"
+	aggregateAndProofType                 = "AGGREGATE_AND_PROOF"
+	aggregationSlotType                   = "AGGREGATION_SLOT"
+	randaoRevealType                      = "RANDAO_REVEAL"
+	voluntaryExitType                     = "VOLUNTARY_EXIT"
+	syncCommitteeMessageType              = "SYNC_COMMITTEE_MESSAGE"
+	syncCommitteeSelectionProofType       = "SYNC_COMMITTEE_SELECTION_PROOF"
+	syncCommitteeContributionAndProofType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+)
+
+// The block versions of BLOCK_V2 signing requests.
+const (
+	phase0Version = "PHASE0"
+	altairVersion = "ALTAIR"
+)
+
+type signRequestJSON struct {
+	Type                        string                           `json:"type"`
+	ForkInfo                    *forkInfoJSON                    `json:"fork_info"`
+	SigningRoot                 string                           `json:"signingRoot"`
+	BeaconBlock                 *beaconBlockV2JSON               `json:"beacon_block,omitempty"`
+	Attestation                 *attestationDataJSON             `json:"attestation,omitempty"`
+	AggregateAndProof           *aggregateAndProofJSON           `json:"aggregate_and_proof,omitempty"`
+	AggregationSlot             *aggregationSlotJSON             `json:"aggregation_slot,omitempty"`
+	RandaoReveal                *randaoRevealJSON                `json:"randao_reveal,omitempty"`
+	VoluntaryExit               *voluntaryExitJSON               `json:"voluntary_exit,omitempty"`
+	SyncCommitteeMessage        *syncCommitteeMessageJSON        `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *syncAggregatorSelectionDataJSON `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *contributionAndProofJSON        `json:"contribution_and_proof,omitempty"`
+}
+
+type signResponseJSON struct {
+	Signature string `json:"signature"`
+}
+
+type forkInfoJSON struct {
+	Fork                  *forkJSON `json:"fork"`
+	GenesisValidatorsRoot string    `json:"genesis_validators_root"`
+}
+
+type forkJSON struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
+// The full block is sent for the PHASE0 and ALTAIR versions of BLOCK_V2 signing requests, while
+// later versions only send the block header.
+type beaconBlockV2JSON struct {
+	Version string           `json:"version"`
+	Block   *beaconBlockJSON `json:"block"`
+}
+
+type beaconBlockJSON struct {
+	Slot          string               `json:"slot"`
+	ProposerIndex string               `json:"proposer_index"`
+	ParentRoot    string               `json:"parent_root"`
+	StateRoot     string               `json:"state_root"`
+	Body          *beaconBlockBodyJSON `json:"body"`
+}
+
+// The sync aggregate is only part of the body of altair blocks.
+type beaconBlockBodyJSON struct {
+	RandaoReveal      string                     `json:"randao_reveal"`
+	Eth1Data          *eth1DataJSON              `json:"eth1_data"`
+	Graffiti          string                     `json:"graffiti"`
+	ProposerSlashings []*proposerSlashingJSON    `json:"proposer_slashings"`
+	AttesterSlashings []*attesterSlashingJSON    `json:"attester_slashings"`
+	Attestations      []*attestationJSON         `json:"attestations"`
+	Deposits          []*depositJSON             `json:"deposits"`
+	VoluntaryExits    []*signedVoluntaryExitJSON `json:"voluntary_exits"`
+	SyncAggregate     *syncAggregateJSON         `json:"sync_aggregate,omitempty"`
+}
+
+type eth1DataJSON struct {
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount string `json:"deposit_count"`
+	BlockHash    string `json:"block_hash"`
+}
+
+type beaconBlockHeaderJSON struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type signedBeaconBlockHeaderJSON struct {
+	Message   *beaconBlockHeaderJSON `json:"message"`
+	Signature string                 `json:"signature"`
+}
+
+type proposerSlashingJSON struct {
+	SignedHeader1 *signedBeaconBlockHeaderJSON `json:"signed_header_1"`
+	SignedHeader2 *signedBeaconBlockHeaderJSON `json:"signed_header_2"`
+}
+
+type indexedAttestationJSON struct {
+	AttestingIndices []string             `json:"attesting_indices"`
+	Data             *attestationDataJSON `json:"data"`
+	Signature        string               `json:"signature"`
+}
+
+type attesterSlashingJSON struct {
+	Attestation1 *indexedAttestationJSON `json:"attestation_1"`
+	Attestation2 *indexedAttestationJSON `json:"attestation_2"`
+}
+
+type depositDataJSON struct {
+	Pubkey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	Signature             string `json:"signature"`
+}
+
+type depositJSON struct {
+	Proof []string         `json:"proof"`
+	Data  *depositDataJSON `json:"data"`
+}
+
+type signedVoluntaryExitJSON struct {
+	Message   *voluntaryExitJSON `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type syncAggregateJSON struct {
+	SyncCommitteeBits      string `json:"sync_committee_bits"`
+	SyncCommitteeSignature string `json:"sync_committee_signature"`
+}
+
+type checkpointJSON struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type attestationDataJSON struct {
+	Slot            string          `json:"slot"`
+	Index           string          `json:"index"`
+	BeaconBlockRoot string          `json:"beacon_block_root"`
+	Source          *checkpointJSON `json:"source"`
+	Target          *checkpointJSON `json:"target"`
+}
+
+type attestationJSON struct {
+	AggregationBits string               `json:"aggregation_bits"`
+	Data            *attestationDataJSON `json:"data"`
+	Signature       string               `json:"signature"`
+}
+
+type aggregateAndProofJSON struct {
+	AggregatorIndex string           `json:"aggregator_index"`
+	Aggregate       *attestationJSON `json:"aggregate"`
+	SelectionProof  string           `json:"selection_proof"`
+}
+
+type aggregationSlotJSON struct {
+	Slot string `json:"slot"`
+}
+
+type randaoRevealJSON struct {
+	Epoch string `json:"epoch"`
+}
+
+type voluntaryExitJSON struct {
+	Epoch          string `json:"epoch"`
+	ValidatorIndex string `json:"validator_index"`
+}
+
+type syncCommitteeMessageJSON struct {
+	BeaconBlockRoot string `json:"beacon_block_root"`
+	Slot            string `json:"slot"`
+}
+
+type syncAggregatorSelectionDataJSON struct {
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+}
+
+type contributionAndProofJSON struct {
+	AggregatorIndex string                         `json:"aggregator_index"`
+	SelectionProof  string                         `json:"selection_proof"`
+	Contribution    *syncCommitteeContributionJSON `json:"contribution"`
+}
+
+type syncCommitteeContributionJSON struct {
+	Slot              string `json:"slot"`
+	BeaconBlockRoot   string `json:"beacon_block_root"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+	AggregationBits   string `json:"aggregation_bits"`
+	Signature         string `json:"signature"`
+}
+
+// newSignRequest converts a sign request of the validator client into the typed signing
+// request of the remote signing API, including the fork information of the signing slot.
+func newSignRequest(req *validatorpb.SignRequest, genesisValidatorsRoot []byte) (*signRequestJSON, error) {
+	fork, err := p2putils.Fork(core.SlotToEpoch(req.SigningSlot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not determine fork of signing slot")
+	}
+	r := &signRequestJSON{
+		ForkInfo: &forkInfoJSON{
+			Fork: &forkJSON{
+				PreviousVersion: hexString(fork.PreviousVersion),
+				CurrentVersion:  hexString(fork.CurrentVersion),
+				Epoch:           uintString(uint64(fork.Epoch)),
+			},
+			GenesisValidatorsRoot: hexString(genesisValidatorsRoot),
+		},
+		SigningRoot: hexString(req.SigningRoot),
+	}
+	switch obj := req.Object.(type) {
+	case *validatorpb.SignRequest_Block:
+		b := obj.Block
+		r.Type = blockV2Type
+		r.BeaconBlock = &beaconBlockV2JSON{
+			Version: phase0Version,
+			Block: &beaconBlockJSON{
+				Slot:          uintString(uint64(b.Slot)),
+				ProposerIndex: uintString(uint64(b.ProposerIndex)),
+				ParentRoot:    hexString(b.ParentRoot),
+				StateRoot:     hexString(b.StateRoot),
+				Body: blockBodyToJSON(
+					b.Body.RandaoReveal, b.Body.Eth1Data, b.Body.Graffiti, b.Body.ProposerSlashings,
+					b.Body.AttesterSlashings, b.Body.Attestations, b.Body.Deposits, b.Body.VoluntaryExits,
+				),
+			},
+		}
+	case *validatorpb.SignRequest_BlockV2:
+		b := obj.BlockV2
+		body := blockBodyToJSON(
+			b.Body.RandaoReveal, b.Body.Eth1Data, b.Body.Graffiti, b.Body.ProposerSlashings,
+			b.Body.AttesterSlashings, b.Body.Attestations, b.Body.Deposits, b.Body.VoluntaryExits,
+		)
+		if b.Body.SyncAggregate != nil {
+			body.SyncAggregate = &syncAggregateJSON{
+				SyncCommitteeBits:      hexString(b.Body.SyncAggregate.SyncCommitteeBits),
+				SyncCommitteeSignature: hexString(b.Body.SyncAggregate.SyncCommitteeSignature),
+			}
+		}
+		r.Type = blockV2Type
+		r.BeaconBlock = &beaconBlockV2JSON{
+			Version: altairVersion,
+			Block: &beaconBlockJSON{
+				Slot:          uintString(uint64(b.Slot)),
+				ProposerIndex: uintString(uint64(b.ProposerIndex)),
+				ParentRoot:    hexString(b.ParentRoot),
+				StateRoot:     hexString(b.StateRoot),
+				Body:          body,
+			},
+		}
+	case *validatorpb.SignRequest_AttestationData:
+		r.Type = attestationType
+		r.Attestation = attestationDataToJSON(obj.AttestationData)
+	case *validatorpb.SignRequest_AggregateAttestationAndProof:
+		agg := obj.AggregateAttestationAndProof
+		r.Type = aggregateAndProofType
+		r.AggregateAndProof = &aggregateAndProofJSON{
+			AggregatorIndex: uintString(uint64(agg.AggregatorIndex)),
+			Aggregate: &attestationJSON{
+				AggregationBits: hexString(agg.Aggregate.AggregationBits),
+				Data:            attestationDataToJSON(agg.Aggregate.Data),
+				Signature:       hexString(agg.Aggregate.Signature),
+			},
+			SelectionProof: hexString(agg.SelectionProof),
+		}
+	case *validatorpb.SignRequest_Slot:
+		r.Type = aggregationSlotType
+		r.AggregationSlot = &aggregationSlotJSON{Slot: uintString(uint64(obj.Slot))}
+	case *validatorpb.SignRequest_Epoch:
+		r.Type = randaoRevealType
+		r.RandaoReveal = &randaoRevealJSON{Epoch: uintString(uint64(obj.Epoch))}
+	case *validatorpb.SignRequest_Exit:
+		r.Type = voluntaryExitType
+		r.VoluntaryExit = &voluntaryExitJSON{
+			Epoch:          uintString(uint64(obj.Exit.Epoch)),
+			ValidatorIndex: uintString(uint64(obj.Exit.ValidatorIndex)),
+		}
+	case *validatorpb.SignRequest_SyncMessageBlockRoot:
+		r.Type = syncCommitteeMessageType
+		r.SyncCommitteeMessage = &syncCommitteeMessageJSON{
+			BeaconBlockRoot: hexString(obj.SyncMessageBlockRoot),
+			Slot:            uintString(uint64(req.SigningSlot)),
+		}
+	case *validatorpb.SignRequest_SyncAggregatorSelectionData:
+		r.Type = syncCommitteeSelectionProofType
+		r.SyncAggregatorSelectionData = &syncAggregatorSelectionDataJSON{
+			Slot:              uintString(uint64(obj.SyncAggregatorSelectionData.Slot)),
+			SubcommitteeIndex: uintString(obj.SyncAggregatorSelectionData.SubcommitteeIndex),
+		}
+	case *validatorpb.SignRequest_ContributionAndProof:
+		c := obj.ContributionAndProof
+		r.Type = syncCommitteeContributionAndProofType
+		r.ContributionAndProof = &contributionAndProofJSON{
+			AggregatorIndex: uintString(uint64(c.AggregatorIndex)),
+			SelectionProof:  hexString(c.SelectionProof),
+			Contribution: &syncCommitteeContributionJSON{
+				Slot:              uintString(uint64(c.Contribution.Slot)),
+				BeaconBlockRoot:   hexString(c.Contribution.BlockRoot),
+				SubcommitteeIndex: uintString(c.Contribution.SubcommitteeIndex),
+				AggregationBits:   hexString(c.Contribution.AggregationBits),
+				Signature:         hexString(c.Contribution.Signature),
+			},
+		}
+	default:
+		return nil, errors.Errorf("unsupported sign request object %T", req.Object)
+	}
+	return r, nil
+}
+
+// Converts the fields shared by the bodies of phase0 and altair blocks.
+func blockBodyToJSON(
+	randaoReveal []byte,
+	eth1Data *ethpb.Eth1Data,
+	graffiti []byte,
+	proposerSlashings []*ethpb.ProposerSlashing,
+	attesterSlashings []*ethpb.AttesterSlashing,
+	atts []*ethpb.Attestation,
+	deposits []*ethpb.Deposit,
+	exits []*ethpb.SignedVoluntaryExit,
+) *beaconBlockBodyJSON {
+	body := &beaconBlockBodyJSON{
+		RandaoReveal:      hexString(randaoReveal),
+		Graffiti:          hexString(graffiti),
+		ProposerSlashings: make([]*proposerSlashingJSON, len(proposerSlashings)),
+		AttesterSlashings: make([]*attesterSlashingJSON, len(attesterSlashings)),
+		Attestations:      make([]*attestationJSON, len(atts)),
+		Deposits:          make([]*depositJSON, len(deposits)),
+		VoluntaryExits:    make([]*signedVoluntaryExitJSON, len(exits)),
+	}
+	if eth1Data != nil {
+		body.Eth1Data = &eth1DataJSON{
+			DepositRoot:  hexString(eth1Data.DepositRoot),
+			DepositCount: uintString(eth1Data.DepositCount),
+			BlockHash:    hexString(eth1Data.BlockHash),
+		}
+	}
+	for i, s := range proposerSlashings {
+		body.ProposerSlashings[i] = &proposerSlashingJSON{
+			SignedHeader1: signedBlockHeaderToJSON(s.Header_1),
+			SignedHeader2: signedBlockHeaderToJSON(s.Header_2),
+		}
+	}
+	for i, s := range attesterSlashings {
+		body.AttesterSlashings[i] = &attesterSlashingJSON{
+			Attestation1: indexedAttestationToJSON(s.Attestation_1),
+			Attestation2: indexedAttestationToJSON(s.Attestation_2),
+		}
+	}
+	for i, att := range atts {
+		body.Attestations[i] = &attestationJSON{
+			AggregationBits: hexString(att.AggregationBits),
+			Data:            attestationDataToJSON(att.Data),
+			Signature:       hexString(att.Signature),
+		}
+	}
+	for i, d := range deposits {
+		proof := make([]string, len(d.Proof))
+		for j, p := range d.Proof {
+			proof[j] = hexString(p)
+		}
+		body.Deposits[i] = &depositJSON{
+			Proof: proof,
+			Data: &depositDataJSON{
+				Pubkey:                hexString(d.Data.PublicKey),
+				WithdrawalCredentials: hexString(d.Data.WithdrawalCredentials),
+				Amount:                uintString(d.Data.Amount),
+				Signature:             hexString(d.Data.Signature),
+			},
+		}
+	}
+	for i, e := range exits {
+		body.VoluntaryExits[i] = &signedVoluntaryExitJSON{
+			Message: &voluntaryExitJSON{
+				Epoch:          uintString(uint64(e.Exit.Epoch)),
+				ValidatorIndex: uintString(uint64(e.Exit.ValidatorIndex)),
+			},
+			Signature: hexString(e.Signature),
+		}
+	}
+	return body
+}
+
+func signedBlockHeaderToJSON(h *ethpb.SignedBeaconBlockHeader) *signedBeaconBlockHeaderJSON {
+	return &signedBeaconBlockHeaderJSON{
+		Message: &beaconBlockHeaderJSON{
+			Slot:          uintString(uint64(h.Header.Slot)),
+			ProposerIndex: uintString(uint64(h.Header.ProposerIndex)),
+			ParentRoot:    hexString(h.Header.ParentRoot),
+			StateRoot:     hexString(h.Header.StateRoot),
+			BodyRoot:      hexString(h.Header.BodyRoot),
+		},
+		Signature: hexString(h.Signature),
+	}
+}
+
+func indexedAttestationToJSON(att *ethpb.IndexedAttestation) *indexedAttestationJSON {
+	indices := make([]string, len(att.AttestingIndices))
+	for i, idx := range att.AttestingIndices {
+		indices[i] = uintString(idx)
+	}
+	return &indexedAttestationJSON{
+		AttestingIndices: indices,
+		Data:             attestationDataToJSON(att.Data),
+		Signature:        hexString(att.Signature),
+	}
+}
+
+func attestationDataToJSON(data *ethpb.AttestationData) *attestationDataJSON {
+	return &attestationDataJSON{
+		Slot:            uintString(uint64(data.Slot)),
+		Index:           uintString(uint64(data.CommitteeIndex)),
+		BeaconBlockRoot: hexString(data.BeaconBlockRoot),
+		Source: &checkpointJSON{
+			Epoch: uintString(uint64(data.Source.Epoch)),
+			Root:  hexString(data.Source.Root),
+		},
+		Target: &checkpointJSON{
+			Epoch: uintString(uint64(data.Target.Epoch)),
+			Root:  hexString(data.Target.Root),
+		},
+	}
+}
+
+func hexString(b []byte) string {
+	return fmt.Sprintf("%#x", b)
+}
+
+func uintString(i uint64) string {
+	return fmt.Sprintf("%d", i)
+}
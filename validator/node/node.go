@@ -14,6 +14,7 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/cmd/validator/flags"
@@ -39,6 +40,7 @@ import (
 	g "github.com/prysmaticlabs/prysm/validator/graffiti"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/web3signer"
 	"github.com/prysmaticlabs/prysm/validator/rpc"
 	slashingprotection "github.com/prysmaticlabs/prysm/validator/slashing-protection"
 	"github.com/prysmaticlabs/prysm/validator/slashing-protection/iface"
@@ -183,6 +185,11 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "could not generate interop keys")
 		}
+	} else if cliCtx.IsSet(flags.Web3SignerURLFlag.Name) {
+		keyManager, err = c.web3SignerKeymanager(cliCtx)
+		if err != nil {
+			return errors.Wrap(err, "could not initialize web3signer keymanager")
+		}
 	} else {
 		// Read the wallet from the specified path.
 		w, err := wallet.OpenWalletOrElseCli(cliCtx, func(cliCtx *cli.Context) (*wallet.Wallet, error) {
@@ -270,6 +277,38 @@ func (c *ValidatorClient) initializeFromCLI(cliCtx *cli.Context) error {
 	return nil
 }
 
+// web3SignerKeymanager creates a keymanager signing with the keys of the remote signer given by
+// the --web3signer-url flag, whose public keys are refreshed until the validator client is closed.
+func (c *ValidatorClient) web3SignerKeymanager(cliCtx *cli.Context) (keymanager.IKeymanager, error) {
+	genesisValidatorsRoot, err := hexutil.Decode(cliCtx.String(flags.Web3SignerGenesisValidatorsRootFlag.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode --%s", flags.Web3SignerGenesisValidatorsRootFlag.Name)
+	}
+	var tlsCfg *web3signer.TLSConfig
+	caCertPath := cliCtx.String(flags.Web3SignerCACertPathFlag.Name)
+	certPath := cliCtx.String(flags.Web3SignerCertPathFlag.Name)
+	keyPath := cliCtx.String(flags.Web3SignerKeyPathFlag.Name)
+	if caCertPath != "" || certPath != "" || keyPath != "" {
+		tlsCfg = &web3signer.TLSConfig{
+			CACertPath:     caCertPath,
+			ClientCertPath: certPath,
+			ClientKeyPath:  keyPath,
+		}
+	}
+	km, err := web3signer.NewKeymanager(c.ctx, &web3signer.SetupConfig{
+		BaseEndpoint:          cliCtx.String(flags.Web3SignerURLFlag.Name),
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+		TLS:                   tlsCfg,
+		RequestTimeout:        cliCtx.Duration(flags.Web3SignerTimeoutFlag.Name),
+		RefreshInterval:       cliCtx.Duration(flags.Web3SignerRefreshIntervalFlag.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.WithField("url", km.BaseEndpoint()).Info("Signing with the keys of a remote signer")
+	return km, nil
+}
+
 func (c *ValidatorClient) initializeForWeb(cliCtx *cli.Context) error {
 	var keyManager keymanager.IKeymanager
 	var err error
@@ -429,6 +468,10 @@ func (c *ValidatorClient) registerValidatorService(
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
 		LogDutyCountDown:           c.cliCtx.Bool(flags.EnableDutyCountDown.Name),
+		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		BeaconApiTimeout:           c.cliCtx.Duration(flags.BeaconRESTApiTimeoutFlag.Name),
+		DoppelgangerEpochs:         c.cliCtx.Uint64(flags.DoppelgangerEpochsFlag.Name),
+		BeaconNodeBroadcast:        c.cliCtx.Bool(flags.EnableBeaconNodeBroadcastFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize validator service")
@@ -479,6 +522,7 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context, km keymanager.
 	walletDir := cliCtx.String(flags.WalletDirFlag.Name)
 	grpcHeaders := c.cliCtx.String(flags.GrpcHeadersFlag.Name)
 	clientCert := c.cliCtx.String(flags.CertFlag.Name)
+	authTokenPath := cliCtx.String(flags.KeymanagerAuthTokenFileFlag.Name)
 	server := rpc.NewServer(cliCtx.Context, &rpc.Config{
 		ValDB:                    c.db,
 		Host:                     rpcHost,
@@ -501,11 +545,16 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context, km keymanager.
 		ClientGrpcRetryDelay:     grpcRetryDelay,
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
+		AuthTokenPath:            authTokenPath,
 	})
 	return c.services.RegisterService(server)
 }
 
 func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
+	var rpcServer *rpc.Server
+	if err := c.services.FetchService(&rpcServer); err != nil {
+		return err
+	}
 	gatewayHost := cliCtx.String(flags.GRPCGatewayHost.Name)
 	if gatewayHost != flags.DefaultGatewayHost {
 		log.WithField("web-host", gatewayHost).Warn(
@@ -558,13 +607,17 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		Mux:           mux,
 	}
 
+	// The standard keymanager API is served outside of the /api prefix of the web UI.
+	gwMux := http.NewServeMux()
+	gwMux.Handle("/eth/v1/", rpcServer.KeymanagerAPIHandler())
+
 	gw := gateway.New(
 		cliCtx.Context,
 		[]gateway.PbMux{pbHandler},
 		muxHandler,
 		rpcAddr,
 		gatewayAddress,
-	).WithAllowedOrigins(allowedOrigins).WithMaxCallRecvMsgSize(maxCallSize)
+	).WithAllowedOrigins(allowedOrigins).WithMaxCallRecvMsgSize(maxCallSize).WithMux(gwMux)
 
 	return c.services.RegisterService(gw)
 }
@@ -14,7 +14,8 @@ import (
 )
 
 // GetBeaconNodeConnection retrieves the current beacon node connection
-// information, as well as its sync status.
+// information, as well as its sync status and the health of each configured
+// beacon node.
 func (s *Server) GetBeaconNodeConnection(ctx context.Context, _ *emptypb.Empty) (*validatorpb.NodeConnectionResponse, error) {
 	syncStatus, err := s.syncChecker.Syncing(ctx)
 	if err != nil || s.validatorService.Status() != nil {
@@ -23,6 +24,7 @@ func (s *Server) GetBeaconNodeConnection(ctx context.Context, _ *emptypb.Empty)
 			BeaconNodeEndpoint: s.nodeGatewayEndpoint,
 			Connected:          false,
 			Syncing:            false,
+			BeaconNodes:        s.beaconNodesHealth(),
 		}, nil
 	}
 	genesis, err := s.genesisFetcher.GenesisInfo(ctx)
@@ -35,9 +37,27 @@ func (s *Server) GetBeaconNodeConnection(ctx context.Context, _ *emptypb.Empty)
 		BeaconNodeEndpoint:     s.nodeGatewayEndpoint,
 		Connected:              true,
 		Syncing:                syncStatus,
+		BeaconNodes:            s.beaconNodesHealth(),
 	}, nil
 }
 
+func (s *Server) beaconNodesHealth() []*validatorpb.BeaconNodeHealth {
+	var nodes []*validatorpb.BeaconNodeHealth
+	for _, h := range s.validatorService.BeaconNodesHealth() {
+		nodes = append(nodes, &validatorpb.BeaconNodeHealth{
+			Endpoint:  h.Endpoint,
+			Connected: h.Connected,
+			Syncing:   h.Syncing,
+			HeadSlot:  uint64(h.HeadSlot),
+			Peers:     h.Peers,
+			ErrorRate: h.ErrorRate,
+			Healthy:   h.Healthy,
+			Active:    h.Active,
+		})
+	}
+	return nodes
+}
+
 // GetLogsEndpoints for the beacon and validator client.
 func (s *Server) GetLogsEndpoints(ctx context.Context, _ *emptypb.Empty) (*validatorpb.LogsEndpointResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "unimplemented")
@@ -54,6 +54,7 @@ type Config struct {
 	NodeGatewayEndpoint      string
 	Wallet                   *wallet.Wallet
 	Keymanager               keymanager.IKeymanager
+	AuthTokenPath            string
 }
 
 // Server defining a gRPC server for the remote signer API.
@@ -94,6 +95,8 @@ type Server struct {
 	validatorMonitoringPort   int
 	validatorGatewayHost      string
 	validatorGatewayPort      int
+	authTokenPath             string
+	authToken                 string
 }
 
 // NewServer instantiates a new gRPC server.
@@ -128,6 +131,7 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		validatorMonitoringPort:  cfg.ValidatorMonitoringPort,
 		validatorGatewayHost:     cfg.ValidatorGatewayHost,
 		validatorGatewayPort:     cfg.ValidatorGatewayPort,
+		authTokenPath:            cfg.AuthTokenPath,
 	}
 }
 
@@ -181,6 +185,11 @@ func (s *Server) Start() {
 	}
 	s.jwtKey = jwtKey
 
+	// We create a new, random bearer token for the standard keymanager API upon validator startup.
+	if err := s.initializeAuthToken(); err != nil {
+		log.WithError(err).Fatal("Could not initialize keymanager API auth token")
+	}
+
 	// Register services available for the gRPC server.
 	reflection.Register(s.grpcServer)
 	validatorpb.RegisterAuthServer(s.grpcServer, s)
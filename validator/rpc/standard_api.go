@@ -0,0 +1,387 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/fileutil"
+	"github.com/prysmaticlabs/prysm/shared/rand"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/derived"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/remote"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/web3signer"
+	slashing "github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+)
+
+// AuthTokenFileName is the name of the file, in the wallet directory, the bearer token of the
+// standard keymanager API is written to unless another path is configured.
+const AuthTokenFileName = "auth-token"
+
+const (
+	keystoresPath  = "/eth/v1/keystores"
+	remoteKeysPath = "/eth/v1/remotekeys"
+)
+
+// keystoreJSON is a key listed by the keystores endpoint.
+type keystoreJSON struct {
+	ValidatingPubkey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	Readonly         bool   `json:"readonly"`
+}
+
+type listKeystoresResponseJSON struct {
+	Data []*keystoreJSON `json:"data"`
+}
+
+type importKeystoresRequestJSON struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection"`
+}
+
+type deleteKeysRequestJSON struct {
+	Pubkeys []string `json:"pubkeys"`
+}
+
+type keyStatusesResponseJSON struct {
+	Data []*keymanager.KeyStatus `json:"data"`
+}
+
+type deleteKeystoresResponseJSON struct {
+	Data               []*keymanager.KeyStatus `json:"data"`
+	SlashingProtection string                  `json:"slashing_protection"`
+}
+
+// remoteKeyJSON is a key listed by, or imported through, the remote keys endpoint.
+type remoteKeyJSON struct {
+	Pubkey   string `json:"pubkey"`
+	URL      string `json:"url"`
+	Readonly bool   `json:"readonly"`
+}
+
+type listRemoteKeysResponseJSON struct {
+	Data []*remoteKeyJSON `json:"data"`
+}
+
+type importRemoteKeysRequestJSON struct {
+	RemoteKeys []*remoteKeyJSON `json:"remote_keys"`
+}
+
+type keymanagerAPIErrorJSON struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// KeymanagerAPIHandler returns the HTTP handler of the standard keymanager API, which serves
+// the keystores and remote keys endpoints to the holders of the bearer token of the server.
+func (s *Server) KeymanagerAPIHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(keystoresPath, s.authorizeKeymanagerAPI(s.handleKeystores))
+	mux.HandleFunc(remoteKeysPath, s.authorizeKeymanagerAPI(s.handleRemoteKeys))
+	return mux
+}
+
+// initializeAuthToken creates a new, random bearer token for the standard keymanager API,
+// and writes it to the auth token file so that it can be read by the API clients.
+func (s *Server) initializeAuthToken() error {
+	token := make([]byte, 32)
+	if _, err := rand.NewGenerator().Read(token); err != nil {
+		return errors.Wrap(err, "could not generate auth token")
+	}
+	tokenPath := s.authTokenPath
+	if tokenPath == "" {
+		tokenPath = filepath.Join(s.walletDir, AuthTokenFileName)
+	}
+	hasDir, err := fileutil.HasDir(filepath.Dir(tokenPath))
+	if err != nil {
+		return err
+	}
+	if !hasDir {
+		if err := fileutil.MkdirAll(filepath.Dir(tokenPath)); err != nil {
+			return errors.Wrapf(err, "could not create directory of auth token file %s", tokenPath)
+		}
+	}
+	encoded := hex.EncodeToString(token)
+	if err := fileutil.WriteFile(tokenPath, []byte(encoded)); err != nil {
+		return errors.Wrapf(err, "could not write auth token file %s", tokenPath)
+	}
+	s.authToken = encoded
+	log.WithField("path", tokenPath).Info("Wrote keymanager API auth token to file")
+	return nil
+}
+
+// authorizeKeymanagerAPI only lets through the requests bearing the auth token of the server.
+func (s *Server) authorizeKeymanagerAPI(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		authHeader := req.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			writeKeymanagerAPIError(w, http.StatusUnauthorized, "Authorization token could not be found")
+			return
+		}
+		token := strings.TrimPrefix(authHeader, "Bearer ")
+		if s.authToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) != 1 {
+			writeKeymanagerAPIError(w, http.StatusForbidden, "Invalid authorization token")
+			return
+		}
+		handler(w, req)
+	}
+}
+
+func (s *Server) handleKeystores(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		s.listKeystores(w, req)
+	case http.MethodPost:
+		s.importKeystores(w, req)
+	case http.MethodDelete:
+		s.deleteKeystores(w, req)
+	default:
+		writeKeymanagerAPIError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not allowed", req.Method))
+	}
+}
+
+// listKeystores lists the keys of the keymanager which are stored locally. The keys of a
+// derived keymanager are read only, as they are derived from the mnemonic of the wallet.
+func (s *Server) listKeystores(w http.ResponseWriter, req *http.Request) {
+	var readonly bool
+	switch s.keymanager.(type) {
+	case *imported.Keymanager:
+	case *derived.Keymanager:
+		readonly = true
+	default:
+		writeKeymanagerAPIError(w, http.StatusBadRequest, "Validator keys are not stored locally with this keymanager")
+		return
+	}
+	pubKeys, err := s.keymanager.FetchValidatingPublicKeys(req.Context())
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not fetch public keys: %v", err))
+		return
+	}
+	keystores := make([]*keystoreJSON, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		keystores[i] = &keystoreJSON{
+			ValidatingPubkey: fmt.Sprintf("%#x", pubKey),
+			Readonly:         readonly,
+		}
+	}
+	writeKeymanagerAPIResponse(w, &listKeystoresResponseJSON{Data: keystores})
+}
+
+// importKeystores imports the slashing protection history of the request, then the keystores
+// decrypted with their respective passwords. The keys are only imported once their slashing
+// protection history is known, so that the validator never signs slashable messages with them.
+func (s *Server) importKeystores(w http.ResponseWriter, req *http.Request) {
+	km, ok := s.keymanager.(*imported.Keymanager)
+	if !ok {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, "Keystores can only be imported with an imported keymanager")
+		return
+	}
+	if s.valDB == nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, "Could not find validator database")
+		return
+	}
+	request := &importKeystoresRequestJSON{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+		return
+	}
+	if len(request.Keystores) != len(request.Passwords) {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf(
+			"Number of keystores and passwords is not equal: %d != %d", len(request.Keystores), len(request.Passwords),
+		))
+		return
+	}
+	keystores := make([]*keymanager.Keystore, len(request.Keystores))
+	for i, encoded := range request.Keystores {
+		keystore := &keymanager.Keystore{}
+		if err := json.Unmarshal([]byte(encoded), keystore); err != nil {
+			writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode keystore %d: %v", i, err))
+			return
+		}
+		keystores[i] = keystore
+	}
+
+	if request.SlashingProtection != "" {
+		buf := bytes.NewBufferString(request.SlashingProtection)
+		if err := slashing.ImportStandardProtectionJSON(req.Context(), s.valDB, buf); err != nil {
+			statuses := make([]*keymanager.KeyStatus, len(keystores))
+			for i := range statuses {
+				statuses[i] = &keymanager.KeyStatus{
+					Status:  keymanager.StatusError,
+					Message: fmt.Sprintf("could not import slashing protection: %v", err),
+				}
+			}
+			writeKeymanagerAPIResponse(w, &keyStatusesResponseJSON{Data: statuses})
+			return
+		}
+	}
+	statuses, err := km.ImportKeystoresWithPasswords(req.Context(), keystores, request.Passwords)
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not import keystores: %v", err))
+		return
+	}
+	writeKeymanagerAPIResponse(w, &keyStatusesResponseJSON{Data: statuses})
+}
+
+// deleteKeystores deletes the keys of the request, then exports their slashing protection
+// history. The keys are deleted first so that no message can be signed with them after their
+// history was exported. Keys which are unknown to the keymanager but have a slashing protection
+// history are reported as not active.
+func (s *Server) deleteKeystores(w http.ResponseWriter, req *http.Request) {
+	km, ok := s.keymanager.(*imported.Keymanager)
+	if !ok {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, "Keystores can only be deleted with an imported keymanager")
+		return
+	}
+	if s.valDB == nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, "Could not find validator database")
+		return
+	}
+	request := &deleteKeysRequestJSON{}
+	if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+		return
+	}
+	pubKeys, err := decodePubKeys(request.Pubkeys)
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	statuses, err := km.DeleteKeystores(req.Context(), pubKeys)
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not delete keystores: %v", err))
+		return
+	}
+
+	eipJSON, err := slashing.ExportStandardProtectionJSON(req.Context(), s.valDB)
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not export slashing protection: %v", err))
+		return
+	}
+	requested := make(map[string]int, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		requested[fmt.Sprintf("%#x", pubKey)] = i
+	}
+	data := make([]*format.ProtectionData, 0, len(pubKeys))
+	for _, protection := range eipJSON.Data {
+		i, ok := requested[protection.Pubkey]
+		if !ok {
+			continue
+		}
+		data = append(data, protection)
+		if statuses[i].Status == keymanager.StatusNotFound {
+			statuses[i].Status = keymanager.StatusNotActive
+		}
+	}
+	eipJSON.Data = data
+	encoded, err := json.Marshal(eipJSON)
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not encode slashing protection: %v", err))
+		return
+	}
+	writeKeymanagerAPIResponse(w, &deleteKeystoresResponseJSON{
+		Data:               statuses,
+		SlashingProtection: string(encoded),
+	})
+}
+
+func (s *Server) handleRemoteKeys(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		s.listRemoteKeys(w, req)
+	case http.MethodPost:
+		request := &importRemoteKeysRequestJSON{}
+		if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+			writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+			return
+		}
+		rejectRemoteKeysChange(w, len(request.RemoteKeys))
+	case http.MethodDelete:
+		request := &deleteKeysRequestJSON{}
+		if err := json.NewDecoder(req.Body).Decode(request); err != nil {
+			writeKeymanagerAPIError(w, http.StatusBadRequest, fmt.Sprintf("Could not decode request body: %v", err))
+			return
+		}
+		rejectRemoteKeysChange(w, len(request.Pubkeys))
+	default:
+		writeKeymanagerAPIError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s is not allowed", req.Method))
+	}
+}
+
+// listRemoteKeys lists the keys of the remote signer. They are read only, as they are
+// managed by the remote signer itself. No keys are listed for the other keymanagers.
+func (s *Server) listRemoteKeys(w http.ResponseWriter, req *http.Request) {
+	var url string
+	switch km := s.keymanager.(type) {
+	case *remote.Keymanager:
+		url = km.KeymanagerOpts().RemoteAddr
+	case *web3signer.Keymanager:
+		url = km.BaseEndpoint()
+	default:
+		writeKeymanagerAPIResponse(w, &listRemoteKeysResponseJSON{Data: []*remoteKeyJSON{}})
+		return
+	}
+	pubKeys, err := s.keymanager.FetchValidatingPublicKeys(req.Context())
+	if err != nil {
+		writeKeymanagerAPIError(w, http.StatusInternalServerError, fmt.Sprintf("Could not fetch public keys: %v", err))
+		return
+	}
+	keys := make([]*remoteKeyJSON, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		keys[i] = &remoteKeyJSON{
+			Pubkey:   fmt.Sprintf("%#x", pubKey),
+			URL:      url,
+			Readonly: true,
+		}
+	}
+	writeKeymanagerAPIResponse(w, &listRemoteKeysResponseJSON{Data: keys})
+}
+
+// rejectRemoteKeysChange reports an error for each of the keys of a request importing or
+// deleting remote keys, which none of the keymanagers supports.
+func rejectRemoteKeysChange(w http.ResponseWriter, numKeys int) {
+	statuses := make([]*keymanager.KeyStatus, numKeys)
+	for i := range statuses {
+		statuses[i] = &keymanager.KeyStatus{
+			Status:  keymanager.StatusError,
+			Message: "remote keys cannot be changed with this keymanager",
+		}
+	}
+	writeKeymanagerAPIResponse(w, &keyStatusesResponseJSON{Data: statuses})
+}
+
+func decodePubKeys(encoded []string) ([][]byte, error) {
+	pubKeys := make([][]byte, len(encoded))
+	for i, pubKey := range encoded {
+		decoded, err := hex.DecodeString(strings.TrimPrefix(pubKey, "0x"))
+		if err != nil || len(decoded) != 48 {
+			return nil, fmt.Errorf("invalid public key %s", pubKey)
+		}
+		pubKeys[i] = decoded
+	}
+	return pubKeys, nil
+}
+
+func writeKeymanagerAPIResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.WithError(err).Error("Could not write keymanager API response")
+	}
+}
+
+func writeKeymanagerAPIError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&keymanagerAPIErrorJSON{Code: code, Message: message}); err != nil {
+		log.WithError(err).Error("Could not write keymanager API error")
+	}
+}
@@ -0,0 +1,240 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil/assert"
+	"github.com/prysmaticlabs/prysm/shared/testutil/require"
+	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/accounts/iface"
+	"github.com/prysmaticlabs/prysm/validator/accounts/wallet"
+	dbtest "github.com/prysmaticlabs/prysm/validator/db/testing"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+	"github.com/prysmaticlabs/prysm/validator/keymanager/imported"
+	"github.com/prysmaticlabs/prysm/validator/slashing-protection/local/standard-protection-format/format"
+	mocks "github.com/prysmaticlabs/prysm/validator/testing"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const keymanagerAPIToken = "0123456789abcdef"
+
+func TestServer_initializeAuthToken(t *testing.T) {
+	s := &Server{walletDir: setupWalletDir(t)}
+	require.NoError(t, s.initializeAuthToken())
+	token, err := ioutil.ReadFile(filepath.Join(s.walletDir, AuthTokenFileName))
+	require.NoError(t, err)
+	assert.Equal(t, s.authToken, string(token))
+	assert.Equal(t, 64, len(token))
+
+	// The token is written to the configured path, and changes on every startup.
+	s.authTokenPath = filepath.Join(t.TempDir(), "keymanager", "token")
+	require.NoError(t, s.initializeAuthToken())
+	newToken, err := ioutil.ReadFile(s.authTokenPath)
+	require.NoError(t, err)
+	assert.Equal(t, s.authToken, string(newToken))
+	assert.NotEqual(t, string(token), string(newToken))
+}
+
+func TestServer_KeymanagerAPI_Authorization(t *testing.T) {
+	km, _ := setupImportedKeymanager(t)
+	s := &Server{keymanager: km, authToken: keymanagerAPIToken}
+	handler := s.KeymanagerAPIHandler()
+
+	req := httptest.NewRequest(http.MethodGet, keystoresPath, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, keystoresPath, nil)
+	req.Header.Set("Authorization", "Bearer wrongtoken")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	errJSON := &keymanagerAPIErrorJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), errJSON))
+	assert.Equal(t, http.StatusForbidden, errJSON.Code)
+
+	rec = keymanagerAPIRequest(t, handler, http.MethodGet, keystoresPath, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_KeymanagerAPI_Keystores(t *testing.T) {
+	km, w := setupImportedKeymanager(t)
+	ctx := context.Background()
+
+	// Create 2 keystores, as well as the public key of a validator which was moved
+	// away from this client but still has a slashing protection history.
+	encryptor := keystorev4.New()
+	keystores := make([]string, 2)
+	pubKeys := make([][48]byte, 3)
+	for i := 0; i < len(keystores); i++ {
+		privKey, err := bls.RandKey()
+		require.NoError(t, err)
+		id, err := uuid.NewRandom()
+		require.NoError(t, err)
+		cryptoFields, err := encryptor.Encrypt(privKey.Marshal(), strongPass)
+		require.NoError(t, err)
+		encodedFile, err := json.Marshal(&keymanager.Keystore{
+			Crypto:  cryptoFields,
+			ID:      id.String(),
+			Version: encryptor.Version(),
+			Pubkey:  fmt.Sprintf("%x", privKey.PublicKey().Marshal()),
+			Name:    encryptor.Name(),
+		})
+		require.NoError(t, err)
+		keystores[i] = string(encodedFile)
+		copy(pubKeys[i][:], privKey.PublicKey().Marshal())
+	}
+	movedKey, err := bls.RandKey()
+	require.NoError(t, err)
+	copy(pubKeys[2][:], movedKey.PublicKey().Marshal())
+	protectedKeys := [][48]byte{pubKeys[0], pubKeys[2]}
+	attestingHistory, proposalHistory := mocks.MockAttestingAndProposalHistories(protectedKeys)
+	protectionJSON, err := mocks.MockSlashingProtectionJSON(protectedKeys, attestingHistory, proposalHistory)
+	require.NoError(t, err)
+	encodedProtection, err := json.Marshal(protectionJSON)
+	require.NoError(t, err)
+
+	s := &Server{
+		keymanager: km,
+		wallet:     w,
+		valDB:      dbtest.SetupDB(t, pubKeys),
+		authToken:  keymanagerAPIToken,
+	}
+	handler := s.KeymanagerAPIHandler()
+	accountsChanged := make(chan [][48]byte, 1)
+	sub := km.SubscribeAccountChanges(accountsChanged)
+	defer sub.Unsubscribe()
+
+	// Import the keystores with the slashing protection history of one of them.
+	rec := keymanagerAPIRequest(t, handler, http.MethodPost, keystoresPath, &importKeystoresRequestJSON{
+		Keystores:          keystores,
+		Passwords:          []string{strongPass, strongPass},
+		SlashingProtection: string(encodedProtection),
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+	importResp := &keyStatusesResponseJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), importResp))
+	require.Equal(t, 2, len(importResp.Data))
+	assert.Equal(t, keymanager.StatusImported, importResp.Data[0].Status)
+	assert.Equal(t, keymanager.StatusImported, importResp.Data[1].Status)
+	assert.Equal(t, 2, len(<-accountsChanged), "Accounts were not reloaded")
+
+	rec = keymanagerAPIRequest(t, handler, http.MethodGet, keystoresPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	listed := &listKeystoresResponseJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), listed))
+	require.Equal(t, 2, len(listed.Data))
+	assert.Equal(t, false, listed.Data[0].Readonly)
+
+	// Delete one of the keys, as well as the moved and an unknown key.
+	unknownKey, err := bls.RandKey()
+	require.NoError(t, err)
+	rec = keymanagerAPIRequest(t, handler, http.MethodDelete, keystoresPath, &deleteKeysRequestJSON{
+		Pubkeys: []string{
+			fmt.Sprintf("%#x", pubKeys[0]),
+			fmt.Sprintf("%#x", pubKeys[2]),
+			fmt.Sprintf("%#x", unknownKey.PublicKey().Marshal()),
+		},
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+	deleted := &deleteKeystoresResponseJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), deleted))
+	require.Equal(t, 3, len(deleted.Data))
+	assert.Equal(t, keymanager.StatusDeleted, deleted.Data[0].Status)
+	assert.Equal(t, keymanager.StatusNotActive, deleted.Data[1].Status)
+	assert.Equal(t, keymanager.StatusNotFound, deleted.Data[2].Status)
+	assert.DeepEqual(t, [][48]byte{pubKeys[1]}, <-accountsChanged)
+
+	exported := &format.EIPSlashingProtectionFormat{}
+	require.NoError(t, json.Unmarshal([]byte(deleted.SlashingProtection), exported))
+	assert.Equal(t, protectionJSON.Metadata, exported.Metadata)
+	exportedKeys := make(map[string]bool)
+	for _, data := range exported.Data {
+		exportedKeys[data.Pubkey] = true
+	}
+	assert.DeepEqual(t, map[string]bool{
+		fmt.Sprintf("%#x", pubKeys[0]): true,
+		fmt.Sprintf("%#x", pubKeys[2]): true,
+	}, exportedKeys)
+
+	keys, err := km.FetchValidatingPublicKeys(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, [][48]byte{pubKeys[1]}, keys)
+}
+
+func TestServer_KeymanagerAPI_Keystores_WrongKeymanager(t *testing.T) {
+	s := &Server{authToken: keymanagerAPIToken}
+	handler := s.KeymanagerAPIHandler()
+
+	rec := keymanagerAPIRequest(t, handler, http.MethodGet, keystoresPath, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = keymanagerAPIRequest(t, handler, http.MethodPost, keystoresPath, &importKeystoresRequestJSON{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = keymanagerAPIRequest(t, handler, http.MethodDelete, keystoresPath, &deleteKeysRequestJSON{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_KeymanagerAPI_RemoteKeys(t *testing.T) {
+	km, _ := setupImportedKeymanager(t)
+	s := &Server{keymanager: km, authToken: keymanagerAPIToken}
+	handler := s.KeymanagerAPIHandler()
+
+	rec := keymanagerAPIRequest(t, handler, http.MethodGet, remoteKeysPath, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	listed := &listRemoteKeysResponseJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), listed))
+	assert.Equal(t, 0, len(listed.Data))
+
+	rec = keymanagerAPIRequest(t, handler, http.MethodPost, remoteKeysPath, &importRemoteKeysRequestJSON{
+		RemoteKeys: []*remoteKeyJSON{{Pubkey: "0x01", URL: "http://localhost:9000"}},
+	})
+	require.Equal(t, http.StatusOK, rec.Code)
+	statuses := &keyStatusesResponseJSON{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), statuses))
+	require.Equal(t, 1, len(statuses.Data))
+	assert.Equal(t, keymanager.StatusError, statuses.Data[0].Status)
+}
+
+func setupImportedKeymanager(t *testing.T) (*imported.Keymanager, *wallet.Wallet) {
+	imported.ResetCaches()
+	ctx := context.Background()
+	w, err := accounts.CreateWalletWithKeymanager(ctx, &accounts.CreateWalletConfig{
+		WalletCfg: &wallet.Config{
+			WalletDir:      setupWalletDir(t),
+			KeymanagerKind: keymanager.Imported,
+			WalletPassword: strongPass,
+		},
+		SkipMnemonicConfirm: true,
+	})
+	require.NoError(t, err)
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false})
+	require.NoError(t, err)
+	importedKM, ok := km.(*imported.Keymanager)
+	require.Equal(t, true, ok)
+	return importedKM, w
+}
+
+func keymanagerAPIRequest(t *testing.T, handler http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var encoded []byte
+	if body != nil {
+		var err error
+		encoded, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(encoded))
+	req.Header.Set("Authorization", "Bearer "+keymanagerAPIToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}